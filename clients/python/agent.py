@@ -0,0 +1,77 @@
+#!/usr/bin/env python3
+"""Reference client for the asteroids remote agent protocol.
+
+Run the game with `-remote localhost:7777`, then:
+
+    python3 agent.py localhost:7777
+
+The policy below is deliberately simple: turn towards the nearest asteroid,
+shoot when roughly facing it and thrust away when something gets close.
+Replace `decide` with your own agent. Only the standard library is used.
+"""
+
+import json
+import math
+import socket
+import sys
+
+
+def nearest(ship, objects, width, height):
+    """Return (object, dx, dy, distance) of the closest object, wrapping edges."""
+    best = None
+    for o in objects:
+        dx = (o["x"] - ship["x"] + width / 2) % width - width / 2
+        dy = (o["y"] - ship["y"] + height / 2) % height - height / 2
+        d = math.hypot(dx, dy)
+        if best is None or d < best[3]:
+            best = (o, dx, dy, d)
+    return best
+
+
+def decide(obs, tick):
+    action = {"left": False, "right": False, "thrust": False, "shoot": False, "hyperspace": False}
+    ship = obs.get("player")
+    if ship is None:
+        return action
+
+    target = nearest(ship, (obs.get("asteroids") or []) + (obs.get("saucers") or []), obs["width"], obs["height"])
+    if target is None:
+        return action
+    o, dx, dy, dist = target
+
+    # Angle 0 points right and grows clockwise (screen y points down).
+    want = math.atan2(dy, dx)
+    diff = (want - ship["angle"] + math.pi) % (2 * math.pi) - math.pi
+    if diff > 0.1:
+        action["right"] = True
+    elif diff < -0.1:
+        action["left"] = True
+    if abs(diff) < 0.3 and tick % 8 == 0:
+        action["shoot"] = True
+    if dist < o["radius"] + 60 and abs(diff) > 2.5:
+        action["thrust"] = True
+    return action
+
+
+def main():
+    host, _, port = (sys.argv[1] if len(sys.argv) > 1 else "localhost:7777").rpartition(":")
+    with socket.create_connection((host or "localhost", int(port))) as sock:
+        stream = sock.makefile("rw", encoding="utf-8", newline="\n")
+        hello = json.loads(stream.readline())
+        print(f"connected: protocol v{hello['version']}, {hello['timeout_ms']}ms per tick", file=sys.stderr)
+
+        episode = 0
+        for line in stream:
+            msg = json.loads(line)
+            if msg.get("type") != "obs":
+                continue
+            obs = msg["obs"]
+            if msg["episode"] != episode:
+                episode = msg["episode"]
+                print(f"episode {episode}", file=sys.stderr)
+            stream.write(json.dumps({"tick": msg["tick"], "action": decide(obs, msg["tick"])}) + "\n")
+            stream.flush()
+
+
+if __name__ == "__main__":
+    main()
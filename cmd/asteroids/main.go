@@ -1,19 +1,247 @@
 package main
 
 import (
-	"log"
+	"expvar"
+	"flag"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/matheus3301/asteroids/internal/crowd"
+	"github.com/matheus3301/asteroids/internal/debugserver"
 	"github.com/matheus3301/asteroids/internal/game"
+	"github.com/matheus3301/asteroids/internal/logging"
+	"github.com/matheus3301/asteroids/internal/mods"
+	"github.com/matheus3301/asteroids/internal/netplay"
+	"github.com/matheus3301/asteroids/internal/remote"
+	"github.com/matheus3301/asteroids/internal/script"
 )
 
+var logger = logging.For("main")
+
+// monitoredGame publishes the game's status and per-system timings to
+// expvar after every update.
+type monitoredGame struct {
+	*game.Game
+	state                             *expvar.String
+	score, lives, level, tick, entity *expvar.Int
+	// systems is each system's rolling ns/tick, in pipeline order.
+	systems []*expvar.Float
+}
+
+func newMonitoredGame(g *game.Game) *monitoredGame {
+	m := &monitoredGame{
+		Game:   g,
+		state:  new(expvar.String),
+		score:  new(expvar.Int),
+		lives:  new(expvar.Int),
+		level:  new(expvar.Int),
+		tick:   new(expvar.Int),
+		entity: new(expvar.Int),
+	}
+	debugserver.Progress.Set("state", m.state)
+	debugserver.Progress.Set("score", m.score)
+	debugserver.Progress.Set("lives", m.lives)
+	debugserver.Progress.Set("level", m.level)
+	debugserver.Progress.Set("tick", m.tick)
+	debugserver.Progress.Set("entities", m.entity)
+	m.systems = debugserver.Floats("systems_ns_per_tick", game.NewSystemTimings().Names)
+	return m
+}
+
+func (m *monitoredGame) Update() error {
+	err := m.Game.Update()
+	st := m.Status()
+	m.state.Set(st.State)
+	m.score.Set(int64(st.Score))
+	m.lives.Set(int64(st.Lives))
+	m.level.Set(int64(st.Level))
+	m.tick.Set(int64(st.Tick))
+	m.entity.Set(int64(st.Entities))
+	if t := m.SystemTimings(); t != nil {
+		for i, f := range m.systems {
+			f.Set(t.Recent[i])
+		}
+	}
+	return err
+}
+
 func main() {
-	ebiten.SetWindowSize(game.ScreenWidth, game.ScreenHeight)
+	seed := flag.Int64("seed", 0, "RNG seed for every game (0 = random per game)")
+	width := flag.Int("width", game.ScreenWidth, "window width in pixels")
+	height := flag.Int("height", game.ScreenHeight, "window height in pixels")
+	fullscreen := flag.Bool("fullscreen", false, "start in fullscreen")
+	mute := flag.Bool("mute", false, "start with the volume at 0")
+	telemetryOn := flag.Bool("telemetry", false, "opt in to local balance statistics (remembered; see STATS in the menu)")
+	pprofAddr := flag.String("pprof", "", "serve pprof and expvar on this address (e.g. localhost:6060)")
+	remoteAddr := flag.String("remote", "", "let an external agent drive the ship over TCP on this address (e.g. localhost:7777)")
+	remoteTimeout := flag.Duration("remote-timeout", remote.DefaultTimeout, "how long each tick waits for the agent before holding its last action")
+	remoteView := flag.Float64("remote-view", 0, "only tell the agent about objects within this many pixels of the ship (0 for no limit)")
+	coopPort := flag.Int("coop-port", netplay.DefaultPort, "port used to host and join co-op games")
+	coopDelay := flag.Int("coop-delay", netplay.DefaultDelay, "co-op input delay in ticks when hosting; higher tolerates worse networks")
+	crowdListen := flag.String("crowd-listen", "", "accept \"<user> <vote>\" lines from chat bridges on this address")
+	crowdIRC := flag.String("crowd-irc", "", "read votes from this IRC server (e.g. irc.chat.twitch.tv:6667)")
+	crowdChannel := flag.String("crowd-channel", "", "IRC channel to read votes from")
+	crowdWindow := flag.Int("crowd-window", crowd.DefaultWindow, "ticks per crowd voting round")
+	autofire := flag.Bool("autofire", false, "fire continuously while Space is held (also in settings)")
+	speed := flag.Float64("speed", 1, "game speed, from 0.5 (practice) to 2 (skimming agents); also in settings")
+	autoPause := flag.Bool("auto-pause", true, "pause the game when the window loses focus (also in settings)")
+	practice := flag.Bool("practice", false, "show the spawn safe radius, the next wave's spawn points and predicted trajectories")
+	aimGuide := flag.Bool("aim-guide", false, "show the lead angle and threat urgency agents observe (also in settings, F4 in game)")
+	aimAssist := flag.Int("aim-assist", 0, "turn the ship onto the lead angle when nearly there, from 0 (off) to 3; assisted games do not count toward the career (also in settings)")
+	mutatorList := flag.String("mutators", "", "comma-separated run mutators: fog, giant, swarm, nohyper (also on the ship screen)")
+	mode := flag.String("mode", "", "game mode the menu starts on: classic, timeattack or survival (also in the menu)")
+	difficulty := flag.String("difficulty", "", "difficulty for this session: easy, normal, hard or insane (default the one chosen in settings)")
+	field := flag.String("field", "", "playfield size, WIDTHxHEIGHT from 800x600 up or fit for the window's shape, for ultrawide and portrait windows; other sizes do not count toward the career")
+	hudCorner := flag.String("hud-corner", game.HUDTopLeft.String(), "screen corner for the HUD: top-left, top-right, bottom-left or bottom-right")
+	hudScale := flag.Float64("hud-scale", 2, "HUD text size")
+	stress := flag.Int("stress", 0, "open a profiling scene with this many asteroids and a timing breakdown")
+	inputScript := flag.String("input-script", "", "fly the ship from an input script file instead of the keyboard (use with -seed)")
+	scriptPath := flag.String("script", "", "load a Starlark mod that changes the game rules (disables replay recording)")
+	collisionDebug := flag.Bool("debug-collisions", false, "start with the collision debug view: 10 ticks a second, colliders and contacts drawn (F5 in game)")
+	devTuning := flag.String("dev", "", "watch this TOML file of gameplay values and apply its changes live (tuned games do not count toward the career)")
+	scriptSteps := flag.Uint64("script-steps", script.DefaultMaxSteps, "interpreter steps each mod hook may run before the mod is switched off")
+	logFlags := logging.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+
+	if err := logFlags.Setup(); err != nil {
+		logging.Fatal(logger, "invalid -log", "err", err)
+	}
+	if *width <= 0 || *height <= 0 {
+		logging.Fatal(logger, "invalid window size", "width", *width, "height", *height)
+	}
+	corner, err := game.ParseHUDCorner(*hudCorner)
+	if err != nil {
+		logging.Fatal(logger, "invalid -hud-corner", "err", err)
+	}
+	mutators, err := game.ParseMutators(*mutatorList)
+	if err != nil {
+		logging.Fatal(logger, "invalid -mutators", "err", err)
+	}
+	if *difficulty != "" {
+		if _, err := game.ParseDifficulty(*difficulty); err != nil {
+			logging.Fatal(logger, "invalid -difficulty", "err", err)
+		}
+	}
+	if *mode != "" {
+		if _, err := game.ParseGameMode(*mode); err != nil {
+			logging.Fatal(logger, "invalid -mode", "err", err)
+		}
+	}
+	fieldW, fieldH, err := game.ParseField(*field, *width, *height)
+	if err != nil {
+		logging.Fatal(logger, "invalid -field", "err", err)
+	}
+	if *speed < game.MinGameSpeed || *speed > game.MaxGameSpeed {
+		logging.Fatal(logger, "invalid -speed", "speed", *speed, "min", game.MinGameSpeed, "max", game.MaxGameSpeed)
+	}
+	if *aimAssist < 0 || *aimAssist > game.MaxAimAssist {
+		logging.Fatal(logger, "invalid -aim-assist", "strength", *aimAssist, "max", game.MaxAimAssist)
+	}
+	if *hudScale <= 0 {
+		logging.Fatal(logger, "invalid -hud-scale", "scale", *hudScale)
+	}
+
+	ebiten.SetWindowSize(*width, *height)
 	ebiten.SetWindowTitle("Asteroids")
+	ebiten.SetWindowIcon(game.WindowIcons())
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+	ebiten.SetFullscreen(*fullscreen)
 
-	g := game.New()
-	if err := ebiten.RunGame(g); err != nil {
-		log.Fatal(err)
+	opts := game.Options{
+		Seed:        *seed,
+		Width:       *width,
+		Height:      *height,
+		Fullscreen:  *fullscreen,
+		Mute:        *mute,
+		Net:         netplay.Factory{Port: *coopPort, Delay: *coopDelay},
+		Telemetry:   *telemetryOn,
+		Autofire:    *autofire,
+		NoAutoPause: !*autoPause,
+		Speed:       *speed,
+		Practice:    *practice,
+		AimGuide:    *aimGuide,
+		AimAssist:   *aimAssist,
+		Mutators:    mutators,
+		Difficulty:  *difficulty,
+		Mode:        *mode,
+		Stress:      *stress,
+		FieldWidth:  fieldW,
+		FieldHeight: fieldH,
+		DevTuning:   *devTuning,
+		HUD:         game.HUDLayout{Corner: corner, Scale: *hudScale},
+		Presence: []game.Presence{game.PresenceFunc(func(st game.Status) {
+			ebiten.SetWindowTitle(game.WindowTitle(st))
+		})},
+		CollisionDebug: *collisionDebug,
+	}
+	if *scriptPath != "" {
+		mod, err := script.Load(*scriptPath, *scriptSteps)
+		if err != nil {
+			logging.Fatal(logger, "loading mod", "err", err)
+		}
+		logger.Info("mod loaded", "path", *scriptPath)
+		opts.Rules = mod
+	}
+	if catalog, err := mods.Default(*scriptSteps); err != nil {
+		logger.Warn("mod packs unavailable", "err", err)
+	} else {
+		opts.Mods = catalog
+	}
+	if *inputScript != "" {
+		s, err := game.LoadInputScript(*inputScript)
+		if err != nil {
+			logging.Fatal(logger, "loading input script", "err", err)
+		}
+		opts.Input = s
+		opts.AutoStart = true
+	}
+	if *remoteAddr != "" && (*crowdListen != "" || *crowdIRC != "") {
+		logging.Fatal(logger, "-remote and the -crowd flags are mutually exclusive")
+	}
+	if *crowdListen != "" || *crowdIRC != "" {
+		votes := crowd.NewVotes(*crowdWindow)
+		if *crowdListen != "" {
+			ln, err := crowd.ListenLines(*crowdListen, votes)
+			if err != nil {
+				logging.Fatal(logger, "starting crowd bridge listener", "err", err)
+			}
+			logger.Info("accepting crowd votes", "addr", ln.Addr().String())
+		}
+		if *crowdIRC != "" {
+			if *crowdChannel == "" {
+				logging.Fatal(logger, "-crowd-irc needs -crowd-channel")
+			}
+			if _, err := crowd.DialIRC(*crowdIRC, *crowdChannel, "", "", votes); err != nil {
+				logging.Fatal(logger, "connecting to IRC", "err", err)
+			}
+			logger.Info("reading crowd votes from IRC", "server", *crowdIRC, "channel", *crowdChannel)
+		}
+		opts.Input = votes
+		opts.AutoStart = true
+	}
+	if *remoteAddr != "" {
+		srv, err := remote.Listen(*remoteAddr, *remoteTimeout)
+		if err != nil {
+			logging.Fatal(logger, "starting remote agent server", "err", err)
+		}
+		srv.LimitView(*remoteView)
+		logger.Info("waiting for agent", "addr", srv.Addr().String(), "timeout", remoteTimeout.Round(time.Millisecond))
+		opts.Input = srv
+		opts.AutoStart = true
+	}
+	opts.SystemTimings = *pprofAddr != ""
+	g := game.NewWithOptions(opts)
+
+	var run ebiten.Game = g
+	if *pprofAddr != "" {
+		addr, err := debugserver.Start(*pprofAddr)
+		if err != nil {
+			logging.Fatal(logger, "starting debug server", "err", err)
+		}
+		logger.Info("debug server listening", "pprof", "http://"+addr.String()+"/debug/pprof/", "vars", "http://"+addr.String()+"/debug/vars")
+		run = newMonitoredGame(g)
+	}
+	if err := ebiten.RunGame(run); err != nil {
+		logging.Fatal(logger, "game exited", "err", err)
 	}
 }
@@ -0,0 +1,134 @@
+// Command balance plays many seeded games under the standard rules, each
+// other difficulty and each run mutator, and reports for each how long the
+// ship survived, how far it got, what it scored and what destroyed it.
+//
+// The ship is flown by the scripted autopilot, or by a remote agent with
+// -remote. The autopilot is deterministic, so the same flags always give
+// the same report: save one with -o before a balance change and pass it
+// as -baseline afterwards, and the command exits non-zero if any
+// configuration moved by more than -tolerance.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/matheus3301/asteroids/internal/balance"
+	"github.com/matheus3301/asteroids/internal/game"
+	"github.com/matheus3301/asteroids/internal/logging"
+	"github.com/matheus3301/asteroids/internal/remote"
+)
+
+var logger = logging.For("balance")
+
+func main() {
+	episodes := flag.Int("episodes", 50, "games per configuration")
+	seed := flag.Int64("seed", 1, "seed of the first game of each configuration; later games use seed+n")
+	maxTicks := flag.Int("max-ticks", 5*60*60, "stop a game that lasts this many ticks")
+	only := flag.String("configs", "", "comma-separated configurations to run (default all): standard, difficulty and mutator IDs")
+	out := flag.String("o", "", "save the report as JSON to this file")
+	baseline := flag.String("baseline", "", "compare with this saved report and exit 1 if anything moved by more than -tolerance")
+	tolerance := flag.Float64("tolerance", 0.1, "allowed change against -baseline: a fraction of each mean, or of all deaths for each cause")
+	remoteAddr := flag.String("remote", "", "let an external agent fly the ship over TCP on this address instead of the autopilot")
+	remoteTimeout := flag.Duration("remote-timeout", remote.DefaultTimeout, "how long each tick waits for the agent before holding its last action")
+	logFlags := logging.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+
+	if err := logFlags.Setup(); err != nil {
+		logging.Fatal(logger, "invalid -log", "err", err)
+	}
+	if *episodes <= 0 || *maxTicks <= 0 {
+		logging.Fatal(logger, "-episodes and -max-ticks must be positive")
+	}
+
+	configs := balance.Configs()
+	if *only != "" {
+		names := strings.Split(*only, ",")
+		for _, n := range names {
+			if !slices.ContainsFunc(configs, func(c balance.Config) bool { return c.Name == n }) {
+				logging.Fatal(logger, "unknown configuration", "name", n)
+			}
+		}
+		configs = slices.DeleteFunc(configs, func(c balance.Config) bool { return !slices.Contains(names, c.Name) })
+	}
+
+	var in game.InputSource = game.Autopilot{}
+	if *remoteAddr != "" {
+		srv, err := remote.Listen(*remoteAddr, *remoteTimeout)
+		if err != nil {
+			logging.Fatal(logger, "starting remote agent server", "err", err)
+		}
+		logger.Info("waiting for agent", "addr", srv.Addr().String())
+		in = srv
+	}
+
+	report := balance.Report{Seed: *seed, Episodes: *episodes, MaxTicks: *maxTicks}
+	for _, c := range configs {
+		logger.Info("playing", "config", c.Name, "episodes", *episodes)
+		report.Results = append(report.Results, balance.Run(c, in, *seed, *episodes, *maxTicks))
+	}
+	printReport(report)
+
+	if *out != "" {
+		b, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			logging.Fatal(logger, "encoding report", "err", err)
+		}
+		if err := os.WriteFile(*out, append(b, '\n'), 0o644); err != nil {
+			logging.Fatal(logger, "saving report", "path", *out, "err", err)
+		}
+	}
+
+	if *baseline != "" {
+		b, err := os.ReadFile(*baseline)
+		if err != nil {
+			logging.Fatal(logger, "reading baseline", "err", err)
+		}
+		var base balance.Report
+		if err := json.Unmarshal(b, &base); err != nil {
+			logging.Fatal(logger, "reading baseline", "path", *baseline, "err", err)
+		}
+		if base.Seed != report.Seed || base.Episodes != report.Episodes || base.MaxTicks != report.MaxTicks {
+			logger.Warn("baseline was made with different -seed, -episodes or -max-ticks")
+		}
+		changes := balance.Compare(base, report, *tolerance)
+		if len(changes) > 0 {
+			fmt.Printf("\n%d changes beyond %.0f%% of the baseline:\n", len(changes), *tolerance*100)
+			for _, c := range changes {
+				fmt.Println("  " + c)
+			}
+			os.Exit(1)
+		}
+		fmt.Printf("\nwithin %.0f%% of the baseline\n", *tolerance*100)
+	}
+}
+
+func printReport(r balance.Report) {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "config\tgames\tsurvival s (p10/p50/p90)\twave\tscore (p10/p50/p90)\tdeaths\t")
+	for _, res := range r.Results {
+		var deaths []string
+		for i := range game.NumDeathCauses {
+			cause := game.DeathCause(i).String()
+			if share := res.Deaths[cause]; share > 0 {
+				deaths = append(deaths, fmt.Sprintf("%s %.0f%%", cause, share*100))
+			}
+		}
+		games := fmt.Sprint(res.Games)
+		if res.TimedOut > 0 {
+			games += fmt.Sprintf(" (%d timed out)", res.TimedOut)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%.0f (%.0f/%.0f/%.0f)\t%.1f\t%.0f (%.0f/%.0f/%.0f)\t%s\t\n",
+			res.Config, games,
+			res.Survival.Mean, res.Survival.P10, res.Survival.P50, res.Survival.P90,
+			res.Wave.Mean,
+			res.Score.Mean, res.Score.P10, res.Score.P50, res.Score.P90,
+			strings.Join(deaths, ", "))
+	}
+	tw.Flush()
+}
@@ -0,0 +1,126 @@
+// Command bench measures headless simulation throughput.
+//
+// It plays the scripted autopilot for a fixed number of ticks, starting a new
+// game whenever the previous one ends, and reports ticks per second,
+// allocations per tick and a per-system time breakdown.
+package main
+
+import (
+	"expvar"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/matheus3301/asteroids/internal/debugserver"
+	"github.com/matheus3301/asteroids/internal/game"
+	"github.com/matheus3301/asteroids/internal/logging"
+)
+
+var logger = logging.For("bench")
+
+// publishEvery is how many ticks pass between updates of the per-system
+// timings served over expvar with -pprof.
+const publishEvery = 1000
+
+func main() {
+	ticks := flag.Int("ticks", 100_000, "number of simulation ticks to run")
+	seed := flag.Int64("seed", 1, "RNG seed of the first game; later games use seed+n")
+	systems := flag.Bool("systems", true, "time each system individually")
+	pprofAddr := flag.String("pprof", "", "serve pprof and expvar on this address (e.g. localhost:6060)")
+	logFlags := logging.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+
+	if err := logFlags.Setup(); err != nil {
+		logging.Fatal(logger, "invalid -log", "err", err)
+	}
+
+	doneTicks := new(expvar.Int)
+	doneGames := new(expvar.Int)
+	doneGames.Set(1)
+	if *pprofAddr != "" {
+		addr, err := debugserver.Start(*pprofAddr)
+		if err != nil {
+			logging.Fatal(logger, "starting debug server", "err", err)
+		}
+		logger.Info("debug server listening", "pprof", "http://"+addr.String()+"/debug/pprof/", "vars", "http://"+addr.String()+"/debug/vars")
+		debugserver.Progress.Set("ticks", doneTicks)
+		debugserver.Progress.Set("games", doneGames)
+	}
+
+	var timings *game.SystemTimings
+	var published []*expvar.Float
+	if *systems {
+		timings = game.NewSystemTimings()
+		if *pprofAddr != "" {
+			published = debugserver.Floats("systems_ns_per_tick", timings.Names)
+		}
+	}
+
+	games := 1
+	w := game.NewGameWorld(*seed)
+	gameTick := 0
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+
+	for i := 0; i < *ticks; i++ {
+		game.StepTimed(w, game.ScriptedInput(gameTick), timings)
+		w.SoundQueue = w.SoundQueue[:0]
+		gameTick++
+		doneTicks.Add(1)
+		if published != nil && i%publishEvery == 0 {
+			for j, f := range published {
+				f.Set(timings.Recent[j])
+			}
+		}
+		if w.GameOver() {
+			w = game.NewGameWorld(*seed + int64(games))
+			games++
+			doneGames.Add(1)
+			gameTick = 0
+		}
+	}
+
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	n := float64(*ticks)
+	fmt.Printf("ticks:        %d (%d games)\n", *ticks, games)
+	fmt.Printf("elapsed:      %v\n", elapsed.Round(time.Millisecond))
+	fmt.Printf("ticks/sec:    %.0f\n", n/elapsed.Seconds())
+	fmt.Printf("ns/tick:      %.0f\n", float64(elapsed.Nanoseconds())/n)
+	fmt.Printf("allocs/tick:  %.1f\n", float64(after.Mallocs-before.Mallocs)/n)
+	fmt.Printf("bytes/tick:   %.0f\n", float64(after.TotalAlloc-before.TotalAlloc)/n)
+
+	if timings != nil {
+		printTimings(timings)
+	}
+}
+
+func printTimings(t *game.SystemTimings) {
+	var total time.Duration
+	order := make([]int, len(t.Names))
+	for i, d := range t.Total {
+		total += d
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool { return t.Total[order[a]] > t.Total[order[b]] })
+
+	fmt.Println()
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "system\tns/tick\tshare\t")
+	for _, i := range order {
+		share := 0.0
+		if total > 0 {
+			share = float64(t.Total[i]) / float64(total) * 100
+		}
+		fmt.Fprintf(tw, "%s\t%.0f\t%.1f%%\t\n", t.Names[i], float64(t.Total[i].Nanoseconds())/float64(t.Ticks), share)
+	}
+	tw.Flush()
+}
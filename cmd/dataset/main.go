@@ -0,0 +1,77 @@
+// Command dataset converts replays into (observation, action) pairs for
+// supervised learning and writes them as a NumPy .npz archive. See
+// internal/dataset for the feature layout and file format.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/matheus3301/asteroids/internal/dataset"
+	"github.com/matheus3301/asteroids/internal/game"
+	"github.com/matheus3301/asteroids/internal/logging"
+)
+
+var logger = logging.For("dataset")
+
+func main() {
+	out := flag.String("o", "dataset.npz", "output file")
+	winning := flag.Bool("winning", false, "keep only frames from waves the player cleared")
+	active := flag.Bool("active", false, "drop frames where no button was held")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: dataset [-o out.npz] [-winning] [-active] [file.replay ...]\n\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "Converts the given replays, or every saved one when none are given.\n\n")
+		flag.PrintDefaults()
+	}
+	logFlags := logging.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+
+	if err := logFlags.Setup(); err != nil {
+		logging.Fatal(logger, "invalid -log", "err", err)
+	}
+
+	paths := flag.Args()
+	if len(paths) == 0 {
+		dir, err := game.ReplayDir()
+		if err != nil {
+			logging.Fatal(logger, "locating replay directory", "err", err)
+		}
+		paths, _ = filepath.Glob(filepath.Join(dir, "*.replay"))
+		if len(paths) == 0 {
+			logging.Fatal(logger, "no replays found", "dir", dir)
+		}
+	}
+
+	filter := dataset.Filter{WinningWaves: *winning, Active: *active}
+	var frames []dataset.Frame
+	for i, path := range paths {
+		r, err := game.LoadReplay(path)
+		if err != nil {
+			logging.Fatal(logger, "loading replay", "path", path, "err", err)
+		}
+		if !r.Compatible() {
+			logger.Warn("replay was recorded with different game rules and may desync", "path", path)
+		}
+		got, desync := dataset.Convert(r, i, filter)
+		if desync >= 0 {
+			logger.Warn("replay desynced; later frames may not match the recording", "path", path, "tick", desync)
+		}
+		logger.Info("converted", "episode", i, "path", path, "frames", len(got))
+		frames = append(frames, got...)
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		logging.Fatal(logger, "creating output", "err", err)
+	}
+	if err := dataset.WriteNPZ(f, frames); err != nil {
+		f.Close()
+		logging.Fatal(logger, "writing dataset", "path", *out, "err", err)
+	}
+	if err := f.Close(); err != nil {
+		logging.Fatal(logger, "writing dataset", "path", *out, "err", err)
+	}
+	fmt.Printf("%s: %d frames from %d replays\n", *out, len(frames), len(paths))
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/matheus3301/asteroids/internal/game"
+	"github.com/matheus3301/asteroids/internal/logging"
+)
+
+var logger = logging.For("replay")
+
+func main() {
+	verify := flag.Bool("verify", false, "re-simulate headlessly and check for desyncs instead of opening a window")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: replay [-verify] [file.replay]\n\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "Plays the given replay, or the most recent one when no file is given.\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "Controls: SPACE pause, LEFT/RIGHT seek, UP/DOWN speed, R restart, ESC menu.\n\n")
+		flag.PrintDefaults()
+	}
+	logFlags := logging.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+
+	if err := logFlags.Setup(); err != nil {
+		logging.Fatal(logger, "invalid -log", "err", err)
+	}
+
+	path := flag.Arg(0)
+	if path == "" {
+		dir, err := game.ReplayDir()
+		if err != nil {
+			logging.Fatal(logger, "locating replay directory", "err", err)
+		}
+		path, err = game.LatestReplay(dir)
+		if err != nil {
+			logging.Fatal(logger, "no replays found", "dir", dir)
+		}
+	}
+
+	r, err := game.LoadReplay(path)
+	if err != nil {
+		logging.Fatal(logger, "loading replay", "path", path, "err", err)
+	}
+	if !r.Compatible() {
+		logger.Warn("replay was recorded with different game rules and may desync", "path", path)
+	}
+
+	if *verify {
+		if tick := game.VerifyReplay(r); tick >= 0 {
+			fmt.Printf("%s: DESYNC at tick %d of %d\n", path, tick, r.Ticks)
+			os.Exit(1)
+		}
+		fmt.Printf("%s: ok (%d ticks, %d checksums)\n", path, r.Ticks, len(r.Checksums))
+		return
+	}
+
+	ebiten.SetWindowSize(game.ScreenWidth, game.ScreenHeight)
+	ebiten.SetWindowTitle("Asteroids Replay")
+	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+
+	g := game.New()
+	g.PlayReplay(r)
+	if err := ebiten.RunGame(g); err != nil {
+		logging.Fatal(logger, "player exited", "err", err)
+	}
+}
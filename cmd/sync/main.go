@@ -0,0 +1,61 @@
+// Command sync copies the player's settings, career, stats and replays to
+// and from a remote they provide, so they can play on several machines.
+// Whichever side changed a file last wins; see package cloudsync.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/matheus3301/asteroids/internal/cloudsync"
+	"github.com/matheus3301/asteroids/internal/logging"
+	"github.com/matheus3301/asteroids/internal/storage"
+)
+
+var logger = logging.For("sync")
+
+func main() {
+	endpoint := flag.String("endpoint", "", "S3-compatible service URL (default: AWS in -region)")
+	region := flag.String("region", os.Getenv("AWS_REGION"), "S3 region (default us-east-1)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: sync [flags] https://dav.example.com/asteroids/ | s3://bucket/prefix\n\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "WebDAV credentials come from the URL or ASTEROIDS_SYNC_USER and ASTEROIDS_SYNC_PASSWORD;\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "S3 credentials from AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY.\n\n")
+		flag.PrintDefaults()
+	}
+	logFlags := logging.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+
+	if err := logFlags.Setup(); err != nil {
+		logging.Fatal(logger, "invalid -log", "err", err)
+	}
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	remote, err := cloudsync.ParseRemote(flag.Arg(0), *endpoint, *region, os.Getenv)
+	if err != nil {
+		logging.Fatal(logger, "invalid remote", "err", err)
+	}
+	dirs, err := storage.Default()
+	if err != nil {
+		logging.Fatal(logger, "locating data directory", "err", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	rep, err := cloudsync.Sync(ctx, remote, cloudsync.Roots(dirs))
+	for _, key := range rep.Uploaded {
+		fmt.Println("uploaded  ", key)
+	}
+	for _, key := range rep.Downloaded {
+		fmt.Println("downloaded", key)
+	}
+	if err != nil {
+		logging.Fatal(logger, "sync failed", "err", err)
+	}
+	fmt.Printf("%d uploaded, %d downloaded\n", len(rep.Uploaded), len(rep.Downloaded))
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/matheus3301/asteroids/internal/canvas"
+	"github.com/matheus3301/asteroids/internal/game"
+)
+
+const (
+	// heatCell is the side of a heatmap cell in world pixels.
+	heatCell = 10
+	// edgeBand is how close to the screen edge counts as hugging it.
+	edgeBand = 100.0
+)
+
+// heatmap accumulates, over every episode of a session, where the ship
+// spent its time, where it fired and where it was lost.
+type heatmap struct {
+	cols, rows  int
+	visits      []int
+	shots, hits []int
+	ticks       int
+	edgeTicks   int
+	episodes    int
+
+	// State captured by begin for the tick being advanced.
+	w         *game.World
+	x, y      float64
+	alive     bool
+	fired     int
+	lost      int
+	lastWorld *game.World
+}
+
+func newHeatmap() *heatmap {
+	cols := int(math.Ceil(game.ScreenWidth / heatCell))
+	rows := int(math.Ceil(game.ScreenHeight / heatCell))
+	return &heatmap{
+		cols: cols, rows: rows,
+		visits: make([]int, cols*rows),
+		shots:  make([]int, cols*rows),
+		hits:   make([]int, cols*rows),
+	}
+}
+
+// begin notes the ship's position and counters before w advances a tick.
+func (h *heatmap) begin(w *game.World) {
+	if w != h.lastWorld {
+		h.lastWorld = w
+		h.episodes++
+	}
+	h.w = w
+	h.x, h.y, h.alive = w.PlayerPosition()
+	h.fired = w.Stats.ShotsFired
+	h.lost = totalDeaths(w)
+}
+
+// end records the tick begun by begin. It reads the same world even if the
+// source has since moved on to a new game, so the last death still counts.
+func (h *heatmap) end() {
+	if h.w == nil || !h.alive {
+		return
+	}
+	i := h.cell(h.x, h.y)
+	h.ticks++
+	h.visits[i]++
+	if h.x < edgeBand || h.x > game.ScreenWidth-edgeBand || h.y < edgeBand || h.y > game.ScreenHeight-edgeBand {
+		h.edgeTicks++
+	}
+	h.shots[i] += h.w.Stats.ShotsFired - h.fired
+	h.hits[i] += totalDeaths(h.w) - h.lost
+	h.w = nil
+}
+
+func (h *heatmap) cell(x, y float64) int {
+	c := min(max(int(x/heatCell), 0), h.cols-1)
+	r := min(max(int(y/heatCell), 0), h.rows-1)
+	return r*h.cols + c
+}
+
+// edgeShare is the fraction of time the ship spent near a screen edge: high
+// for a corner camper, close to the area share of the band for a roamer.
+func (h *heatmap) edgeShare() float64 {
+	if h.ticks == 0 {
+		return 0
+	}
+	return float64(h.edgeTicks) / float64(h.ticks)
+}
+
+// render draws the time spent per cell on a log colour ramp, shots as
+// yellow dots and deaths as red crosses, at world resolution.
+func (h *heatmap) render() *canvas.Raster {
+	r := canvas.NewRaster(game.ScreenWidth, game.ScreenHeight)
+	r.Fill(color.Black)
+
+	peak, peakShots := 0, 0
+	for i := range h.visits {
+		peak = max(peak, h.visits[i])
+		peakShots = max(peakShots, h.shots[i])
+	}
+	for i, v := range h.visits {
+		if v == 0 {
+			continue
+		}
+		x, y := float64(i%h.cols*heatCell), float64(i/h.cols*heatCell)
+		r.FillRect(x, y, heatCell, heatCell, heatColor(math.Log1p(float64(v))/math.Log1p(float64(peak))))
+	}
+	for i := range h.visits {
+		cx := float64(i%h.cols*heatCell) + heatCell/2
+		cy := float64(i/h.cols*heatCell) + heatCell/2
+		if n := h.shots[i]; n > 0 {
+			r.FillCircle(cx, cy, 1+2*float64(n)/float64(peakShots), color.RGBA{255, 255, 0, 160})
+		}
+		if h.hits[i] > 0 {
+			red := color.RGBA{255, 40, 40, 255}
+			r.StrokeLine(cx-4, cy-4, cx+4, cy+4, 2, red)
+			r.StrokeLine(cx-4, cy+4, cx+4, cy-4, 2, red)
+		}
+	}
+	return r
+}
+
+// heatColor maps t in [0, 1] from dark blue through magenta to white.
+func heatColor(t float64) color.RGBA {
+	t = min(max(t, 0), 1)
+	switch {
+	case t < 0.5:
+		s := t / 0.5
+		return color.RGBA{uint8(200 * s), 0, uint8(80 + 120*s), 255}
+	default:
+		s := (t - 0.5) / 0.5
+		return color.RGBA{uint8(200 + 55*s), uint8(255 * s), uint8(200 + 55*s), 255}
+	}
+}
+
+func totalDeaths(w *game.World) int {
+	n := 0
+	for _, d := range w.Stats.Deaths {
+		n += d
+	}
+	return n
+}
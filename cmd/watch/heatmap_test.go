@@ -0,0 +1,103 @@
+package main
+
+import (
+	"image/color"
+	"path/filepath"
+	"testing"
+
+	"github.com/matheus3301/asteroids/internal/canvas"
+	"github.com/matheus3301/asteroids/internal/game"
+)
+
+// shipAt is a world with the ship alone at x, y.
+func shipAt(x, y float64) *game.World {
+	w := game.NewWorld()
+	w.Player = game.SpawnPlayer(w, x, y)
+	return w
+}
+
+// record adds ticks ticks of w to h, firing shots and losing deaths ships
+// on the last of them.
+func record(h *heatmap, w *game.World, ticks, shots, deaths int) {
+	for i := 0; i < ticks; i++ {
+		h.begin(w)
+		if i == ticks-1 {
+			w.Stats.ShotsFired += shots
+			w.Stats.Deaths[game.DeathAsteroid] += deaths
+		}
+		h.end()
+	}
+}
+
+func TestHeatmap_Bins(t *testing.T) {
+	h := newHeatmap()
+	record(h, shipAt(25, 35), 8, 3, 0)
+	record(h, shipAt(400, 300), 2, 0, 1)
+
+	corner, centre := h.cell(25, 35), h.cell(400, 300)
+	if corner != 3*h.cols+2 {
+		t.Fatalf("(25, 35) should fall in column 2, row 3, got cell %d", corner)
+	}
+	if h.visits[corner] != 8 || h.visits[centre] != 2 || h.ticks != 10 {
+		t.Errorf("visits %d and %d of %d ticks, want 8 and 2 of 10", h.visits[corner], h.visits[centre], h.ticks)
+	}
+	if h.shots[corner] != 3 || h.hits[centre] != 1 || h.hits[corner] != 0 {
+		t.Errorf("shots %d, deaths %d and %d", h.shots[corner], h.hits[centre], h.hits[corner])
+	}
+	if h.episodes != 2 {
+		t.Errorf("each world is an episode, got %d", h.episodes)
+	}
+	if got := h.edgeShare(); got != 0.8 {
+		t.Errorf("edge share = %v, want 0.8", got)
+	}
+	if h.cell(-5, 1e6) != (h.rows-1)*h.cols {
+		t.Error("positions off the screen should be clamped to the edge cells")
+	}
+}
+
+func TestHeatmap_DeadShipIsNotCounted(t *testing.T) {
+	h := newHeatmap()
+	w := game.NewWorld()
+	h.begin(w)
+	h.end()
+	if h.ticks != 0 || h.episodes != 1 {
+		t.Errorf("a tick without a ship should not be binned: %d ticks", h.ticks)
+	}
+}
+
+func TestHeatmap_Render(t *testing.T) {
+	h := newHeatmap()
+	record(h, shipAt(25, 35), 8, 0, 0)
+	record(h, shipAt(405, 305), 2, 0, 0)
+
+	path := filepath.Join(t.TempDir(), "heat.png")
+	if err := h.render().SavePNG(path); err != nil {
+		t.Fatal(err)
+	}
+	img, err := canvas.LoadPNG(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := img.Bounds(); b.Dx() != game.ScreenWidth || b.Dy() != game.ScreenHeight {
+		t.Fatalf("the heatmap should be %dx%d, got %v", game.ScreenWidth, game.ScreenHeight, b)
+	}
+	if got := img.RGBAAt(25, 35); got != (color.RGBA{255, 255, 255, 255}) {
+		t.Errorf("the busiest cell should be white, got %v", got)
+	}
+	quiet := img.RGBAAt(405, 305)
+	if quiet.R == 0 || quiet.R >= 255 || quiet.G != 0 {
+		t.Errorf("a quieter cell should sit between blue and white on the log scale, got %v", quiet)
+	}
+	if got := img.RGBAAt(700, 500); got != (color.RGBA{0, 0, 0, 255}) {
+		t.Errorf("unvisited cells should stay black, got %v", got)
+	}
+}
+
+func TestHeatColor_Ramp(t *testing.T) {
+	if got := heatColor(-1); got != heatColor(0) || got != (color.RGBA{0, 0, 80, 255}) {
+		t.Errorf("the ramp should start at dark blue, got %v", got)
+	}
+	if got := heatColor(2); got != (color.RGBA{255, 255, 255, 255}) {
+		t.Errorf("the ramp should end at white, got %v", got)
+	}
+}
@@ -0,0 +1,280 @@
+// Command watch runs the simulation without a window and shows it in the
+// terminal, for keeping an eye on agents on machines without a display.
+//
+// The ship is driven by a replay file, a remote agent (-remote) or, by
+// default, the scripted autopilot. With -tui the playfield is drawn as
+// braille or ASCII art a few times per second; without it only the status
+// line is printed. A summary of every finished game is shown as well, and
+// -retry plays the same seed again instead of moving on. With -heatmap the
+// session's ship positions, shots and deaths are accumulated over every
+// episode and saved as a PNG on exit.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"image/color"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/matheus3301/asteroids/internal/canvas"
+	"github.com/matheus3301/asteroids/internal/game"
+	"github.com/matheus3301/asteroids/internal/logging"
+	"github.com/matheus3301/asteroids/internal/remote"
+	"github.com/matheus3301/asteroids/internal/tui"
+)
+
+var logger = logging.For("watch")
+
+// source advances a world one tick at a time.
+type source interface {
+	World() *game.World
+	Advance()
+	Done() bool
+}
+
+// replaySource plays back a recording.
+type replaySource struct {
+	runner *game.ReplayRunner
+}
+
+func (s replaySource) World() *game.World { return s.runner.World }
+func (s replaySource) Advance()           { s.runner.Advance() }
+func (s replaySource) Done() bool         { return s.runner.Done() }
+
+// liveSource plays endless games, starting the next one whenever the ship
+// runs out of lives: with the next seed, or the same one again with retry.
+type liveSource struct {
+	w     *game.World
+	seed  int64
+	input game.InputSource
+	retry bool
+	// episode counts finished games; ended, if set, is given a summary of
+	// each one.
+	episode int
+	ended   func(summary string)
+}
+
+func (s *liveSource) World() *game.World { return s.w }
+func (s *liveSource) Done() bool         { return false }
+
+func (s *liveSource) Advance() {
+	game.Step(s.w, s.input.NextInput(s.w))
+	if s.w.GameOver() {
+		s.episode++
+		if s.ended != nil {
+			s.ended(summary(s.episode, s.seed, s.w))
+		}
+		if !s.retry {
+			s.seed++
+		}
+		s.w = game.NewGameWorld(s.seed)
+	}
+}
+
+// summary describes a finished game: its result, how well the ship shot and
+// what it was lost to.
+func summary(episode int, seed int64, w *game.World) string {
+	st := w.Stats
+	kills := st.AsteroidsDestroyed + st.SaucersDestroyed
+	perShot := 0.0
+	if st.ShotsFired > 0 {
+		perShot = float64(kills) / float64(st.ShotsFired)
+	}
+	var deaths []string
+	for c, n := range st.Deaths {
+		if n > 0 {
+			deaths = append(deaths, fmt.Sprintf("%s %d", game.DeathCause(c), n))
+		}
+	}
+	return fmt.Sprintf("EPISODE %d  SEED %d  SCORE %d  LEVEL %d  TICKS %d  SHOTS %d  KILLS/SHOT %.2f  JUMPS %d  LOST TO %s",
+		episode, seed, w.Score, w.Level, w.Tick, st.ShotsFired, perShot, st.HyperspaceJumps, strings.Join(deaths, ", "))
+}
+
+func main() {
+	useTUI := flag.Bool("tui", false, "draw the playfield in the terminal")
+	styleName := flag.String("style", "braille", "terminal drawing style: braille or ascii")
+	cols := flag.Int("cols", 100, "width of the playfield in terminal columns")
+	hz := flag.Float64("hz", 4, "screen refreshes per second")
+	tps := flag.Int("tps", 60, "simulation ticks per second")
+	seed := flag.Int64("seed", 1, "RNG seed of the first game when not playing a replay")
+	remoteAddr := flag.String("remote", "", "let an external agent drive the ship over TCP on this address")
+	remoteTimeout := flag.Duration("remote-timeout", remote.DefaultTimeout, "how long each tick waits for the agent before holding its last action")
+	remoteView := flag.Float64("remote-view", 0, "only tell the agent about objects within this many pixels of the ship (0 for no limit)")
+	minHold := flag.Int("min-hold", 0, "hold each turn or thrust the agent presses for at least this many ticks")
+	trajectories := flag.Bool("trajectories", false, "with -tui, draw where objects are heading and mark those on course to hit the ship")
+	heatPath := flag.String("heatmap", "", "save a heatmap of ship positions, shots and deaths to this PNG on exit")
+	retry := flag.Bool("retry", false, "play the same seed again after every game over instead of the next one")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: watch [-tui] [-remote addr] [file.replay]\n\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "Runs the game headlessly and prints it to the terminal.\n\n")
+		flag.PrintDefaults()
+	}
+	logFlags := logging.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+
+	if err := logFlags.Setup(); err != nil {
+		logging.Fatal(logger, "invalid -log", "err", err)
+	}
+	style, err := tui.ParseStyle(*styleName)
+	if err != nil {
+		logging.Fatal(logger, "invalid -style", "err", err)
+	}
+	if *cols < 10 || *hz <= 0 || *tps <= 0 {
+		logging.Fatal(logger, "-cols must be at least 10, -hz and -tps must be positive")
+	}
+	if *remoteAddr != "" && flag.NArg() > 0 {
+		logging.Fatal(logger, "-remote cannot be combined with a replay file")
+	}
+	if *minHold > 1 && flag.NArg() > 0 {
+		logging.Fatal(logger, "-min-hold cannot be combined with a replay file")
+	}
+	if *retry && flag.NArg() > 0 {
+		logging.Fatal(logger, "-retry cannot be combined with a replay file")
+	}
+	hold := func(in game.InputSource) game.InputSource {
+		if *minHold <= 1 {
+			return in
+		}
+		return &game.HoldInput{Source: in, Hold: game.ActionHold{MinTicks: *minHold}}
+	}
+
+	var src source
+	switch {
+	case flag.NArg() > 0:
+		r, err := game.LoadReplay(flag.Arg(0))
+		if err != nil {
+			logging.Fatal(logger, "loading replay", "path", flag.Arg(0), "err", err)
+		}
+		src = replaySource{game.NewReplayRunner(r)}
+	case *remoteAddr != "":
+		srv, err := remote.Listen(*remoteAddr, *remoteTimeout)
+		if err != nil {
+			logging.Fatal(logger, "starting remote agent server", "err", err)
+		}
+		srv.LimitView(*remoteView)
+		logger.Info("waiting for agent", "addr", srv.Addr().String())
+		src = &liveSource{w: game.NewGameWorld(*seed), seed: *seed, input: hold(srv), retry: *retry}
+	default:
+		src = &liveSource{w: game.NewGameWorld(*seed), seed: *seed, input: hold(game.Autopilot{}), retry: *retry}
+	}
+
+	var heat *heatmap
+	if *heatPath != "" {
+		heat = newHeatmap()
+		defer func() {
+			if err := heat.render().SavePNG(*heatPath); err != nil {
+				logger.Error("saving heatmap", "path", *heatPath, "err", err)
+				return
+			}
+			logger.Info("heatmap saved", "path", *heatPath, "episodes", heat.episodes,
+				"ticks", heat.ticks, "edge_share", fmt.Sprintf("%.2f", heat.edgeShare()))
+		}()
+	}
+
+	var view *screen
+	if *useTUI {
+		view = newScreen(*cols, style)
+		view.trajectories = *trajectories
+		view.start()
+		defer view.stop()
+	}
+	if live, ok := src.(*liveSource); ok {
+		live.ended = func(summary string) {
+			if view != nil {
+				view.last = summary
+				return
+			}
+			fmt.Println(summary)
+		}
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	tick := time.NewTicker(time.Second / time.Duration(*tps))
+	defer tick.Stop()
+	ticksPerFrame := max(1, int(float64(*tps) / *hz))
+
+	for n := 0; ; n++ {
+		select {
+		case <-interrupt:
+			return
+		case <-tick.C:
+		}
+		if heat != nil {
+			heat.begin(src.World())
+		}
+		src.Advance()
+		if heat != nil {
+			heat.end()
+		}
+		w := src.World()
+		w.SoundQueue = w.SoundQueue[:0]
+
+		if n%ticksPerFrame == 0 || src.Done() {
+			if view != nil {
+				view.draw(w)
+			} else {
+				fmt.Println(status(w))
+			}
+		}
+		if src.Done() {
+			return
+		}
+	}
+}
+
+// status is the one-line HUD printed under the playfield.
+func status(w *game.World) string {
+	return fmt.Sprintf("SCORE %d  LIVES %d  LEVEL %d  TICK %d", w.Score, w.Lives, w.Level, w.Tick)
+}
+
+// screen redraws the playfield in place using ANSI escapes.
+type screen struct {
+	raster *canvas.Raster
+	scale  float64
+	style  tui.Style
+	// trajectories draws game.DrawTrajectories over the playfield.
+	trajectories bool
+	// last summarises the previous game, shown under the status line.
+	last string
+	out  strings.Builder
+}
+
+func newScreen(cols int, style tui.Style) *screen {
+	scale := float64(cols*tui.CellW) / game.ScreenWidth
+	return &screen{
+		raster: canvas.NewRaster(cols*tui.CellW, int(game.ScreenHeight*scale)),
+		scale:  scale,
+		style:  style,
+	}
+}
+
+// start clears the terminal and hides the cursor.
+func (s *screen) start() { fmt.Print("\x1b[2J\x1b[?25l") }
+
+// stop shows the cursor again.
+func (s *screen) stop() { fmt.Print("\x1b[?25h\n") }
+
+func (s *screen) draw(w *game.World) {
+	s.raster.Fill(color.Black)
+	c := canvas.Scale(s.raster, s.scale)
+	game.DrawWorld(w, c)
+	if s.trajectories {
+		game.DrawTrajectories(w, c)
+	}
+
+	s.out.Reset()
+	s.out.WriteString("\x1b[H")
+	s.out.WriteString(tui.Render(s.raster.Img, s.style))
+	s.out.WriteString("\n\x1b[K")
+	s.out.WriteString(status(w))
+	if s.last != "" {
+		s.out.WriteString("\n\x1b[K")
+		s.out.WriteString(s.last)
+	}
+	s.out.WriteString("\x1b[J")
+	fmt.Print(s.out.String())
+}
@@ -2,7 +2,10 @@ module github.com/matheus3301/asteroids
 
 go 1.25.0
 
-require github.com/hajimehoshi/ebiten/v2 v2.9.8
+require (
+	github.com/hajimehoshi/ebiten/v2 v2.9.8
+	go.starlark.net v0.0.0-20231121155337-90ade8b19d09
+)
 
 require (
 	github.com/ebitengine/gomobile v0.0.0-20250923094054-ea854a63cce1 // indirect
@@ -0,0 +1,165 @@
+// Package balance plays many seeded games under each configuration of the
+// rules and summarises how they went: how long the ship survived, how far
+// it got, what it scored and what destroyed it. Saved reports can be
+// compared so a change that makes the game much easier or harder is
+// caught before it ships.
+package balance
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/matheus3301/asteroids/internal/game"
+)
+
+// ticksPerSecond converts survival from ticks to seconds.
+const ticksPerSecond = 60
+
+// Config is one set of rules the report covers.
+type Config struct {
+	Name  string
+	Rules game.Ruleset
+}
+
+// Configs are the standard rules, each other difficulty and each run
+// mutator on its own.
+func Configs() []Config {
+	configs := []Config{{Name: "standard", Rules: game.StandardRules()}}
+	for i, d := range game.Difficulties {
+		if game.Difficulty(i) == game.DifficultyNormal {
+			continue
+		}
+		r := game.StandardRules()
+		r.Config = d.Apply(r.Config)
+		configs = append(configs, Config{Name: d.ID, Rules: r})
+	}
+	for i, m := range game.Mutators {
+		r := game.StandardRules()
+		r.Config = game.MutatorSet(0).Toggle(i).Apply(r.Config)
+		configs = append(configs, Config{Name: m.ID, Rules: r})
+	}
+	return configs
+}
+
+// Dist summarises a distribution.
+type Dist struct {
+	Mean float64 `json:"mean"`
+	P10  float64 `json:"p10"`
+	P50  float64 `json:"p50"`
+	P90  float64 `json:"p90"`
+}
+
+// distOf summarises values, which it sorts.
+func distOf(values []float64) Dist {
+	if len(values) == 0 {
+		return Dist{}
+	}
+	sort.Float64s(values)
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	at := func(q float64) float64 {
+		return values[int(math.Round(q*float64(len(values)-1)))]
+	}
+	return Dist{Mean: sum / float64(len(values)), P10: at(0.1), P50: at(0.5), P90: at(0.9)}
+}
+
+// Result is how the games of one configuration went.
+type Result struct {
+	Config string `json:"config"`
+	Games  int    `json:"games"`
+	// TimedOut counts games stopped at the tick limit with lives left.
+	TimedOut int `json:"timed_out"`
+	// Survival is in seconds and Wave is the level reached.
+	Survival Dist `json:"survival_seconds"`
+	Wave     Dist `json:"wave"`
+	Score    Dist `json:"score"`
+	// Deaths is the share of all ships lost to each cause.
+	Deaths map[string]float64 `json:"deaths"`
+}
+
+// Report is a balance run: a Result per configuration.
+type Report struct {
+	Seed     int64    `json:"seed"`
+	Episodes int      `json:"episodes"`
+	MaxTicks int      `json:"max_ticks"`
+	Results  []Result `json:"results"`
+}
+
+// Result returns the result for the named configuration.
+func (r Report) Result(config string) (Result, bool) {
+	i := slices.IndexFunc(r.Results, func(res Result) bool { return res.Config == config })
+	if i < 0 {
+		return Result{}, false
+	}
+	return r.Results[i], true
+}
+
+// Run plays episodes games of c, with seeds seed, seed+1, ..., each until
+// the ship runs out of lives or maxTicks pass.
+func Run(c Config, in game.InputSource, seed int64, episodes, maxTicks int) Result {
+	res := Result{Config: c.Name, Games: episodes, Deaths: map[string]float64{}}
+	var survival, wave, score []float64
+	var deaths [game.NumDeathCauses]int
+	lost := 0
+	for i := range episodes {
+		w := game.NewModdedWorld(seed+int64(i), c.Rules)
+		for !w.GameOver() && w.Tick < maxTicks {
+			game.Step(w, in.NextInput(w))
+			w.SoundQueue = w.SoundQueue[:0]
+		}
+		if !w.GameOver() {
+			res.TimedOut++
+		}
+		survival = append(survival, float64(w.Tick)/ticksPerSecond)
+		wave = append(wave, float64(w.Level))
+		score = append(score, float64(w.Score))
+		for cause, n := range w.Stats.Deaths {
+			deaths[cause] += n
+			lost += n
+		}
+	}
+	res.Survival, res.Wave, res.Score = distOf(survival), distOf(wave), distOf(score)
+	for cause, n := range deaths {
+		if lost > 0 {
+			res.Deaths[game.DeathCause(cause).String()] = float64(n) / float64(lost)
+		}
+	}
+	return res
+}
+
+// Compare lists how cur has moved from base beyond tolerance: mean
+// survival, wave or score changing by more than that fraction, or the
+// share of deaths to a cause by more than that many points. Configurations
+// missing from either report are skipped.
+func Compare(base, cur Report, tolerance float64) []string {
+	var out []string
+	for _, c := range cur.Results {
+		b, ok := base.Result(c.Config)
+		if !ok {
+			continue
+		}
+		check := func(what string, was, now float64) {
+			if was == 0 && now == 0 {
+				return
+			}
+			if change := (now - was) / math.Max(math.Abs(was), 1e-9); math.Abs(change) > tolerance {
+				out = append(out, fmt.Sprintf("%s: mean %s %.4g -> %.4g (%+.0f%%)", c.Config, what, was, now, change*100))
+			}
+		}
+		check("survival", b.Survival.Mean, c.Survival.Mean)
+		check("wave", b.Wave.Mean, c.Wave.Mean)
+		check("score", b.Score.Mean, c.Score.Mean)
+
+		for i := range game.NumDeathCauses {
+			cause := game.DeathCause(i).String()
+			if d := c.Deaths[cause] - b.Deaths[cause]; math.Abs(d) > tolerance {
+				out = append(out, fmt.Sprintf("%s: deaths to %s %.0f%% -> %.0f%%", c.Config, cause, b.Deaths[cause]*100, c.Deaths[cause]*100))
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,81 @@
+package balance
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/matheus3301/asteroids/internal/game"
+)
+
+// scripted flies the bench autopilot.
+type scripted struct{}
+
+func (scripted) NextInput(w *game.World) game.InputState { return game.ScriptedInput(w.Tick) }
+
+func TestConfigs_StandardAndEachMutator(t *testing.T) {
+	configs := Configs()
+	if len(configs) != len(game.Difficulties)+len(game.Mutators) || configs[0].Name != "standard" || !configs[0].Rules.Standard() {
+		t.Fatalf("unexpected configs %v", configs)
+	}
+	for _, c := range configs[1:] {
+		if c.Rules.Standard() {
+			t.Errorf("%s: changed nothing", c.Name)
+		}
+		if err := c.Rules.Config.Validate(); err != nil {
+			t.Errorf("%s: %v", c.Name, err)
+		}
+	}
+}
+
+func TestRun_Reproducible(t *testing.T) {
+	c := Configs()[0]
+	a := Run(c, scripted{}, 1, 4, 3000)
+	b := Run(c, scripted{}, 1, 4, 3000)
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("same seeds gave different results:\n%+v\n%+v", a, b)
+	}
+	if a.Games != 4 || a.Survival.Mean <= 0 || a.Survival.P90 > 3000/ticksPerSecond {
+		t.Errorf("implausible result %+v", a)
+	}
+	var share float64
+	for _, s := range a.Deaths {
+		share += s
+	}
+	if a.TimedOut < a.Games && (share < 0.999 || share > 1.001) {
+		t.Errorf("death shares add up to %v", share)
+	}
+}
+
+func TestDistOf(t *testing.T) {
+	d := distOf([]float64{5, 1, 4, 2, 3, 6, 7, 8, 9, 10, 11})
+	if d.Mean != 6 || d.P10 != 2 || d.P50 != 6 || d.P90 != 10 {
+		t.Errorf("got %+v", d)
+	}
+	if (distOf(nil) != Dist{}) {
+		t.Error("empty distribution should be zero")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	base := Report{Results: []Result{{
+		Config:   "standard",
+		Survival: Dist{Mean: 100},
+		Wave:     Dist{Mean: 3},
+		Score:    Dist{Mean: 5000},
+		Deaths:   map[string]float64{"asteroid": 0.8, "saucer bullet": 0.2},
+	}}}
+	cur := base
+	cur.Results = []Result{base.Results[0]}
+	cur.Results[0].Survival.Mean = 105
+	if got := Compare(base, cur, 0.1); len(got) != 0 {
+		t.Errorf("a 5%% change is within tolerance, got %v", got)
+	}
+
+	cur.Results[0].Score.Mean = 3000
+	cur.Results[0].Deaths = map[string]float64{"asteroid": 0.5, "saucer bullet": 0.5}
+	got := Compare(base, cur, 0.1)
+	if len(got) != 3 || !strings.Contains(got[0], "score") || !strings.Contains(got[1], "asteroid") {
+		t.Errorf("expected score and both death shares flagged, got %v", got)
+	}
+}
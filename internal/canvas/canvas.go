@@ -0,0 +1,134 @@
+// Package canvas abstracts the few drawing primitives the game uses, so
+// screens can be drawn without a GPU: to a software raster for golden-image
+// tests and terminal output, or to a recording for asserting on draw calls.
+package canvas
+
+import "image/color"
+
+// Canvas is a drawing surface. Coordinates are in screen pixels.
+type Canvas interface {
+	// Fill paints the whole canvas.
+	Fill(clr color.Color)
+	StrokeLine(x1, y1, x2, y2, width float64, clr color.Color)
+	StrokeRect(x, y, w, h, width float64, clr color.Color)
+	FillRect(x, y, w, h float64, clr color.Color)
+	FillCircle(cx, cy, r float64, clr color.Color)
+}
+
+// Op is one recorded draw call.
+type Op struct {
+	Kind  string // "fill", "line", "rect", "fillrect" or "circle"
+	Args  []float64
+	Color color.RGBA
+}
+
+// Recording is a Canvas that remembers every call.
+type Recording struct {
+	Ops []Op
+}
+
+func (r *Recording) add(kind string, clr color.Color, args ...float64) {
+	r.Ops = append(r.Ops, Op{Kind: kind, Args: args, Color: toRGBA(clr)})
+}
+
+// Fill implements Canvas.
+func (r *Recording) Fill(clr color.Color) { r.add("fill", clr) }
+
+// StrokeLine implements Canvas.
+func (r *Recording) StrokeLine(x1, y1, x2, y2, width float64, clr color.Color) {
+	r.add("line", clr, x1, y1, x2, y2, width)
+}
+
+// StrokeRect implements Canvas.
+func (r *Recording) StrokeRect(x, y, w, h, width float64, clr color.Color) {
+	r.add("rect", clr, x, y, w, h, width)
+}
+
+// FillRect implements Canvas.
+func (r *Recording) FillRect(x, y, w, h float64, clr color.Color) {
+	r.add("fillrect", clr, x, y, w, h)
+}
+
+// FillCircle implements Canvas.
+func (r *Recording) FillCircle(cx, cy, radius float64, clr color.Color) {
+	r.add("circle", clr, cx, cy, radius)
+}
+
+// Count returns how many recorded calls are of the given kind.
+func (r *Recording) Count(kind string) int {
+	n := 0
+	for _, op := range r.Ops {
+		if op.Kind == kind {
+			n++
+		}
+	}
+	return n
+}
+
+func toRGBA(c color.Color) color.RGBA {
+	if rgba, ok := c.(color.RGBA); ok {
+		return rgba
+	}
+	r, g, b, a := c.RGBA()
+	return color.RGBA{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8), uint8(a >> 8)}
+}
+
+// Scale returns a Canvas that draws to c with every coordinate and width
+// multiplied by f.
+func Scale(c Canvas, f float64) Canvas {
+	return scaled{c, f}
+}
+
+type scaled struct {
+	c Canvas
+	f float64
+}
+
+func (s scaled) Fill(clr color.Color) { s.c.Fill(clr) }
+
+func (s scaled) StrokeLine(x1, y1, x2, y2, width float64, clr color.Color) {
+	s.c.StrokeLine(x1*s.f, y1*s.f, x2*s.f, y2*s.f, width*s.f, clr)
+}
+
+func (s scaled) StrokeRect(x, y, w, h, width float64, clr color.Color) {
+	s.c.StrokeRect(x*s.f, y*s.f, w*s.f, h*s.f, width*s.f, clr)
+}
+
+func (s scaled) FillRect(x, y, w, h float64, clr color.Color) {
+	s.c.FillRect(x*s.f, y*s.f, w*s.f, h*s.f, clr)
+}
+
+func (s scaled) FillCircle(cx, cy, r float64, clr color.Color) {
+	s.c.FillCircle(cx*s.f, cy*s.f, r*s.f, clr)
+}
+
+// Offset returns a Canvas that draws to c moved by dx, dy.
+func Offset(c Canvas, dx, dy float64) Canvas {
+	if dx == 0 && dy == 0 {
+		return c
+	}
+	return offset{c, dx, dy}
+}
+
+type offset struct {
+	c      Canvas
+	dx, dy float64
+}
+
+func (o offset) Fill(clr color.Color) { o.c.Fill(clr) }
+
+func (o offset) StrokeLine(x1, y1, x2, y2, width float64, clr color.Color) {
+	o.c.StrokeLine(x1+o.dx, y1+o.dy, x2+o.dx, y2+o.dy, width, clr)
+}
+
+func (o offset) StrokeRect(x, y, w, h, width float64, clr color.Color) {
+	o.c.StrokeRect(x+o.dx, y+o.dy, w, h, width, clr)
+}
+
+func (o offset) FillRect(x, y, w, h float64, clr color.Color) {
+	o.c.FillRect(x+o.dx, y+o.dy, w, h, clr)
+}
+
+func (o offset) FillCircle(cx, cy, r float64, clr color.Color) {
+	o.c.FillCircle(cx+o.dx, cy+o.dy, r, clr)
+}
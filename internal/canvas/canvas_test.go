@@ -0,0 +1,138 @@
+package canvas
+
+import (
+	"image/color"
+	"path/filepath"
+	"testing"
+)
+
+var (
+	black = color.RGBA{0, 0, 0, 255}
+	white = color.RGBA{255, 255, 255, 255}
+)
+
+func TestRecording(t *testing.T) {
+	var r Recording
+	r.Fill(black)
+	r.StrokeLine(0, 0, 10, 10, 1, white)
+	r.StrokeLine(0, 10, 10, 0, 1, white)
+	r.FillCircle(5, 5, 2, color.Gray{128})
+
+	if r.Count("line") != 2 || r.Count("circle") != 1 || len(r.Ops) != 4 {
+		t.Errorf("ops = %+v", r.Ops)
+	}
+	if got := r.Ops[3].Color; got != (color.RGBA{128, 128, 128, 255}) {
+		t.Errorf("colour not converted to RGBA: %v", got)
+	}
+}
+
+func TestRaster_Line(t *testing.T) {
+	r := NewRaster(10, 10)
+	r.Fill(black)
+	r.StrokeLine(0, 5, 10, 5, 1, white)
+
+	for x := 0; x < 10; x++ {
+		if r.Img.RGBAAt(x, 5) != white {
+			t.Fatalf("pixel (%d,5) not painted", x)
+		}
+		if r.Img.RGBAAt(x, 2) != black {
+			t.Fatalf("pixel (%d,2) should be untouched", x)
+		}
+	}
+}
+
+func TestRaster_FillRectAndCircle(t *testing.T) {
+	r := NewRaster(20, 20)
+	r.FillRect(2, 2, 4, 3, white)
+	r.FillCircle(15, 15, 3, white)
+
+	painted := 0
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			if r.Img.RGBAAt(x, y) == white {
+				painted++
+			}
+		}
+	}
+	if painted != 12 {
+		t.Errorf("rect painted %d pixels, want 12", painted)
+	}
+	if r.Img.RGBAAt(15, 15) != white || r.Img.RGBAAt(19, 19) == white {
+		t.Error("circle should cover its centre and not the corner")
+	}
+}
+
+func TestRaster_AlphaBlends(t *testing.T) {
+	r := NewRaster(1, 1)
+	r.Fill(black)
+	r.FillRect(0, 0, 1, 1, color.RGBA{255, 255, 255, 128})
+	if got := r.Img.RGBAAt(0, 0); got.R < 120 || got.R > 135 || got.A != 255 {
+		t.Errorf("half-transparent white over black = %v", got)
+	}
+}
+
+func TestRaster_ClipsToBounds(t *testing.T) {
+	r := NewRaster(5, 5)
+	r.StrokeLine(-100, -100, 100, 100, 3, white)
+	r.FillCircle(-50, 2, 10, white)
+}
+
+func TestSaveLoadDiff(t *testing.T) {
+	r := NewRaster(8, 8)
+	r.Fill(black)
+	r.StrokeRect(1, 1, 6, 6, 1, white)
+	path := filepath.Join(t.TempDir(), "x.png")
+	if err := r.SavePNG(path); err != nil {
+		t.Fatal(err)
+	}
+	img, err := LoadPNG(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, err := Diff(r.Img, img); err != nil || n != 0 {
+		t.Errorf("round trip differs in %d pixels (%v)", n, err)
+	}
+
+	r.FillRect(3, 3, 2, 2, white)
+	if n, _ := Diff(r.Img, img); n != 4 {
+		t.Errorf("expected 4 differing pixels, got %d", n)
+	}
+	if _, err := Diff(r.Img, NewRaster(2, 2).Img); err == nil {
+		t.Error("expected an error for mismatched sizes")
+	}
+}
+
+func TestScale(t *testing.T) {
+	var r Recording
+	c := Scale(&r, 0.5)
+	c.StrokeLine(10, 20, 30, 40, 2, white)
+	c.FillCircle(100, 100, 8, white)
+
+	want := [][]float64{{5, 10, 15, 20, 1}, {50, 50, 4}}
+	for i, op := range r.Ops {
+		for j, v := range want[i] {
+			if op.Args[j] != v {
+				t.Fatalf("op %d = %v, want %v", i, op.Args, want[i])
+			}
+		}
+	}
+}
+
+func TestOffset(t *testing.T) {
+	var r Recording
+	c := Offset(&r, 100, -50)
+	c.StrokeLine(10, 20, 30, 40, 2, white)
+	c.FillRect(0, 60, 8, 4, white)
+
+	want := [][]float64{{110, -30, 130, -10, 2}, {100, 10, 8, 4}}
+	for i, op := range r.Ops {
+		for j, v := range want[i] {
+			if op.Args[j] != v {
+				t.Fatalf("op %d = %v, want %v", i, op.Args, want[i])
+			}
+		}
+	}
+	if Offset(&r, 0, 0) != Canvas(&r) {
+		t.Error("a zero offset should draw straight to the canvas")
+	}
+}
@@ -0,0 +1,166 @@
+package canvas
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+// Raster is a Canvas that draws into an in-memory image on the CPU. It is
+// not antialiased: a pixel is painted when its centre is inside the shape,
+// which keeps the output identical on every machine.
+type Raster struct {
+	Img *image.RGBA
+}
+
+// NewRaster returns a transparent raster of the given size.
+func NewRaster(w, h int) *Raster {
+	return &Raster{Img: image.NewRGBA(image.Rect(0, 0, w, h))}
+}
+
+// Fill implements Canvas.
+func (r *Raster) Fill(clr color.Color) {
+	c := toRGBA(clr)
+	b := r.Img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			r.Img.SetRGBA(x, y, c)
+		}
+	}
+}
+
+// StrokeLine implements Canvas.
+func (r *Raster) StrokeLine(x1, y1, x2, y2, width float64, clr color.Color) {
+	half := math.Max(width/2, 0.5)
+	dx, dy := x2-x1, y2-y1
+	lenSq := dx*dx + dy*dy
+	r.paint(math.Min(x1, x2)-half, math.Min(y1, y2)-half, math.Max(x1, x2)+half, math.Max(y1, y2)+half, clr,
+		func(px, py float64) bool {
+			t := 0.0
+			if lenSq > 0 {
+				t = math.Max(0, math.Min(1, ((px-x1)*dx+(py-y1)*dy)/lenSq))
+			}
+			ex, ey := px-(x1+t*dx), py-(y1+t*dy)
+			return ex*ex+ey*ey <= half*half
+		})
+}
+
+// StrokeRect implements Canvas.
+func (r *Raster) StrokeRect(x, y, w, h, width float64, clr color.Color) {
+	r.StrokeLine(x, y, x+w, y, width, clr)
+	r.StrokeLine(x+w, y, x+w, y+h, width, clr)
+	r.StrokeLine(x+w, y+h, x, y+h, width, clr)
+	r.StrokeLine(x, y+h, x, y, width, clr)
+}
+
+// FillRect implements Canvas.
+func (r *Raster) FillRect(x, y, w, h float64, clr color.Color) {
+	r.paint(x, y, x+w, y+h, clr, func(px, py float64) bool {
+		return px >= x && px < x+w && py >= y && py < y+h
+	})
+}
+
+// FillCircle implements Canvas.
+func (r *Raster) FillCircle(cx, cy, radius float64, clr color.Color) {
+	radius = math.Max(radius, 0.5)
+	r.paint(cx-radius, cy-radius, cx+radius, cy+radius, clr, func(px, py float64) bool {
+		dx, dy := px-cx, py-cy
+		return dx*dx+dy*dy <= radius*radius
+	})
+}
+
+// paint blends clr over every pixel in the box whose centre satisfies inside.
+func (r *Raster) paint(minX, minY, maxX, maxY float64, clr color.Color, inside func(px, py float64) bool) {
+	src := toRGBA(clr)
+	if src.A == 0 {
+		return
+	}
+	b := r.Img.Bounds()
+	x0, x1 := max(b.Min.X, int(math.Floor(minX))), min(b.Max.X-1, int(math.Ceil(maxX)))
+	y0, y1 := max(b.Min.Y, int(math.Floor(minY))), min(b.Max.Y-1, int(math.Ceil(maxY)))
+	for y := y0; y <= y1; y++ {
+		for x := x0; x <= x1; x++ {
+			if inside(float64(x)+0.5, float64(y)+0.5) {
+				r.Img.SetRGBA(x, y, over(src, r.Img.RGBAAt(x, y)))
+			}
+		}
+	}
+}
+
+// over composites a non-premultiplied src over dst.
+func over(src, dst color.RGBA) color.RGBA {
+	if src.A == 255 {
+		return src
+	}
+	a := uint32(src.A)
+	mix := func(s, d uint8) uint8 {
+		return uint8((uint32(s)*a + uint32(d)*(255-a)) / 255)
+	}
+	return color.RGBA{
+		R: mix(src.R, dst.R),
+		G: mix(src.G, dst.G),
+		B: mix(src.B, dst.B),
+		A: uint8(a + uint32(dst.A)*(255-a)/255),
+	}
+}
+
+// SavePNG writes the raster to path.
+func (r *Raster) SavePNG(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, r.Img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// LoadPNG reads an image written by SavePNG.
+func LoadPNG(path string) (*image.RGBA, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	img, err := png.Decode(f)
+	if err != nil {
+		return nil, err
+	}
+	if rgba, ok := img.(*image.RGBA); ok {
+		return rgba, nil
+	}
+	b := img.Bounds()
+	rgba := image.NewRGBA(b)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			rgba.Set(x, y, img.At(x, y))
+		}
+	}
+	return rgba, nil
+}
+
+// Diff counts the pixels that differ between two images of the same size.
+func Diff(a, b *image.RGBA) (int, error) {
+	if a.Bounds() != b.Bounds() {
+		return 0, fmt.Errorf("size %v differs from %v", a.Bounds().Size(), b.Bounds().Size())
+	}
+	n := 0
+	bounds := a.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if a.RGBAAt(x, y) != b.RGBAAt(x, y) {
+				n++
+			}
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,269 @@
+// Package cloudsync copies the player's files (settings, profile, stats and
+// replays) to and from a remote the player provides, a WebDAV share or an
+// S3-compatible bucket, so they can move between machines.
+//
+// The remote keeps a manifest of every file's modification time. A sync
+// compares it with the local files and, for each one, copies the newer
+// side over the older; files missing on one side are copied to it.
+// Deletions are not synced.
+package cloudsync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/matheus3301/asteroids/internal/logging"
+	"github.com/matheus3301/asteroids/internal/storage"
+)
+
+var logger = logging.For("cloudsync")
+
+// ManifestName is the manifest's key on the remote.
+const ManifestName = "manifest.json"
+
+// ErrNotFound is returned by Remote.Get for a key that does not exist.
+var ErrNotFound = errors.New("not found on remote")
+
+// Remote stores files by slash-separated key.
+type Remote interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Put(ctx context.Context, key string, data []byte) error
+}
+
+// Manifest records the modification time of every synced file, by key.
+type Manifest struct {
+	Files map[string]time.Time `json:"files"`
+}
+
+// Root is a local directory synced under a key prefix, such as the data
+// directory under "data".
+type Root struct {
+	Prefix string
+	Dir    string
+}
+
+// Roots are the directories holding the player's files.
+func Roots(d storage.Dirs) []Root {
+	roots := []Root{{Prefix: "config", Dir: d.Config}}
+	if d.Data != d.Config {
+		roots = append(roots, Root{Prefix: "data", Dir: d.Data})
+	}
+	return roots
+}
+
+// Report says what a sync copied, by key.
+type Report struct {
+	Uploaded, Downloaded []string
+}
+
+// Sync brings the roots and the remote up to date with each other.
+func Sync(ctx context.Context, r Remote, roots []Root) (Report, error) {
+	var rep Report
+	m, err := loadManifest(ctx, r)
+	if err != nil {
+		return rep, err
+	}
+	local, err := scan(roots)
+	if err != nil {
+		return rep, err
+	}
+
+	keys := make([]string, 0, len(local)+len(m.Files))
+	for key := range local {
+		keys = append(keys, key)
+	}
+	for key := range m.Files {
+		if _, ok := local[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		l, remote := local[key], m.Files[key]
+		switch {
+		case l.mod.Equal(remote):
+			continue
+		case l.mod.After(remote):
+			data, err := os.ReadFile(l.path)
+			if err != nil {
+				return rep, err
+			}
+			if err := r.Put(ctx, key, data); err != nil {
+				return rep, fmt.Errorf("uploading %s: %w", key, err)
+			}
+			m.Files[key] = l.mod
+			rep.Uploaded = append(rep.Uploaded, key)
+		default:
+			file, ok := localPath(roots, key)
+			if !ok {
+				logger.Warn("skipping remote file outside the synced directories", "key", key)
+				continue
+			}
+			data, err := r.Get(ctx, key)
+			if err != nil {
+				return rep, fmt.Errorf("downloading %s: %w", key, err)
+			}
+			if err := writeFile(file, data, remote); err != nil {
+				return rep, err
+			}
+			rep.Downloaded = append(rep.Downloaded, key)
+		}
+	}
+
+	if len(rep.Uploaded) > 0 {
+		b, err := json.MarshalIndent(m, "", "  ")
+		if err != nil {
+			return rep, err
+		}
+		if err := r.Put(ctx, ManifestName, b); err != nil {
+			return rep, fmt.Errorf("uploading manifest: %w", err)
+		}
+	}
+	return rep, nil
+}
+
+func loadManifest(ctx context.Context, r Remote) (*Manifest, error) {
+	m := &Manifest{}
+	b, err := r.Get(ctx, ManifestName)
+	switch {
+	case errors.Is(err, ErrNotFound):
+	case err != nil:
+		return nil, fmt.Errorf("downloading manifest: %w", err)
+	default:
+		if err := json.Unmarshal(b, m); err != nil {
+			return nil, fmt.Errorf("reading manifest: %w", err)
+		}
+	}
+	if m.Files == nil {
+		m.Files = map[string]time.Time{}
+	}
+	return m, nil
+}
+
+// localFile is a file found under a root.
+type localFile struct {
+	path string
+	mod  time.Time
+}
+
+// scan lists the files under roots by key. Temporary files left by an
+// interrupted save are skipped. Times are truncated to what the manifest
+// keeps so that they compare equal after a round trip.
+func scan(roots []Root) (map[string]localFile, error) {
+	files := map[string]localFile{}
+	for _, root := range roots {
+		err := filepath.WalkDir(root.Dir, func(p string, d fs.DirEntry, err error) error {
+			if errors.Is(err, fs.ErrNotExist) && p == root.Dir {
+				return fs.SkipDir
+			}
+			if err != nil || d.IsDir() || strings.HasSuffix(p, ".tmp") {
+				return err
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(root.Dir, p)
+			if err != nil {
+				return err
+			}
+			key := path.Join(root.Prefix, filepath.ToSlash(rel))
+			files[key] = localFile{path: p, mod: info.ModTime().UTC().Truncate(time.Millisecond)}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// localPath is where the file for key goes, if key is inside a root.
+func localPath(roots []Root, key string) (string, bool) {
+	prefix, rel, ok := strings.Cut(key, "/")
+	if !ok || !fs.ValidPath(rel) {
+		return "", false
+	}
+	for _, root := range roots {
+		if root.Prefix == prefix {
+			return filepath.Join(root.Dir, filepath.FromSlash(rel)), true
+		}
+	}
+	return "", false
+}
+
+// writeFile replaces path with data, atomically, and gives it the
+// modification time the manifest has for it.
+func writeFile(p string, data []byte, mod time.Time) error {
+	if _, err := storage.Ensure(filepath.Dir(p)); err != nil {
+		return err
+	}
+	tmp := p + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	if err := os.Chtimes(tmp, mod, mod); err != nil {
+		return err
+	}
+	return os.Rename(tmp, p)
+}
+
+// ParseRemote makes the Remote for raw: an http or https URL for a WebDAV
+// share, or "s3://bucket/prefix" for an S3-compatible bucket at endpoint
+// (AWS's own for region when empty). Credentials are read with getenv:
+// ASTEROIDS_SYNC_USER and ASTEROIDS_SYNC_PASSWORD, or the URL's user info,
+// for WebDAV; AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY for S3.
+func ParseRemote(raw, endpoint, region string, getenv func(string) string) (Remote, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "http", "https":
+		dav := &WebDAV{User: getenv("ASTEROIDS_SYNC_USER"), Password: getenv("ASTEROIDS_SYNC_PASSWORD")}
+		if u.User != nil {
+			dav.User = u.User.Username()
+			dav.Password, _ = u.User.Password()
+			u.User = nil
+		}
+		dav.Base = u.String()
+		return dav, nil
+	case "s3":
+		if u.Host == "" {
+			return nil, fmt.Errorf("remote %q: no bucket", raw)
+		}
+		if region == "" {
+			region = "us-east-1"
+		}
+		if endpoint == "" {
+			endpoint = "https://s3." + region + ".amazonaws.com"
+		}
+		s := &S3{
+			Endpoint:  endpoint,
+			Region:    region,
+			Bucket:    u.Host,
+			Prefix:    strings.Trim(u.Path, "/"),
+			AccessKey: getenv("AWS_ACCESS_KEY_ID"),
+			SecretKey: getenv("AWS_SECRET_ACCESS_KEY"),
+		}
+		if s.Prefix != "" {
+			s.Prefix += "/"
+		}
+		if s.AccessKey == "" || s.SecretKey == "" {
+			return nil, errors.New("S3 needs AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+		}
+		return s, nil
+	}
+	return nil, fmt.Errorf("remote %q: want an http(s) WebDAV URL or s3://bucket/prefix", raw)
+}
@@ -0,0 +1,234 @@
+package cloudsync
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/matheus3301/asteroids/internal/storage"
+)
+
+// memRemote is a Remote kept in memory.
+type memRemote struct {
+	files map[string][]byte
+	puts  int
+}
+
+func (m *memRemote) Get(_ context.Context, key string) ([]byte, error) {
+	b, ok := m.files[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return b, nil
+}
+
+func (m *memRemote) Put(_ context.Context, key string, data []byte) error {
+	if m.files == nil {
+		m.files = map[string][]byte{}
+	}
+	m.files[key] = data
+	m.puts++
+	return nil
+}
+
+// write creates a file under dir with the given modification time.
+func write(t *testing.T, dir, name, data string, mod time.Time) {
+	t.Helper()
+	p := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(p, mod, mod); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func read(t *testing.T, dir, name string) string {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestSync_MovesFilesBetweenMachines(t *testing.T) {
+	ctx := context.Background()
+	remote := &memRemote{}
+	day := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	home := Roots(storage.At(t.TempDir()))
+	write(t, home[1].Dir, "profile.json", "home profile", day)
+	write(t, home[1].Dir, "replays/a.replay", "replay", day)
+	write(t, home[0].Dir, "config.json", "settings", day)
+	write(t, home[1].Dir, "telemetry.json.tmp", "half written", day)
+	rep, err := Sync(ctx, remote, home)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"config/config.json", "data/profile.json", "data/replays/a.replay"}
+	if !slices.Equal(rep.Uploaded, want) || len(rep.Downloaded) != 0 {
+		t.Fatalf("first sync = %+v, want uploads %v", rep, want)
+	}
+
+	work := Roots(storage.At(t.TempDir()))
+	write(t, work[1].Dir, "profile.json", "old work profile", day.Add(-time.Hour))
+	if rep, err = Sync(ctx, remote, work); err != nil {
+		t.Fatal(err)
+	}
+	if len(rep.Downloaded) != 3 || len(rep.Uploaded) != 0 {
+		t.Fatalf("second sync = %+v, want three downloads", rep)
+	}
+	if read(t, work[1].Dir, "profile.json") != "home profile" || read(t, work[1].Dir, "replays/a.replay") != "replay" {
+		t.Error("the newer remote files should replace and fill in the local ones")
+	}
+
+	// Played at work later: the newer local profile goes up, and syncing
+	// again changes nothing.
+	write(t, work[1].Dir, "profile.json", "work profile", day.Add(time.Hour))
+	if rep, err = Sync(ctx, remote, work); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(rep.Uploaded, []string{"data/profile.json"}) {
+		t.Fatalf("third sync = %+v, want the profile uploaded", rep)
+	}
+	puts := remote.puts
+	if rep, err = Sync(ctx, remote, work); err != nil {
+		t.Fatal(err)
+	}
+	if len(rep.Uploaded)+len(rep.Downloaded) != 0 || remote.puts != puts {
+		t.Errorf("an up-to-date sync should copy nothing, got %+v", rep)
+	}
+
+	if _, err = Sync(ctx, remote, home); err != nil {
+		t.Fatal(err)
+	}
+	if read(t, home[1].Dir, "profile.json") != "work profile" {
+		t.Error("home should pick up the profile played at work")
+	}
+}
+
+func TestSync_IgnoresKeysOutsideTheRoots(t *testing.T) {
+	remote := &memRemote{files: map[string][]byte{
+		ManifestName: []byte(`{"files":{"../escape":"2026-01-01T00:00:00Z","weights/x":"2026-01-01T00:00:00Z"}}`),
+	}}
+	dirs := storage.At(t.TempDir())
+	rep, err := Sync(context.Background(), remote, Roots(dirs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rep.Downloaded) != 0 {
+		t.Errorf("expected nothing downloaded, got %v", rep.Downloaded)
+	}
+}
+
+// davServer is a minimal WebDAV share: PUT needs the parent collection.
+func davServer(t *testing.T) (*httptest.Server, map[string]string) {
+	var mu sync.Mutex
+	files := map[string]string{}
+	cols := map[string]bool{"/": true}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if u, p, _ := r.BasicAuth(); u != "me" || p != "pw" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.Method {
+		case "MKCOL":
+			dir := strings.TrimSuffix(r.URL.Path, "/")
+			switch {
+			case cols[dir]:
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			case !cols[path.Dir(dir)]:
+				w.WriteHeader(http.StatusConflict)
+			default:
+				cols[dir] = true
+				w.WriteHeader(http.StatusCreated)
+			}
+		case http.MethodPut:
+			if !cols[path.Dir(r.URL.Path)] {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			b, _ := io.ReadAll(r.Body)
+			files[r.URL.Path] = string(b)
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodGet:
+			b, ok := files[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			io.WriteString(w, b)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, files
+}
+
+func TestWebDAV_CreatesCollections(t *testing.T) {
+	srv, files := davServer(t)
+	dav := &WebDAV{Base: srv.URL + "/", User: "me", Password: "pw"}
+	ctx := context.Background()
+
+	if err := dav.Put(ctx, "data/replays/a.replay", []byte("replay")); err != nil {
+		t.Fatal(err)
+	}
+	if files["/data/replays/a.replay"] != "replay" {
+		t.Fatalf("files = %v", files)
+	}
+	got, err := dav.Get(ctx, "data/replays/a.replay")
+	if err != nil || string(got) != "replay" {
+		t.Errorf("Get = %q, %v", got, err)
+	}
+	if _, err := dav.Get(ctx, "data/none.json"); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if err := (&WebDAV{Base: srv.URL}).Put(ctx, "x", nil); err == nil {
+		t.Error("expected an error without credentials")
+	}
+}
+
+func TestParseRemote(t *testing.T) {
+	env := map[string]string{"AWS_ACCESS_KEY_ID": "id", "AWS_SECRET_ACCESS_KEY": "secret"}
+	getenv := func(k string) string { return env[k] }
+
+	r, err := ParseRemote("https://me:pw@dav.example.com/asteroids/", "", "", getenv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dav, ok := r.(*WebDAV); !ok || dav.Base != "https://dav.example.com/asteroids/" || dav.User != "me" || dav.Password != "pw" {
+		t.Errorf("WebDAV remote = %+v", r)
+	}
+
+	r, err = ParseRemote("s3://saves/me/asteroids", "", "eu-west-1", getenv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, ok := r.(*S3)
+	if !ok || s.Bucket != "saves" || s.Prefix != "me/asteroids/" || s.Endpoint != "https://s3.eu-west-1.amazonaws.com" || s.AccessKey != "id" {
+		t.Errorf("S3 remote = %+v", r)
+	}
+
+	for _, bad := range []string{"ftp://host/x", "s3:///prefix"} {
+		if _, err := ParseRemote(bad, "", "", getenv); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+	if _, err := ParseRemote("s3://saves", "", "", func(string) string { return "" }); err == nil {
+		t.Error("expected an error without S3 credentials")
+	}
+}
@@ -0,0 +1,104 @@
+package cloudsync
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// WebDAV is a Remote on a WebDAV share. Keys are paths under Base, and
+// collections are created as files are put into them.
+type WebDAV struct {
+	// Base is the URL of the collection to sync into, such as
+	// "https://dav.example.com/asteroids/".
+	Base string
+	// User and Password, if set, are sent with basic authentication.
+	User, Password string
+	Client         *http.Client
+}
+
+func (w *WebDAV) url(key string) string {
+	return strings.TrimSuffix(w.Base, "/") + "/" + key
+}
+
+func (w *WebDAV) do(ctx context.Context, method, key string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, w.url(key), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if w.User != "" || w.Password != "" {
+		req.SetBasicAuth(w.User, w.Password)
+	}
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return client.Do(req)
+}
+
+// Get implements Remote.
+func (w *WebDAV) Get(ctx context.Context, key string) ([]byte, error) {
+	resp, err := w.do(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, ErrNotFound
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("GET %s: %s", key, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// Put implements Remote. A share answers 409 Conflict to a PUT into a
+// missing collection, so the collections are made and the PUT retried.
+func (w *WebDAV) Put(ctx context.Context, key string, data []byte) error {
+	status, err := w.status(ctx, http.MethodPut, key, data)
+	if err == nil && status == http.StatusConflict {
+		if err := w.mkcolAll(ctx, path.Dir(key)); err != nil {
+			return err
+		}
+		status, err = w.status(ctx, http.MethodPut, key, data)
+	}
+	if err != nil {
+		return err
+	}
+	if status < 200 || status > 299 {
+		return fmt.Errorf("PUT %s: %s", key, http.StatusText(status))
+	}
+	return nil
+}
+
+// mkcolAll creates the collection dir and any missing parents.
+func (w *WebDAV) mkcolAll(ctx context.Context, dir string) error {
+	if dir == "." || dir == "/" {
+		return nil
+	}
+	if err := w.mkcolAll(ctx, path.Dir(dir)); err != nil {
+		return err
+	}
+	status, err := w.status(ctx, "MKCOL", dir+"/", nil)
+	if err != nil {
+		return err
+	}
+	// 405 Method Not Allowed means the collection already exists.
+	if status != http.StatusCreated && status != http.StatusMethodNotAllowed {
+		return fmt.Errorf("MKCOL %s: %s", dir, http.StatusText(status))
+	}
+	return nil
+}
+
+func (w *WebDAV) status(ctx context.Context, method, key string, body []byte) (int, error) {
+	resp, err := w.do(ctx, method, key, body)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
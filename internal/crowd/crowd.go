@@ -0,0 +1,188 @@
+// Package crowd turns chat votes into ship input, "Twitch plays" style.
+//
+// Viewers vote for an action by typing its name. Votes are counted over a
+// window of ticks; when the window closes, the most popular action is
+// applied for the whole of the next window. Each viewer has one vote per
+// window (voting again changes it), which keeps spammers from outvoting
+// everyone else.
+//
+// Votes arrive through feeds: ListenLines accepts "<user> <message>" lines
+// over TCP, for bridges from any chat service, and DialIRC reads PRIVMSGs
+// from an IRC channel such as Twitch chat.
+package crowd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/matheus3301/asteroids/internal/game"
+	"github.com/matheus3301/asteroids/internal/logging"
+)
+
+// DefaultWindow is the voting window in ticks.
+const DefaultWindow = 60
+
+// maxVoters bounds memory if a feed is flooded with made-up user names.
+const maxVoters = 10_000
+
+var logger = logging.For("crowd")
+
+// Action is one of the things the crowd can vote for.
+type Action int
+
+const (
+	ActionNone Action = iota
+	ActionLeft
+	ActionRight
+	ActionThrust
+	ActionShoot
+	ActionHyperspace
+	numActions
+)
+
+var actionNames = [numActions]string{"none", "left", "right", "thrust", "shoot", "hyper"}
+
+// aliases maps chat words to actions.
+var aliases = map[string]Action{
+	"none": ActionNone, "wait": ActionNone,
+	"left": ActionLeft, "l": ActionLeft,
+	"right": ActionRight, "r": ActionRight,
+	"thrust": ActionThrust, "up": ActionThrust, "go": ActionThrust,
+	"shoot": ActionShoot, "fire": ActionShoot,
+	"hyper": ActionHyperspace, "hyperspace": ActionHyperspace, "jump": ActionHyperspace,
+}
+
+func (a Action) String() string {
+	if a < 0 || a >= numActions {
+		return fmt.Sprintf("Action(%d)", int(a))
+	}
+	return actionNames[a]
+}
+
+// ParseAction reads a vote from a chat message. Only the first word counts,
+// optionally prefixed with '!'.
+func ParseAction(msg string) (Action, bool) {
+	fields := strings.Fields(strings.ToLower(msg))
+	if len(fields) == 0 {
+		return 0, false
+	}
+	a, ok := aliases[strings.TrimPrefix(fields[0], "!")]
+	return a, ok
+}
+
+// Votes tallies votes and implements game.InputSource.
+type Votes struct {
+	window int
+
+	mu      sync.Mutex
+	ballots map[string]Action // this window's vote per user
+	tick    int               // ticks into the current window
+	current Action            // the action being applied
+}
+
+// NewVotes counts votes over windows of the given number of ticks.
+func NewVotes(window int) *Votes {
+	if window <= 0 {
+		window = DefaultWindow
+	}
+	return &Votes{window: window, ballots: map[string]Action{}}
+}
+
+// Cast records a chat message from user. Messages that are not votes are
+// ignored. It reports whether the message counted as a vote.
+func (v *Votes) Cast(user, msg string) bool {
+	a, ok := ParseAction(msg)
+	if !ok || user == "" {
+		return false
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if _, voted := v.ballots[user]; !voted && len(v.ballots) >= maxVoters {
+		return false
+	}
+	v.ballots[user] = a
+	return true
+}
+
+// Tally returns the live count for each action in the current window.
+func (v *Votes) Tally() [numActions]int {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.tally()
+}
+
+func (v *Votes) tally() [numActions]int {
+	var t [numActions]int
+	for _, a := range v.ballots {
+		t[a]++
+	}
+	return t
+}
+
+// NextInput implements game.InputSource. The winning action is held for the
+// whole window; shoot and hyperspace only press on its first tick.
+func (v *Votes) NextInput(*game.World) game.InputState {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.tick >= v.window {
+		v.current = winner(v.tally())
+		clear(v.ballots)
+		v.tick = 0
+	}
+	first := v.tick == 0
+	v.tick++
+
+	switch v.current {
+	case ActionLeft:
+		return game.InputState{RotateLeft: true}
+	case ActionRight:
+		return game.InputState{RotateRight: true}
+	case ActionThrust:
+		return game.InputState{Thrust: true}
+	case ActionShoot:
+		return game.InputState{Shoot: first}
+	case ActionHyperspace:
+		return game.InputState{Hyperspace: first}
+	}
+	return game.InputState{}
+}
+
+// winner picks the action with most votes. Ties go to the earlier action,
+// so an empty window means ActionNone.
+func winner(t [numActions]int) Action {
+	best := ActionNone
+	for a := ActionNone; a < numActions; a++ {
+		if t[a] > t[best] {
+			best = a
+		}
+	}
+	return best
+}
+
+// OverlayLines implements game.InputOverlay with the live tallies, most
+// popular first, and the action currently being applied.
+func (v *Votes) OverlayLines() []string {
+	v.mu.Lock()
+	t := v.tally()
+	current := v.current
+	left := (v.window - v.tick + 59) / 60
+	v.mu.Unlock()
+
+	order := make([]Action, 0, numActions)
+	for a := ActionNone + 1; a < numActions; a++ {
+		order = append(order, a)
+	}
+	sort.SliceStable(order, func(i, j int) bool { return t[order[i]] > t[order[j]] })
+
+	lines := []string{
+		fmt.Sprintf("CROWD: %s", strings.ToUpper(current.String())),
+		fmt.Sprintf("NEXT IN %dS", left),
+	}
+	for _, a := range order {
+		lines = append(lines, fmt.Sprintf("%-6s %3d %s", strings.ToUpper(a.String()), t[a], strings.Repeat("-", min(t[a], 20))))
+	}
+	return lines
+}
@@ -0,0 +1,154 @@
+package crowd
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matheus3301/asteroids/internal/game"
+)
+
+func TestParseAction(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want Action
+		ok   bool
+	}{
+		{"left", ActionLeft, true},
+		{"!FIRE now", ActionShoot, true},
+		{"  up ", ActionThrust, true},
+		{"jump", ActionHyperspace, true},
+		{"hello chat", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseAction(tt.msg)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseAction(%q) = %v, %v; want %v, %v", tt.msg, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+// runWindow calls NextInput for one full window and returns the inputs.
+func runWindow(v *Votes) []game.InputState {
+	ins := make([]game.InputState, v.window)
+	for i := range ins {
+		ins[i] = v.NextInput(nil)
+	}
+	return ins
+}
+
+func TestVotes_MajorityAppliesNextWindow(t *testing.T) {
+	v := NewVotes(10)
+	v.Cast("a", "left")
+	v.Cast("b", "right")
+	v.Cast("c", "right")
+
+	for _, in := range runWindow(v) {
+		if in != (game.InputState{}) {
+			t.Fatalf("votes should not apply during their own window, got %+v", in)
+		}
+	}
+	for _, in := range runWindow(v) {
+		if !in.RotateRight || in.RotateLeft {
+			t.Fatalf("expected the majority (right) for the whole window, got %+v", in)
+		}
+	}
+	// Nobody voted in the last window.
+	if in := v.NextInput(nil); in != (game.InputState{}) {
+		t.Errorf("an empty window should mean no input, got %+v", in)
+	}
+}
+
+func TestVotes_OneVotePerUser(t *testing.T) {
+	v := NewVotes(10)
+	for i := 0; i < 50; i++ {
+		v.Cast("spammer", "left")
+	}
+	v.Cast("a", "thrust")
+	v.Cast("b", "thrust")
+	v.Cast("c", "right")
+	v.Cast("c", "thrust") // changed their mind
+
+	tally := v.Tally()
+	if tally[ActionLeft] != 1 || tally[ActionThrust] != 3 || tally[ActionRight] != 0 {
+		t.Errorf("tally = %v", tally)
+	}
+}
+
+func TestVotes_ShootPressesOncePerWindow(t *testing.T) {
+	v := NewVotes(10)
+	v.Cast("a", "fire")
+	runWindow(v)
+
+	shots := 0
+	for _, in := range runWindow(v) {
+		if in.Shoot {
+			shots++
+		}
+	}
+	if shots != 1 {
+		t.Errorf("expected one shot per window, got %d", shots)
+	}
+}
+
+func TestVotes_OverlayShowsTallies(t *testing.T) {
+	v := NewVotes(60)
+	v.Cast("a", "hyper")
+	lines := v.OverlayLines()
+	if !strings.HasPrefix(lines[0], "CROWD: NONE") {
+		t.Errorf("first line = %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[2], "HYPER") {
+		t.Errorf("most voted action should come first, got %q", lines[2])
+	}
+}
+
+func TestParsePrivmsg(t *testing.T) {
+	user, msg, ok := parsePrivmsg("@badge-info=;color=#FF0000 :viewer!viewer@viewer.tmi.twitch.tv PRIVMSG #chan :left please")
+	if !ok || user != "viewer" || msg != "left please" {
+		t.Errorf("got %q %q %v", user, msg, ok)
+	}
+	if _, _, ok := parsePrivmsg(":tmi.twitch.tv 001 justinfan1 :Welcome"); ok {
+		t.Error("non-PRIVMSG lines should be ignored")
+	}
+}
+
+func TestListenLines(t *testing.T) {
+	v := NewVotes(60)
+	ln, err := ListenLines("127.0.0.1:0", v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	for i := 0; i < 3; i++ {
+		fmt.Fprintf(conn, "user%d thrust\n", i)
+	}
+	fmt.Fprintf(conn, "lurker just chatting\n")
+
+	for i := 0; i < 100 && v.Tally()[ActionThrust] < 3; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := v.Tally()[ActionThrust]; got != 3 {
+		t.Errorf("expected 3 thrust votes, got %d", got)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	l := newRateLimit(2)
+	now := time.Unix(100, 0)
+	if !l.allow(now) || !l.allow(now) || l.allow(now) {
+		t.Error("expected two events then a refusal")
+	}
+	if !l.allow(now.Add(time.Second)) {
+		t.Error("the limit should reset after a second")
+	}
+}
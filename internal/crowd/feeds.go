@@ -0,0 +1,132 @@
+package crowd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// maxLineRate is how many lines per second a single bridge connection may
+// send before the excess is dropped.
+const maxLineRate = 200
+
+// ListenLines accepts bridge connections on addr. Each line is
+// "<user> <message>"; the returned listener stops the feed when closed.
+func ListenLines(addr string, v *Votes) (net.Listener, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				if !errors.Is(err, net.ErrClosed) {
+					logger.Error("accept", "err", err)
+				}
+				return
+			}
+			logger.Info("bridge connected", "addr", conn.RemoteAddr())
+			go func() {
+				defer conn.Close()
+				readLines(conn, v)
+			}()
+		}
+	}()
+	return ln, nil
+}
+
+func readLines(r io.Reader, v *Votes) {
+	sc := bufio.NewScanner(r)
+	limit := newRateLimit(maxLineRate)
+	for sc.Scan() {
+		if !limit.allow(time.Now()) {
+			continue
+		}
+		user, msg, ok := strings.Cut(strings.TrimSpace(sc.Text()), " ")
+		if ok {
+			v.Cast(user, msg)
+		}
+	}
+}
+
+// rateLimit allows up to n events per second.
+type rateLimit struct {
+	n     int
+	start time.Time
+	count int
+}
+
+func newRateLimit(n int) *rateLimit {
+	return &rateLimit{n: n}
+}
+
+func (l *rateLimit) allow(now time.Time) bool {
+	if now.Sub(l.start) >= time.Second {
+		l.start, l.count = now, 0
+	}
+	l.count++
+	return l.count <= l.n
+}
+
+// DialIRC joins channel on the IRC server at addr and casts a vote for
+// every chat message. Without a password it logs in anonymously, which
+// Twitch allows for reading chat. It returns once the connection is up;
+// the returned connection stops the feed when closed.
+func DialIRC(addr, channel, nick, pass string, v *Votes) (io.Closer, error) {
+	conn, err := net.DialTimeout("tcp", addr, 10*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	if nick == "" {
+		nick = fmt.Sprintf("justinfan%d", time.Now().UnixNano()%100000)
+	}
+	if pass != "" {
+		fmt.Fprintf(conn, "PASS %s\r\n", pass)
+	}
+	fmt.Fprintf(conn, "NICK %s\r\n", nick)
+	fmt.Fprintf(conn, "JOIN #%s\r\n", strings.TrimPrefix(strings.ToLower(channel), "#"))
+
+	go func() {
+		defer conn.Close()
+		sc := bufio.NewScanner(conn)
+		for sc.Scan() {
+			line := sc.Text()
+			if strings.HasPrefix(line, "PING") {
+				fmt.Fprintf(conn, "PONG%s\r\n", strings.TrimPrefix(line, "PING"))
+				continue
+			}
+			if user, msg, ok := parsePrivmsg(line); ok {
+				v.Cast(user, msg)
+			}
+		}
+		logger.Warn("irc connection closed", "addr", addr, "err", sc.Err())
+	}()
+	return conn, nil
+}
+
+// parsePrivmsg extracts the sender and text of an IRC PRIVMSG line such as
+// ":nick!user@host PRIVMSG #channel :hello". IRCv3 tags are skipped.
+func parsePrivmsg(line string) (user, msg string, ok bool) {
+	if strings.HasPrefix(line, "@") {
+		_, line, _ = strings.Cut(line, " ")
+	}
+	prefix, rest, ok := strings.Cut(line, " ")
+	if !ok || !strings.HasPrefix(prefix, ":") {
+		return "", "", false
+	}
+	command, rest, ok := strings.Cut(rest, " ")
+	if !ok || command != "PRIVMSG" {
+		return "", "", false
+	}
+	_, msg, ok = strings.Cut(rest, " :")
+	if !ok {
+		return "", "", false
+	}
+	user, _, _ = strings.Cut(prefix[1:], "!")
+	return user, msg, user != ""
+}
@@ -0,0 +1,153 @@
+// Package dataset turns recorded replays into (observation, action) pairs
+// for supervised learning, such as behaviour cloning from human games.
+//
+// Each frame pairs the world as it was before a tick with the buttons held
+// on that tick. Observations are flattened into a fixed-width vector of
+// float32 features, described by FeatureNames, so they can be stacked into
+// a matrix; see WriteNPZ for the file format.
+package dataset
+
+import (
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/matheus3301/asteroids/internal/game"
+)
+
+// Buttons are the action columns, in order.
+var Buttons = []string{"left", "right", "thrust", "shoot", "hyperspace", "missile", "shield"}
+
+const (
+	// nearAsteroids, nearSaucers and nearSaucerBullets are how many of the
+	// closest objects of each kind are kept, nearest first.
+	nearAsteroids     = 8
+	nearSaucers       = 1
+	nearSaucerBullets = 4
+)
+
+var shipFeatures = []string{
+	"ship_present", "ship_x", "ship_y", "ship_vx", "ship_vy",
+	"ship_cos", "ship_sin", "ship_invulnerable", "ship_hyperspace_cooldown",
+	"ship_shot_cooldown", "ship_weapon_tier", "ship_missiles",
+	"ship_shield", "ship_shield_up",
+	"lives", "level",
+}
+
+var objectFeatures = []string{"present", "dx", "dy", "vx", "vy", "radius", "cpa_ticks", "cpa_dist"}
+
+// FeatureNames names each column of a Features vector. Positions are in
+// pixels and velocities in pixels per tick; objects are given relative to
+// the ship, measured across the screen edges, and absent slots are zero.
+var FeatureNames = featureNames()
+
+func featureNames() []string {
+	names := append([]string(nil), shipFeatures...)
+	groups := []struct {
+		prefix string
+		n      int
+	}{{"asteroid", nearAsteroids}, {"saucer", nearSaucers}, {"saucer_bullet", nearSaucerBullets}}
+	for _, g := range groups {
+		for i := 0; i < g.n; i++ {
+			for _, f := range objectFeatures {
+				names = append(names, g.prefix+strconv.Itoa(i)+"_"+f)
+			}
+		}
+	}
+	return names
+}
+
+// Features flattens obs into a vector laid out as FeatureNames.
+func Features(obs game.Observation) []float32 {
+	v := make([]float32, 0, len(FeatureNames))
+	if s := obs.Player; s != nil {
+		v = append(v, 1, f32(s.X), f32(s.Y), f32(s.VX), f32(s.VY),
+			f32(math.Cos(s.Angle)), f32(math.Sin(s.Angle)), float32(bit(s.Invulnerable)),
+			float32(s.HyperspaceCooldown), float32(s.ShotCooldown),
+			float32(s.WeaponTier), float32(s.Missiles),
+			float32(s.Shield), float32(bit(s.ShieldUp)))
+	} else {
+		v = append(v, make([]float32, 14)...)
+	}
+	v = append(v, float32(obs.Lives), float32(obs.Level))
+	v = appendNearest(v, obs.Asteroids, nearAsteroids)
+	v = appendNearest(v, obs.Saucers, nearSaucers)
+	v = appendNearest(v, obs.SaucerBullets, nearSaucerBullets)
+	return v
+}
+
+// appendNearest adds the n objects closest to the ship, padding with
+// zeroed slots.
+func appendNearest(v []float32, objs []game.ObjectObservation, n int) []float32 {
+	objs = append([]game.ObjectObservation(nil), objs...)
+	sort.SliceStable(objs, func(i, j int) bool {
+		return math.Hypot(objs[i].DX, objs[i].DY) < math.Hypot(objs[j].DX, objs[j].DY)
+	})
+	for i := 0; i < n; i++ {
+		if i >= len(objs) {
+			v = append(v, make([]float32, len(objectFeatures))...)
+			continue
+		}
+		o := objs[i]
+		v = append(v, 1, f32(o.DX), f32(o.DY), f32(o.VX), f32(o.VY),
+			f32(o.Radius), f32(o.CPATicks), f32(o.CPADist))
+	}
+	return v
+}
+
+// Action is the buttons held on a tick, laid out as Buttons.
+func Action(in game.InputState) []uint8 {
+	return []uint8{bit(in.RotateLeft), bit(in.RotateRight), bit(in.Thrust), bit(in.Shoot), bit(in.Hyperspace), bit(in.Missile), bit(in.Shield)}
+}
+
+// Frame is one training example.
+type Frame struct {
+	// Episode is the index of the replay the frame came from.
+	Episode  int
+	Tick     int
+	Features []float32
+	Action   []uint8
+}
+
+// Filter selects which frames of a replay are kept.
+type Filter struct {
+	// WinningWaves keeps only frames from waves the player cleared.
+	WinningWaves bool
+	// Active drops frames where no button was held.
+	Active bool
+}
+
+// Convert re-simulates r and returns its frames that pass f, tagged with
+// episode. It also reports the tick at which the replay desynced, or -1;
+// frames after a desync no longer match what the player saw.
+func Convert(r *game.Replay, episode int, f Filter) ([]Frame, int) {
+	p := game.NewReplayRunner(r)
+	var frames, wave []Frame
+	level := p.World.Level
+	for !p.Done() {
+		obs := game.Observe(p.World)
+		p.Advance()
+		in := p.Input()
+		if !f.Active || in != (game.InputState{}) {
+			wave = append(wave, Frame{Episode: episode, Tick: obs.Tick, Features: Features(obs), Action: Action(in)})
+		}
+		if p.World.Level != level {
+			level = p.World.Level
+			frames = append(frames, wave...)
+			wave = wave[:0]
+		}
+	}
+	if !f.WinningWaves {
+		frames = append(frames, wave...)
+	}
+	return frames, p.DesyncTick
+}
+
+func f32(x float64) float32 { return float32(x) }
+
+func bit(v bool) uint8 {
+	if v {
+		return 1
+	}
+	return 0
+}
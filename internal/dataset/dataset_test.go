@@ -0,0 +1,176 @@
+package dataset
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/matheus3301/asteroids/internal/game"
+)
+
+// record plays seed with in for up to ticks ticks and returns the replay.
+func record(seed int64, ticks int, in game.InputSource) *game.Replay {
+	w := game.NewGameWorld(seed)
+	rec := game.NewReplayRecorder(w)
+	for w.Tick < ticks && !w.GameOver() {
+		input := in.NextInput(w)
+		rec.Input(w, input)
+		game.Step(w, input)
+		rec.Check(w)
+	}
+	return rec.Finish(w)
+}
+
+type scripted struct{}
+
+func (scripted) NextInput(w *game.World) game.InputState { return game.ScriptedInput(w.Tick) }
+
+func TestFeatures_MatchNames(t *testing.T) {
+	w := game.NewGameWorld(1)
+	if got := len(Features(game.Observe(w))); got != len(FeatureNames) {
+		t.Fatalf("got %d features for %d names", got, len(FeatureNames))
+	}
+	if got := len(Features(game.Observation{})); got != len(FeatureNames) {
+		t.Errorf("an observation without a ship gave %d features", got)
+	}
+}
+
+func TestFeatures_Shield(t *testing.T) {
+	obs := game.Observation{Player: &game.ShipObservation{Shield: 90, ShieldUp: true}}
+	v := Features(obs)
+	got := map[string]float32{}
+	for i, name := range FeatureNames {
+		got[name] = v[i]
+	}
+	if got["ship_shield"] != 90 || got["ship_shield_up"] != 1 {
+		t.Errorf("shield features = %v and %v, want 90 and 1", got["ship_shield"], got["ship_shield_up"])
+	}
+	a := Action(game.InputState{Shield: true})
+	if a[len(a)-1] != 1 || Buttons[len(Buttons)-1] != "shield" {
+		t.Errorf("holding the shield should set the shield column: %v", a)
+	}
+}
+
+func TestFeatures_NearestAsteroidFirst(t *testing.T) {
+	obs := game.Observation{Asteroids: []game.ObjectObservation{
+		{DX: 300, Radius: 40},
+		{DX: -20, DY: 10, Radius: 10},
+	}}
+	v := Features(obs)
+	at := func(name string) float32 {
+		for i, n := range FeatureNames {
+			if n == name {
+				return v[i]
+			}
+		}
+		t.Fatalf("no feature %q", name)
+		return 0
+	}
+	if at("asteroid0_radius") != 10 || at("asteroid1_radius") != 40 {
+		t.Error("asteroids should be ordered nearest first")
+	}
+	if at("asteroid2_present") != 0 {
+		t.Error("missing asteroids should leave empty slots")
+	}
+}
+
+func TestConvert_Filters(t *testing.T) {
+	r := record(1, 600, scripted{})
+
+	all, desync := Convert(r, 3, Filter{})
+	if desync >= 0 {
+		t.Fatalf("replay desynced at tick %d", desync)
+	}
+	if len(all) != r.Ticks {
+		t.Fatalf("expected one frame per tick, got %d of %d", len(all), r.Ticks)
+	}
+	if all[0].Episode != 3 || all[0].Tick != 0 || all[1].Tick != 1 {
+		t.Errorf("frames not tagged in order: %+v %+v", all[0].Tick, all[1].Tick)
+	}
+
+	active, _ := Convert(r, 0, Filter{Active: true})
+	if len(active) == 0 || len(active) >= len(all) {
+		t.Errorf("expected only some frames to be active, got %d of %d", len(active), len(all))
+	}
+	for _, f := range active {
+		if !bytes.ContainsRune(f.Action, 1) {
+			t.Fatalf("idle frame at tick %d was kept", f.Tick)
+		}
+	}
+
+	// The autopilot does not clear the first wave in ten seconds.
+	if won, _ := Convert(r, 0, Filter{WinningWaves: true}); len(won) != 0 {
+		t.Errorf("expected no frames from an uncleared wave, got %d", len(won))
+	}
+}
+
+func TestConvert_KeepsClearedWave(t *testing.T) {
+	script, err := game.LoadInputScript("../game/testdata/inputs/wave1.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The script clears wave one; the recording runs on into wave two.
+	r := record(7, len(script.Ticks)+300, script)
+
+	won, _ := Convert(r, 0, Filter{WinningWaves: true})
+	if len(won) != len(script.Ticks) {
+		t.Errorf("expected the %d frames of the first wave, got %d of %d", len(script.Ticks), len(won), r.Ticks)
+	}
+}
+
+func TestWriteNPZ(t *testing.T) {
+	frames, _ := Convert(record(1, 30, scripted{}), 1, Filter{})
+	var buf bytes.Buffer
+	if err := WriteNPZ(&buf, frames); err != nil {
+		t.Fatal(err)
+	}
+
+	z, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, f := range z.File {
+		names = append(names, f.Name)
+	}
+	if got := strings.Join(names, " "); got != "features.npy actions.npy episodes.npy ticks.npy" {
+		t.Fatalf("unexpected arrays %q", got)
+	}
+
+	for i, want := range []string{
+		fmt.Sprintf("'shape': (30, %d)", len(FeatureNames)),
+		"'shape': (30, 7)",
+	} {
+		rc, _ := z.File[i].Open()
+		data, _ := io.ReadAll(rc)
+		if !strings.Contains(string(data[:128]), want) {
+			t.Errorf("%s: expected %s in %q", z.File[i].Name, want, data[:128])
+		}
+	}
+
+	rc, _ := z.File[3].Open()
+	data, _ := io.ReadAll(rc)
+	if string(data[:6]) != "\x93NUMPY" {
+		t.Fatal("missing .npy magic")
+	}
+	n := int(binary.LittleEndian.Uint16(data[8:10]))
+	if (10+n)%64 != 0 {
+		t.Errorf("header of %d bytes is not padded to 64", n)
+	}
+	if header := string(data[10 : 10+n]); !strings.Contains(header, "'shape': (30,)") {
+		t.Errorf("unexpected header %q", header)
+	}
+	if got := binary.LittleEndian.Uint32(data[10+n+4*29:]); got != 29 {
+		t.Errorf("last tick = %d, want 29", got)
+	}
+}
+
+func TestWriteNPZ_RejectsMalformedFrames(t *testing.T) {
+	if err := WriteNPZ(io.Discard, []Frame{{Features: []float32{1}}}); err == nil {
+		t.Error("expected an error for a short frame")
+	}
+}
@@ -0,0 +1,90 @@
+package dataset
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteNPZ writes frames as a NumPy .npz archive, which numpy.load reads
+// directly. It holds four arrays with one row per frame:
+//
+//	features  float32 (N, len(FeatureNames))  observation before the tick
+//	actions   uint8   (N, len(Buttons))       1 where the button was held
+//	episodes  int32   (N,)                    Frame.Episode
+//	ticks     int32   (N,)                    Frame.Tick
+//
+// Arrays are little-endian and stored uncompressed.
+func WriteNPZ(w io.Writer, frames []Frame) error {
+	n := len(frames)
+	features := make([]float32, 0, n*len(FeatureNames))
+	actions := make([]uint8, 0, n*len(Buttons))
+	episodes := make([]int32, n)
+	ticks := make([]int32, n)
+	for i, f := range frames {
+		if len(f.Features) != len(FeatureNames) || len(f.Action) != len(Buttons) {
+			return fmt.Errorf("frame %d has %d features and %d buttons, want %d and %d",
+				i, len(f.Features), len(f.Action), len(FeatureNames), len(Buttons))
+		}
+		features = append(features, f.Features...)
+		actions = append(actions, f.Action...)
+		episodes[i] = int32(f.Episode)
+		ticks[i] = int32(f.Tick)
+	}
+
+	z := zip.NewWriter(w)
+	arrays := []struct {
+		name  string
+		descr string
+		shape []int
+		data  any
+	}{
+		{"features", "<f4", []int{n, len(FeatureNames)}, features},
+		{"actions", "|u1", []int{n, len(Buttons)}, actions},
+		{"episodes", "<i4", []int{n}, episodes},
+		{"ticks", "<i4", []int{n}, ticks},
+	}
+	for _, a := range arrays {
+		f, err := z.CreateHeader(&zip.FileHeader{Name: a.name + ".npy", Method: zip.Store})
+		if err != nil {
+			return err
+		}
+		if err := writeNPY(f, a.descr, a.shape, a.data); err != nil {
+			return fmt.Errorf("writing %s: %w", a.name, err)
+		}
+	}
+	return z.Close()
+}
+
+// writeNPY writes one array in the .npy version 1.0 format: a magic
+// string, a Python dict literal describing the array padded to a multiple
+// of 64 bytes, then the raw data in C order.
+func writeNPY(w io.Writer, descr string, shape []int, data any) error {
+	dims := make([]string, len(shape))
+	for i, d := range shape {
+		dims[i] = fmt.Sprint(d)
+	}
+	tuple := strings.Join(dims, ", ")
+	if len(shape) == 1 {
+		tuple += ","
+	}
+	header := fmt.Sprintf("{'descr': '%s', 'fortran_order': False, 'shape': (%s), }", descr, tuple)
+	const prefix = 10 // magic, version and header length
+	pad := 64 - (prefix+len(header)+1)%64
+	if pad == 64 {
+		pad = 0
+	}
+	header += strings.Repeat(" ", pad) + "\n"
+
+	var buf bytes.Buffer
+	buf.WriteString("\x93NUMPY\x01\x00")
+	binary.Write(&buf, binary.LittleEndian, uint16(len(header)))
+	buf.WriteString(header)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	return binary.Write(w, binary.LittleEndian, data)
+}
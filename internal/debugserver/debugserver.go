@@ -0,0 +1,59 @@
+// Package debugserver exposes net/http/pprof profiles and expvar metrics on
+// a side port so long-running processes can be inspected without restarting.
+package debugserver
+
+import (
+	"expvar"
+	"net"
+	"net/http"
+	_ "net/http/pprof" // registers /debug/pprof/ on http.DefaultServeMux
+	"runtime"
+	"time"
+)
+
+// Progress holds application-specific counters (ticks, score, games, ...).
+// Callers add their own expvar.Int/Float values to it; it is served under
+// the "progress" key of /debug/vars.
+var Progress = expvar.NewMap("progress")
+
+func init() {
+	expvar.Publish("goroutines", expvar.Func(func() any {
+		return runtime.NumGoroutine()
+	}))
+	expvar.Publish("gc", expvar.Func(func() any {
+		var m runtime.MemStats
+		runtime.ReadMemStats(&m)
+		return map[string]any{
+			"num_gc":         m.NumGC,
+			"pause_total_ms": float64(m.PauseTotalNs) / 1e6,
+			"last_gc":        time.Unix(0, int64(m.LastGC)).UTC().Format(time.RFC3339),
+			"heap_alloc":     m.HeapAlloc,
+			"heap_objects":   m.HeapObjects,
+		}
+	}))
+}
+
+// Start listens on addr and serves /debug/pprof/ and /debug/vars in the
+// background. It returns the bound address, which is useful with ":0".
+func Start(addr string) (net.Addr, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	go func() { _ = http.Serve(ln, http.DefaultServeMux) }()
+	return ln.Addr(), nil
+}
+
+// Floats publishes a map of one Float per name under key in Progress, for a
+// set of related figures such as per-system timings, and returns the Floats
+// in the order of names for the caller to set.
+func Floats(key string, names []string) []*expvar.Float {
+	m := new(expvar.Map).Init()
+	floats := make([]*expvar.Float, len(names))
+	for i, name := range names {
+		floats[i] = new(expvar.Float)
+		m.Set(name, floats[i])
+	}
+	Progress.Set(key, m)
+	return floats
+}
@@ -0,0 +1,76 @@
+package debugserver
+
+import (
+	"encoding/json"
+	"expvar"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func get(t *testing.T, url string) []byte {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET %s: status %d", url, resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
+
+func TestStart_ServesVarsAndPprof(t *testing.T) {
+	addr, err := Start("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	ticks := new(expvar.Int)
+	ticks.Set(42)
+	Progress.Set("ticks", ticks)
+
+	var vars map[string]json.RawMessage
+	if err := json.Unmarshal(get(t, "http://"+addr.String()+"/debug/vars"), &vars); err != nil {
+		t.Fatalf("decoding /debug/vars: %v", err)
+	}
+	for _, key := range []string{"progress", "goroutines", "gc", "memstats"} {
+		if _, ok := vars[key]; !ok {
+			t.Errorf("/debug/vars missing %q", key)
+		}
+	}
+	var progress map[string]int
+	if err := json.Unmarshal(vars["progress"], &progress); err != nil {
+		t.Fatal(err)
+	}
+	if progress["ticks"] != 42 {
+		t.Errorf("expected progress.ticks=42, got %d", progress["ticks"])
+	}
+
+	get(t, "http://"+addr.String()+"/debug/pprof/")
+}
+
+func TestStart_BadAddress(t *testing.T) {
+	if _, err := Start("not-an-address"); err == nil {
+		t.Error("expected an error for an invalid address")
+	}
+}
+
+func TestFloats_PublishesAMapInProgress(t *testing.T) {
+	floats := Floats("systems", []string{"Physics", "Collision"})
+	defer Progress.Delete("systems")
+	floats[0].Set(120)
+	floats[1].Set(4500.5)
+
+	var got map[string]float64
+	if err := json.Unmarshal([]byte(Progress.Get("systems").String()), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["Physics"] != 120 || got["Collision"] != 4500.5 {
+		t.Errorf("expected Physics=120 Collision=4500.5, got %v", got)
+	}
+}
@@ -0,0 +1,130 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/matheus3301/asteroids/internal/canvas"
+)
+
+// accessibilityLabels are the rows of the accessibility page, opened from
+// the settings screen.
+var accessibilityLabels = []string{
+	"AIM GUIDE",
+	"AIM ASSIST",
+	"REDUCED MOTION",
+	"BACK",
+}
+
+func (g *Game) openAccessibility() {
+	g.accessibilityCursor = 0
+	g.state = stateAccessibility
+}
+
+// setReducedMotion turns reduced motion on or off and keeps the choice in
+// the profile.
+func (g *Game) setReducedMotion(on bool) {
+	g.profile.ReducedMotion = on
+	g.saveProfile()
+}
+
+// skipHitStop runs out a hit-stop straight away in reduced motion. The
+// frozen ticks still happen, so the game and its replay are unchanged;
+// they are just not held on screen.
+func (g *Game) skipHitStop() {
+	if !g.profile.ReducedMotion {
+		return
+	}
+	for g.world.HitStop > 0 && g.state == statePlaying {
+		g.StepPlaying(InputState{})
+	}
+}
+
+func (g *Game) updateAccessibility() {
+	rows := len(accessibilityLabels)
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.sound.PlayBlip()
+		g.state = stateSettings
+		return
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyUp) {
+		g.accessibilityCursor = (g.accessibilityCursor + rows - 1) % rows
+		g.sound.PlayBlip()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyDown) {
+		g.accessibilityCursor = (g.accessibilityCursor + 1) % rows
+		g.sound.PlayBlip()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+		g.sound.PlayConfirm()
+		g.accessibilitySelect()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyLeft) {
+		g.accessibilityChange(-1)
+		g.sound.PlayBlip()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyRight) {
+		g.accessibilityChange(1)
+		g.sound.PlayBlip()
+	}
+}
+
+func (g *Game) accessibilitySelect() {
+	switch g.accessibilityCursor {
+	case 0: // Aim guide — toggle
+		g.settings.aimGuide = !g.settings.aimGuide
+	case 1: // Aim assist — cycle forward
+		g.setAimAssist((g.settings.aimAssist + 1) % (MaxAimAssist + 1))
+	case 2: // Reduced motion — toggle
+		g.setReducedMotion(!g.profile.ReducedMotion)
+	case 3: // Back
+		g.state = stateSettings
+	}
+}
+
+// accessibilityChange steps the row under the cursor by dir, -1 or 1.
+func (g *Game) accessibilityChange(dir int) {
+	switch g.accessibilityCursor {
+	case 0:
+		g.settings.aimGuide = !g.settings.aimGuide
+	case 1:
+		g.setAimAssist(g.settings.aimAssist + dir)
+	case 2:
+		g.setReducedMotion(!g.profile.ReducedMotion)
+	}
+}
+
+func (g *Game) drawAccessibility(screen canvas.Canvas) {
+	white := color.RGBA{255, 255, 255, 255}
+	green := color.RGBA{0, 255, 0, 255}
+	grey := color.RGBA{100, 100, 100, 255}
+	drawCentered(screen, "ACCESSIBILITY", 100, 4, white)
+
+	onOff := func(on bool) string {
+		if on {
+			return "ON"
+		}
+		return "OFF"
+	}
+	for i, label := range accessibilityLabels {
+		clr := white
+		if i == g.accessibilityCursor {
+			clr = green
+		}
+		text := label
+		switch i {
+		case 0:
+			text = fmt.Sprintf("%s: %s", label, onOff(g.settings.aimGuide))
+		case 1:
+			text = fmt.Sprintf("%s: %s", label, aimAssistLabels[g.settings.aimAssist])
+		case 2:
+			text = fmt.Sprintf("%s: %s", label, onOff(g.profile.ReducedMotion))
+		}
+		drawCentered(screen, text, 200+float64(i)*40, 2.5, clr)
+	}
+	drawCentered(screen, "REDUCED MOTION: NO HIT-STOP FREEZE, NO BLINKING", 420, 1.5, grey)
+	drawCentered(screen, "INVULNERABLE SHIPS SHOW A SHIELD RING INSTEAD", 440, 1.5, grey)
+	drawCentered(screen, "LEFT-RIGHT TO CHANGE . ENTER TO TOGGLE . ESC TO GO BACK", 560, 1.5, grey)
+}
@@ -0,0 +1,64 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/matheus3301/asteroids/internal/canvas"
+	"github.com/matheus3301/asteroids/internal/profile"
+	"github.com/matheus3301/asteroids/internal/storage"
+)
+
+func TestReducedMotion_SavedInProfile(t *testing.T) {
+	restore := storage.Override(storage.At(t.TempDir()))
+	defer restore()
+
+	g := New()
+	g.openAccessibility()
+	g.accessibilityCursor = 2
+	g.accessibilitySelect()
+	if !g.profile.ReducedMotion {
+		t.Fatal("REDUCED MOTION should toggle on")
+	}
+	if !loadProfile().ReducedMotion {
+		t.Error("reduced motion should be saved in the profile")
+	}
+}
+
+func TestReducedMotion_ShieldRingInsteadOfBlink(t *testing.T) {
+	w := NewWorldWithSeed(1)
+	w.Player = SpawnPlayer(w, 400, 300)
+	w.players[w.Player].BlinkTimer = 0 // the hidden half of the blink
+
+	lines := func() int {
+		var rec canvas.Recording
+		DrawWorld(w, &rec)
+		return rec.Count("line")
+	}
+	if n := lines(); n != 0 {
+		t.Fatalf("a blinking ship should be hidden, drew %d lines", n)
+	}
+	w.ReducedMotion = true
+	if n := lines(); n <= shieldRingSegments {
+		t.Errorf("expected the ship and its shield ring, drew %d lines", n)
+	}
+	w.players[w.Player].Invulnerable = false
+	if n := lines(); n >= shieldRingSegments {
+		t.Errorf("expected no shield ring once vulnerable, drew %d lines", n)
+	}
+}
+
+func TestReducedMotion_SkipsHitStop(t *testing.T) {
+	g := NewWithOptions(Options{Seed: 1})
+	g.settings.autoPause = false
+	g.reset()
+	g.profile = &profile.Profile{ReducedMotion: true}
+	g.world.HitStop = 3
+	tick := g.world.Tick
+
+	if err := g.Update(); err != nil {
+		t.Fatal(err)
+	}
+	if g.world.HitStop != 0 || g.world.Tick != tick+3 {
+		t.Errorf("expected the freeze run out in one frame, hit-stop %d after %d ticks", g.world.HitStop, g.world.Tick-tick)
+	}
+}
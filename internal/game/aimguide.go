@@ -0,0 +1,133 @@
+package game
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/matheus3301/asteroids/internal/canvas"
+	"github.com/matheus3301/asteroids/internal/geom"
+)
+
+const (
+	// aimTolerance is how far, in radians, the ship may point from the
+	// lead angle and still be on target.
+	aimTolerance = 0.1
+	// aimNeedleLength is the length of the lead needle in pixels.
+	aimNeedleLength = 50.0
+	// aimAssistWindow is how far, in radians, the ship may point from the
+	// lead angle for aim assist at full strength to turn it the rest of
+	// the way. Weaker assist narrows the window.
+	aimAssistWindow = 0.35
+	// MaxAimAssist is the strongest aim assist setting.
+	MaxAimAssist = 3
+)
+
+// leadAngle is the direction to fire from the ship so a bullet at
+// bulletSpeed meets the nearest asteroid or saucer, worked out from the
+// observation the way an agent would. ok is false without a ship, without
+// a target or when no bullet can catch the target.
+func leadAngle(obs Observation, bulletSpeed float64) (angle float64, ok bool) {
+	if obs.Player == nil {
+		return 0, false
+	}
+	var target *ObjectObservation
+	best := math.Inf(1)
+	for _, list := range [][]ObjectObservation{obs.Asteroids, obs.Saucers} {
+		for i := range list {
+			o := &list[i]
+			if d := math.Hypot(o.DX, o.DY) - o.Radius; d < best {
+				target, best = o, d
+			}
+		}
+	}
+	if target == nil {
+		return 0, false
+	}
+	// Bullets do not inherit the ship's velocity.
+	t, ok := geom.Intercept(target.DX, target.DY, target.VX, target.VY, bulletSpeed)
+	if !ok {
+		return 0, false
+	}
+	return math.Atan2(target.DY+target.VY*t, target.DX+target.VX*t), true
+}
+
+// angleOff is the signed angle from a to b, in (-Pi, Pi].
+func angleOff(a, b float64) float64 {
+	d := math.Mod(b-a, 2*math.Pi)
+	if d > math.Pi {
+		d -= 2 * math.Pi
+	} else if d <= -math.Pi {
+		d += 2 * math.Pi
+	}
+	return d
+}
+
+// assistAim turns the ship towards the lead angle when the player is not
+// turning and is already pointing close to it, as if they had pressed the
+// rotate key themselves. strength runs from 0 (off) to MaxAimAssist. The
+// ship is left alone once it is within half a turn step of the lead, so
+// the assist settles instead of wobbling.
+func assistAim(w *World, in InputState, strength int) InputState {
+	if strength <= 0 || in.RotateLeft || in.RotateRight {
+		return in
+	}
+	obs := ObserveVisible(w)
+	angle, ok := leadAngle(obs, w.Config.BulletSpeed)
+	if !ok {
+		return in
+	}
+	off := angleOff(obs.Player.Angle, angle)
+	window := aimAssistWindow * float64(min(strength, MaxAimAssist)) / MaxAimAssist
+	if math.Abs(off) > window || math.Abs(off) <= w.Config.RotationSpeed/2 {
+		return in
+	}
+	in.RotateRight = off > 0
+	in.RotateLeft = off < 0
+	return in
+}
+
+// threatUrgency is how soon something on course to hit the ship will
+// arrive, from 0 (nothing within two seconds) to 1 (now), using the closest
+// approach figures in the observation.
+func threatUrgency(obs Observation) float64 {
+	if obs.Player == nil {
+		return 0
+	}
+	urgency := 0.0
+	for _, list := range [][]ObjectObservation{obs.Asteroids, obs.Saucers, obs.SaucerBullets} {
+		for _, o := range list {
+			if o.CPADist >= o.Radius+playerRadius || o.CPATicks > trajectoryTicks {
+				continue
+			}
+			urgency = math.Max(urgency, 1-o.CPATicks/trajectoryTicks)
+		}
+	}
+	return urgency
+}
+
+// drawAimGuide shows two things agents are given: a needle from the ship
+// along the lead angle, green when the ship points along it, and a bar of
+// threat urgency at the bottom of the screen.
+func drawAimGuide(w *World, screen canvas.Canvas) {
+	obs := ObserveVisible(w)
+	if obs.Player == nil {
+		return
+	}
+	ship := obs.Player
+	if angle, ok := leadAngle(obs, w.Config.BulletSpeed); ok {
+		clr := color.RGBA{255, 255, 0, 255}
+		if math.Abs(angleOff(ship.Angle, angle)) < aimTolerance {
+			clr = color.RGBA{0, 255, 0, 255}
+		}
+		strokeLine(screen, ship.X, ship.Y,
+			ship.X+math.Cos(angle)*aimNeedleLength, ship.Y+math.Sin(angle)*aimNeedleLength, clr)
+	}
+
+	grey := color.RGBA{100, 100, 100, 255}
+	x, y := w.Config.FieldWidth/2-60, w.Config.FieldHeight-24
+	DrawText(screen, "THREAT", x-TextWidth("THREAT ", 1.5), y-2, 1.5, grey)
+	screen.StrokeRect(x, y, 120, 8, 1, grey)
+	if u := threatUrgency(obs); u > 0 {
+		screen.FillRect(x, y, 120*u, 8, color.RGBA{255, 60, 60, 255})
+	}
+}
@@ -0,0 +1,152 @@
+package game
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/matheus3301/asteroids/internal/canvas"
+	"github.com/matheus3301/asteroids/internal/profile"
+)
+
+func TestLeadAngle_LeadsAMovingTarget(t *testing.T) {
+	// A target straight to the right moving down is met below it.
+	obs := Observation{
+		Player:    &ShipObservation{},
+		Asteroids: []ObjectObservation{{DX: 100, VY: 2, Radius: 10}},
+	}
+	angle, ok := leadAngle(obs, 10)
+	if !ok {
+		t.Fatal("expected a lead angle")
+	}
+	if want := math.Asin(0.2); math.Abs(angle-want) > 1e-9 {
+		t.Errorf("lead angle %.4f, want %.4f", angle, want)
+	}
+}
+
+func TestLeadAngle_NearestTarget(t *testing.T) {
+	obs := Observation{
+		Player:    &ShipObservation{},
+		Asteroids: []ObjectObservation{{DX: 300, Radius: 10}},
+		Saucers:   []ObjectObservation{{DY: -100, Radius: 10}},
+	}
+	if angle, ok := leadAngle(obs, 10); !ok || math.Abs(angle+math.Pi/2) > 1e-9 {
+		t.Errorf("expected to aim up at the saucer, got %.4f %v", angle, ok)
+	}
+	if _, ok := leadAngle(Observation{Player: &ShipObservation{}}, 10); ok {
+		t.Error("expected no lead angle without targets")
+	}
+	// Nothing can catch a target running away faster than a bullet.
+	obs = Observation{Player: &ShipObservation{}, Asteroids: []ObjectObservation{{DX: 100, VX: 20}}}
+	if _, ok := leadAngle(obs, 10); ok {
+		t.Error("expected no lead angle for an uncatchable target")
+	}
+}
+
+func TestThreatUrgency(t *testing.T) {
+	obs := Observation{Player: &ShipObservation{}, Asteroids: []ObjectObservation{
+		{Radius: 10, CPATicks: 90, CPADist: 5},
+		{Radius: 10, CPATicks: 30, CPADist: 40}, // passes wide
+	}}
+	if got := threatUrgency(obs); math.Abs(got-0.25) > 1e-9 {
+		t.Errorf("urgency %.3f, want 0.25", got)
+	}
+	obs.SaucerBullets = []ObjectObservation{{Radius: 2, CPATicks: 12}}
+	if got := threatUrgency(obs); math.Abs(got-0.9) > 1e-9 {
+		t.Errorf("urgency %.3f, want 0.9 for the bullet", got)
+	}
+	if got := threatUrgency(Observation{}); got != 0 {
+		t.Errorf("urgency %.3f without a ship", got)
+	}
+}
+
+func TestDrawAimGuide_NeedleTurnsGreenOnTarget(t *testing.T) {
+	w := NewWorldWithSeed(1)
+	w.Player = SpawnPlayer(w, 400, 300)
+	SpawnAsteroid(w, 400, 100, SizeSmall)
+	*w.velocities[sortedEntities(w.asteroids)[0]] = Velocity{}
+
+	needle := func() color.RGBA {
+		var rec canvas.Recording
+		drawAimGuide(w, &rec)
+		if len(rec.Ops) == 0 || rec.Ops[0].Kind != "line" {
+			t.Fatal("expected the needle first")
+		}
+		return rec.Ops[0].Color
+	}
+	w.rotations[w.Player].Angle = -math.Pi / 2
+	if got := needle(); got != (color.RGBA{0, 255, 0, 255}) {
+		t.Errorf("needle %v when pointing at the asteroid", got)
+	}
+	w.rotations[w.Player].Angle = 0
+	if got := needle(); got != (color.RGBA{255, 255, 0, 255}) {
+		t.Errorf("needle %v when pointing away", got)
+	}
+}
+
+func TestAimGuide_SettingsToggle(t *testing.T) {
+	g := NewWithOptions(Options{AimGuide: true})
+	if !g.settings.aimGuide {
+		t.Fatal("Options.AimGuide should turn the guide on")
+	}
+	g.openAccessibility()
+	g.accessibilitySelect()
+	if g.settings.aimGuide {
+		t.Error("AIM GUIDE should toggle off")
+	}
+}
+
+func TestAssistAim_TurnsOntoNearbyLead(t *testing.T) {
+	w := NewWorldWithSeed(1)
+	w.Player = SpawnPlayer(w, 400, 300)
+	a := SpawnAsteroid(w, 400, 100, SizeSmall)
+	*w.velocities[a] = Velocity{}
+	rot := w.rotations[w.Player]
+
+	rot.Angle = -math.Pi/2 - 0.2
+	if in := assistAim(w, InputState{Thrust: true}, MaxAimAssist); !in.RotateRight || in.RotateLeft || !in.Thrust {
+		t.Errorf("expected a turn right towards the asteroid, got %+v", in)
+	}
+	if in := assistAim(w, InputState{}, 1); in.RotateLeft || in.RotateRight {
+		t.Error("weak assist should not reach 0.2 rad")
+	}
+	if in := assistAim(w, InputState{RotateLeft: true}, MaxAimAssist); in.RotateRight {
+		t.Error("assist should not fight the player's own turn")
+	}
+	rot.Angle = -math.Pi/2 + w.Config.RotationSpeed/4
+	if in := assistAim(w, InputState{}, MaxAimAssist); in.RotateLeft || in.RotateRight {
+		t.Error("assist should settle once on target")
+	}
+	rot.Angle = 0
+	if in := assistAim(w, InputState{}, MaxAimAssist); in.RotateLeft || in.RotateRight {
+		t.Error("assist should not snap from far off the lead")
+	}
+}
+
+func TestAimAssist_NotCountedInCareer(t *testing.T) {
+	g := NewWithOptions(Options{AimAssist: 2})
+	if kb := g.input.(KeyboardInput); kb.AimAssist != 2 {
+		t.Fatalf("keyboard assist %d, want 2", kb.AimAssist)
+	}
+	g.settings.autoPause = false
+	g.reset()
+	g.profile = &profile.Profile{}
+	if err := g.Update(); err != nil {
+		t.Fatal(err)
+	}
+	g.world.Score = 5_000
+	g.recordCareer()
+	if !g.assisted || g.profile.Games != 0 {
+		t.Error("an assisted game should not count towards the career")
+	}
+
+	g.accessibilityCursor = 1
+	g.accessibilitySelect()
+	if g.settings.aimAssist != 3 {
+		t.Errorf("expected the assist to cycle up, got %d", g.settings.aimAssist)
+	}
+	g.accessibilitySelect()
+	if g.settings.aimAssist != 0 || g.input.(KeyboardInput).AimAssist != 0 {
+		t.Error("cycling past the top should turn the assist off")
+	}
+}
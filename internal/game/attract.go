@@ -0,0 +1,150 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/matheus3301/asteroids/internal/canvas"
+)
+
+// attractIdleFrames is how long the main menu waits for a key before the
+// demo reel starts.
+const attractIdleFrames = 20 * 60
+
+// attractSlotFrames is how long each demo plays before the reel moves on
+// to the next source.
+const attractSlotFrames = 30 * 60
+
+// demoSource is one source of the attract mode's demo reel.
+type demoSource struct {
+	// caption is shown above the demo while it plays.
+	caption string
+	// start returns the world to show and a function that advances it a
+	// tick, reporting false once the demo has ended. A nil world means
+	// the source has nothing to show and is skipped.
+	start func(g *Game) (*World, func() bool)
+}
+
+// demoSources are the reel's sources in the order they take turns. There
+// is no high-score ghost yet to add to them.
+var demoSources = []demoSource{
+	{caption: "DEMO - AUTOPILOT", start: startAutopilotDemo},
+	{caption: "DEMO - LAST GAME", start: startReplayDemo},
+}
+
+// attractReel is the demo reel shown while the menu sits idle. Demos are
+// silent.
+type attractReel struct {
+	// idle is how many menu frames have passed without a key held.
+	idle int
+	// source is the demo playing, as an index into demoSources, and
+	// frames how long it has played.
+	source  int
+	frames  int
+	world   *World
+	advance func() bool
+	// autopilots counts the autopilot demos, each played on its own seed.
+	autopilots int64
+	// replay is the latest replay, loaded once when the reel starts, or
+	// nil if there is none that plays back under the current rules.
+	replay *Replay
+}
+
+// startAutopilotDemo flies a fresh game with the Autopilot.
+func startAutopilotDemo(g *Game) (*World, func() bool) {
+	g.attract.autopilots++
+	w := NewGameWorld(g.attract.autopilots)
+	return w, func() bool {
+		Step(w, Autopilot{}.NextInput(w))
+		return !w.GameOver()
+	}
+}
+
+// startReplayDemo plays the latest replay, if there is one.
+func startReplayDemo(g *Game) (*World, func() bool) {
+	if g.attract.replay == nil {
+		return nil, nil
+	}
+	p := NewReplayRunner(g.attract.replay)
+	return p.World, func() bool {
+		p.Advance()
+		return !p.Done()
+	}
+}
+
+// idleMenu counts menu frames without a key held and starts the demo reel
+// once there have been attractIdleFrames of them. It reports whether the
+// reel started.
+func (g *Game) idleMenu(pressed bool) bool {
+	if pressed {
+		g.attract.idle = 0
+		return false
+	}
+	g.attract.idle++
+	if g.attract.idle < attractIdleFrames {
+		return false
+	}
+	g.startAttract()
+	return true
+}
+
+// startAttract starts the demo reel from its first source. A replay
+// recorded under other rules would desync, so it is left out.
+func (g *Game) startAttract() {
+	g.attract.idle = 0
+	g.attract.replay = g.latestReplay()
+	if r := g.attract.replay; r != nil && !r.Compatible() {
+		g.attract.replay = nil
+	}
+	g.attract.source = len(demoSources) - 1
+	g.nextDemo()
+	g.state = stateAttract
+}
+
+// nextDemo switches to the next source with something to show. The
+// autopilot always has, so one is always found.
+func (g *Game) nextDemo() {
+	a := &g.attract
+	for {
+		a.source = (a.source + 1) % len(demoSources)
+		if a.world, a.advance = demoSources[a.source].start(g); a.world != nil {
+			break
+		}
+	}
+	a.frames = 0
+}
+
+func (g *Game) updateAttract() {
+	if len(inpututil.AppendPressedKeys(nil)) > 0 {
+		g.stopAttract()
+		return
+	}
+	g.stepAttract()
+}
+
+// stepAttract advances the demo a tick, moving on to the next source once
+// its time is up or it has ended.
+func (g *Game) stepAttract() {
+	a := &g.attract
+	a.frames++
+	if !a.advance() || a.frames >= attractSlotFrames {
+		g.nextDemo()
+		return
+	}
+	a.world.SoundQueue = a.world.SoundQueue[:0]
+}
+
+// stopAttract goes back to the menu.
+func (g *Game) stopAttract() {
+	g.attract.world, g.attract.advance, g.attract.replay = nil, nil, nil
+	g.state = stateMenu
+}
+
+func (g *Game) drawAttract(screen canvas.Canvas) {
+	a := &g.attract
+	screen.Fill(a.world.Palette.Background)
+	DrawWorld(a.world, g.worldCanvas(screen, a.world))
+	ui := g.menuCanvas(screen)
+	drawCentered(ui, demoSources[a.source].caption, 30, 2, color.RGBA{255, 210, 60, 255})
+	drawCentered(ui, "PRESS ANY KEY", ScreenHeight-40, 2, color.RGBA{150, 150, 150, 255})
+}
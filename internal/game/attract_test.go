@@ -0,0 +1,81 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/matheus3301/asteroids/internal/storage"
+)
+
+func TestAttract_StartsWhenTheMenuIsIdle(t *testing.T) {
+	restore := storage.Override(storage.At(t.TempDir()))
+	defer restore()
+
+	g := New()
+	for i := 0; i < attractIdleFrames-1; i++ {
+		g.idleMenu(i == attractIdleFrames/2)
+	}
+	if g.state != stateMenu {
+		t.Fatal("a key press should restart the idle timer")
+	}
+	for !g.idleMenu(false) {
+	}
+	if g.state != stateAttract || g.attract.source != 0 || g.attract.world == nil {
+		t.Fatalf("expected the autopilot demo, state %v source %d", g.state, g.attract.source)
+	}
+	g.stopAttract()
+	if g.state != stateMenu || g.attract.world != nil {
+		t.Errorf("a key should return to the menu, state %v", g.state)
+	}
+}
+
+func TestAttract_RotatesSources(t *testing.T) {
+	restore := storage.Override(storage.At(t.TempDir()))
+	defer restore()
+
+	g := New()
+	g.startAttract()
+	first := g.attract.world
+	for i := 0; i < attractSlotFrames; i++ {
+		g.stepAttract()
+	}
+	// With no replay anywhere the reel goes straight on to a new
+	// autopilot game.
+	if g.attract.source != 0 || g.attract.world == first || g.attract.frames != 0 {
+		t.Fatalf("expected a new autopilot demo, source %d", g.attract.source)
+	}
+
+	g.stopAttract()
+	g.lastReplay = recordGame(3, 600)
+	g.startAttract()
+	for i := 0; i < attractSlotFrames && g.attract.source == 0; i++ {
+		g.stepAttract()
+	}
+	if g.attract.source != 1 || g.attract.world.Seed != 3 {
+		t.Fatalf("expected the last game's replay, source %d", g.attract.source)
+	}
+	for i := 0; i < 600; i++ {
+		g.stepAttract()
+	}
+	if g.attract.source != 0 {
+		t.Errorf("a replay that ends should hand over before its slot is up, source %d", g.attract.source)
+	}
+}
+
+func TestAttract_SkipsIncompatibleReplays(t *testing.T) {
+	restore := storage.Override(storage.At(t.TempDir()))
+	defer restore()
+
+	g := New()
+	g.lastReplay = recordGame(3, 600)
+	g.lastReplay.ConfigHash++
+	g.startAttract()
+	if g.attract.replay != nil {
+		t.Fatal("a replay recorded under other rules should not be shown")
+	}
+	for i := 0; i < attractSlotFrames; i++ {
+		g.stepAttract()
+	}
+	if g.attract.source != 0 {
+		t.Errorf("the reel should stay on the autopilot, source %d", g.attract.source)
+	}
+}
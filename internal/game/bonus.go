@@ -0,0 +1,110 @@
+package game
+
+import "math"
+
+const (
+	starDropChance     = 0.5
+	starLife           = 5 * 60
+	starPoints         = 500
+	starRadius         = 10.0
+	starSpeed          = 0.6
+	comboWindow        = 90
+	comboStep          = 5
+	comboMaxMultiplier = 5
+)
+
+// starVerts is a five-pointed star outline.
+var starVerts = func() [][2]float64 {
+	verts := make([][2]float64, 10)
+	for i := range verts {
+		r := starRadius
+		if i%2 == 1 {
+			r *= 0.45
+		}
+		ang := float64(i)/10*2*math.Pi - math.Pi/2
+		verts[i] = [2]float64{math.Cos(ang) * r, math.Sin(ang) * r}
+	}
+	return verts
+}()
+
+// SpawnStar creates a bonus star that drifts from (x, y) until collected or
+// expired.
+func SpawnStar(w *World, x, y float64) Entity {
+	e := w.Spawn()
+
+	dir := w.rng.Float64() * 2 * math.Pi
+	w.positions[e] = &Position{X: x, Y: y}
+	w.velocities[e] = &Velocity{
+		X: math.Cos(dir) * starSpeed,
+		Y: math.Sin(dir) * starSpeed,
+	}
+	w.rotations[e] = &Rotation{Spin: 0.03}
+	w.colliders[e] = &Collider{Radius: starRadius, Layer: LayerPickup}
+	w.wrappers[e] = true
+
+	w.renderables[e] = &Renderable{
+		Kind:     ShapePolygon,
+		Vertices: starVerts,
+		Color:    w.Palette.Bonus,
+		Scale:    1,
+	}
+
+	w.pickups[e] = &PickupTag{Life: w.Config.StarLife}
+
+	return e
+}
+
+// maybeDropStar gives a destroyed small saucer a chance to leave a star.
+func maybeDropStar(w *World, size SaucerSize, x, y float64) {
+	if size == SaucerSmall && w.rng.Float64() < w.Config.StarDropChance {
+		SpawnStar(w, x, y)
+	}
+}
+
+// addCombo counts a kill toward the combo and restarts its window.
+func addCombo(w *World) {
+	w.Combo++
+	w.ComboTimer = w.Config.ComboWindow
+}
+
+// ComboMultiplier is what bonus points are multiplied by: one more for
+// every ComboStep kills in a row, up to ComboMaxMultiplier.
+func (w *World) ComboMultiplier() int {
+	return min(1+w.Combo/w.Config.ComboStep, w.Config.ComboMaxMultiplier)
+}
+
+// BonusSystem ends combos that have gone quiet and expires or collects
+// bonus stars.
+func BonusSystem(w *World) {
+	if w.LifeBonusFlash > 0 {
+		w.LifeBonusFlash--
+	}
+	if w.ComboTimer > 0 {
+		w.ComboTimer--
+		if w.ComboTimer == 0 {
+			w.Combo = 0
+		}
+	}
+
+	for _, e := range sortedEntities(w.pickups) {
+		p := w.pickups[e]
+		p.Life--
+		if p.Life <= 0 {
+			w.Destroy(e)
+			continue
+		}
+		for _, pe := range sortedEntities(w.players) {
+			if overlaps(w, e, pe) {
+				before := w.Score
+				w.Score += w.Config.StarPoints * w.ComboMultiplier()
+				checkExtraLife(w)
+				checkWeaponTier(w)
+				w.players[pe].Score += w.Score - before
+				w.Stats.PowerUps++
+				w.SoundQueue = append(w.SoundQueue, SoundPickup)
+				w.Destroy(e)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package game
+
+import "testing"
+
+func TestBonusSystem_CollectScoresWithCombo(t *testing.T) {
+	w := NewWorld()
+	SpawnPlayer(w, 100, 100)
+	star := SpawnStar(w, 100, 100)
+	w.Combo = comboStep * 2
+
+	BonusSystem(w)
+
+	if w.Alive(star) {
+		t.Error("touching the star should collect it")
+	}
+	if want := starPoints * 3; w.Score != want {
+		t.Errorf("expected %d points, got %d", want, w.Score)
+	}
+	if w.Stats.PowerUps != 1 {
+		t.Errorf("expected 1 pickup counted, got %d", w.Stats.PowerUps)
+	}
+}
+
+func TestBonusSystem_StarExpires(t *testing.T) {
+	w := NewWorld()
+	star := SpawnStar(w, 100, 100)
+
+	for i := 0; i < starLife; i++ {
+		BonusSystem(w)
+	}
+
+	if w.Alive(star) {
+		t.Error("an uncollected star should despawn after its lifetime")
+	}
+	if w.Score != 0 {
+		t.Error("an expired star should not score")
+	}
+}
+
+func TestBonusSystem_ComboTimesOut(t *testing.T) {
+	w := NewWorld()
+	addCombo(w)
+	addCombo(w)
+
+	for i := 0; i < comboWindow; i++ {
+		BonusSystem(w)
+	}
+
+	if w.Combo != 0 {
+		t.Errorf("combo should reset after the window, got %d", w.Combo)
+	}
+}
+
+func TestComboMultiplier_Capped(t *testing.T) {
+	w := NewWorld()
+	w.Combo = 1000
+	if got := w.ComboMultiplier(); got != comboMaxMultiplier {
+		t.Errorf("expected multiplier capped at %d, got %d", comboMaxMultiplier, got)
+	}
+}
+
+func TestMaybeDropStar_OnlySmallSaucers(t *testing.T) {
+	w := NewWorld()
+	w.Config.StarDropChance = 1
+
+	maybeDropStar(w, SaucerLarge, 100, 100)
+	if len(w.pickups) != 0 {
+		t.Error("large saucers should not drop stars")
+	}
+	maybeDropStar(w, SaucerSmall, 100, 100)
+	if len(w.pickups) != 1 {
+		t.Error("small saucers should drop a star")
+	}
+}
+
+func TestKillPlayer_ResetsCombo(t *testing.T) {
+	w := NewGameWorld(1)
+	w.Combo, w.ComboTimer = 7, 30
+
+	killPlayer(w, w.Player, DeathAsteroid)
+
+	if w.Combo != 0 {
+		t.Errorf("dying should end the combo, got %d", w.Combo)
+	}
+}
@@ -0,0 +1,63 @@
+package game
+
+import "time"
+
+const (
+	// frameBudget is the time a frame may take at 60 frames a second.
+	frameBudget = time.Second / 60
+	// budgetWindow is how many recent frames the watchdog averages.
+	budgetWindow = 30
+	// budgetRestoreFrames is how many frames in a row must have headroom
+	// before full effects come back, so the watchdog does not flicker
+	// between modes.
+	budgetRestoreFrames = 120
+)
+
+// frameWatchdog tracks how long recent frames took to update and draw and
+// turns on the low-detail mode when they run over budget. Effects come back
+// once the average falls below three quarters of the budget and stays
+// there.
+type frameWatchdog struct {
+	samples  [budgetWindow]time.Duration
+	n, next  int
+	total    time.Duration
+	calm     int
+	degraded bool
+}
+
+// record adds one frame's work time and reports whether effects should be
+// reduced.
+func (f *frameWatchdog) record(d time.Duration) bool {
+	if f.n == budgetWindow {
+		f.total -= f.samples[f.next]
+	} else {
+		f.n++
+	}
+	f.samples[f.next] = d
+	f.total += d
+	f.next = (f.next + 1) % budgetWindow
+
+	avg := f.average()
+	switch {
+	case f.n == budgetWindow && avg > frameBudget:
+		f.degraded = true
+		f.calm = 0
+	case f.degraded && avg < frameBudget*3/4:
+		f.calm++
+		if f.calm >= budgetRestoreFrames {
+			f.degraded = false
+			f.calm = 0
+		}
+	default:
+		f.calm = 0
+	}
+	return f.degraded
+}
+
+// average is the mean frame time over the window.
+func (f *frameWatchdog) average() time.Duration {
+	if f.n == 0 {
+		return 0
+	}
+	return f.total / time.Duration(f.n)
+}
@@ -0,0 +1,66 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matheus3301/asteroids/internal/canvas"
+)
+
+func TestFrameWatchdog_DegradesAndRestores(t *testing.T) {
+	var f frameWatchdog
+	for i := 0; i < budgetWindow-1; i++ {
+		if f.record(2 * frameBudget) {
+			t.Fatal("should wait for a full window before reducing effects")
+		}
+	}
+	if !f.record(2 * frameBudget) {
+		t.Fatal("a window of slow frames should reduce effects")
+	}
+
+	// It takes a window of fast frames to bring the average down, then
+	// budgetRestoreFrames more of headroom.
+	frames := 0
+	for f.record(time.Millisecond) {
+		frames++
+		if frames > budgetWindow+budgetRestoreFrames {
+			t.Fatal("effects never came back")
+		}
+	}
+	if frames < budgetRestoreFrames {
+		t.Errorf("effects came back after %d frames", frames)
+	}
+}
+
+func TestFrameWatchdog_SpikeResetsCalm(t *testing.T) {
+	f := frameWatchdog{degraded: true}
+	for i := 0; i < budgetRestoreFrames-1; i++ {
+		f.record(time.Millisecond)
+	}
+	f.record(budgetWindow * frameBudget)
+	for i := 0; i < budgetWindow; i++ {
+		f.record(time.Millisecond)
+	}
+	if !f.degraded {
+		t.Error("a spike should restart the wait for headroom")
+	}
+}
+
+func TestRenderSystem_LowDetailThinsParticles(t *testing.T) {
+	w := NewWorldWithSeed(1)
+	for i := 0; i < 10; i++ {
+		SpawnParticle(w, 100, 100)
+	}
+	var full canvas.Recording
+	RenderSystem(w, &full, 1)
+	if n := full.Count("circle"); n != 10 {
+		t.Fatalf("drew %d of 10 particles", n)
+	}
+
+	w.LowDetail = true
+	var low canvas.Recording
+	RenderSystem(w, &low, 1)
+	if n := low.Count("circle"); n != 5 {
+		t.Errorf("low detail drew %d of 10 particles, want 5", n)
+	}
+}
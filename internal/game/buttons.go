@@ -0,0 +1,39 @@
+package game
+
+// buttonLatch turns the one-shot buttons (shoot, hyperspace and missile)
+// into presses for gameplay ticks. It does its own edge detection, once per
+// frame, instead of asking inpututil from each tick, so that:
+//
+//   - a press on a frame that runs no tick, such as one at a slowed game
+//     speed or the frame that closes the pause menu, is kept for the next
+//     tick instead of lost;
+//   - a press is used by one tick only, however many ticks its frame runs;
+//   - a button held through a pause or menu does not count as pressed when
+//     play resumes.
+type buttonLatch struct {
+	// held is the buttons down on the last frame.
+	held InputState
+	// pending is the presses not yet given to a tick.
+	pending InputState
+}
+
+// Frame records the buttons down this frame, latching the new presses.
+func (l *buttonLatch) Frame(down InputState) {
+	l.pending.Shoot = l.pending.Shoot || down.Shoot && !l.held.Shoot
+	l.pending.Hyperspace = l.pending.Hyperspace || down.Hyperspace && !l.held.Hyperspace
+	l.pending.Missile = l.pending.Missile || down.Missile && !l.held.Missile
+	l.held = down
+}
+
+// Take returns the latched presses and forgets them.
+func (l *buttonLatch) Take() InputState {
+	in := l.pending
+	l.pending = InputState{}
+	return in
+}
+
+// Clear forgets the latched presses. Buttons still down stay held, so they
+// need releasing and pressing again to count.
+func (l *buttonLatch) Clear() {
+	l.pending = InputState{}
+}
@@ -0,0 +1,100 @@
+package game
+
+import "testing"
+
+func TestButtonLatch_Edges(t *testing.T) {
+	var l buttonLatch
+	l.Frame(InputState{Shoot: true})
+	l.Frame(InputState{Shoot: true, Missile: true})
+	if in := l.Take(); !in.Shoot || !in.Missile {
+		t.Errorf("expected both presses latched, got %+v", in)
+	}
+	if in := l.Take(); in != (InputState{}) {
+		t.Errorf("a press should be taken once, got %+v", in)
+	}
+	l.Frame(InputState{Shoot: true})
+	if in := l.Take(); in.Shoot {
+		t.Error("a held button should not press again")
+	}
+	l.Frame(InputState{})
+	l.Frame(InputState{Hyperspace: true})
+	l.Clear()
+	l.Frame(InputState{Hyperspace: true})
+	if in := l.Take(); in.Hyperspace {
+		t.Error("a cleared press held on should not come back")
+	}
+}
+
+// playFrames runs g through a script of frames, one entry per frame with
+// the one-shot buttons held down on it. act, when set for a frame, stands
+// in for the menu key pressed on it, since tests cannot press keys.
+func playFrames(g *Game, frames []InputState, act map[int]func()) {
+	for i, held := range frames {
+		g.readButtons = func() InputState { return held }
+		if f := act[i]; f != nil {
+			g.buttons.Frame(g.readButtons())
+			f()
+			g.endFrame()
+			continue
+		}
+		g.Update()
+	}
+}
+
+// newLatchGame starts a keyboard game at speed for the button tests.
+func newLatchGame(t *testing.T, speed float64) *Game {
+	t.Helper()
+	g := NewWithOptions(Options{Seed: 1, Speed: speed})
+	g.settings.autoPause = false
+	g.reset()
+	g.world.players[g.world.Player].Invulnerable = false
+	return g
+}
+
+func TestButtons_PressOnResumeFrameFires(t *testing.T) {
+	g := newLatchGame(t, 1)
+	shoot := InputState{Shoot: true}
+	resume := func() {
+		g.pauseCursor = 0
+		g.pauseSelect()
+	}
+	playFrames(g, []InputState{{}, {}, {}, shoot, shoot, {}}, map[int]func(){
+		1: g.pause,
+		3: resume,
+	})
+	if n := g.world.Stats.ShotsFired; n != 1 {
+		t.Errorf("a press on the frame play resumes should fire once, fired %d", n)
+	}
+}
+
+func TestButtons_PressWhilePausedIsDropped(t *testing.T) {
+	g := newLatchGame(t, 1)
+	shoot := InputState{Shoot: true}
+	resume := func() {
+		g.pauseCursor = 0
+		g.pauseSelect()
+	}
+	// Space goes down while paused and is still held when play resumes.
+	playFrames(g, []InputState{{}, {}, shoot, shoot, shoot, {}}, map[int]func(){
+		1: g.pause,
+		3: resume,
+	})
+	if n := g.world.Stats.ShotsFired; n != 0 {
+		t.Errorf("a press made while paused should not fire, fired %d", n)
+	}
+}
+
+func TestButtons_OnePressPerFrameAtAnySpeed(t *testing.T) {
+	// At double speed one frame runs two ticks; the press fires once.
+	g := newLatchGame(t, 2)
+	playFrames(g, []InputState{{Shoot: true}, {}}, nil)
+	if n := g.world.Stats.ShotsFired; n != 1 {
+		t.Errorf("expected one shot at double speed, fired %d", n)
+	}
+	// At half speed the press lands on a frame with no tick and is kept.
+	g = newLatchGame(t, 0.5)
+	playFrames(g, []InputState{{Shoot: true}, {}, {}}, nil)
+	if n := g.world.Stats.ShotsFired; n != 1 {
+		t.Errorf("expected the half-speed press to fire once, fired %d", n)
+	}
+}
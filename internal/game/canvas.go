@@ -0,0 +1,34 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/matheus3301/asteroids/internal/canvas"
+)
+
+// ebitenCanvas draws to an ebiten image.
+type ebitenCanvas struct {
+	img *ebiten.Image
+}
+
+var _ canvas.Canvas = ebitenCanvas{}
+
+func (c ebitenCanvas) Fill(clr color.Color) { c.img.Fill(clr) }
+
+func (c ebitenCanvas) StrokeLine(x1, y1, x2, y2, width float64, clr color.Color) {
+	vector.StrokeLine(c.img, float32(x1), float32(y1), float32(x2), float32(y2), float32(width), clr, false)
+}
+
+func (c ebitenCanvas) StrokeRect(x, y, w, h, width float64, clr color.Color) {
+	vector.StrokeRect(c.img, float32(x), float32(y), float32(w), float32(h), float32(width), clr, false)
+}
+
+func (c ebitenCanvas) FillRect(x, y, w, h float64, clr color.Color) {
+	vector.FillRect(c.img, float32(x), float32(y), float32(w), float32(h), clr, false)
+}
+
+func (c ebitenCanvas) FillCircle(cx, cy, r float64, clr color.Color) {
+	vector.FillCircle(c.img, float32(cx), float32(cy), float32(r), clr, false)
+}
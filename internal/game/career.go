@@ -0,0 +1,323 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/matheus3301/asteroids/internal/canvas"
+	"github.com/matheus3301/asteroids/internal/profile"
+)
+
+// UnlockKind is what an unlock gives the player.
+type UnlockKind int
+
+const (
+	UnlockShip UnlockKind = iota
+	UnlockPalette
+	UnlockMode
+)
+
+// Unlock is a career reward and the milestone that earns it.
+type Unlock struct {
+	ID   string
+	Name string
+	Kind UnlockKind
+	// Need describes the milestone for the CAREER screen.
+	Need    string
+	reached func(p *profile.Profile) bool
+}
+
+// ShipShape is an outline for the player ship, pointing right, in units of
+// the ship's collision radius.
+type ShipShape [][2]float64
+
+// classicShip is the standard triangle.
+var classicShip = ShipShape{{1, 0}, {-0.8, -0.6}, {-0.8, 0.6}}
+
+var shipShapes = map[string]ShipShape{
+	"dart":  {{1, 0}, {-0.8, -0.55}, {-0.35, 0}, {-0.8, 0.55}},
+	"arrow": {{1, 0}, {-0.1, -0.7}, {-0.8, -0.7}, {-0.5, 0}, {-0.8, 0.7}, {-0.1, 0.7}},
+	"wing":  {{1, 0}, {0.1, -0.25}, {-0.5, -0.95}, {-0.8, -0.3}, {-0.8, 0.3}, {-0.5, 0.95}, {0.1, 0.25}},
+}
+
+// vertices scales the shape to a ship of the given radius.
+func (s ShipShape) vertices(radius float64) [][2]float64 {
+	if len(s) == 0 {
+		s = classicShip
+	}
+	verts := make([][2]float64, len(s))
+	for i, v := range s {
+		verts[i] = [2]float64{v[0] * radius, v[1] * radius}
+	}
+	return verts
+}
+
+var cosmeticPalettes = map[string]func(Palette) Palette{
+	"amber": func(p Palette) Palette {
+		amber := color.RGBA{255, 176, 0, 255}
+		dim := color.RGBA{180, 110, 0, 255}
+		p.HUD, p.Ship, p.Asteroid, p.Bullet, p.Particle = amber, amber, dim, amber, dim
+		p.Saucer, p.SaucerBullet = amber, dim
+		return p
+	},
+	"ice": func(p Palette) Palette {
+		p.Background = color.RGBA{0, 8, 24, 255}
+		p.Ship = color.RGBA{180, 240, 255, 255}
+		p.Asteroid = color.RGBA{120, 170, 220, 255}
+		p.Particle = color.RGBA{200, 230, 255, 255}
+		return p
+	},
+	"neon": func(p Palette) Palette {
+		p.Ship = color.RGBA{255, 0, 255, 255}
+		p.Asteroid = color.RGBA{0, 255, 255, 255}
+		p.Bullet = color.RGBA{255, 255, 0, 255}
+		p.Particle = color.RGBA{255, 0, 160, 255}
+		return p
+	},
+}
+
+// modePurist is the unlockable arcade purist mode.
+const modePurist = "purist"
+
+// PuristConfig strips c back to the arcade original and makes it harsher:
+// no weapon upgrades, missiles, asteroid variants or bonus stars, and
+// saucers arrive sooner.
+func PuristConfig(c GameConfig) GameConfig {
+	c.WeaponRapidScore, c.WeaponSpreadScore = 0, 0
+	c.MissileAmmo = 0
+	c.GoldenChance, c.ExplosiveChance, c.ArmoredChance = 0, 0, 0
+	c.StarDropChance = 0
+	c.SaucerInitialDelay /= 2
+	c.SaucerRespawnDelay /= 2
+	return c
+}
+
+// unlocks is every career reward, in the order the CAREER screen lists them.
+var unlocks = []Unlock{
+	{ID: "dart", Name: "DART SHIP", Kind: UnlockShip, Need: "SCORE 25,000 IN TOTAL",
+		reached: func(p *profile.Profile) bool { return p.TotalScore >= 25_000 }},
+	{ID: "amber", Name: "AMBER PALETTE", Kind: UnlockPalette, Need: "PLAY 10 GAMES",
+		reached: func(p *profile.Profile) bool { return p.Games >= 10 }},
+	{ID: "arrow", Name: "ARROW SHIP", Kind: UnlockShip, Need: "DESTROY 500 OBJECTS",
+		reached: func(p *profile.Profile) bool { return p.Kills() >= 500 }},
+	{ID: "neon", Name: "NEON PALETTE", Kind: UnlockPalette, Need: "SCORE 30,000 IN ONE GAME",
+		reached: func(p *profile.Profile) bool { return p.BestScore >= 30_000 }},
+	{ID: "ice", Name: "ICE PALETTE", Kind: UnlockPalette, Need: "DESTROY 2,000 OBJECTS",
+		reached: func(p *profile.Profile) bool { return p.Kills() >= 2_000 }},
+	{ID: "wing", Name: "WING SHIP", Kind: UnlockShip, Need: "SCORE 150,000 IN TOTAL",
+		reached: func(p *profile.Profile) bool { return p.TotalScore >= 150_000 }},
+	{ID: modePurist, Name: "ARCADE PURIST MODE", Kind: UnlockMode, Need: "SCORE 250,000 IN TOTAL",
+		reached: func(p *profile.Profile) bool { return p.TotalScore >= 250_000 }},
+}
+
+// checkUnlocks records every unlock p has reached and returns the new ones.
+func checkUnlocks(p *profile.Profile) []Unlock {
+	var earned []Unlock
+	for _, u := range unlocks {
+		if u.reached(p) && p.Unlock(u.ID) {
+			earned = append(earned, u)
+		}
+	}
+	return earned
+}
+
+// applyCosmetics changes r to the player's chosen ship, palette and mode.
+// Choices that are no longer unlocked are ignored.
+func applyCosmetics(r Ruleset, p *profile.Profile) Ruleset {
+	if p.Has(p.Ship) {
+		r.Ship = shipShapes[p.Ship]
+	}
+	if f := cosmeticPalettes[p.Palette]; f != nil && p.Has(p.Palette) {
+		r.Palette = f(r.Palette)
+	}
+	if p.Mode == modePurist && p.Has(modePurist) {
+		r.Config = PuristConfig(r.Config)
+	}
+	return r
+}
+
+// loadProfile reads the saved career. Problems only cost the career, so
+// they are logged and a new one is started.
+func loadProfile() *profile.Profile {
+	path, err := profile.Path()
+	if err == nil {
+		var p *profile.Profile
+		if p, err = profile.Load(path); err == nil {
+			return p
+		}
+	}
+	logger.Warn("profile not loaded", "err", err)
+	return &profile.Profile{}
+}
+
+func (g *Game) saveProfile() {
+	path, err := profile.Path()
+	if err == nil {
+		err = g.profile.Save(path)
+	}
+	if err != nil {
+		logger.Warn("profile not saved", "err", err)
+	}
+}
+
+// recordCareer adds the finished game to the career and remembers what it
+// unlocked so the game-over screen can announce it.
+func (g *Game) recordCareer() {
+	g.newUnlocks = nil
+	g.newBest = false
+	if !g.countsForCareer || g.world == nil {
+		return
+	}
+	// Mutated runs and runs off the normal difficulty or the classic mode
+	// only compete with runs under the same mode, mutators and difficulty.
+	if key := runKey(g.runMode, g.runDifficulty, g.runMutators); key != "" {
+		g.newBest = g.profile.RecordMutatorBest(key, g.world.Score)
+		if g.newBest {
+			g.saveProfile()
+		}
+		return
+	}
+	g.profile.Add(profile.Game{
+		Score:              g.world.Score,
+		AsteroidsDestroyed: g.world.Stats.AsteroidsDestroyed,
+		SaucersDestroyed:   g.world.Stats.SaucersDestroyed,
+	})
+	g.newUnlocks = checkUnlocks(g.profile)
+	g.saveProfile()
+}
+
+// --- Career screen ---
+
+// careerRows are the choices on the CAREER screen.
+var careerRows = []string{"SHIP", "PALETTE", "MODE", "BACK"}
+
+// careerOptions lists the choosable IDs for a row: the standard option ("")
+// and then everything of that kind the player has unlocked.
+func careerOptions(p *profile.Profile, kind UnlockKind) []string {
+	opts := []string{""}
+	for _, u := range unlocks {
+		if u.Kind == kind && p.Has(u.ID) {
+			opts = append(opts, u.ID)
+		}
+	}
+	return opts
+}
+
+// careerChoice returns the profile field a row edits.
+func careerChoice(p *profile.Profile, row int) (*string, UnlockKind) {
+	switch row {
+	case 0:
+		return &p.Ship, UnlockShip
+	case 1:
+		return &p.Palette, UnlockPalette
+	}
+	return &p.Mode, UnlockMode
+}
+
+// cycleCareer moves a row's choice by delta through the unlocked options.
+func (g *Game) cycleCareer(row, delta int) {
+	if row >= len(careerRows)-1 {
+		return
+	}
+	choice, kind := careerChoice(g.profile, row)
+	opts := careerOptions(g.profile, kind)
+	i := 0
+	for j, id := range opts {
+		if id == *choice {
+			i = j
+		}
+	}
+	*choice = opts[(i+delta+len(opts))%len(opts)]
+	g.saveProfile()
+}
+
+func (g *Game) updateCareer() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.sound.PlayBlip()
+		g.state = stateMenu
+		return
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyUp) {
+		g.careerCursor = (g.careerCursor + len(careerRows) - 1) % len(careerRows)
+		g.sound.PlayBlip()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyDown) {
+		g.careerCursor = (g.careerCursor + 1) % len(careerRows)
+		g.sound.PlayBlip()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyLeft) {
+		g.cycleCareer(g.careerCursor, -1)
+		g.sound.PlayBlip()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyRight) {
+		g.cycleCareer(g.careerCursor, 1)
+		g.sound.PlayBlip()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+		g.sound.PlayConfirm()
+		if g.careerCursor == len(careerRows)-1 {
+			g.state = stateMenu
+			return
+		}
+		g.cycleCareer(g.careerCursor, 1)
+	}
+}
+
+// choiceName is how a chosen unlock ID is shown.
+func choiceName(id, standard string) string {
+	if id == "" {
+		return standard
+	}
+	for _, u := range unlocks {
+		if u.ID == id {
+			return u.Name
+		}
+	}
+	return id
+}
+
+func (g *Game) drawCareer(screen canvas.Canvas) {
+	white := color.RGBA{255, 255, 255, 255}
+	grey := color.RGBA{100, 100, 100, 255}
+	green := color.RGBA{0, 255, 0, 255}
+	p := g.profile
+	drawCentered(screen, "CAREER", 50, 4, white)
+
+	drawCentered(screen, fmt.Sprintf("GAMES %d   TOTAL SCORE %d   BEST %d   KILLS %d",
+		p.Games, p.TotalScore, p.BestScore, p.Kills()), 110, 1.5, grey)
+
+	values := []string{
+		choiceName(p.Ship, "CLASSIC SHIP"),
+		choiceName(p.Palette, "CLASSIC PALETTE"),
+		choiceName(p.Mode, "STANDARD MODE"),
+	}
+	for i, label := range careerRows {
+		clr := white
+		if i == g.careerCursor {
+			clr = green
+		}
+		text := label
+		if i < len(values) {
+			text = fmt.Sprintf("%-8s< %s >", label, values[i])
+		}
+		DrawText(screen, text, 160, 150+float64(i)*32, 2, clr)
+	}
+
+	// Preview of the chosen ship in the chosen palette.
+	rules := applyCosmetics(StandardRules(), p)
+	drawPolygon(screen, &Position{X: 640, Y: 200}, -math.Pi/2, rules.Ship.vertices(playerRadius*2), rules.Palette.Ship)
+
+	y := 310.0
+	for _, u := range unlocks {
+		box, clr := "[ ]", grey
+		if p.Has(u.ID) {
+			box, clr = "[X]", white
+		}
+		DrawText(screen, fmt.Sprintf("%s %-20s %s", box, u.Name, u.Need), 100, y, 1.5, clr)
+		y += 24
+	}
+	drawCentered(screen, "LEFT/RIGHT TO CHOOSE . ESC TO GO BACK", 570, 1.5, grey)
+}
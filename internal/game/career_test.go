@@ -0,0 +1,119 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/matheus3301/asteroids/internal/profile"
+)
+
+func TestCheckUnlocks_GrantsAtThreshold(t *testing.T) {
+	p := &profile.Profile{TotalScore: 24_999}
+	if got := checkUnlocks(p); len(got) != 0 {
+		t.Fatalf("nothing should unlock below the threshold, got %v", got)
+	}
+
+	p.TotalScore = 25_000
+	got := checkUnlocks(p)
+	if len(got) != 1 || got[0].ID != "dart" {
+		t.Fatalf("expected the dart ship to unlock, got %v", got)
+	}
+	if again := checkUnlocks(p); len(again) != 0 {
+		t.Errorf("an unlock should only be announced once, got %v", again)
+	}
+}
+
+func TestApplyCosmetics_IgnoresLockedChoices(t *testing.T) {
+	p := &profile.Profile{Ship: "dart", Palette: "neon", Mode: modePurist}
+	r := applyCosmetics(StandardRules(), p)
+
+	if r.Ship != nil {
+		t.Error("a locked ship should not be applied")
+	}
+	if r.Palette != DefaultPalette() {
+		t.Error("a locked palette should not be applied")
+	}
+	if r.Config != DefaultConfig() {
+		t.Error("a locked mode should not be applied")
+	}
+}
+
+func TestApplyCosmetics_AppliesUnlockedChoices(t *testing.T) {
+	p := &profile.Profile{Ship: "dart", Palette: "neon", Mode: modePurist}
+	p.Unlock("dart")
+	p.Unlock("neon")
+	p.Unlock(modePurist)
+	r := applyCosmetics(StandardRules(), p)
+
+	if len(r.Ship) != len(shipShapes["dart"]) {
+		t.Errorf("expected the dart outline, got %v", r.Ship)
+	}
+	if r.Palette.Ship == DefaultPalette().Ship {
+		t.Error("expected the neon ship colour")
+	}
+	if r.Config.MissileAmmo != 0 || r.Config.WeaponRapidScore != 0 {
+		t.Error("purist mode should remove missiles and weapon upgrades")
+	}
+
+	w := NewModdedWorld(1, r)
+	if got := len(w.renderables[w.Player].Vertices); got != len(shipShapes["dart"]) {
+		t.Errorf("player should use the dart outline, got %d vertices", got)
+	}
+}
+
+func TestRecordCareer_AddsGame(t *testing.T) {
+	g := newPlaying()
+	g.profile = &profile.Profile{TotalScore: 24_000}
+	g.world.Score = 1_500
+	g.world.Stats.AsteroidsDestroyed = 12
+
+	g.recordCareer()
+
+	if g.profile.Games != 1 || g.profile.AsteroidsDestroyed != 12 {
+		t.Errorf("game not recorded: %+v", g.profile)
+	}
+	if len(g.newUnlocks) != 1 || g.newUnlocks[0].ID != "dart" {
+		t.Errorf("expected the dart ship to unlock, got %v", g.newUnlocks)
+	}
+}
+
+func TestRecordCareer_SkipsModdedGames(t *testing.T) {
+	g := newPlaying()
+	g.profile = &profile.Profile{}
+	g.countsForCareer = false
+	g.world.Score = 100_000
+
+	g.recordCareer()
+
+	if g.profile.Games != 0 {
+		t.Error("modded games should not count towards the career")
+	}
+}
+
+func TestCycleCareer_OnlyUnlockedOptions(t *testing.T) {
+	g := New()
+	g.profile = &profile.Profile{}
+	g.profile.Unlock("arrow")
+
+	g.cycleCareer(0, 1)
+	if g.profile.Ship != "arrow" {
+		t.Errorf("expected arrow, got %q", g.profile.Ship)
+	}
+	g.cycleCareer(0, 1)
+	if g.profile.Ship != "" {
+		t.Errorf("expected to wrap back to the classic ship, got %q", g.profile.Ship)
+	}
+	g.cycleCareer(1, 1)
+	if g.profile.Palette != "" {
+		t.Errorf("no palettes are unlocked, got %q", g.profile.Palette)
+	}
+}
+
+func TestMenuSelect_Career(t *testing.T) {
+	g := New()
+	g.menuCursor = 5
+	g.menuSelect()
+
+	if g.state != stateCareer {
+		t.Errorf("expected stateCareer, got %v", g.state)
+	}
+}
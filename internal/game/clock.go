@@ -0,0 +1,19 @@
+package game
+
+import "time"
+
+// Clock tells the time. The Game reads it for seeds of unseeded games and
+// for frame timing; tests substitute one that does not move on its own.
+type Clock interface {
+	Now() time.Time
+}
+
+// wallClock is the real time.
+type wallClock struct{}
+
+func (wallClock) Now() time.Time { return time.Now() }
+
+// since is how long ago start was by c.
+func since(c Clock, start time.Time) time.Duration {
+	return c.Now().Sub(start)
+}
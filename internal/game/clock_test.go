@@ -0,0 +1,37 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock that only moves when told to.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func TestNewSeed_FromClock(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	g := NewWithOptions(Options{Clock: clock})
+
+	g.reset()
+	if want := clock.now.UnixNano(); g.world.Seed != want {
+		t.Errorf("unseeded game got seed %d, want the clock's %d", g.world.Seed, want)
+	}
+
+	clock.Advance(time.Second)
+	g.reset()
+	if want := clock.now.UnixNano(); g.world.Seed != want {
+		t.Errorf("next game got seed %d, want %d", g.world.Seed, want)
+	}
+
+	g = NewWithOptions(Options{Clock: clock, Seed: 42})
+	g.reset()
+	if g.world.Seed != 42 {
+		t.Errorf("fixed seed ignored, got %d", g.world.Seed)
+	}
+}
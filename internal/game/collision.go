@@ -0,0 +1,99 @@
+package game
+
+// CollisionLayer is a bit naming what kind of object a collider is.
+type CollisionLayer uint8
+
+const (
+	LayerPlayer CollisionLayer = 1 << iota
+	LayerPlayerBullet
+	LayerEnemy
+	LayerEnemyBullet
+	LayerPickup
+	LayerHazard
+)
+
+// pointLayers collide as points: only the radius of what they hit counts.
+// Their own Radius is for drawing and observations.
+const pointLayers = LayerPlayerBullet | LayerEnemyBullet
+
+// collisionMasks are the layers each layer can hit unless its Collider
+// sets a Mask. Pickups are collected by BonusSystem, not CollisionSystem.
+var collisionMasks = map[CollisionLayer]CollisionLayer{
+	LayerPlayer:       LayerHazard | LayerEnemy | LayerEnemyBullet | LayerPickup,
+	LayerPlayerBullet: LayerHazard | LayerEnemy,
+	LayerEnemy:        LayerPlayer | LayerPlayerBullet,
+	LayerEnemyBullet:  LayerPlayer,
+	LayerPickup:       LayerPlayer,
+	LayerHazard:       LayerPlayer | LayerPlayerBullet,
+}
+
+// mask is the set of layers c can hit.
+func (c *Collider) mask() CollisionLayer {
+	if c.Mask != 0 {
+		return c.Mask
+	}
+	return collisionMasks[c.Layer]
+}
+
+// reach is how far from its centre c hits: its radius, or nothing for a
+// point.
+func (c *Collider) reach() float64 {
+	if c.Layer&pointLayers != 0 {
+		return 0
+	}
+	return c.Radius
+}
+
+// collisionLayers lists every collider's entity by layer, each in entity
+// order, so the collision pass visits them the same way every tick.
+func collisionLayers(w *World) map[CollisionLayer][]Entity {
+	layers := make(map[CollisionLayer][]Entity)
+	for _, e := range sortedEntities(w.colliders) {
+		if w.positions[e] == nil {
+			continue
+		}
+		layer := w.colliders[e].Layer
+		layers[layer] = append(layers[layer], e)
+	}
+	return layers
+}
+
+// overlaps reports whether a can hit b and their circles overlap. It
+// compares squared distances, so no square root is taken.
+func overlaps(w *World, a, b Entity) bool {
+	ac, bc := w.colliders[a], w.colliders[b]
+	if ac == nil || bc == nil || ac.mask()&bc.Layer == 0 {
+		return false
+	}
+	ap, bp := w.positions[a], w.positions[b]
+	if ap == nil || bp == nil {
+		return false
+	}
+	reach := ac.reach() + bc.reach()
+	dx, dy := ap.X-bp.X, ap.Y-bp.Y
+	return dx*dx+dy*dy < reach*reach
+}
+
+// firstWithin returns the first of targets whose centre is within dist of
+// e's, whatever their layers.
+func firstWithin(w *World, e Entity, targets []Entity, dist float64) (Entity, bool) {
+	p := w.positions[e]
+	for _, t := range targets {
+		if tp := w.positions[t]; tp != nil {
+			if dx, dy := p.X-tp.X, p.Y-tp.Y; dx*dx+dy*dy < dist*dist {
+				return t, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// firstHit returns the first of targets that e overlaps.
+func firstHit(w *World, e Entity, targets []Entity) (Entity, bool) {
+	for _, t := range targets {
+		if overlaps(w, e, t) {
+			return t, true
+		}
+	}
+	return 0, false
+}
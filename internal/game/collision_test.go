@@ -0,0 +1,96 @@
+package game
+
+import "testing"
+
+// placeCollider adds a collider on layer at (x, y).
+func placeCollider(w *World, x, y, radius float64, layer CollisionLayer) Entity {
+	e := w.Spawn()
+	w.positions[e] = &Position{X: x, Y: y}
+	w.colliders[e] = &Collider{Radius: radius, Layer: layer}
+	return e
+}
+
+func TestOverlaps_FollowsMasks(t *testing.T) {
+	w := NewWorld()
+	ship := placeCollider(w, 100, 100, 15, LayerPlayer)
+	rock := placeCollider(w, 120, 100, 10, LayerHazard)
+	star := placeCollider(w, 100, 110, 10, LayerPickup)
+
+	if !overlaps(w, ship, rock) || !overlaps(w, rock, ship) {
+		t.Error("a ship and an asteroid should collide both ways")
+	}
+	if overlaps(w, rock, star) {
+		t.Error("asteroids should pass through pickups")
+	}
+
+	w.colliders[ship].Mask = LayerPickup
+	if overlaps(w, ship, rock) {
+		t.Error("a Mask should replace the layer's usual targets")
+	}
+	if !overlaps(w, ship, star) {
+		t.Error("a Mask should keep the layers it names")
+	}
+}
+
+func TestOverlaps_BulletsArePoints(t *testing.T) {
+	w := NewWorld()
+	rock := placeCollider(w, 100, 100, 10, LayerHazard)
+	edge := placeCollider(w, 111, 100, 2, LayerPlayerBullet)
+	inside := placeCollider(w, 109, 100, 2, LayerPlayerBullet)
+
+	if overlaps(w, edge, rock) {
+		t.Error("a bullet's own radius should not count")
+	}
+	if !overlaps(w, inside, rock) {
+		t.Error("a bullet inside the asteroid should hit it")
+	}
+}
+
+func TestCollisionLayers_EntityOrder(t *testing.T) {
+	w := NewWorld()
+	a := placeCollider(w, 0, 0, 10, LayerHazard)
+	placeCollider(w, 0, 0, 10, LayerPlayer)
+	b := placeCollider(w, 0, 0, 10, LayerHazard)
+	unplaced := w.Spawn()
+	w.colliders[unplaced] = &Collider{Radius: 10, Layer: LayerHazard}
+
+	got := collisionLayers(w)[LayerHazard]
+	if len(got) != 2 || got[0] != a || got[1] != b {
+		t.Errorf("hazards = %v, want [%d %d]", got, a, b)
+	}
+}
+
+func TestCollision_ShootDownSaucerBullets(t *testing.T) {
+	// shoot fires a bullet at a saucer bullet heading for the ship and
+	// reports what the collision pass makes of it.
+	shoot := func(enabled bool) (*World, CollisionEvent) {
+		w := NewWorldWithSeed(1)
+		w.Config.SaucerBulletShootDown = enabled
+		w.Player = SpawnPlayer(w, 400, 300)
+		w.players[w.Player].Invulnerable = false
+		saucer := SpawnSaucer(w, SaucerLarge)
+		b := SpawnBullet(w, w.Player)
+		sb := SpawnSaucerBullet(w, saucer, 400, 300)
+		*w.positions[b] = Position{X: 400, Y: 300}
+		*w.positions[sb] = Position{X: 403, Y: 302}
+		return w, CollisionSystem(w)
+	}
+
+	if _, ev := shoot(false); len(ev.ShootDowns) != 0 || !ev.PlayerHit {
+		t.Fatalf("bullets should pass through each other by default: %+v", ev)
+	}
+	w, ev := shoot(true)
+	if len(ev.ShootDowns) != 1 || ev.PlayerHit {
+		t.Fatalf("expected the saucer bullet shot down before it hit: %+v", ev)
+	}
+	CollisionResponseSystem(w, ev)
+	if len(w.saucerBullets) != 0 || len(w.bullets) != 0 {
+		t.Error("both bullets should be destroyed")
+	}
+	if w.Score != shootDownPoints || w.players[w.Player].Score != shootDownPoints {
+		t.Errorf("score %d, want %d credited to the ship", w.Score, shootDownPoints)
+	}
+	if w.Stats.BulletsShotDown != 1 || len(w.particles) == 0 {
+		t.Error("expected the shoot-down counted with a spark")
+	}
+}
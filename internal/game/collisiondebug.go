@@ -0,0 +1,121 @@
+package game
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/matheus3301/asteroids/internal/canvas"
+)
+
+// collisionDebugSpeed runs the collision debug view at 10 ticks a second,
+// a sixth of normal, with frames interpolated in between.
+const collisionDebugSpeed = 10.0 / 60
+
+// colliderSegments is how many lines draw a collider's circle.
+const colliderSegments = 24
+
+// contactPoints is where each collision in ev happened: the point on the
+// line between the two centres where their circles meet. Points, such as
+// bullets, meet at their centre.
+func contactPoints(w *World, ev CollisionEvent) [][2]float64 {
+	var pts [][2]float64
+	add := func(a, b Entity) {
+		if p, ok := contact(w, a, b); ok {
+			pts = append(pts, p)
+		}
+	}
+	for _, h := range ev.BulletHits {
+		add(h.Bullet, h.Asteroid)
+	}
+	for _, h := range ev.SaucerBulletHits {
+		add(h.Bullet, h.Saucer)
+	}
+	for _, s := range ev.ShootDowns {
+		add(s.Bullet, s.Target)
+	}
+	if ev.PlayerHit {
+		add(ev.PlayerEntity, ev.PlayerHazard)
+	}
+	return pts
+}
+
+func contact(w *World, a, b Entity) ([2]float64, bool) {
+	ap, bp := w.positions[a], w.positions[b]
+	ac, bc := w.colliders[a], w.colliders[b]
+	if ap == nil || bp == nil || ac == nil || bc == nil {
+		return [2]float64{}, false
+	}
+	t := 0.5
+	if reach := ac.reach() + bc.reach(); reach > 0 {
+		t = ac.reach() / reach
+	}
+	return [2]float64{ap.X + (bp.X-ap.X)*t, ap.Y + (bp.Y-ap.Y)*t}, true
+}
+
+// colliderColor is how the collision debug view draws a layer.
+func colliderColor(l CollisionLayer) color.RGBA {
+	switch l {
+	case LayerPlayer, LayerPlayerBullet:
+		return color.RGBA{0, 255, 255, 255}
+	case LayerPickup:
+		return color.RGBA{255, 255, 0, 255}
+	}
+	return color.RGBA{255, 80, 80, 255}
+}
+
+// drawCollisionDebug draws what the collision pass sees: every collider's
+// reach as a circle, or a cross for points, and the last tick's contacts.
+// Circles crossing an edge are drawn again where the wrapped shape shows
+// on the far side, so a contact that the shapes suggest but the circles
+// do not make is easy to spot.
+func drawCollisionDebug(w *World, screen canvas.Canvas, alpha float64) {
+	fw, fh := w.Config.FieldWidth, w.Config.FieldHeight
+	for _, e := range sortedEntities(w.colliders) {
+		pos, _ := w.poseAt(e, alpha)
+		if pos == nil {
+			continue
+		}
+		c := w.colliders[e]
+		clr := colliderColor(c.Layer)
+		r := c.reach()
+		for _, ox := range wrapCopies(pos.X, r, fw) {
+			for _, oy := range wrapCopies(pos.Y, r, fh) {
+				if r == 0 {
+					drawCross(screen, pos.X+ox, pos.Y+oy, 3, clr)
+				} else {
+					strokeCircle(screen, pos.X+ox, pos.Y+oy, r, clr)
+				}
+			}
+		}
+	}
+	magenta := color.RGBA{255, 0, 255, 255}
+	for _, p := range w.Contacts {
+		drawCross(screen, p[0], p[1], 6, magenta)
+	}
+	DrawText(screen, "COLLISIONS . 10 TPS . F5 TO CLOSE", 10, fh-20, 1.5, color.RGBA{255, 255, 0, 255})
+}
+
+// wrapCopies are the offsets along one axis of size at which a circle at
+// x of radius r shows: itself, and across the edge it overlaps.
+func wrapCopies(x, r, size float64) []float64 {
+	switch {
+	case x-r < 0:
+		return []float64{0, size}
+	case x+r > size:
+		return []float64{0, -size}
+	}
+	return []float64{0}
+}
+
+func strokeCircle(screen canvas.Canvas, x, y, r float64, clr color.RGBA) {
+	for i := range colliderSegments {
+		a1 := 2 * math.Pi * float64(i) / colliderSegments
+		a2 := 2 * math.Pi * float64(i+1) / colliderSegments
+		strokeLine(screen, x+math.Cos(a1)*r, y+math.Sin(a1)*r, x+math.Cos(a2)*r, y+math.Sin(a2)*r, clr)
+	}
+}
+
+func drawCross(screen canvas.Canvas, x, y, size float64, clr color.RGBA) {
+	strokeLine(screen, x-size, y-size, x+size, y+size, clr)
+	strokeLine(screen, x-size, y+size, x+size, y-size, clr)
+}
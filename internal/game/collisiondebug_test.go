@@ -0,0 +1,81 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/matheus3301/asteroids/internal/canvas"
+)
+
+func TestContactPoints_WhereCirclesMeet(t *testing.T) {
+	w := NewWorldWithSeed(1)
+	ship := SpawnPlayer(w, 100, 100)
+	w.players[ship].Invulnerable = false
+	rock := SpawnAsteroid(w, 130, 100, SizeMedium)
+	w.velocities[rock].X, w.velocities[rock].Y = 0, 0
+
+	ev := CollisionSystem(w)
+	if !ev.PlayerHit || ev.PlayerHazard != rock {
+		t.Fatalf("expected the ship to be hit by the asteroid, got %+v", ev)
+	}
+	pts := contactPoints(w, ev)
+	r := w.colliders[rock].Radius
+	want := 100 + 30*playerRadius/(playerRadius+r)
+	if len(pts) != 1 || pts[0] != [2]float64{want, 100} {
+		t.Errorf("contacts %v, want one at (%v, 100)", pts, want)
+	}
+}
+
+// shootSource holds still and fires whenever it can.
+type shootSource struct{}
+
+func (shootSource) NextInput(*World) InputState { return InputState{Shoot: true} }
+
+func TestCollisionDebug_RecordsContactsAndRunsSlowly(t *testing.T) {
+	g := NewWithOptions(Options{Seed: 3, Input: shootSource{}, CollisionDebug: true})
+	g.reset()
+	w := g.world
+	for e := range w.asteroids {
+		w.Destroy(e)
+	}
+	a := SpawnAsteroid(w, 600, 300, SizeLarge)
+	w.velocities[a].X, w.velocities[a].Y = 0, 0
+	w.rotations[w.Player].Angle = 0
+
+	frames := 0
+	for w.Tick < 60 {
+		g.updatePlaying()
+		frames++
+		if len(w.Contacts) > 0 {
+			break
+		}
+	}
+	if frames < 6*w.Tick-6 {
+		t.Errorf("%d frames ran %d ticks, want about 10 ticks a second", frames, w.Tick)
+	}
+	if len(w.Contacts) == 0 {
+		t.Fatal("no contact recorded for a shot at an asteroid")
+	}
+
+	rec := &canvas.Recording{}
+	drawCollisionDebug(w, rec, 1)
+	if rec.Count("line") == 0 {
+		t.Error("no colliders drawn")
+	}
+
+	g.collisionDebug = false
+	if g.speed() != g.settings.speed {
+		t.Error("turning the view off should restore the game speed")
+	}
+}
+
+func TestWrapCopies(t *testing.T) {
+	if got := wrapCopies(5, 10, 800); len(got) != 2 || got[1] != 800 {
+		t.Errorf("circle over the left edge: %v", got)
+	}
+	if got := wrapCopies(795, 10, 800); len(got) != 2 || got[1] != -800 {
+		t.Errorf("circle over the right edge: %v", got)
+	}
+	if got := wrapCopies(400, 10, 800); len(got) != 1 {
+		t.Errorf("circle inside: %v", got)
+	}
+}
@@ -21,6 +21,10 @@ type Rotation struct {
 // Collider represents a circular collision boundary.
 type Collider struct {
 	Radius float64
+	// Layer is what kind of object this is. Mask lists the layers it can
+	// hit; zero means the usual ones for its layer, from collisionMasks.
+	Layer CollisionLayer
+	Mask  CollisionLayer
 }
 
 // ShapeKind identifies how to render an entity.
@@ -49,6 +53,28 @@ type PlayerControl struct {
 	BlinkTimer         int
 	HyperspacePressed  bool
 	HyperspaceCooldown int
+	Weapon             WeaponTier
+	ShotCooldown       int // ticks until the weapon can fire again
+	MissilePressed     bool
+	Missiles           int // homing missiles left
+	ShieldPressed      bool
+	ShieldUp           bool // raised this tick
+	Shield             int  // ticks left in the shield meter
+	Slot               int  // index into Inputs; 0 unless playing co-op
+
+	// Score is the points this ship's shots and pickups earned toward the
+	// shared score. Shots counts its bullets and missiles fired, Hits
+	// those that hit something and Misses those that ran out of life
+	// first.
+	Score, Shots, Hits, Misses int
+	// Kills counts what its shots destroyed, by kind.
+	Kills [NumKillKinds]int
+}
+
+// Accuracy is the fraction of the ship's shots that hit something, out of
+// those that have hit or missed; shots still in flight are left out.
+func (pc *PlayerControl) Accuracy() float64 {
+	return accuracy(pc.Hits, pc.Misses)
 }
 
 // AsteroidSize represents the three asteroid sizes.
@@ -62,12 +88,15 @@ const (
 
 // AsteroidTag marks an entity as an asteroid.
 type AsteroidTag struct {
-	Size AsteroidSize
+	Size    AsteroidSize
+	Variant AsteroidVariant
+	Armor   int // hits left before the next one breaks it
 }
 
 // BulletTag marks an entity as a bullet with a lifetime.
 type BulletTag struct {
-	Life int
+	Life  int
+	Owner Entity // the ship that fired it
 }
 
 // ParticleTag marks an entity as a particle with a lifetime.
@@ -92,6 +121,24 @@ type SaucerTag struct {
 	VerticalTimer int     // ticks until the next vertical direction change
 }
 
+// Previous is an entity's pose before the last tick, kept so replays can
+// be drawn smoothly between ticks.
+type Previous struct {
+	X, Y  float64
+	Angle float64
+}
+
+// MissileTag marks an entity as a homing missile with a lifetime.
+type MissileTag struct {
+	Life  int
+	Owner Entity // the ship that fired it
+}
+
+// PickupTag marks an entity as a collectable bonus that expires.
+type PickupTag struct {
+	Life int
+}
+
 // SaucerBulletTag marks an entity as a saucer-fired bullet.
 type SaucerBulletTag struct {
 	Life int
@@ -0,0 +1,249 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// GameConfig holds the gameplay tuning values that mods and presets may
+// change. Start from DefaultConfig; the zero value is not playable.
+type GameConfig struct {
+	RotationSpeed float64 `json:"rotation_speed"`
+	ThrustPower   float64 `json:"thrust_power"`
+	MaxSpeed      float64 `json:"max_speed"`
+	Friction      float64 `json:"friction"`
+
+	BulletSpeed float64 `json:"bullet_speed"`
+	BulletLife  int     `json:"bullet_life"`
+	MaxBullets  int     `json:"max_bullets"`
+	// ShotCooldown is the number of ticks between shots.
+	ShotCooldown int `json:"shot_cooldown"`
+
+	// Weapon tiers: the score that earns each (0 never does) and what the
+	// upgraded guns allow.
+	WeaponRapidScore  int     `json:"weapon_rapid_score"`
+	WeaponSpreadScore int     `json:"weapon_spread_score"`
+	RapidMaxBullets   int     `json:"rapid_max_bullets"`
+	RapidShotCooldown int     `json:"rapid_shot_cooldown"`
+	SpreadAngle       float64 `json:"spread_angle"`
+
+	// Homing missiles: ammo at the start of each wave, speed, how fast they
+	// turn (radians per tick) and how long they fly.
+	MissileAmmo     int     `json:"missile_ammo"`
+	MissileSpeed    float64 `json:"missile_speed"`
+	MissileTurnRate float64 `json:"missile_turn_rate"`
+	MissileLife     int     `json:"missile_life"`
+
+	// Asteroid variants: the chance a wave asteroid is each variant, and
+	// how they behave.
+	GoldenChance     float64 `json:"golden_chance"`
+	ExplosiveChance  float64 `json:"explosive_chance"`
+	ArmoredChance    float64 `json:"armored_chance"`
+	GoldenMultiplier int     `json:"golden_multiplier"`
+	ExplosionRadius  float64 `json:"explosion_radius"`
+	ArmoredHits      int     `json:"armored_hits"`
+
+	// Bonus stars dropped by small saucers, and the kill combo that
+	// multiplies their points.
+	StarDropChance     float64 `json:"star_drop_chance"`
+	StarLife           int     `json:"star_life"`
+	StarPoints         int     `json:"star_points"`
+	ComboWindow        int     `json:"combo_window"`
+	ComboStep          int     `json:"combo_step"`
+	ComboMaxMultiplier int     `json:"combo_max_multiplier"`
+
+	// SpawnPattern places each wave's asteroids: "uniform", "ring" or
+	// "corners".
+	SpawnPattern string `json:"spawn_pattern"`
+
+	// HitStopTicks is the freeze-frame when the player dies or a saucer is
+	// destroyed; 0 turns it off.
+	HitStopTicks int `json:"hit_stop_ticks"`
+
+	// HyperspaceRisk is the chance a hyperspace jump destroys the ship.
+	HyperspaceRisk float64 `json:"hyperspace_risk"`
+	// NoHyperspace turns the hyperspace button off.
+	NoHyperspace bool `json:"no_hyperspace"`
+	// ShieldTicks is how long a full shield meter can be held up; 0 means
+	// no shield. ShieldHitCost is what absorbing a collision takes from it.
+	ShieldTicks   int `json:"shield_ticks"`
+	ShieldHitCost int `json:"shield_hit_cost"`
+
+	// WaveScale multiplies how many asteroids each wave has, AsteroidScale
+	// their size and AsteroidFragments is how many pieces a large or
+	// medium asteroid breaks into.
+	WaveScale         float64 `json:"wave_scale"`
+	AsteroidScale     float64 `json:"asteroid_scale"`
+	AsteroidFragments int     `json:"asteroid_fragments"`
+	// AsteroidSpeed multiplies how fast asteroids fly.
+	AsteroidSpeed float64 `json:"asteroid_speed"`
+
+	// FogRadius hides everything further than this from the ship; 0 means
+	// no fog.
+	FogRadius float64 `json:"fog_radius"`
+
+	// FieldWidth and FieldHeight are the size of the wrapping playfield.
+	// It can grow past the standard ScreenWidth x ScreenHeight to fill
+	// ultrawide or portrait windows, but not shrink below it.
+	FieldWidth  float64 `json:"field_width"`
+	FieldHeight float64 `json:"field_height"`
+
+	StartingLives  int `json:"starting_lives"`
+	ExtraLifeEvery int `json:"extra_life_every"`
+	// MaxLives caps extra lives. Thresholds crossed at the cap score
+	// LifeBonusPoints instead.
+	MaxLives        int `json:"max_lives"`
+	LifeBonusPoints int `json:"life_bonus_points"`
+
+	// MaxSaucers is how many saucers can be in play at once.
+	MaxSaucers         int `json:"max_saucers"`
+	SaucerInitialDelay int `json:"saucer_initial_delay"`
+	SaucerRespawnDelay int `json:"saucer_respawn_delay"`
+	// SaucerClearance keeps entering saucers this far from any ship's row.
+	SaucerClearance float64 `json:"saucer_clearance"`
+	// SaucerLeadLevel is the first level at which small saucers aim ahead
+	// of a moving ship; 0 means they never do.
+	SaucerLeadLevel int `json:"saucer_lead_level"`
+	// SaucerBulletShootDown lets player bullets destroy saucer bullets
+	// they pass close to, for shootDownPoints each.
+	SaucerBulletShootDown bool `json:"saucer_bullet_shoot_down"`
+	// LargeSaucer and SmallSaucer tune how each size of saucer shoots
+	// and moves as the score rises.
+	LargeSaucer SaucerBehavior `json:"large_saucer"`
+	SmallSaucer SaucerBehavior `json:"small_saucer"`
+
+	// MaxAsteroids and MaxParticles bound how many of each can be alive,
+	// keeping the cost of a tick bounded; 0 means no limit. Asteroids past
+	// the cap are not spawned; a particle past it replaces the oldest.
+	MaxAsteroids int `json:"max_asteroids"`
+	MaxParticles int `json:"max_particles"`
+}
+
+// DefaultConfig returns the standard rules.
+func DefaultConfig() GameConfig {
+	return GameConfig{
+		RotationSpeed:      rotationSpeed,
+		ThrustPower:        thrustPower,
+		MaxSpeed:           maxSpeed,
+		Friction:           friction,
+		BulletSpeed:        bulletSpeed,
+		BulletLife:         bulletLife,
+		MaxBullets:         MaxPlayerBullets,
+		ShotCooldown:       shotCooldown,
+		WeaponRapidScore:   weaponRapidScore,
+		WeaponSpreadScore:  weaponSpreadScore,
+		RapidMaxBullets:    rapidMaxBullets,
+		RapidShotCooldown:  rapidShotCooldown,
+		SpreadAngle:        spreadAngle,
+		MissileAmmo:        missileAmmo,
+		MissileSpeed:       missileSpeed,
+		MissileTurnRate:    missileTurnRate,
+		MissileLife:        missileLife,
+		GoldenChance:       goldenChance,
+		ExplosiveChance:    explosiveChance,
+		ArmoredChance:      armoredChance,
+		GoldenMultiplier:   goldenMultiplier,
+		ExplosionRadius:    explosionRadius,
+		ArmoredHits:        armoredHits,
+		StarDropChance:     starDropChance,
+		StarLife:           starLife,
+		StarPoints:         starPoints,
+		ComboWindow:        comboWindow,
+		ComboStep:          comboStep,
+		ComboMaxMultiplier: comboMaxMultiplier,
+		SpawnPattern:       SpawnUniform,
+		HitStopTicks:       hitStopTicks,
+		HyperspaceRisk:     hyperspaceRisk,
+		ShieldTicks:        shieldTicks,
+		ShieldHitCost:      shieldHitCost,
+		WaveScale:          1,
+		AsteroidScale:      1,
+		AsteroidFragments:  2,
+		AsteroidSpeed:      1,
+		FieldWidth:         ScreenWidth,
+		FieldHeight:        ScreenHeight,
+		StartingLives:      3,
+		ExtraLifeEvery:     10_000,
+		MaxLives:           maxLives,
+		LifeBonusPoints:    lifeBonusPoints,
+		MaxSaucers:         maxSaucers,
+		SaucerInitialDelay: saucerInitialDelay,
+		SaucerRespawnDelay: saucerRespawnDelay,
+		SaucerClearance:    saucerClearance,
+		SaucerLeadLevel:    saucerLeadLevel,
+		LargeSaucer:        standardSaucer(saucerLargeAimError, saucerLargeAimError),
+		SmallSaucer:        standardSaucer(saucerSmallAimErrorEasy, saucerSmallAimError),
+		MaxAsteroids:       maxAsteroids,
+		MaxParticles:       maxParticles,
+	}
+}
+
+// Validate reports every value that is out of range.
+func (c GameConfig) Validate() error {
+	var errs []error
+	check := func(ok bool, field string, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: "+format, append([]any{field}, args...)...))
+		}
+	}
+	check(c.RotationSpeed > 0 && c.RotationSpeed < 1, "rotation_speed", "must be between 0 and 1")
+	check(c.ThrustPower > 0, "thrust_power", "must be positive")
+	check(c.MaxSpeed > 0, "max_speed", "must be positive")
+	check(c.Friction > 0 && c.Friction <= 1, "friction", "must be in (0, 1]")
+	check(c.BulletSpeed > 0, "bullet_speed", "must be positive")
+	check(c.BulletLife > 0, "bullet_life", "must be positive")
+	check(c.MaxBullets >= 1 && c.MaxBullets <= 100, "max_bullets", "must be between 1 and 100")
+	check(c.ShotCooldown >= 0, "shot_cooldown", "cannot be negative")
+	check(c.WeaponRapidScore >= 0, "weapon_rapid_score", "cannot be negative")
+	check(c.WeaponSpreadScore >= 0, "weapon_spread_score", "cannot be negative")
+	check(c.RapidMaxBullets >= 1 && c.RapidMaxBullets <= 100, "rapid_max_bullets", "must be between 1 and 100")
+	check(c.RapidShotCooldown >= 0, "rapid_shot_cooldown", "cannot be negative")
+	check(c.SpreadAngle >= 0 && c.SpreadAngle < math.Pi/2, "spread_angle", "must be between 0 and pi/2")
+	check(c.MissileAmmo >= 0 && c.MissileAmmo <= 99, "missile_ammo", "must be between 0 and 99")
+	check(c.MissileSpeed > 0, "missile_speed", "must be positive")
+	check(c.MissileTurnRate >= 0, "missile_turn_rate", "cannot be negative")
+	check(c.MissileLife > 0, "missile_life", "must be positive")
+	chances := c.GoldenChance + c.ExplosiveChance + c.ArmoredChance
+	check(c.GoldenChance >= 0 && c.ExplosiveChance >= 0 && c.ArmoredChance >= 0 && chances <= 1,
+		"golden_chance, explosive_chance, armored_chance", "must not be negative or add up to more than 1")
+	check(c.GoldenMultiplier >= 1, "golden_multiplier", "must be at least 1")
+	check(c.ExplosionRadius >= 0, "explosion_radius", "cannot be negative")
+	check(c.ArmoredHits >= 1 && c.ArmoredHits <= 10, "armored_hits", "must be between 1 and 10")
+	check(c.StarDropChance >= 0 && c.StarDropChance <= 1, "star_drop_chance", "must be between 0 and 1")
+	check(c.StarLife > 0, "star_life", "must be positive")
+	check(c.StarPoints >= 0, "star_points", "cannot be negative")
+	check(c.ComboWindow > 0, "combo_window", "must be positive")
+	check(c.ComboStep > 0, "combo_step", "must be positive")
+	check(c.ComboMaxMultiplier >= 1, "combo_max_multiplier", "must be at least 1")
+	check(slices.Contains(spawnPatterns, c.SpawnPattern), "spawn_pattern", "must be one of %s", strings.Join(spawnPatterns, ", "))
+	check(c.HitStopTicks >= 0 && c.HitStopTicks <= 30, "hit_stop_ticks", "must be between 0 and 30")
+	check(c.HyperspaceRisk >= 0 && c.HyperspaceRisk <= 1, "hyperspace_risk", "must be between 0 and 1")
+	check(c.ShieldTicks >= 0, "shield_ticks", "cannot be negative")
+	check(c.ShieldHitCost >= 0, "shield_hit_cost", "cannot be negative")
+	check(c.WaveScale > 0 && c.WaveScale <= 4, "wave_scale", "must be between 0 and 4")
+	check(c.AsteroidScale > 0 && c.AsteroidScale <= 3, "asteroid_scale", "must be between 0 and 3")
+	check(c.AsteroidFragments >= 0 && c.AsteroidFragments <= 4, "asteroid_fragments", "must be between 0 and 4")
+	check(c.AsteroidSpeed > 0 && c.AsteroidSpeed <= 4, "asteroid_speed", "must be between 0 and 4")
+	check(c.FogRadius >= 0, "fog_radius", "cannot be negative")
+	check(c.FieldWidth >= ScreenWidth && c.FieldWidth <= maxFieldSize, "field_width", "must be between %d and %d", ScreenWidth, maxFieldSize)
+	check(c.FieldHeight >= ScreenHeight && c.FieldHeight <= maxFieldSize, "field_height", "must be between %d and %d", ScreenHeight, maxFieldSize)
+	check(c.StartingLives >= 1 && c.StartingLives <= 99, "starting_lives", "must be between 1 and 99")
+	check(c.ExtraLifeEvery > 0, "extra_life_every", "must be positive")
+	check(c.MaxLives >= 1 && c.MaxLives <= 99, "max_lives", "must be between 1 and 99")
+	// Bonus points below a threshold's spacing cannot cross the next one
+	// on their own.
+	check(c.LifeBonusPoints >= 0 && c.LifeBonusPoints < c.ExtraLifeEvery, "life_bonus_points", "must be between 0 and extra_life_every")
+	check(c.MaxSaucers >= 0 && c.MaxSaucers <= 8, "max_saucers", "must be between 0 and 8")
+	check(c.SaucerInitialDelay >= 0, "saucer_initial_delay", "cannot be negative")
+	check(c.SaucerRespawnDelay >= 0, "saucer_respawn_delay", "cannot be negative")
+	check(c.SaucerClearance >= 0, "saucer_clearance", "cannot be negative")
+	check(c.SaucerLeadLevel >= 0, "saucer_lead_level", "cannot be negative")
+	errs = append(errs, c.LargeSaucer.validate("large_saucer")...)
+	errs = append(errs, c.SmallSaucer.validate("small_saucer")...)
+	check(c.MaxAsteroids >= 0, "max_asteroids", "cannot be negative")
+	check(c.MaxParticles >= 0, "max_particles", "cannot be negative")
+	return errors.Join(errs...)
+}
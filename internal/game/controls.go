@@ -0,0 +1,131 @@
+package game
+
+import (
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/matheus3301/asteroids/internal/canvas"
+)
+
+// actionLabels name each action on the controls page.
+var actionLabels = [NumActions]string{"ROTATE LEFT", "ROTATE RIGHT", "THRUST", "SHOOT", "HYPERSPACE", "MISSILE", "SHIELD", "PAUSE"}
+
+// The controls page lists one row per action and then these.
+const (
+	controlsReset = int(NumActions) + iota
+	controlsBack
+	controlsRows
+)
+
+// controlsPage is the state of the controls page, opened from the
+// settings screen.
+type controlsPage struct {
+	cursor int
+	// waiting is set while the action under the cursor waits for its new
+	// key.
+	waiting bool
+}
+
+func (g *Game) openControls() {
+	g.controls = controlsPage{}
+	g.state = stateControls
+}
+
+// bindKey makes k the key for a and keeps the bindings in the profile.
+func (g *Game) bindKey(a Action, k ebiten.Key) {
+	g.keys.Bind(a, k)
+	g.profile.Keys = g.keys.Names()
+	g.saveProfile()
+}
+
+// resetKeys goes back to the default key bindings.
+func (g *Game) resetKeys() {
+	g.keys = DefaultKeyBindings()
+	g.profile.Keys = nil
+	g.saveProfile()
+}
+
+func (g *Game) updateControls() {
+	c := &g.controls
+	if c.waiting {
+		keys := inpututil.AppendJustPressedKeys(nil)
+		if len(keys) == 0 {
+			return
+		}
+		c.waiting = false
+		if keys[0] == ebiten.KeyEscape {
+			g.sound.PlayBlip()
+			return
+		}
+		g.bindKey(Action(c.cursor), keys[0])
+		g.sound.PlayConfirm()
+		return
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.sound.PlayBlip()
+		g.state = stateSettings
+		return
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyUp) {
+		c.cursor = (c.cursor + controlsRows - 1) % controlsRows
+		g.sound.PlayBlip()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyDown) {
+		c.cursor = (c.cursor + 1) % controlsRows
+		g.sound.PlayBlip()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+		g.sound.PlayConfirm()
+		g.controlsSelect()
+	}
+}
+
+func (g *Game) controlsSelect() {
+	switch g.controls.cursor {
+	case controlsReset:
+		g.resetKeys()
+	case controlsBack:
+		g.state = stateSettings
+	default:
+		g.controls.waiting = true
+	}
+}
+
+func (g *Game) drawControls(screen canvas.Canvas) {
+	white := color.RGBA{255, 255, 255, 255}
+	green := color.RGBA{0, 255, 0, 255}
+	grey := color.RGBA{100, 100, 100, 255}
+	drawCentered(screen, "CONTROLS", 60, 4, white)
+
+	for i := 0; i < controlsRows; i++ {
+		clr := white
+		if i == g.controls.cursor {
+			clr = green
+		}
+		y := 140 + float64(i)*34
+		switch i {
+		case controlsReset:
+			DrawText(screen, "RESET TO DEFAULTS", 160, y+10, 2, clr)
+		case controlsBack:
+			DrawText(screen, "BACK", 160, y+10, 2, clr)
+		default:
+			DrawText(screen, actionLabels[i], 160, y, 2, clr)
+			keys := make([]string, len(g.keys[i]))
+			for j, k := range g.keys[i] {
+				keys[j] = keyLabel(k)
+			}
+			value := strings.Join(keys, " / ")
+			if i == g.controls.cursor && g.controls.waiting {
+				value = "PRESS A KEY"
+			}
+			DrawText(screen, value, 420, y, 2, clr)
+		}
+	}
+	hint := "ENTER TO REBIND . ESC TO GO BACK"
+	if g.controls.waiting {
+		hint = "PRESS THE NEW KEY . ESC TO CANCEL"
+	}
+	drawCentered(screen, hint, 560, 1.5, grey)
+}
@@ -0,0 +1,233 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/matheus3301/asteroids/internal/canvas"
+)
+
+var coopShipColor = color.RGBA{0, 200, 255, 255}
+
+// NetSession is a running lockstep co-op session. Package netplay provides
+// the implementation; the game only drives it once per frame.
+type NetSession interface {
+	// World is the shared simulation, or nil until both players are
+	// connected and have agreed on a seed.
+	World() *World
+	// Advance submits the local player's input and steps the world as far
+	// as both players' inputs allow. It never blocks.
+	Advance(local InputState)
+	// Status describes anything the player should know about the
+	// connection, or "" while the game runs normally.
+	Status() string
+	Close() error
+}
+
+// NetFactory opens co-op sessions from the connection screen.
+type NetFactory interface {
+	Host(seed int64) (NetSession, error)
+	Join(addr string) (NetSession, error)
+}
+
+const (
+	coopHost = iota
+	coopJoin
+	coopBack
+)
+
+// maxAddrLen bounds the address typed on the connection screen.
+const maxAddrLen = 40
+
+// coopScreen is the state of the co-op connection screen.
+type coopScreen struct {
+	cursor int
+	addr   string
+	err    string
+}
+
+func (g *Game) openCoop() {
+	g.coop.cursor = coopHost
+	g.coop.err = ""
+	if g.netFactory == nil {
+		g.coop.err = "CO-OP IS NOT AVAILABLE IN THIS BUILD"
+	}
+	g.state = stateCoop
+}
+
+func (g *Game) updateCoop() {
+	// Waiting for the peer: only cancelling is possible.
+	if g.net != nil {
+		if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+			g.closeNet()
+			return
+		}
+		if w := g.net.World(); w != nil {
+			g.startNetGame(w)
+		}
+		return
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.sound.PlayBlip()
+		g.state = stateMenu
+		return
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyUp) {
+		g.coop.cursor = (g.coop.cursor + coopBack) % (coopBack + 1)
+		g.sound.PlayBlip()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyDown) {
+		g.coop.cursor = (g.coop.cursor + 1) % (coopBack + 1)
+		g.sound.PlayBlip()
+	}
+	if g.coop.cursor == coopJoin {
+		for _, r := range ebiten.AppendInputChars(nil) {
+			if len(g.coop.addr) < maxAddrLen && r > ' ' && r < 0x7f {
+				g.coop.addr += string(r)
+			}
+		}
+		if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) && g.coop.addr != "" {
+			g.coop.addr = g.coop.addr[:len(g.coop.addr)-1]
+		}
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+		g.sound.PlayConfirm()
+		g.coopSelect()
+	}
+}
+
+func (g *Game) coopSelect() {
+	if g.coop.cursor == coopBack {
+		g.state = stateMenu
+		return
+	}
+	if g.netFactory == nil {
+		return
+	}
+
+	var (
+		s   NetSession
+		err error
+	)
+	switch g.coop.cursor {
+	case coopHost:
+		s, err = g.netFactory.Host(g.newSeed())
+	case coopJoin:
+		if g.coop.addr == "" {
+			g.coop.err = "TYPE THE HOST ADDRESS FIRST"
+			return
+		}
+		s, err = g.netFactory.Join(g.coop.addr)
+	}
+	if err != nil {
+		logger.Warn("co-op connection failed", "err", err)
+		g.coop.err = strings.ToUpper(err.Error())
+		return
+	}
+	g.coop.err = ""
+	g.net = s
+}
+
+// startNetGame switches to gameplay once the session has a world. Co-op
+// games are not recorded: replays hold a single player's input. Their
+// game-over screen shows the scoreboard rather than a best score, so the
+// last single-player run's mode and difficulty are forgotten.
+func (g *Game) startNetGame(w *World) {
+	g.sound.Reset()
+	g.sound.SetMasterVolume(float64(g.settings.volume) / 10.0)
+	g.world = w
+	g.recorder = nil
+	g.newUnlocks = nil
+	g.runMutators = 0
+	g.runDifficulty = DifficultyNormal
+	g.runMode = ModeClassic
+	g.assisted = false
+	g.state = statePlaying
+}
+
+// coopScoreboard is a line per ship of a co-op game, in slot order: the
+// points its shots and pickups earned, what its shots destroyed and how
+// many of them hit, out of those that hit or missed.
+func coopScoreboard(w *World) []string {
+	if len(w.players) < 2 {
+		return nil
+	}
+	var lines []string
+	for _, e := range sortedEntities(w.players) {
+		pc := w.players[e]
+		kills := 0
+		for _, n := range pc.Kills {
+			kills += n
+		}
+		lines = append(lines, fmt.Sprintf("P%d  %d PTS  %d KILLS  %d/%d HIT", pc.Slot+1, pc.Score, kills, pc.Hits, pc.Hits+pc.Misses))
+	}
+	return lines
+}
+
+// updateNetPlaying is updatePlaying for a co-op session.
+func (g *Game) updateNetPlaying() {
+	w := g.world
+	g.net.Advance(g.input.NextInput(w))
+	SoundSystem(g.sound, w)
+
+	if w.GameOver() {
+		g.sound.StopAll()
+		g.recordTelemetry()
+		g.state = stateGameOver
+	}
+}
+
+// closeNet ends the co-op session, if any.
+func (g *Game) closeNet() {
+	if g.net == nil {
+		return
+	}
+	if err := g.net.Close(); err != nil {
+		logger.Debug("closing co-op session", "err", err)
+	}
+	g.net = nil
+}
+
+func (g *Game) drawCoop(screen canvas.Canvas) {
+	drawCentered(screen, "CO-OP", 100, 4, color.RGBA{255, 255, 255, 255})
+
+	if g.net != nil {
+		drawCentered(screen, g.net.Status(), 280, 2.5, color.RGBA{255, 255, 255, 255})
+		drawCentered(screen, "ESC TO CANCEL", 500, 1.5, color.RGBA{100, 100, 100, 255})
+		return
+	}
+
+	labels := []string{"HOST GAME", "JOIN: " + g.coop.addr, "BACK"}
+	if g.coop.cursor == coopJoin && (g.clock.Now().UnixMilli()/500)%2 == 0 {
+		labels[coopJoin] += "_"
+	}
+	for i, label := range labels {
+		clr := color.RGBA{255, 255, 255, 255}
+		if i == g.coop.cursor {
+			clr = color.RGBA{0, 255, 0, 255}
+		}
+		drawCentered(screen, label, 230+float64(i)*60, 2.5, clr)
+	}
+	if g.coop.err != "" {
+		drawCentered(screen, g.coop.err, 430, 1.5, color.RGBA{255, 0, 0, 255})
+	}
+	drawCentered(screen, "TYPE AN ADDRESS TO JOIN . ENTER TO CONNECT . ESC TO GO BACK", 500, 1.5, color.RGBA{100, 100, 100, 255})
+}
+
+// drawNetStatus overlays the session status during a co-op game.
+func (g *Game) drawNetStatus(screen canvas.Canvas) {
+	if g.net == nil {
+		return
+	}
+	if s := g.net.Status(); s != "" {
+		drawCentered(screen, s, ScreenHeight/2-100, 2, color.RGBA{255, 165, 0, 255})
+	}
+}
+
+func drawCentered(screen canvas.Canvas, text string, y, scale float64, clr color.RGBA) {
+	DrawText(screen, text, (ScreenWidth-TextWidth(text, scale))/2, y, scale, clr)
+}
@@ -0,0 +1,201 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewCoopWorld_OneShipPerSlot(t *testing.T) {
+	w := NewCoopWorld(1)
+	if len(w.players) != MaxPlayers {
+		t.Fatalf("expected %d ships, got %d", MaxPlayers, len(w.players))
+	}
+	seen := map[int]bool{}
+	for _, pc := range w.players {
+		seen[pc.Slot] = true
+	}
+	for slot := 0; slot < MaxPlayers; slot++ {
+		if !seen[slot] {
+			t.Errorf("no ship in slot %d", slot)
+		}
+	}
+	if w.players[w.Player].Slot != 0 {
+		t.Error("w.Player should be the slot 0 ship")
+	}
+}
+
+func TestStepCoop_InputsGoToTheirSlot(t *testing.T) {
+	w := NewCoopWorld(1)
+	angles := map[int]float64{}
+	for e, pc := range w.players {
+		angles[pc.Slot] = w.rotations[e].Angle
+	}
+
+	StepCoop(w, Inputs{{RotateLeft: true}, {RotateRight: true}})
+
+	for e, pc := range w.players {
+		got := w.rotations[e].Angle
+		switch pc.Slot {
+		case 0:
+			if got >= angles[0] {
+				t.Errorf("slot 0 should turn left: %v -> %v", angles[0], got)
+			}
+		case 1:
+			if got <= angles[1] {
+				t.Errorf("slot 1 should turn right: %v -> %v", angles[1], got)
+			}
+		}
+	}
+}
+
+func TestCoop_ScoreGoesToTheShooter(t *testing.T) {
+	w := NewCoopWorld(1)
+	for _, e := range sortedEntities(w.asteroids) {
+		w.Destroy(e)
+	}
+	var p2 Entity
+	for e, pc := range w.players {
+		if pc.Slot == 1 {
+			p2 = e
+		}
+	}
+
+	StepCoop(w, Inputs{{}, {Shoot: true}})
+	bullets := sortedEntities(w.bullets)
+	if len(bullets) != 1 || w.bullets[bullets[0]].Owner != p2 {
+		t.Fatalf("expected one bullet owned by the second ship, got %v", bullets)
+	}
+	bpos := w.positions[bullets[0]]
+	SpawnAsteroid(w, bpos.X, bpos.Y, SizeSmall)
+	SpawnAsteroid(w, 100, 100, SizeLarge) // keeps the wave from ending
+
+	CollisionResponseSystem(w, CollisionSystem(w))
+
+	if pc := w.players[p2]; pc.Score != w.Score || pc.Score == 0 || pc.Shots != 1 || pc.Hits != 1 {
+		t.Errorf("second ship: score %d of %d, %d shots, %d hits", pc.Score, w.Score, pc.Shots, pc.Hits)
+	}
+	if pc := w.players[w.Player]; pc.Score != 0 || pc.Shots != 0 {
+		t.Errorf("first ship should have no credit, got %+v", pc)
+	}
+	if got := w.players[p2].Accuracy(); got != 1 {
+		t.Errorf("accuracy = %v, want 1", got)
+	}
+}
+
+func TestStepCoop_Deterministic(t *testing.T) {
+	a, b := NewCoopWorld(9), NewCoopWorld(9)
+	for tick := 0; tick < 600; tick++ {
+		in := Inputs{ScriptedInput(tick), ScriptedInput(tick + 100)}
+		StepCoop(a, in)
+		StepCoop(b, in)
+	}
+	if a.Checksum() != b.Checksum() {
+		t.Error("co-op worlds with the same seed and inputs diverged")
+	}
+}
+
+type fakeSession struct {
+	world    *World
+	advanced int
+	closed   bool
+}
+
+func (s *fakeSession) World() *World      { return s.world }
+func (s *fakeSession) Advance(InputState) { s.advanced++ }
+func (s *fakeSession) Status() string     { return "WAITING" }
+func (s *fakeSession) Close() error       { s.closed = true; return nil }
+
+type fakeFactory struct {
+	session *fakeSession
+	joined  string
+	err     error
+}
+
+func (f *fakeFactory) Host(seed int64) (NetSession, error) {
+	return f.session, f.err
+}
+
+func (f *fakeFactory) Join(addr string) (NetSession, error) {
+	f.joined = addr
+	return f.session, f.err
+}
+
+func TestCoop_HostWaitsThenPlays(t *testing.T) {
+	s := &fakeSession{}
+	g := NewWithOptions(Options{Net: &fakeFactory{session: s}})
+	g.ensureSound()
+	g.openCoop()
+	g.coop.cursor = coopHost
+	g.coopSelect()
+
+	if g.net == nil {
+		t.Fatal("hosting should open a session")
+	}
+	if err := g.Update(); err != nil {
+		t.Fatal(err)
+	}
+	if g.state != stateCoop {
+		t.Fatalf("should keep waiting without a world, state %s", g.state)
+	}
+
+	s.world = NewCoopWorld(1)
+	for i := 0; i < 2; i++ {
+		if err := g.Update(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if g.state != statePlaying || g.world != s.world {
+		t.Fatalf("expected to play the session's world, state %s", g.state)
+	}
+	if s.advanced != 1 {
+		t.Errorf("expected the session to advance once, got %d", s.advanced)
+	}
+	if g.recorder != nil {
+		t.Error("co-op games should not be recorded")
+	}
+
+	g.state = statePaused
+	g.pauseCursor = 1
+	g.pauseSelect()
+	if !s.closed || g.net != nil {
+		t.Error("quitting to the menu should close the session")
+	}
+}
+
+func TestCoop_JoinNeedsAddress(t *testing.T) {
+	f := &fakeFactory{session: &fakeSession{}}
+	g := NewWithOptions(Options{Net: f})
+	g.openCoop()
+	g.coop.cursor = coopJoin
+	g.coopSelect()
+	if g.net != nil || g.coop.err == "" {
+		t.Error("joining without an address should show an error")
+	}
+
+	g.coop.addr = "10.0.0.2"
+	g.coopSelect()
+	if f.joined != "10.0.0.2" || g.net == nil {
+		t.Errorf("expected to join 10.0.0.2, joined %q", f.joined)
+	}
+}
+
+func TestCoop_ConnectionErrorIsShown(t *testing.T) {
+	g := NewWithOptions(Options{Net: &fakeFactory{err: errors.New("connection refused")}})
+	g.openCoop()
+	g.coopSelect()
+	if g.net != nil || g.coop.err != "CONNECTION REFUSED" {
+		t.Errorf("expected the error on screen, got %q", g.coop.err)
+	}
+}
+
+func TestCoop_UnavailableWithoutFactory(t *testing.T) {
+	g := New()
+	g.openCoop()
+	if g.coop.err == "" {
+		t.Error("expected a message when co-op is not available")
+	}
+	g.coopSelect()
+	if g.net != nil {
+		t.Error("no session can be opened without a factory")
+	}
+}
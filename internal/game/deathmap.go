@@ -0,0 +1,85 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/matheus3301/asteroids/internal/canvas"
+	"github.com/matheus3301/asteroids/internal/telemetry"
+)
+
+// The death map is drawn as a faded playfield in this area of the stats
+// screen, keeping the standard field's shape.
+const (
+	deathMapX, deathMapY = 160.0, 114.0
+	deathMapW, deathMapH = 480.0, 360.0
+)
+
+// updateDeathMap picks the cause shown with Up and Down, cycling through
+// every cause and then all of them together.
+func (g *Game) updateDeathMap() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyUp) {
+		g.deathMapCause = (g.deathMapCause + NumDeathCauses) % (NumDeathCauses + 1)
+		g.sound.PlayBlip()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyDown) {
+		g.deathMapCause = (g.deathMapCause + 1) % (NumDeathCauses + 1)
+		g.sound.PlayBlip()
+	}
+}
+
+// deathMapCells is the death map's counts for the chosen cause.
+func (g *Game) deathMapCells() []int {
+	if g.deathMapCause >= NumDeathCauses {
+		return g.telemetry.DeathMap.Cells()
+	}
+	return g.telemetry.DeathMap.Cells(g.deathMapCause.String())
+}
+
+// deathColor shades a cell from dark red for a single loss to yellow for
+// the cell with the most, t being its share of that peak.
+func deathColor(t float64) color.RGBA {
+	return color.RGBA{uint8(110 + 145*t), uint8(230 * t * t), 0, 255}
+}
+
+// drawDeathMap is the stats page showing where ships were lost over every
+// recorded game, on a faded playfield with the respawn point marked.
+func (g *Game) drawDeathMap(screen canvas.Canvas) {
+	white := color.RGBA{255, 255, 255, 255}
+	grey := color.RGBA{100, 100, 100, 255}
+	faded := color.RGBA{40, 40, 50, 255}
+
+	cause := "ALL CAUSES"
+	if g.deathMapCause < NumDeathCauses {
+		cause = strings.ToUpper(g.deathMapCause.String())
+	}
+	cells := g.deathMapCells()
+	total, peak := 0, 0
+	for _, n := range cells {
+		total += n
+		peak = max(peak, n)
+	}
+	drawCentered(screen, fmt.Sprintf("DEATH MAP - %s - %d LOST", cause, total), 96, 1.5, white)
+
+	screen.FillRect(deathMapX, deathMapY, deathMapW, deathMapH, color.RGBA{8, 8, 14, 255})
+	screen.StrokeRect(deathMapX, deathMapY, deathMapW, deathMapH, 1, faded)
+	cw, ch := deathMapW/telemetry.DeathMapCols, deathMapH/telemetry.DeathMapRows
+	for i, n := range cells {
+		if n == 0 {
+			continue
+		}
+		x := deathMapX + float64(i%telemetry.DeathMapCols)*cw
+		y := deathMapY + float64(i/telemetry.DeathMapCols)*ch
+		screen.FillRect(x, y, cw, ch, deathColor(float64(n)/float64(peak)))
+	}
+	// The ship respawns at the centre.
+	cx, cy := deathMapX+deathMapW/2, deathMapY+deathMapH/2
+	screen.StrokeLine(cx-8, cy, cx+8, cy, 1, white)
+	screen.StrokeLine(cx, cy-8, cx, cy+8, 1, white)
+
+	drawCentered(screen, "UP/DOWN TO PICK A CAUSE . + IS THE RESPAWN POINT", 494, 1.5, grey)
+	drawCentered(screen, statsHint, 560, 1.5, grey)
+}
@@ -0,0 +1,79 @@
+package game
+
+import "github.com/matheus3301/asteroids/internal/tuning"
+
+// devPollEvery is how many frames pass between checks of the tuning file.
+const devPollEvery = 30
+
+// devTuning applies a TOML tuning file of GameConfig values to the game as
+// it is edited, for Options.DevTuning.
+type devTuning struct {
+	watcher *tuning.Watcher
+	// data is the last version of the file that applied cleanly.
+	data   []byte
+	frames int
+}
+
+// apply returns c with the tuning file's values, or c itself before the
+// file has applied cleanly. It is safe to call on a nil devTuning.
+func (d *devTuning) apply(c GameConfig) GameConfig {
+	if d == nil || d.data == nil {
+		return c
+	}
+	tuned := c
+	if err := tuning.Decode(d.data, &tuned); err != nil || tuned.Validate() != nil {
+		return c
+	}
+	return tuned
+}
+
+// pollTuning checks the tuning file now and then and, when it has changed,
+// applies it to new games and to the single-player game in play, which is
+// given the config a new game of the same run would start with. Replays,
+// co-op, drills and the tutorial are left alone. A file that does not
+// parse or validate is reported and the last good one stays in effect.
+// The playfield cannot change under a running game, so a new size waits
+// for the next one.
+func (g *Game) pollTuning() {
+	d := g.dev
+	if d == nil {
+		return
+	}
+	d.frames++
+	if d.frames%devPollEvery != 1 {
+		return
+	}
+	data, changed, err := d.watcher.Changed()
+	if err != nil {
+		logger.Warn("reading tuning file", "path", d.watcher.Path(), "err", err)
+		return
+	}
+	if !changed {
+		return
+	}
+	c := DefaultConfig()
+	if err := tuning.Decode(data, &c); err != nil {
+		logger.Warn("tuning file not applied", "path", d.watcher.Path(), "err", err)
+		return
+	}
+	if err := c.Validate(); err != nil {
+		logger.Warn("tuning file not applied", "path", d.watcher.Path(), "err", err)
+		return
+	}
+	d.data = data
+	logger.Info("tuning applied", "path", d.watcher.Path())
+
+	w := g.world
+	if w == nil || g.state != statePlaying || g.net != nil || g.drills.active != nil || g.tutorial != nil {
+		return
+	}
+	c = g.runRules(g.rules(), w.ShipType, g.runMutators, g.runDifficulty, g.runMode).Config
+	c.FieldWidth, c.FieldHeight = w.Config.FieldWidth, w.Config.FieldHeight
+	if c != w.Config {
+		w.Config = c
+		// Its replay would no longer reproduce it, and it is no longer
+		// played by the standard rules.
+		g.recorder = nil
+		g.countsForCareer = false
+	}
+}
@@ -0,0 +1,105 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeTuning writes a tuning file, dated mod so the watcher sees each
+// version.
+func writeTuning(t *testing.T, path, data string, mod time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, mod, mod); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDevTuning_AppliesToNewAndRunningGames(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tuning.toml")
+	now := time.Now()
+	writeTuning(t, path, "max_bullets = 9\n[small_saucer.hard]\naim_error = 0.3\n", now)
+
+	g := NewWithOptions(Options{DevTuning: path})
+	g.reset()
+	w := g.world
+	if w.Config.MaxBullets != 9 || w.Config.SmallSaucer.Hard.AimError != 0.3 {
+		t.Fatalf("new game not tuned: max_bullets=%d aim_error=%v", w.Config.MaxBullets, w.Config.SmallSaucer.Hard.AimError)
+	}
+	if g.recorder != nil || g.countsForCareer {
+		t.Error("a tuned game should neither be recorded nor count toward the career")
+	}
+
+	writeTuning(t, path, "max_bullets = 5\nfield_width = 1200\n", now.Add(time.Second))
+	g.dev.frames = 0
+	g.pollTuning()
+	if w.Config.MaxBullets != 5 {
+		t.Errorf("running game not retuned: max_bullets=%d", w.Config.MaxBullets)
+	}
+	if w.Config.FieldWidth != ScreenWidth {
+		t.Errorf("playfield changed under a running game: %v", w.Config.FieldWidth)
+	}
+
+	writeTuning(t, path, "max_bullets = -1\n", now.Add(2*time.Second))
+	g.dev.frames = 0
+	g.pollTuning()
+	if w.Config.MaxBullets != 5 {
+		t.Errorf("invalid tuning applied: max_bullets=%d", w.Config.MaxBullets)
+	}
+	g.reset()
+	if g.world.Config.MaxBullets != 5 {
+		t.Errorf("next game should keep the last good tuning, got max_bullets=%d", g.world.Config.MaxBullets)
+	}
+}
+
+func TestDevTuning_RunningGameMatchesANewOne(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tuning.toml")
+	now := time.Now()
+	writeTuning(t, path, "max_bullets = 9\n", now)
+
+	g := NewWithOptions(Options{DevTuning: path, Difficulty: "hard"})
+	g.reset()
+	w := g.world
+
+	// The difficulty scales the tuned speed mid-game as it does for a new
+	// game, instead of the file overriding it.
+	writeTuning(t, path, "asteroid_speed = 2\n", now.Add(time.Second))
+	g.dev.frames = 0
+	g.pollTuning()
+	g.reset()
+	if w.Config != g.world.Config {
+		t.Errorf("retuned game differs from a new one: asteroid_speed %v vs %v", w.Config.AsteroidSpeed, g.world.Config.AsteroidSpeed)
+	}
+	if w.Config.AsteroidSpeed != 2.5 {
+		t.Errorf("asteroid_speed = %v, want 2 at hard's 1.25x", w.Config.AsteroidSpeed)
+	}
+}
+
+func TestDevTuning_LeavesReplaysAlone(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tuning.toml")
+	now := time.Now()
+	writeTuning(t, path, "max_bullets = 4\n", now)
+
+	g := NewWithOptions(Options{DevTuning: path})
+	g.PlayReplay(recordGame(5, 1200))
+	p := g.replay.runner
+	for i := 0; i < 300; i++ {
+		p.Advance()
+	}
+	writeTuning(t, path, "max_bullets = 1\nasteroid_speed = 3\n", now.Add(time.Second))
+	g.dev.frames = 0
+	g.pollTuning()
+	if g.dev.data == nil {
+		t.Fatal("the tuning file should still be read during a replay")
+	}
+	for !p.Done() {
+		p.Advance()
+	}
+	if p.DesyncTick >= 0 || p.World.Config.MaxBullets != DefaultConfig().MaxBullets {
+		t.Errorf("a tuning edit should not touch the replay, desync at %d", p.DesyncTick)
+	}
+}
@@ -0,0 +1,153 @@
+package game
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Difficulty is an index into Difficulties.
+type Difficulty int
+
+// The difficulties, in the order the settings screen cycles through them.
+const (
+	DifficultyEasy Difficulty = iota
+	DifficultyNormal
+	DifficultyHard
+	DifficultyInsane
+)
+
+// DifficultyConfig is a difficulty preset. Like a mutator it only changes
+// the GameConfig, so it combines with ships, modes, mutators and mods.
+type DifficultyConfig struct {
+	ID   string
+	Name string
+	// AsteroidSpeed multiplies how fast asteroids fly.
+	AsteroidSpeed float64
+	// SaucerDelay multiplies the wait before the first saucer and between
+	// saucers.
+	SaucerDelay float64
+	// StartingLives replaces the lives a game starts with; 0 keeps them.
+	StartingLives int
+	// SaucerAim multiplies how far each saucer's shots can miss. Taking
+	// enough off large saucers makes them aim instead of firing at random.
+	SaucerAim float64
+	// SaucerHardScore multiplies the score at which saucers reach their
+	// hard profile, where small saucers stop missing.
+	SaucerHardScore float64
+}
+
+// Difficulties are the difficulty presets. Profiles keep the chosen one and
+// best scores by ID, so IDs must not change. Normal changes nothing.
+var Difficulties = []DifficultyConfig{
+	{ID: "easy", Name: "EASY", AsteroidSpeed: 0.75, SaucerDelay: 1.5, StartingLives: 5, SaucerAim: 1.5, SaucerHardScore: 2},
+	{ID: "normal", Name: "NORMAL", AsteroidSpeed: 1, SaucerDelay: 1, SaucerAim: 1, SaucerHardScore: 1},
+	{ID: "hard", Name: "HARD", AsteroidSpeed: 1.25, SaucerDelay: 0.75, SaucerAim: 0.75, SaucerHardScore: 0.75},
+	{ID: "insane", Name: "INSANE", AsteroidSpeed: 1.5, SaucerDelay: 0.5, StartingLives: 2, SaucerAim: 0.5, SaucerHardScore: 0.5},
+}
+
+func (d Difficulty) String() string {
+	if d < 0 || int(d) >= len(Difficulties) {
+		return fmt.Sprintf("Difficulty(%d)", int(d))
+	}
+	return Difficulties[d].Name
+}
+
+// Apply changes c by the preset.
+func (d DifficultyConfig) Apply(c GameConfig) GameConfig {
+	c.AsteroidSpeed *= d.AsteroidSpeed
+	c.SaucerInitialDelay = int(math.Round(float64(c.SaucerInitialDelay) * d.SaucerDelay))
+	c.SaucerRespawnDelay = int(math.Round(float64(c.SaucerRespawnDelay) * d.SaucerDelay))
+	if d.StartingLives > 0 {
+		c.StartingLives = d.StartingLives
+	}
+	c.LargeSaucer = c.LargeSaucer.withAim(d.SaucerAim, d.SaucerHardScore)
+	c.SmallSaucer = c.SmallSaucer.withAim(d.SaucerAim, d.SaucerHardScore)
+	return c
+}
+
+// withAim multiplies the aim error of both profiles by aim and the score
+// at which the hard one takes over by hardScore.
+func (b SaucerBehavior) withAim(aim, hardScore float64) SaucerBehavior {
+	b.Easy.AimError *= aim
+	b.Hard.AimError *= aim
+	b.HardScore = int(math.Round(float64(b.HardScore) * hardScore))
+	return b
+}
+
+// ParseDifficulty finds a difficulty by ID, such as "hard".
+func ParseDifficulty(id string) (Difficulty, error) {
+	for i, d := range Difficulties {
+		if d.ID == id {
+			return Difficulty(i), nil
+		}
+	}
+	ids := make([]string, len(Difficulties))
+	for i, d := range Difficulties {
+		ids[i] = d.ID
+	}
+	return DifficultyNormal, fmt.Errorf("unknown difficulty %q (want one of %s)", id, strings.Join(ids, ", "))
+}
+
+// applyDifficulty changes the rules of a run by its difficulty.
+func applyDifficulty(r Ruleset, d Difficulty) Ruleset {
+	r.Config = Difficulties[d].Apply(r.Config)
+	return r
+}
+
+// runKey is the high-score table for a run in mode m at d with mutators s,
+// such as "hard+fog" or "survival+hard", or "" for a classic normal run
+// without mutators, which counts toward the career instead.
+func runKey(m GameMode, d Difficulty, s MutatorSet) string {
+	var parts []string
+	if m != ModeClassic {
+		parts = append(parts, gameModeIDs[m])
+	}
+	if d != DifficultyNormal {
+		parts = append(parts, Difficulties[d].ID)
+	}
+	if k := s.Key(); k != "" {
+		parts = append(parts, k)
+	}
+	return strings.Join(parts, "+")
+}
+
+// runName names a run's mode, difficulty and mutators for the screen, such
+// as "HARD+FOG".
+func runName(m GameMode, d Difficulty, s MutatorSet) string {
+	var parts []string
+	if m != ModeClassic {
+		parts = append(parts, m.String())
+	}
+	if d != DifficultyNormal {
+		parts = append(parts, Difficulties[d].Name)
+	}
+	if s != 0 {
+		parts = append(parts, s.String())
+	}
+	return strings.Join(parts, "+")
+}
+
+// difficultyFromID reads a difficulty ID from the profile or options, where
+// empty means normal. An unknown one is logged and normal is used.
+func difficultyFromID(id string) Difficulty {
+	if id == "" {
+		return DifficultyNormal
+	}
+	d, err := ParseDifficulty(id)
+	if err != nil {
+		logger.Warn("difficulty not set", "err", err)
+	}
+	return d
+}
+
+// setDifficulty chooses the difficulty of the next run and keeps the
+// choice in the profile.
+func (g *Game) setDifficulty(d Difficulty) {
+	g.difficulty = d
+	g.profile.Difficulty = ""
+	if d != DifficultyNormal {
+		g.profile.Difficulty = Difficulties[d].ID
+	}
+	g.saveProfile()
+}
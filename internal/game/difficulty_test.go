@@ -0,0 +1,129 @@
+package game
+
+import (
+	"math"
+	"testing"
+
+	"github.com/matheus3301/asteroids/internal/profile"
+	"github.com/matheus3301/asteroids/internal/storage"
+)
+
+func TestDifficulty_NormalChangesNothing(t *testing.T) {
+	if c := Difficulties[DifficultyNormal].Apply(DefaultConfig()); c != DefaultConfig() {
+		t.Errorf("normal should leave the standard rules alone:\n%+v", c)
+	}
+}
+
+func TestDifficulty_PresetsAreValidAndOrdered(t *testing.T) {
+	var prev GameConfig
+	for i, d := range Difficulties {
+		c := d.Apply(DefaultConfig())
+		if err := c.Validate(); err != nil {
+			t.Errorf("%s: %v", d.Name, err)
+		}
+		if i > 0 {
+			harder := c.AsteroidSpeed > prev.AsteroidSpeed &&
+				c.SaucerInitialDelay < prev.SaucerInitialDelay &&
+				c.StartingLives <= prev.StartingLives &&
+				c.LargeSaucer.Hard.AimError <= prev.LargeSaucer.Hard.AimError
+			if !harder {
+				t.Errorf("%s should be harder than %s", d.Name, Difficulties[i-1].Name)
+			}
+		}
+		prev = c
+	}
+}
+
+func TestDifficulty_SaucerAim(t *testing.T) {
+	var prev SaucerBehavior
+	for i, d := range Difficulties {
+		small := d.Apply(DefaultConfig()).SmallSaucer
+		if e := small.At(0).AimError; e <= 0 {
+			t.Errorf("%s: small saucers should miss at first, error %v", d.Name, e)
+		}
+		if e := small.At(small.HardScore).AimError; e != 0 {
+			t.Errorf("%s: small saucers should shoot dead on from %d, error %v", d.Name, small.HardScore, e)
+		}
+		if i > 0 && (small.At(0).AimError >= prev.At(0).AimError || small.HardScore >= prev.HardScore) {
+			t.Errorf("%s: small saucers should miss less and sharpen sooner than on %s", d.Name, Difficulties[i-1].Name)
+		}
+		prev = small
+	}
+	insane := Difficulties[DifficultyInsane].Apply(DefaultConfig())
+	if e := insane.LargeSaucer.At(0).AimError; e >= math.Pi {
+		t.Errorf("large saucers should aim on insane, error %v", e)
+	}
+}
+
+func TestDifficulty_AsteroidSpeed(t *testing.T) {
+	speeds := func(d Difficulty) float64 {
+		w := NewModdedWorld(1, applyDifficulty(StandardRules(), d))
+		var sum float64
+		for _, e := range sortedEntities(w.asteroids) {
+			v := w.velocities[e]
+			sum += math.Hypot(v.X, v.Y)
+		}
+		return sum
+	}
+	normal, hard := speeds(DifficultyNormal), speeds(DifficultyHard)
+	if math.Abs(hard-normal*1.25) > 1e-9 {
+		t.Errorf("hard asteroids should fly 1.25x as fast: %v vs %v", hard, normal)
+	}
+}
+
+func TestParseDifficulty(t *testing.T) {
+	if d, err := ParseDifficulty("insane"); err != nil || d != DifficultyInsane {
+		t.Errorf("got %v, %v", d, err)
+	}
+	if _, err := ParseDifficulty("nightmare"); err == nil {
+		t.Error("expected an error for an unknown difficulty")
+	}
+}
+
+func TestRunKey(t *testing.T) {
+	fog := MutatorSet(0).Toggle(mutatorIndex("fog"))
+	for _, tc := range []struct {
+		d         Difficulty
+		s         MutatorSet
+		key, name string
+	}{
+		{DifficultyNormal, 0, "", ""},
+		{DifficultyNormal, fog, "fog", "FOG"},
+		{DifficultyHard, 0, "hard", "HARD"},
+		{DifficultyHard, fog, "hard+fog", "HARD+FOG"},
+	} {
+		if key, name := runKey(ModeClassic, tc.d, tc.s), runName(ModeClassic, tc.d, tc.s); key != tc.key || name != tc.name {
+			t.Errorf("%v %v: got %q %q, want %q %q", tc.d, tc.s, key, name, tc.key, tc.name)
+		}
+	}
+}
+
+func TestSettings_DifficultySavedAndApplied(t *testing.T) {
+	restore := storage.Override(storage.At(t.TempDir()))
+	defer restore()
+
+	g := New()
+	g.settingsCursor = 7
+	g.settingsRight()
+	if g.difficulty != DifficultyHard || g.profile.Difficulty != "hard" {
+		t.Fatalf("expected hard kept in the profile, got %v and %q", g.difficulty, g.profile.Difficulty)
+	}
+	if New().difficulty != DifficultyHard {
+		t.Error("the choice should be loaded with the profile")
+	}
+
+	g.reset()
+	if g.world.Config.AsteroidSpeed != 1.25 {
+		t.Errorf("a new game should be hard, asteroid speed %v", g.world.Config.AsteroidSpeed)
+	}
+	g.profile = &profile.Profile{BestScore: 100}
+	g.world.Score = 5_000
+	g.recordCareer()
+	if g.profile.Games != 0 || g.profile.MutatorBests["hard"] != 5_000 {
+		t.Errorf("a hard run should keep its own best, not count toward the career: %+v", g.profile)
+	}
+
+	if g := NewWithOptions(Options{Difficulty: "easy"}); g.difficulty != DifficultyEasy {
+		t.Errorf("the option should override the saved choice, got %v", g.difficulty)
+	}
+}
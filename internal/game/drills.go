@@ -0,0 +1,254 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/matheus3301/asteroids/internal/canvas"
+)
+
+// Medal is the grade earned on a drill.
+type Medal int
+
+const (
+	MedalNone Medal = iota
+	MedalBronze
+	MedalSilver
+	MedalGold
+)
+
+func (m Medal) String() string {
+	switch m {
+	case MedalBronze:
+		return "BRONZE"
+	case MedalSilver:
+		return "SILVER"
+	case MedalGold:
+		return "GOLD"
+	}
+	return "-"
+}
+
+// medalColors are how each medal is drawn on the DRILLS screen.
+var medalColors = [...]color.RGBA{
+	MedalNone:   {100, 100, 100, 255},
+	MedalBronze: {205, 127, 50, 255},
+	MedalSilver: {192, 192, 192, 255},
+	MedalGold:   {255, 210, 60, 255},
+}
+
+// Drill is a short practice scenario with a goal. Every attempt starts from
+// the same seed, so the asteroids and saucers move the same way each time.
+type Drill struct {
+	ID   string
+	Name string
+	Goal string
+	Seed int64
+	// Limit is how many ticks the drill lasts. Meeting the goal within Gold
+	// or Silver ticks earns those medals, and within Limit a bronze.
+	Limit, Gold, Silver int
+	// Survive makes lasting the whole limit a bronze even if the goal was
+	// not met.
+	Survive bool
+
+	// setup places the drill's asteroids and saucers around the ship.
+	setup func(w *World)
+	// met reports whether the goal has been reached.
+	met func(w *World) bool
+}
+
+// Drills are the scenarios on the DRILLS screen. Profiles keep medals by
+// ID, so IDs must not change.
+var Drills = []Drill{
+	{
+		ID: "sweep", Name: "CLEAN SWEEP", Goal: "DESTROY 3 SMALL ASTEROIDS IN 10S",
+		Seed: 101, Limit: 600, Gold: 240, Silver: 420,
+		setup: func(w *World) {
+			SpawnAsteroid(w, 200, 150, SizeSmall)
+			SpawnAsteroid(w, 600, 150, SizeSmall)
+			SpawnAsteroid(w, 400, 480, SizeSmall)
+		},
+		met: func(w *World) bool { return w.Stats.AsteroidsDestroyed >= 3 },
+	},
+	{
+		ID: "split", Name: "SPLIT DECISION", Goal: "CLEAR A LARGE ASTEROID AND ALL ITS PIECES IN 20S",
+		Seed: 202, Limit: 1200, Gold: 540, Silver: 840,
+		setup: func(w *World) {
+			SpawnAsteroid(w, 150, 150, SizeLarge)
+		},
+		// One large asteroid breaks into two medium and four small ones.
+		met: func(w *World) bool { return w.Stats.AsteroidsDestroyed >= 7 },
+	},
+	{
+		ID: "ambush", Name: "SAUCER AMBUSH", Goal: "SURVIVE A SMALL SAUCER FOR 15S, OR SHOOT IT DOWN",
+		Seed: 303, Limit: 900, Gold: 300, Silver: 600, Survive: true,
+		setup: func(w *World) {
+			SpawnAsteroid(w, 100, 100, SizeLarge)
+			SpawnAsteroid(w, 700, 500, SizeLarge)
+			SpawnSaucer(w, SaucerSmall)
+		},
+		met: func(w *World) bool { return w.Stats.SaucersDestroyed >= 1 },
+	},
+}
+
+// newDrillWorld creates the world for an attempt at d: one life, the ship
+// at the centre and only the drill's own asteroids and saucer.
+func newDrillWorld(d Drill) *World {
+	w := NewWorldWithSeed(d.Seed)
+	w.Lives = 1
+	w.Level = 1
+	w.Saucer.SpawnTimer = d.Limit + 1
+	w.Config.SaucerRespawnDelay = d.Limit + 1
+	w.Player = SpawnPlayer(w, w.Config.FieldWidth/2, w.Config.FieldHeight/2)
+	d.setup(w)
+	return w
+}
+
+// Grade is the medal for an attempt that ended after ticks, having met the
+// goal or not.
+func (d Drill) Grade(ticks int, met bool) Medal {
+	switch {
+	case !met && d.Survive && ticks >= d.Limit:
+		return MedalBronze
+	case !met || ticks > d.Limit:
+		return MedalNone
+	case ticks <= d.Gold:
+		return MedalGold
+	case ticks <= d.Silver:
+		return MedalSilver
+	}
+	return MedalBronze
+}
+
+// drillScreen is the DRILLS screen and the result of the last attempt.
+type drillScreen struct {
+	cursor int
+	// active is the drill being played, or nil in a normal game.
+	active *Drill
+	// last is the drill last attempted, with its medal and length.
+	last   *Drill
+	medal  Medal
+	ticks  int
+	record bool
+}
+
+func (g *Game) openDrills() {
+	g.drills.cursor = 0
+	g.drills.last = nil
+	g.state = stateDrills
+}
+
+// startDrill begins an attempt at Drills[i]. Drills are not recorded as
+// replays and do not count toward the career.
+func (g *Game) startDrill(i int) {
+	g.closeNet()
+	g.ensureSound()
+	g.sound.Reset()
+	g.sound.SetMasterVolume(float64(g.settings.volume) / 10.0)
+	g.drills.active = &Drills[i]
+	g.tutorial = nil
+	g.world = newDrillWorld(Drills[i])
+	g.state = statePlaying
+	g.stepAccum = 0
+	g.buttons.Clear()
+	g.recorder = nil
+	g.newUnlocks = nil
+	g.countsForCareer = false
+	g.runMutators = 0
+	g.runDifficulty = DifficultyNormal
+	g.runMode = ModeClassic
+	g.assisted = false
+}
+
+// checkDrill ends the attempt once the goal is met, the ship is lost or
+// time runs out, and keeps the medal if it is the player's best and was
+// earned without aim assist.
+func (g *Game) checkDrill() {
+	d, w := g.drills.active, g.world
+	met := d.met(w) && !w.GameOver()
+	if !met && !w.GameOver() && w.Tick < d.Limit {
+		return
+	}
+	g.sound.StopAll()
+	g.drills.last, g.drills.active = d, nil
+	g.drills.ticks = w.Tick
+	g.drills.medal = d.Grade(w.Tick, met)
+	g.drills.record = !g.assisted && g.profile.RecordDrill(d.ID, int(g.drills.medal))
+	if g.drills.record {
+		g.saveProfile()
+	}
+	g.state = stateDrills
+}
+
+// drawDrillStatus shows the drill being played and the time left in it.
+func (g *Game) drawDrillStatus(screen canvas.Canvas) {
+	d := g.drills.active
+	if d == nil {
+		return
+	}
+	left := float64(max(d.Limit-g.world.Tick, 0)) / 60
+	drawCentered(screen, fmt.Sprintf("%s  %.1fS", d.Name, left), 20, 2, color.RGBA{255, 210, 60, 255})
+}
+
+func (g *Game) updateDrills() {
+	rows := len(Drills) + 1
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.sound.PlayBlip()
+		g.state = stateMenu
+		return
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyUp) {
+		g.drills.cursor = (g.drills.cursor + rows - 1) % rows
+		g.sound.PlayBlip()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyDown) {
+		g.drills.cursor = (g.drills.cursor + 1) % rows
+		g.sound.PlayBlip()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+		g.sound.PlayConfirm()
+		if g.drills.cursor == len(Drills) {
+			g.state = stateMenu
+			return
+		}
+		g.startDrill(g.drills.cursor)
+	}
+}
+
+func (g *Game) drawDrills(screen canvas.Canvas) {
+	white := color.RGBA{255, 255, 255, 255}
+	grey := color.RGBA{100, 100, 100, 255}
+	green := color.RGBA{0, 255, 0, 255}
+	drawCentered(screen, "DRILLS", 50, 4, white)
+
+	for i, d := range Drills {
+		clr := white
+		if i == g.drills.cursor {
+			clr = green
+		}
+		y := 130 + float64(i)*70
+		DrawText(screen, d.Name, 100, y, 2.5, clr)
+		best := Medal(g.profile.Drills[d.ID])
+		DrawText(screen, best.String(), 560, y, 2.5, medalColors[best])
+		DrawText(screen, d.Goal, 100, y+30, 1.5, grey)
+	}
+	clr := white
+	if g.drills.cursor == len(Drills) {
+		clr = green
+	}
+	DrawText(screen, "BACK", 100, 130+float64(len(Drills))*70, 2.5, clr)
+
+	if d := g.drills.last; d != nil {
+		text := d.Name + ": FAILED"
+		if m := g.drills.medal; m != MedalNone {
+			text = fmt.Sprintf("%s: %s IN %.1fS", d.Name, m, float64(g.drills.ticks)/60)
+			if g.drills.record {
+				text += " - NEW BEST!"
+			}
+		}
+		drawCentered(screen, text, 480, 2, medalColors[g.drills.medal])
+	}
+	drawCentered(screen, "GOLD AND SILVER FOR MEETING THE GOAL QUICKLY . ESC TO GO BACK", 570, 1.5, grey)
+}
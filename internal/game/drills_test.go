@@ -0,0 +1,108 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/matheus3301/asteroids/internal/profile"
+	"github.com/matheus3301/asteroids/internal/storage"
+)
+
+func TestDrill_Grade(t *testing.T) {
+	d := Drill{Limit: 600, Gold: 240, Silver: 420}
+	cases := []struct {
+		ticks int
+		met   bool
+		want  Medal
+	}{
+		{200, true, MedalGold},
+		{240, true, MedalGold},
+		{300, true, MedalSilver},
+		{500, true, MedalBronze},
+		{600, false, MedalNone},
+		{100, false, MedalNone},
+	}
+	for _, c := range cases {
+		if got := d.Grade(c.ticks, c.met); got != c.want {
+			t.Errorf("Grade(%d, %v) = %v, want %v", c.ticks, c.met, got, c.want)
+		}
+	}
+
+	d.Survive = true
+	if got := d.Grade(600, false); got != MedalBronze {
+		t.Errorf("surviving the limit should earn bronze, got %v", got)
+	}
+	if got := d.Grade(300, false); got != MedalNone {
+		t.Errorf("dying early should earn nothing, got %v", got)
+	}
+}
+
+func TestNewDrillWorld_OnlyTheDrill(t *testing.T) {
+	for _, d := range Drills {
+		w := newDrillWorld(d)
+		n := len(w.asteroids)
+		if n == 0 {
+			t.Fatalf("%s: a drill needs asteroids, or the first tick spawns a wave", d.ID)
+		}
+		for w.Tick < d.Limit && w.Lives > 0 {
+			Step(w, InputState{})
+		}
+		if w.Lives > 0 && w.Level != 1 {
+			t.Errorf("%s: a wave was spawned during the drill", d.ID)
+		}
+		if d.ID != "ambush" && len(w.saucers) != 0 {
+			t.Errorf("%s: a saucer arrived during the drill", d.ID)
+		}
+	}
+}
+
+func TestNewDrillWorld_SameEveryAttempt(t *testing.T) {
+	a, b := newDrillWorld(Drills[0]), newDrillWorld(Drills[0])
+	for i := 0; i < 120; i++ {
+		Step(a, InputState{})
+		Step(b, InputState{})
+	}
+	if a.Checksum() != b.Checksum() {
+		t.Error("two attempts at a drill should play out the same")
+	}
+}
+
+func TestCheckDrill_RecordsBestMedal(t *testing.T) {
+	restore := storage.Override(storage.At(t.TempDir()))
+	defer restore()
+
+	g := New()
+	g.profile = &profile.Profile{}
+	g.menuCursor = 6
+	g.menuSelect()
+	if g.state != stateDrills {
+		t.Fatalf("expected stateDrills, got %v", g.state)
+	}
+
+	g.startDrill(0)
+	g.world.Stats.AsteroidsDestroyed = 3
+	g.StepPlaying(InputState{})
+	if g.state != stateDrills || g.drills.active != nil {
+		t.Fatalf("meeting the goal should end the drill, state %v", g.state)
+	}
+	if g.drills.medal != MedalGold || !g.drills.record {
+		t.Errorf("expected a new gold, got %v (record %v)", g.drills.medal, g.drills.record)
+	}
+	if g.profile.Drills["sweep"] != int(MedalGold) {
+		t.Errorf("the medal was not kept: %v", g.profile.Drills)
+	}
+
+	g.startDrill(0)
+	killPlayer(g.world, g.world.Player, DeathAsteroid)
+	g.StepPlaying(InputState{})
+	if g.drills.medal != MedalNone || g.drills.record {
+		t.Errorf("losing the ship should fail the drill, got %v", g.drills.medal)
+	}
+	if g.profile.Drills["sweep"] != int(MedalGold) {
+		t.Error("a failed attempt should keep the best medal")
+	}
+
+	g.reset()
+	if g.drills.active != nil {
+		t.Error("a normal game should not be a drill")
+	}
+}
@@ -1,5 +1,11 @@
 package game
 
+import (
+	"math/rand"
+	"sort"
+	"time"
+)
+
 // Entity is a unique identifier for a game object.
 type Entity uint64
 
@@ -17,25 +23,87 @@ type World struct {
 	players       map[Entity]*PlayerControl
 	asteroids     map[Entity]*AsteroidTag
 	bullets       map[Entity]*BulletTag
+	missiles      map[Entity]*MissileTag
+	pickups       map[Entity]*PickupTag
 	particles     map[Entity]*ParticleTag
 	saucers       map[Entity]*SaucerTag
 	saucerBullets map[Entity]*SaucerBulletTag
 	wrappers      map[Entity]bool // entities that wrap around screen
+	previous      map[Entity]*Previous
 
 	// Singleton game-progression state
-	Player           Entity
-	Score            int
-	Lives            int
-	Level            int
-	NextExtraLifeAt  int
-	SaucerActive     Entity
-	SaucerSpawnTimer int
+	Player          Entity
+	Score           int
+	Lives           int
+	Level           int
+	NextExtraLifeAt int
+	// Saucer is the saucer lifecycle; see SaucerLifecycleSystem.
+	Saucer SaucerState
+	// Kills lists what players' shots destroyed since the tick began.
+	Kills []Kill
+	// Combo counts kills made in quick succession; ComboTimer is the ticks
+	// left for the next kill to extend it.
+	Combo      int
+	ComboTimer int
+	// HitStop is the ticks of freeze-frame left; see TimeScale.
+	HitStop int
+	// LifeBonusFlash is the ticks left of the HUD flash for an extra life
+	// paid out as points.
+	LifeBonusFlash int
+	// ParticlesEvicted and AsteroidsSkipped count what the entity caps in
+	// Config have dropped, for the debug overlay.
+	ParticlesEvicted int
+	AsteroidsSkipped int
 
 	SoundQueue []SoundEvent
+
+	// Stats counts balance-relevant events over the game so far.
+	Stats GameStats
+
+	// Config holds the tuning values systems read. Palette colours new
+	// entities. Both default to the standard game.
+	Config  GameConfig
+	Palette Palette
+	// LowDetail draws only every other particle. It is set when frames run
+	// over budget and never affects the simulation.
+	LowDetail bool
+	// ReducedMotion draws invulnerable ships steadily inside a shield ring
+	// instead of blinking, and holds HUD notes instead of flashing them.
+	// Like LowDetail it never affects the simulation.
+	ReducedMotion bool
+	// RecordContacts makes every tick keep in Contacts where its
+	// collisions happened, for the collision debug view. Neither affects
+	// the simulation.
+	RecordContacts bool
+	Contacts       [][2]float64
+	// Ship is the outline new player ships get; nil is the classic
+	// triangle.
+	Ship ShipShape
+	// ShipType is the index of the ShipTypes entry the player flies.
+	ShipType int
+	// Hooks, when set, lets a mod change the rules. See RuleHooks.
+	Hooks RuleHooks
+
+	// Tick counts simulation steps since the world was created.
+	Tick int
+	// Seed is the value the world's random source was seeded with.
+	Seed int64
+	rng  RNG
 }
 
+// NewWorld creates an empty world seeded from the current time.
 func NewWorld() *World {
+	return NewWorldWithSeed(time.Now().UnixNano())
+}
+
+// NewWorldWithSeed creates an empty world whose random source is seeded with
+// seed, so that the same seed and inputs always reproduce the same game.
+func NewWorldWithSeed(seed int64) *World {
 	return &World{
+		Seed:          seed,
+		Config:        DefaultConfig(),
+		Palette:       DefaultPalette(),
+		rng:           rand.New(rand.NewSource(seed)),
 		nextID:        1,
 		entities:      make(map[Entity]bool),
 		positions:     make(map[Entity]*Position),
@@ -46,10 +114,13 @@ func NewWorld() *World {
 		players:       make(map[Entity]*PlayerControl),
 		asteroids:     make(map[Entity]*AsteroidTag),
 		bullets:       make(map[Entity]*BulletTag),
+		missiles:      make(map[Entity]*MissileTag),
+		pickups:       make(map[Entity]*PickupTag),
 		particles:     make(map[Entity]*ParticleTag),
 		saucers:       make(map[Entity]*SaucerTag),
 		saucerBullets: make(map[Entity]*SaucerBulletTag),
 		wrappers:      make(map[Entity]bool),
+		previous:      make(map[Entity]*Previous),
 	}
 }
 
@@ -70,10 +141,13 @@ func (w *World) Destroy(e Entity) {
 	delete(w.players, e)
 	delete(w.asteroids, e)
 	delete(w.bullets, e)
+	delete(w.missiles, e)
+	delete(w.pickups, e)
 	delete(w.particles, e)
 	delete(w.saucers, e)
 	delete(w.saucerBullets, e)
 	delete(w.wrappers, e)
+	delete(w.previous, e)
 }
 
 // Alive returns whether an entity still exists.
@@ -85,3 +159,35 @@ func (w *World) Alive(e Entity) bool {
 func (w *World) BulletCount() int {
 	return len(w.bullets)
 }
+
+// AsteroidCount returns the number of asteroids in play.
+func (w *World) AsteroidCount() int {
+	return len(w.asteroids)
+}
+
+// SaucerCount returns the number of saucers in play.
+func (w *World) SaucerCount() int {
+	return len(w.saucers)
+}
+
+// PlayerPosition returns where the first player's ship is, or false when it
+// has none.
+func (w *World) PlayerPosition() (x, y float64, ok bool) {
+	pos := w.positions[w.Player]
+	if pos == nil || w.players[w.Player] == nil {
+		return 0, 0, false
+	}
+	return pos.X, pos.Y, true
+}
+
+// sortedEntities returns the keys of a component store in ascending order.
+// Systems that consume randomness while iterating use it so that map order
+// cannot change the outcome of a seeded game.
+func sortedEntities[V any](m map[Entity]V) []Entity {
+	keys := make([]Entity, 0, len(m))
+	for e := range m {
+		keys = append(keys, e)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
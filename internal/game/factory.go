@@ -1,9 +1,9 @@
 package game
 
 import (
-	"image/color"
+	"cmp"
 	"math"
-	"math/rand"
+	"slices"
 )
 
 const (
@@ -23,6 +23,31 @@ const (
 	saucerVerticalTimerMin = 60
 	saucerVerticalTimerMax = 180
 	saucerVerticalSpeed    = 0.8
+	// Large saucers shoot in any direction. Small ones miss by up to
+	// saucerSmallAimErrorEasy at first and grow more accurate with the
+	// score, as in the arcade original, shooting dead on from
+	// saucerHardScore.
+	saucerLargeAimError     = math.Pi
+	saucerSmallAimErrorEasy = 0.3
+	saucerSmallAimError     = 0.0
+	// saucerHardScore is the score at which saucers reach their Hard
+	// profile. Only the small saucer's aim changes with it.
+	saucerHardScore = 40_000
+	// maxSaucers is how many saucers the standard game has in play at once.
+	maxSaucers = 1
+	// saucerClearance is how far from every ship's row a saucer enters.
+	saucerClearance = 80.0
+	// saucerLeadLevel is the first level at which small saucers lead a
+	// moving ship.
+	saucerLeadLevel = 3
+	// shootDownRadius is how close a player bullet must pass a saucer
+	// bullet to shoot it down, and shootDownPoints what doing so scores.
+	shootDownRadius = 6.0
+	shootDownPoints = 50
+
+	// maxAsteroids and maxParticles are the standard entity caps.
+	maxAsteroids = 96
+	maxParticles = 512
 )
 
 // SpawnPlayer creates the player ship entity.
@@ -32,24 +57,28 @@ func SpawnPlayer(w *World, x, y float64) Entity {
 	w.positions[e] = &Position{X: x, Y: y}
 	w.velocities[e] = &Velocity{}
 	w.rotations[e] = &Rotation{Angle: -math.Pi / 2}
-	w.colliders[e] = &Collider{Radius: playerRadius}
+	w.colliders[e] = &Collider{Radius: playerRadius, Layer: LayerPlayer}
 	w.wrappers[e] = true
 
-	// Triangle vertices (local space, pointing right at angle=0)
+	// Outline vertices (local space, pointing right at angle=0)
+	verts := w.Ship.vertices(playerRadius)
+	kind := ShapeTriangle
+	if len(verts) != 3 {
+		kind = ShapePolygon
+	}
 	w.renderables[e] = &Renderable{
-		Kind: ShapeTriangle,
-		Vertices: [][2]float64{
-			{playerRadius, 0},                          // nose
-			{-playerRadius * 0.8, -playerRadius * 0.6}, // left
-			{-playerRadius * 0.8, playerRadius * 0.6},  // right
-		},
-		Color: color.RGBA{0, 255, 0, 255},
-		Scale: 1,
+		Kind:     kind,
+		Vertices: verts,
+		Color:    w.Palette.Ship,
+		Scale:    1,
 	}
 
 	w.players[e] = &PlayerControl{
 		Invulnerable:      true,
 		InvulnerableTimer: 120,
+		Weapon:            WeaponSingle,
+		Missiles:          w.Config.MissileAmmo,
+		Shield:            w.Config.ShieldTicks,
 	}
 
 	return e
@@ -57,23 +86,26 @@ func SpawnPlayer(w *World, x, y float64) Entity {
 
 // SpawnAsteroid creates an asteroid entity.
 func SpawnAsteroid(w *World, x, y float64, size AsteroidSize) Entity {
+	return spawnAsteroidVariant(w, x, y, size, VariantNormal)
+}
+
+// spawnAsteroidVariant creates an asteroid entity of the given variant.
+func spawnAsteroidVariant(w *World, x, y float64, size AsteroidSize, variant AsteroidVariant) Entity {
 	e := w.Spawn()
 
-	var radius, speed float64
+	radius := asteroidRadius[size] * w.Config.AsteroidScale
+	var speed float64
 	switch size {
 	case SizeLarge:
-		radius = 40
 		speed = 1.0
 	case SizeMedium:
-		radius = 20
 		speed = 1.8
 	case SizeSmall:
-		radius = 10
 		speed = 2.5
 	}
 
-	dir := rand.Float64() * 2 * math.Pi
-	spd := speed * (0.5 + rand.Float64())
+	dir := w.rng.Float64() * 2 * math.Pi
+	spd := speed * (0.5 + w.rng.Float64()) * w.Config.AsteroidSpeed
 
 	w.positions[e] = &Position{X: x, Y: y}
 	w.velocities[e] = &Velocity{
@@ -81,57 +113,55 @@ func SpawnAsteroid(w *World, x, y float64, size AsteroidSize) Entity {
 		Y: math.Sin(dir) * spd,
 	}
 	w.rotations[e] = &Rotation{
-		Spin: (rand.Float64() - 0.5) * 0.04,
+		Spin: (w.rng.Float64() - 0.5) * 0.04,
 	}
-	w.colliders[e] = &Collider{Radius: radius}
+	w.colliders[e] = &Collider{Radius: radius, Layer: LayerHazard}
 	w.wrappers[e] = true
 
-	// Generate irregular polygon vertices
-	numVerts := 8 + rand.Intn(5)
-	verts := make([][2]float64, numVerts)
-	for i := range verts {
-		ang := float64(i) / float64(numVerts) * 2 * math.Pi
-		r := radius * (0.7 + rand.Float64()*0.3)
-		verts[i] = [2]float64{math.Cos(ang) * r, math.Sin(ang) * r}
-	}
-
 	w.renderables[e] = &Renderable{
 		Kind:     ShapePolygon,
-		Vertices: verts,
-		Color:    color.RGBA{200, 200, 200, 255},
-		Scale:    1,
+		Vertices: asteroidShapes[variant][size][w.rng.Intn(asteroidShapePool)],
+		Color:    variantColor(w.Palette, variant),
+		Scale:    w.Config.AsteroidScale,
 	}
 
-	w.asteroids[e] = &AsteroidTag{Size: size}
+	w.asteroids[e] = &AsteroidTag{Size: size, Variant: variant}
+	if variant == VariantArmored {
+		w.asteroids[e].Armor = w.Config.ArmoredHits - 1
+	}
 
 	return e
 }
 
 // SpawnBullet creates a bullet fired from the player.
 func SpawnBullet(w *World, playerEntity Entity) Entity {
+	return spawnBullet(w, playerEntity, w.rotations[playerEntity].Angle)
+}
+
+// spawnBullet creates a bullet fired from the player at the given angle.
+func spawnBullet(w *World, playerEntity Entity, angle float64) Entity {
 	e := w.Spawn()
 
 	pos := w.positions[playerEntity]
-	rot := w.rotations[playerEntity]
 
 	w.positions[e] = &Position{
-		X: pos.X + math.Cos(rot.Angle)*playerRadius,
-		Y: pos.Y + math.Sin(rot.Angle)*playerRadius,
+		X: pos.X + math.Cos(angle)*playerRadius,
+		Y: pos.Y + math.Sin(angle)*playerRadius,
 	}
 	w.velocities[e] = &Velocity{
-		X: math.Cos(rot.Angle) * bulletSpeed,
-		Y: math.Sin(rot.Angle) * bulletSpeed,
+		X: math.Cos(angle) * w.Config.BulletSpeed,
+		Y: math.Sin(angle) * w.Config.BulletSpeed,
 	}
-	w.colliders[e] = &Collider{Radius: 2}
+	w.colliders[e] = &Collider{Radius: 2, Layer: LayerPlayerBullet}
 	w.wrappers[e] = true
 
 	w.renderables[e] = &Renderable{
 		Kind:  ShapeCircle,
-		Color: color.RGBA{255, 255, 255, 255},
+		Color: w.Palette.Bullet,
 		Scale: 2,
 	}
 
-	w.bullets[e] = &BulletTag{Life: bulletLife}
+	w.bullets[e] = &BulletTag{Life: w.Config.BulletLife, Owner: playerEntity}
 
 	return e
 }
@@ -153,65 +183,109 @@ func SpawnSaucer(w *World, size SaucerSize) Entity {
 	// Enter from left or right edge
 	dirX := 1.0
 	x := -radius
-	if rand.Intn(2) == 0 {
+	if w.rng.Intn(2) == 0 {
 		dirX = -1.0
-		x = ScreenWidth + radius
+		x = w.Config.FieldWidth + radius
 	}
-	// Random Y in middle 60% of screen
-	y := ScreenHeight*0.2 + rand.Float64()*ScreenHeight*0.6
+	y := saucerEntryY(w, w.rng.Float64())
 
 	w.positions[e] = &Position{X: x, Y: y}
 	w.velocities[e] = &Velocity{X: dirX * speed, Y: 0}
 	w.rotations[e] = &Rotation{}
-	w.colliders[e] = &Collider{Radius: radius}
+	w.colliders[e] = &Collider{Radius: radius, Layer: LayerEnemy}
 
 	verts := saucerVertices(radius)
 	w.renderables[e] = &Renderable{
 		Kind:     ShapePolygon,
 		Vertices: verts,
-		Color:    color.RGBA{255, 0, 0, 255},
+		Color:    w.Palette.Saucer,
 		Scale:    1,
 	}
 
 	w.saucers[e] = &SaucerTag{
 		Size:          size,
 		DirectionX:    dirX,
-		ShootCooldown: saucerShootCooldownMin + rand.Intn(saucerShootCooldownMax-saucerShootCooldownMin),
-		VerticalTimer: saucerVerticalTimerMin + rand.Intn(saucerVerticalTimerMax-saucerVerticalTimerMin),
+		ShootCooldown: saucerCooldown(w, saucerProfile(w, size)),
+		VerticalTimer: saucerVerticalTimerMin + w.rng.Intn(saucerVerticalTimerMax-saucerVerticalTimerMin),
 	}
 
 	return e
 }
 
-// SpawnSaucerBullet creates a bullet fired by a saucer. px, py is the player position (for aimed shots).
+// saucerEntryY maps u in [0, 1) to a row in the middle 60% of the screen
+// that is at least SaucerClearance from every ship's row, so a saucer never
+// appears on top of the player. If the ships leave no such row, any row in
+// the band is used.
+func saucerEntryY(w *World, u float64) float64 {
+	lo, hi := w.Config.FieldHeight*0.2, w.Config.FieldHeight*0.8
+	c := w.Config.SaucerClearance
+
+	// Blocked stretches of the band, merged and in order.
+	var blocked [][2]float64
+	for _, e := range sortedEntities(w.players) {
+		if pos := w.positions[e]; pos != nil {
+			a, b := max(pos.Y-c, lo), min(pos.Y+c, hi)
+			if a < b {
+				blocked = append(blocked, [2]float64{a, b})
+			}
+		}
+	}
+	slices.SortFunc(blocked, func(p, q [2]float64) int { return cmp.Compare(p[0], q[0]) })
+	var merged [][2]float64
+	for _, iv := range blocked {
+		if n := len(merged); n > 0 && iv[0] <= merged[n-1][1] {
+			merged[n-1][1] = max(merged[n-1][1], iv[1])
+			continue
+		}
+		merged = append(merged, iv)
+	}
+
+	free := hi - lo
+	for _, iv := range merged {
+		free -= iv[1] - iv[0]
+	}
+	if free <= 0 {
+		return lo + u*(hi-lo)
+	}
+	// Walk the band skipping blocked stretches.
+	y := lo + u*free
+	for _, iv := range merged {
+		if y < iv[0] {
+			break
+		}
+		y += iv[1] - iv[0]
+	}
+	return y
+}
+
+// SpawnSaucerBullet creates a bullet fired by a saucer at px, py, missing by
+// up to the saucer's aim error.
 func SpawnSaucerBullet(w *World, saucerEntity Entity, px, py float64) Entity {
 	e := w.Spawn()
 
 	spos := w.positions[saucerEntity]
 	st := w.saucers[saucerEntity]
 
-	var angle float64
-	if st.Size == SaucerSmall {
-		// Aim at player
-		dx := px - spos.X
-		dy := py - spos.Y
-		angle = math.Atan2(dy, dx)
-	} else {
-		// Random direction
-		angle = rand.Float64() * 2 * math.Pi
+	p := saucerProfile(w, st.Size)
+	angle := math.Atan2(py-spos.Y, px-spos.X)
+	switch {
+	case p.AimError >= math.Pi:
+		angle = w.rng.Float64() * 2 * math.Pi
+	case p.AimError > 0:
+		angle += (w.rng.Float64()*2 - 1) * p.AimError
 	}
 
 	w.positions[e] = &Position{X: spos.X, Y: spos.Y}
 	w.velocities[e] = &Velocity{
-		X: math.Cos(angle) * saucerBulletSpeed,
-		Y: math.Sin(angle) * saucerBulletSpeed,
+		X: math.Cos(angle) * p.BulletSpeed,
+		Y: math.Sin(angle) * p.BulletSpeed,
 	}
-	w.colliders[e] = &Collider{Radius: 2}
+	w.colliders[e] = &Collider{Radius: 2, Layer: LayerEnemyBullet}
 	w.wrappers[e] = true
 
 	w.renderables[e] = &Renderable{
 		Kind:  ShapeCircle,
-		Color: color.RGBA{255, 100, 100, 255},
+		Color: w.Palette.SaucerBullet,
 		Scale: 2,
 	}
 
@@ -220,13 +294,17 @@ func SpawnSaucerBullet(w *World, saucerEntity Entity, px, py float64) Entity {
 	return e
 }
 
-// SpawnParticle creates an explosion particle.
+// SpawnParticle creates an explosion particle. At Config.MaxParticles the
+// oldest particle makes way for it.
 func SpawnParticle(w *World, x, y float64) Entity {
+	if limit := w.Config.MaxParticles; limit > 0 && len(w.particles) >= limit {
+		evictOldestParticle(w)
+	}
 	e := w.Spawn()
 
-	angle := rand.Float64() * 2 * math.Pi
-	speed := 1.0 + rand.Float64()*3.0
-	life := 20 + rand.Intn(20)
+	angle := w.rng.Float64() * 2 * math.Pi
+	speed := 1.0 + w.rng.Float64()*3.0
+	life := 20 + w.rng.Intn(20)
 
 	w.positions[e] = &Position{X: x, Y: y}
 	w.velocities[e] = &Velocity{
@@ -236,7 +314,7 @@ func SpawnParticle(w *World, x, y float64) Entity {
 
 	w.renderables[e] = &Renderable{
 		Kind:  ShapeCircle,
-		Color: color.RGBA{255, 200, 50, 255},
+		Color: w.Palette.Particle,
 		Scale: 1.5,
 	}
 
@@ -244,3 +322,28 @@ func SpawnParticle(w *World, x, y float64) Entity {
 
 	return e
 }
+
+// evictOldestParticle destroys the particle spawned first. Entity IDs only
+// grow, so that is the lowest one.
+func evictOldestParticle(w *World) {
+	var oldest Entity
+	for e := range w.particles {
+		if oldest == 0 || e < oldest {
+			oldest = e
+		}
+	}
+	if oldest != 0 {
+		w.Destroy(oldest)
+		w.ParticlesEvicted++
+	}
+}
+
+// asteroidRoom reports whether another asteroid fits under
+// Config.MaxAsteroids, counting it as skipped if not.
+func asteroidRoom(w *World) bool {
+	if limit := w.Config.MaxAsteroids; limit > 0 && len(w.asteroids) >= limit {
+		w.AsteroidsSkipped++
+		return false
+	}
+	return true
+}
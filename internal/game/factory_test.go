@@ -201,29 +201,16 @@ func TestSpawnAsteroid_PolygonVertices(t *testing.T) {
 }
 
 func TestSpawnAsteroid_SpeedScalesWithSize(t *testing.T) {
-	// Run multiple times to check that small asteroids are generally faster
-	var largeSpeeds, smallSpeeds []float64
-	for i := 0; i < 20; i++ {
-		w := NewWorld()
-		el := SpawnAsteroid(w, 100, 100, SizeLarge)
-		vl := w.velocities[el]
-		largeSpeeds = append(largeSpeeds, math.Sqrt(vl.X*vl.X+vl.Y*vl.Y))
-
-		es := SpawnAsteroid(w, 100, 100, SizeSmall)
-		vs := w.velocities[es]
-		smallSpeeds = append(smallSpeeds, math.Sqrt(vs.X*vs.X+vs.Y*vs.Y))
-	}
-
-	var avgLarge, avgSmall float64
-	for i := range largeSpeeds {
-		avgLarge += largeSpeeds[i]
-		avgSmall += smallSpeeds[i]
+	// Middle rolls give every size its base speed.
+	w := withRolls(NewWorld(), 0.5)
+	speed := func(size AsteroidSize) float64 {
+		v := w.velocities[SpawnAsteroid(w, 100, 100, size)]
+		return math.Hypot(v.X, v.Y)
 	}
-	avgLarge /= float64(len(largeSpeeds))
-	avgSmall /= float64(len(smallSpeeds))
 
-	if avgSmall <= avgLarge {
-		t.Errorf("small asteroids should be faster on average: large=%v, small=%v", avgLarge, avgSmall)
+	large, medium, small := speed(SizeLarge), speed(SizeMedium), speed(SizeSmall)
+	if !(large < medium && medium < small) {
+		t.Errorf("smaller asteroids should be faster: large=%v, medium=%v, small=%v", large, medium, small)
 	}
 }
 
@@ -369,9 +356,9 @@ func TestSpawnSaucer_SmallRadius(t *testing.T) {
 }
 
 func TestSpawnSaucer_EdgeSpawn(t *testing.T) {
-	// Run multiple times to verify spawns are at edges
-	for i := 0; i < 20; i++ {
-		w := NewWorld()
+	// The first roll picks the edge.
+	for _, roll := range []float64{0.25, 0.75} {
+		w := withRolls(NewWorld(), roll, 0.5)
 		e := SpawnSaucer(w, SaucerLarge)
 		pos := w.positions[e]
 		col := w.colliders[e]
@@ -379,14 +366,14 @@ func TestSpawnSaucer_EdgeSpawn(t *testing.T) {
 		atLeft := pos.X == -col.Radius
 		atRight := pos.X == ScreenWidth+col.Radius
 		if !atLeft && !atRight {
-			t.Errorf("saucer should spawn at edge, got X=%v", pos.X)
+			t.Errorf("roll %v: saucer should spawn at edge, got X=%v", roll, pos.X)
 		}
 	}
 }
 
 func TestSpawnSaucer_VelocityMatchesDirection(t *testing.T) {
-	for i := 0; i < 20; i++ {
-		w := NewWorld()
+	for _, roll := range []float64{0.25, 0.75} {
+		w := withRolls(NewWorld(), roll, 0.5)
 		e := SpawnSaucer(w, SaucerLarge)
 		vel := w.velocities[e]
 		st := w.saucers[e]
@@ -416,6 +403,48 @@ func TestSpawnSaucer_PolygonRenderable(t *testing.T) {
 	}
 }
 
+func TestSpawnSaucer_KeepsClearOfPlayerRow(t *testing.T) {
+	for seed := int64(0); seed < 200; seed++ {
+		w := NewWorldWithSeed(seed)
+		SpawnPlayer(w, ScreenWidth/2, ScreenHeight/2)
+		e := SpawnSaucer(w, SaucerSmall)
+
+		y := w.positions[e].Y
+		if math.Abs(y-ScreenHeight/2) < saucerClearance {
+			t.Fatalf("seed %d: saucer entered at y=%v, within %v of the player", seed, y, saucerClearance)
+		}
+		if y < ScreenHeight*0.2 || y > ScreenHeight*0.8 {
+			t.Fatalf("seed %d: saucer entered outside the middle band at y=%v", seed, y)
+		}
+	}
+}
+
+func TestSaucerEntryY_SkipsEveryShip(t *testing.T) {
+	w := NewCoopWorld(1)
+	w.positions[w.Player].Y = 200
+	for _, e := range sortedEntities(w.players) {
+		if e != w.Player {
+			w.positions[e].Y = 400
+		}
+	}
+	for u := 0.0; u < 1; u += 0.01 {
+		y := saucerEntryY(w, u)
+		if math.Abs(y-200) < saucerClearance || math.Abs(y-400) < saucerClearance {
+			t.Fatalf("u=%v: y=%v is too close to a ship", u, y)
+		}
+	}
+}
+
+func TestSaucerEntryY_NoRoomFallsBack(t *testing.T) {
+	w := NewWorld()
+	w.Config.SaucerClearance = ScreenHeight
+	SpawnPlayer(w, 100, ScreenHeight/2)
+
+	if y := saucerEntryY(w, 0.5); y != ScreenHeight/2 {
+		t.Errorf("with no free rows the whole band should be used, got y=%v", y)
+	}
+}
+
 // --------------- SpawnSaucerBullet ---------------
 
 func TestSpawnSaucerBullet_HasAllComponents(t *testing.T) {
@@ -475,6 +504,7 @@ func TestSpawnSaucerBullet_SpeedMagnitude(t *testing.T) {
 
 func TestSpawnSaucerBullet_SmallSaucerAimsAtPlayer(t *testing.T) {
 	w := NewWorld()
+	w.Score = saucerHardScore // from here on small saucers shoot dead on
 	saucer := SpawnSaucer(w, SaucerSmall)
 	// Force saucer position to known location
 	w.positions[saucer] = &Position{X: 100, Y: 100}
@@ -560,3 +590,51 @@ func TestSpawnParticle_HasParticleTag(t *testing.T) {
 		t.Errorf("initial Life (%d) should equal MaxLife (%d)", pt.Life, pt.MaxLife)
 	}
 }
+
+func TestSpawnParticle_EvictsOldestAtCap(t *testing.T) {
+	w := NewWorld()
+	w.Config.MaxParticles = 3
+	first := SpawnParticle(w, 0, 0)
+	SpawnParticle(w, 0, 0)
+	SpawnParticle(w, 0, 0)
+
+	SpawnParticle(w, 0, 0)
+
+	if len(w.particles) != 3 {
+		t.Errorf("expected 3 particles at the cap, got %d", len(w.particles))
+	}
+	if w.Alive(first) {
+		t.Error("the oldest particle should have been evicted")
+	}
+	if w.ParticlesEvicted != 1 {
+		t.Errorf("expected 1 eviction counted, got %d", w.ParticlesEvicted)
+	}
+}
+
+func TestShootAsteroid_FragmentsRespectCap(t *testing.T) {
+	w := NewWorld()
+	w.Config.MaxAsteroids = 2
+	big := SpawnAsteroid(w, 100, 100, SizeLarge)
+	SpawnAsteroid(w, 300, 300, SizeLarge)
+
+	shootAsteroid(w, big, 0, 0)
+
+	if w.AsteroidCount() != 2 {
+		t.Errorf("expected the cap of 2 asteroids, got %d", w.AsteroidCount())
+	}
+	if w.AsteroidsSkipped != 1 {
+		t.Errorf("expected 1 fragment skipped, got %d", w.AsteroidsSkipped)
+	}
+}
+
+func TestSpawnWave_RespectsCap(t *testing.T) {
+	w := NewWorld()
+	w.Level = 5
+	w.Config.MaxAsteroids = 4
+
+	spawnWave(w)
+
+	if w.AsteroidCount() != 4 {
+		t.Errorf("expected the wave cut to 4 asteroids, got %d", w.AsteroidCount())
+	}
+}
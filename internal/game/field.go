@@ -0,0 +1,72 @@
+package game
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/matheus3301/asteroids/internal/canvas"
+)
+
+// FitField is the smallest playfield that is at least ScreenWidth x
+// ScreenHeight and has the shape of a width x height window, so the game
+// fills an ultrawide or portrait window instead of being letterboxed.
+func FitField(width, height int) (w, h float64) {
+	if width <= 0 || height <= 0 {
+		return ScreenWidth, ScreenHeight
+	}
+	aspect := float64(width) / float64(height)
+	if aspect >= float64(ScreenWidth)/ScreenHeight {
+		return math.Round(ScreenHeight * aspect), ScreenHeight
+	}
+	return ScreenWidth, math.Round(ScreenWidth / aspect)
+}
+
+// ParseField reads a playfield size: "" for the standard one, "fit" for
+// FitField of a width x height window, or explicit sizes such as
+// "1280x600".
+func ParseField(s string, width, height int) (w, h float64, err error) {
+	switch s {
+	case "":
+		return ScreenWidth, ScreenHeight, nil
+	case "fit":
+		w, h = FitField(width, height)
+	default:
+		if _, err := fmt.Sscanf(s, "%gx%g", &w, &h); err != nil {
+			return 0, 0, fmt.Errorf("playfield %q: want WIDTHxHEIGHT or fit", s)
+		}
+	}
+	if w < ScreenWidth || w > maxFieldSize || h < ScreenHeight || h > maxFieldSize {
+		return 0, 0, fmt.Errorf("playfield %gx%g: must be from %dx%d to %dx%d", w, h, ScreenWidth, ScreenHeight, maxFieldSize, maxFieldSize)
+	}
+	return w, h, nil
+}
+
+// standardField reports whether the game's playfield is the standard size.
+func (g *Game) standardField() bool {
+	return g.fieldWidth == ScreenWidth && g.fieldHeight == ScreenHeight
+}
+
+// withField sets the playfield of r to the game's.
+func (g *Game) withField(r Ruleset) Ruleset {
+	r.Config.FieldWidth, r.Config.FieldHeight = g.fieldWidth, g.fieldHeight
+	return r
+}
+
+// centered returns screen moved so that a w x h area is centred in the
+// game's playfield. Menus are laid out for ScreenWidth x ScreenHeight and
+// drawn centred; worlds smaller than the playfield, such as replays of
+// standard games, are too.
+func (g *Game) centered(screen canvas.Canvas, w, h float64) canvas.Canvas {
+	return canvas.Offset(screen, (g.fieldWidth-w)/2, (g.fieldHeight-h)/2)
+}
+
+// menuCanvas is screen with the ScreenWidth x ScreenHeight menu area
+// centred.
+func (g *Game) menuCanvas(screen canvas.Canvas) canvas.Canvas {
+	return g.centered(screen, ScreenWidth, ScreenHeight)
+}
+
+// worldCanvas is screen with w's playfield centred.
+func (g *Game) worldCanvas(screen canvas.Canvas, w *World) canvas.Canvas {
+	return g.centered(screen, w.Config.FieldWidth, w.Config.FieldHeight)
+}
@@ -0,0 +1,103 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/matheus3301/asteroids/internal/canvas"
+)
+
+func TestFitField(t *testing.T) {
+	cases := []struct {
+		width, height int
+		w, h          float64
+	}{
+		{800, 600, 800, 600},
+		{2560, 1080, 1422, 600},
+		{1080, 1920, 800, 1422},
+		{0, 0, 800, 600},
+	}
+	for _, c := range cases {
+		if w, h := FitField(c.width, c.height); w != c.w || h != c.h {
+			t.Errorf("FitField(%d, %d) = %vx%v, want %vx%v", c.width, c.height, w, h, c.w, c.h)
+		}
+	}
+}
+
+func TestParseField(t *testing.T) {
+	if w, h, err := ParseField("1280x600", 0, 0); err != nil || w != 1280 || h != 600 {
+		t.Errorf("got %vx%v, %v", w, h, err)
+	}
+	if w, h, err := ParseField("fit", 2560, 1080); err != nil || w != 1422 || h != 600 {
+		t.Errorf("fit got %vx%v, %v", w, h, err)
+	}
+	for _, bad := range []string{"wide", "640x480", "5000x600"} {
+		if _, _, err := ParseField(bad, 800, 600); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
+
+// wideWorld is an empty world on a 1600x600 playfield.
+func wideWorld() *World {
+	w := NewWorldWithSeed(1)
+	w.Config.FieldWidth = 1600
+	return w
+}
+
+func TestWideField_WrapsAtItsEdges(t *testing.T) {
+	w := wideWorld()
+	a := SpawnAsteroid(w, 1610, 300, SizeSmall)
+	b := SpawnAsteroid(w, 1000, 300, SizeSmall)
+	WrapSystem(w)
+	if x := w.positions[a].X; x != 10 {
+		t.Errorf("expected the asteroid to wrap to x 10, got %v", x)
+	}
+	if w.positions[b].X != 1000 {
+		t.Error("an asteroid past the standard width should stay put on a wide field")
+	}
+	if d := w.Field().Distance(10, 300, 1590, 300); d != 20 {
+		t.Errorf("distance across the edge %v, want 20", d)
+	}
+}
+
+func TestWideField_GameAndObservation(t *testing.T) {
+	r := StandardRules()
+	r.Config.FieldWidth = 1600
+	w := NewModdedWorld(1, r)
+	obs := Observe(w)
+	if obs.Width != 1600 || obs.Player.X != 800 {
+		t.Errorf("expected the ship in the middle of a 1600 wide field, got x %v of %v", obs.Player.X, obs.Width)
+	}
+	far := false
+	for _, a := range obs.Asteroids {
+		far = far || a.X > ScreenWidth
+	}
+	if !far {
+		t.Error("expected asteroids to spawn across the whole field")
+	}
+}
+
+func TestGame_WideFieldLayout(t *testing.T) {
+	g := NewWithOptions(Options{FieldWidth: 1600})
+	if w, h := g.Layout(0, 0); w != 1600 || h != 600 {
+		t.Errorf("layout %dx%d, want the playfield", w, h)
+	}
+	g.reset()
+	if g.world.Config.FieldWidth != 1600 || g.countsForCareer || g.recorder != nil {
+		t.Error("a wide game should use the field and neither count nor be recorded")
+	}
+
+	// The menu is centred on the wider screen.
+	g.state = stateMenu
+	var rec canvas.Recording
+	g.draw(&rec)
+	minX := 1600.0
+	for _, op := range rec.Ops {
+		if op.Kind == "line" {
+			minX = min(minX, op.Args[0], op.Args[2])
+		}
+	}
+	if minX < 400 {
+		t.Errorf("menu drawn from x %v, expected it shifted 400px right", minX)
+	}
+}
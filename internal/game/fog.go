@@ -0,0 +1,61 @@
+package game
+
+import "image/color"
+
+// fogFade is how far inside Config.FogRadius objects start to fade out.
+const fogFade = 60.0
+
+// fogAlpha is how visible something at (x, y) is through the fog around
+// the ship at ship: 1 within FogRadius-fogFade, falling to 0 at FogRadius.
+// Without fog, or without a ship to see from, everything is visible.
+func fogAlpha(w *World, ship *Position, x, y float64) float64 {
+	r := w.Config.FogRadius
+	if r <= 0 || ship == nil {
+		return 1
+	}
+	d := w.Field().Distance(ship.X, ship.Y, x, y)
+	return min(max((r-d)/min(fogFade, r), 0), 1)
+}
+
+// fade dims clr by a, from 1 (unchanged) to 0 (invisible).
+func fade(clr color.RGBA, a float64) color.RGBA {
+	if a >= 1 {
+		return clr
+	}
+	return color.RGBA{uint8(float64(clr.R) * a), uint8(float64(clr.G) * a), uint8(float64(clr.B) * a), uint8(float64(clr.A) * a)}
+}
+
+// ObserveVisible is Observe limited to what a player can see through the
+// fog: objects fully hidden by it are left out, so agents playing a foggy
+// run have no more to go on than a human. Without fog it is Observe.
+func ObserveVisible(w *World) Observation {
+	obs := Observe(w)
+	if w.Config.FogRadius <= 0 || obs.Player == nil {
+		return obs
+	}
+	return obs.Within(w.Config.FogRadius)
+}
+
+// Within returns obs without the objects further than radius from the
+// ship. The ship itself is always kept.
+func (obs Observation) Within(radius float64) Observation {
+	if obs.Player == nil {
+		return obs
+	}
+	keep := func(list []ObjectObservation) []ObjectObservation {
+		var out []ObjectObservation
+		for _, o := range list {
+			if o.DX*o.DX+o.DY*o.DY < radius*radius {
+				out = append(out, o)
+			}
+		}
+		return out
+	}
+	obs.Bullets = keep(obs.Bullets)
+	obs.Missiles = keep(obs.Missiles)
+	obs.Pickups = keep(obs.Pickups)
+	obs.Asteroids = keep(obs.Asteroids)
+	obs.Saucers = keep(obs.Saucers)
+	obs.SaucerBullets = keep(obs.SaucerBullets)
+	return obs
+}
@@ -0,0 +1,69 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/matheus3301/asteroids/internal/canvas"
+)
+
+func TestFogAlpha_FadesTowardsTheEdge(t *testing.T) {
+	w := NewWorldWithSeed(1)
+	ship := &Position{X: 400, Y: 300}
+	if fogAlpha(w, ship, 0, 0) != 1 {
+		t.Error("without fog everything should be visible")
+	}
+	w.Config.FogRadius = 180
+	for _, tc := range []struct{ x, want float64 }{
+		{500, 1}, // well inside
+		{400 + 150, 0.5},
+		{400 + 180, 0}, // at the edge
+		{790, 0},
+	} {
+		if got := fogAlpha(w, ship, tc.x, 300); got != tc.want {
+			t.Errorf("alpha at %vpx = %v, want %v", tc.x-400, got, tc.want)
+		}
+	}
+	// Distances are measured across the screen edges.
+	if fogAlpha(w, &Position{X: 10, Y: 300}, ScreenWidth-10, 300) != 1 {
+		t.Error("an object just across the edge should be visible")
+	}
+}
+
+func TestRenderSystem_FogDims(t *testing.T) {
+	w := NewWorldWithSeed(1)
+	w.Config.FogRadius = 180
+	w.Player = SpawnPlayer(w, 400, 300)
+	a := SpawnAsteroid(w, 550, 300, SizeSmall)
+
+	var rec canvas.Recording
+	RenderSystem(w, &rec, 1)
+	want := fade(w.renderables[a].Color, 0.5)
+	dimmed := 0
+	for _, op := range rec.Ops {
+		if op.Color == want {
+			dimmed++
+		}
+	}
+	if dimmed == 0 || want.A != 127 {
+		t.Errorf("expected the asteroid drawn at half brightness %v", want)
+	}
+}
+
+func TestObserveVisible(t *testing.T) {
+	w := NewWorldWithSeed(1)
+	w.Player = SpawnPlayer(w, 400, 300)
+	SpawnAsteroid(w, 450, 300, SizeSmall)
+	SpawnAsteroid(w, 700, 300, SizeSmall)
+
+	if got := len(ObserveVisible(w).Asteroids); got != 2 {
+		t.Errorf("without fog every asteroid should be observed, got %d", got)
+	}
+	w.Config.FogRadius = 180
+	obs := ObserveVisible(w)
+	if len(obs.Asteroids) != 1 || obs.Asteroids[0].DX != 50 {
+		t.Errorf("expected only the near asteroid, got %+v", obs.Asteroids)
+	}
+	if obs.Player == nil {
+		t.Error("the ship should always be observed")
+	}
+}
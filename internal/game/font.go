@@ -3,8 +3,7 @@ package game
 import (
 	"image/color"
 
-	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/matheus3301/asteroids/internal/canvas"
 )
 
 // Each glyph is defined as line segments in a 5×7 grid.
@@ -167,11 +166,32 @@ var fontGlyphs = map[rune][][4]float64{
 	'-': {
 		{1, 3.5, 4, 3.5},
 	},
+	'+': {
+		{1, 3.5, 4, 3.5}, {2.5, 2, 2.5, 5},
+	},
+	'/': {
+		{0, 7, 5, 0},
+	},
+	',': {
+		{2, 6, 3, 6}, {3, 6, 3, 7}, {3, 7, 2, 8},
+	},
+	'[': {
+		{4, 0, 1.5, 0}, {1.5, 0, 1.5, 7}, {1.5, 7, 4, 7},
+	},
+	']': {
+		{1, 0, 3.5, 0}, {3.5, 0, 3.5, 7}, {3.5, 7, 1, 7},
+	},
+	'<': {
+		{4, 0.5, 1, 3.5}, {1, 3.5, 4, 6.5},
+	},
+	'>': {
+		{1, 0.5, 4, 3.5}, {4, 3.5, 1, 6.5},
+	},
 	' ': {},
 }
 
 // DrawText renders text using vector line segments.
-func DrawText(screen *ebiten.Image, text string, x, y, scale float64, clr color.RGBA) {
+func DrawText(screen canvas.Canvas, text string, x, y, scale float64, clr color.RGBA) {
 	cx := x
 	for _, ch := range text {
 		if ch >= 'a' && ch <= 'z' {
@@ -187,11 +207,11 @@ func DrawText(screen *ebiten.Image, text string, x, y, scale float64, clr color.
 			y1 := y + s[1]*scale
 			x2 := cx + s[2]*scale
 			y2 := y + s[3]*scale
-			w := float32(1.5)
+			w := 1.5
 			if scale > 4 {
-				w = float32(scale * 0.4)
+				w = scale * 0.4
 			}
-			vector.StrokeLine(screen, float32(x1), float32(y1), float32(x2), float32(y2), w, clr, false)
+			screen.StrokeLine(x1, y1, x2, y2, w, clr)
 		}
 		cx += 6 * scale
 	}
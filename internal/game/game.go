@@ -4,28 +4,30 @@ import (
 	"fmt"
 	"image/color"
 	"math"
-	"math/rand"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/matheus3301/asteroids/internal/canvas"
+	"github.com/matheus3301/asteroids/internal/leaderboard"
+	"github.com/matheus3301/asteroids/internal/logging"
+	"github.com/matheus3301/asteroids/internal/profile"
+	"github.com/matheus3301/asteroids/internal/telemetry"
+	"github.com/matheus3301/asteroids/internal/tuning"
 )
 
+var logger = logging.For("game")
+
 const (
 	ScreenWidth  = 800
 	ScreenHeight = 600
 
 	saucerInitialDelay = 600
 	saucerRespawnDelay = 600
-
-	hudIconScale = 0.52
 )
 
-var shipIconVerts = [][2]float64{
-	{playerRadius * hudIconScale, 0},
-	{-playerRadius * 0.8 * hudIconScale, -playerRadius * 0.6 * hudIconScale},
-	{-playerRadius * 0.8 * hudIconScale, playerRadius * 0.6 * hudIconScale},
-}
-
 type state int
 
 const (
@@ -34,26 +36,332 @@ const (
 	statePlaying
 	statePaused
 	stateGameOver
+	stateReplay
+	stateCoop
+	stateStats
+	stateMods
+	stateCareer
+	stateShipSelect
+	stateStress
+	stateDrills
+	stateAccessibility
+	stateControls
+	stateAttract
 )
 
+func (s state) String() string {
+	switch s {
+	case stateMenu:
+		return "menu"
+	case stateSettings:
+		return "settings"
+	case statePlaying:
+		return "playing"
+	case statePaused:
+		return "paused"
+	case stateGameOver:
+		return "gameover"
+	case stateReplay:
+		return "replay"
+	case stateCoop:
+		return "coop"
+	case stateStats:
+		return "stats"
+	case stateMods:
+		return "mods"
+	case stateCareer:
+		return "career"
+	case stateShipSelect:
+		return "shipselect"
+	case stateStress:
+		return "stress"
+	case stateDrills:
+		return "drills"
+	case stateAccessibility:
+		return "accessibility"
+	case stateControls:
+		return "controls"
+	case stateAttract:
+		return "attract"
+	}
+	return "unknown"
+}
+
 // Game implements ebiten.Game and orchestrates the ECS world.
 type Game struct {
 	world *World
 	state state
 	sound *SoundManager
 
-	menuCursor     int
-	settingsCursor int
-	pauseCursor    int
-	settings       settings
-	quit           bool
+	menuCursor          int
+	settingsCursor      int
+	accessibilityCursor int
+	controls            controlsPage
+	pauseCursor         int
+	shipType            int
+	settings            settings
+	hud                 HUDLayout
+	quit                bool
+
+	recorder   *ReplayRecorder
+	lastReplay *Replay
+	replay     *replayViewer
+	stress     *stressScene
+
+	seed      int64
+	input     InputSource
+	autoStart bool
+	restartIn int
+	practice  bool
+	// debug shows the entity counters; F3 toggles it during play.
+	debug bool
+	// collisionDebug slows play to 10 ticks a second and draws colliders
+	// and contacts; F5 toggles it during play.
+	collisionDebug bool
+	// timings times each system during play, once the debug overlay has
+	// been shown or with Options.SystemTimings; nil otherwise.
+	timings *SystemTimings
+	// unfocused is set while the window is in the background.
+	unfocused bool
+	// stepAccum is the fraction of a tick owed at the current game speed.
+	stepAccum float64
+	// keys are the keyboard player's bindings, kept in the profile.
+	keys KeyBindings
+	// buttons latches the keyboard's one-shot buttons frame by frame;
+	// readButtons polls them and is replaced in tests.
+	buttons     buttonLatch
+	readButtons func() InputState
+	// watchdog reduces effects when frames run over budget; updateTime
+	// is how long the last Update took, counted towards the frame.
+	watchdog   frameWatchdog
+	updateTime time.Duration
+	// clock seeds unseeded games and times frames.
+	clock Clock
+	// dev applies the -dev tuning file; nil without one.
+	dev *devTuning
+
+	scriptRules RuleHooks
+	modCatalog  ModCatalog
+	modContent  ModContent
+	mods        modsScreen
+
+	drills     drillScreen
+	attract    attractReel
+	tutorial   *tutorial // the tutorial being played, or nil
+	coop       coopScreen
+	netFactory NetFactory
+	net        NetSession
+
+	telemetry *telemetry.Summary
+	// leaderboards rank standard runs by time, wave and accuracy, whether
+	// or not telemetry is on.
+	leaderboards *leaderboard.Boards
+	// statsPage is the stats screen page shown, and deathMapCause the
+	// cause the death map shows, or NumDeathCauses for all of them.
+	statsPage     int
+	deathMapCause DeathCause
+
+	presence     []Presence
+	lastPresence Status
+	presenceSent bool
+
+	profile         *profile.Profile
+	careerCursor    int
+	countsForCareer bool
+	newUnlocks      []Unlock
+	// mutators are chosen on the ship screen for the next run and
+	// runMutators are those of the current run; newBest is set when the
+	// run beat the best under them.
+	mutators    MutatorSet
+	runMutators MutatorSet
+	newBest     bool
+	// difficulty is chosen in settings for the next run and
+	// runDifficulty is that of the current run.
+	difficulty    Difficulty
+	runDifficulty Difficulty
+	// mode is chosen in the main menu for the next run and runMode is
+	// that of the current run; survivalIn counts down to the next
+	// survival asteroid.
+	mode       GameMode
+	runMode    GameMode
+	survivalIn int
+	// assisted is set once the current game has run with aim assist on; it
+	// then no longer counts toward the career.
+	assisted bool
+	// fieldWidth and fieldHeight are the playfield of new games and the
+	// size of the screen.
+	fieldWidth, fieldHeight float64
+}
+
+// Options configures a Game at startup.
+type Options struct {
+	// Seed fixes the RNG seed of every game started from the menu.
+	// Zero picks a fresh seed per game.
+	Seed int64
+	// Width and Height select the matching resolution preset in settings.
+	Width, Height int
+	Fullscreen    bool
+	Mute          bool
+	// Input drives the player ship. Nil means the keyboard.
+	Input InputSource
+	// AutoStart skips the menu and starts a new game straight away, and
+	// again shortly after every game over. Useful for unattended agents.
+	AutoStart bool
+	// Net opens sessions for the co-op screen. Nil disables co-op.
+	Net NetFactory
+	// Telemetry opts in to local balance statistics, as if turned on in
+	// settings. The choice is remembered.
+	Telemetry bool
+	// Rules installs mod hooks in every single-player game, after those of
+	// any enabled mod packs. Such games are not recorded as replays.
+	Rules RuleHooks
+	// Mods lists mod packs for the MODS screen. Nil disables mod packs.
+	Mods ModCatalog
+	// Autofire fires while Space is held, as if turned on in settings.
+	Autofire bool
+	// Practice draws the spawn safe radius, the next wave's spawn points
+	// and predicted trajectories over single-player games, for tuning wave
+	// placement and checking threat detection.
+	Practice bool
+	// Speed scales the single-player game speed, from MinGameSpeed to
+	// MaxGameSpeed. Zero means normal speed.
+	Speed float64
+	// NoAutoPause keeps games running when the window loses focus, for
+	// streaming setups where another window has the focus.
+	NoAutoPause bool
+	// HUD places the in-game HUD.
+	HUD HUDLayout
+	// Mutators are chosen for every run, as if picked on the ship screen.
+	Mutators MutatorSet
+	// Difficulty is the ID of the difficulty for every run, as if chosen
+	// in settings but not remembered. Empty keeps the player's choice.
+	Difficulty string
+	// Mode is the ID of the game mode the menu starts on, as if chosen
+	// there. Empty means classic.
+	Mode string
+	// AimGuide shows the lead angle and threat urgency agents observe, as
+	// if turned on in settings.
+	AimGuide bool
+	// AimAssist turns the keyboard player's ship onto the lead angle when
+	// it is nearly there, from 0 (off) to MaxAimAssist, as if set in
+	// settings. Assisted games do not count toward the career.
+	AimAssist int
+	// Presence lists hooks told when the state, score, lives or level
+	// change, such as the window title or a rich-presence plugin.
+	Presence []Presence
+	// Stress opens a profiling scene with this many asteroids instead of
+	// the menu. Zero disables it.
+	Stress int
+	// FieldWidth and FieldHeight enlarge the playfield past ScreenWidth x
+	// ScreenHeight, for ultrawide or portrait windows; see FitField. Zero
+	// keeps the standard size. Games on other sizes do not count toward
+	// the career and are not recorded.
+	FieldWidth, FieldHeight float64
+	// SystemTimings times each system during play from the start, for
+	// SystemTimings to report, instead of only once the debug overlay is
+	// opened.
+	SystemTimings bool
+	// Clock, when set, replaces the wall clock for seeding unseeded games
+	// and timing frames.
+	Clock Clock
+	// CollisionDebug starts with the collision debug view on; F5 toggles
+	// it during play.
+	CollisionDebug bool
+	// DevTuning, when set, is a TOML file of GameConfig values that is
+	// watched and applied to new games and the one in play as it changes.
+	// Tuned games do not count toward the career and are not recorded.
+	DevTuning string
 }
 
+// autoRestartDelay is how long the game-over screen stays up with AutoStart.
+const autoRestartDelay = 120
+
 func New() *Game {
+	return NewWithOptions(Options{})
+}
+
+// NewWithOptions creates a Game configured by opts.
+func NewWithOptions(opts Options) *Game {
 	g := &Game{
-		state: stateMenu,
+		state:     stateMenu,
+		seed:      opts.Seed,
+		input:     opts.Input,
+		autoStart: opts.AutoStart,
+		practice:  opts.Practice,
+		hud:       opts.HUD,
+		mutators:  opts.Mutators,
+
+		collisionDebug: opts.CollisionDebug,
+
+		scriptRules: opts.Rules,
+		modCatalog:  opts.Mods,
+
+		netFactory:   opts.Net,
+		presence:     opts.Presence,
+		clock:        opts.Clock,
+		telemetry:    loadTelemetry(),
+		leaderboards: loadLeaderboards(),
+		profile:      loadProfile(),
+	}
+	if g.clock == nil {
+		g.clock = wallClock{}
+	}
+	g.keys = KeyBindingsFromNames(g.profile.Keys)
+	g.difficulty = difficultyFromID(g.profile.Difficulty)
+	if opts.Difficulty != "" {
+		g.difficulty = difficultyFromID(opts.Difficulty)
+	}
+	if opts.Mode != "" {
+		m, err := ParseGameMode(opts.Mode)
+		if err != nil {
+			logger.Warn("mode not set", "err", err)
+		}
+		g.mode = m
+	}
+	g.readButtons = g.keys.heldButtons
+	if opts.Telemetry && !g.telemetry.Enabled {
+		g.setTelemetry(true)
+	}
+	if g.input == nil {
+		g.input = KeyboardInput{buttons: &g.buttons, keys: &g.keys}
 	}
 	g.settings.volume = 10
+	if opts.Mute {
+		g.settings.volume = 0
+	}
+	g.settings.fullscreen = opts.Fullscreen
+	g.setAutofire(opts.Autofire)
+	g.settings.autoPause = !opts.NoAutoPause
+	g.settings.aimGuide = opts.AimGuide
+	g.setAimAssist(opts.AimAssist)
+	g.setSpeed(1)
+	if opts.Speed != 0 {
+		g.setSpeed(opts.Speed)
+	}
+	if i := resolutionIndexFor(opts.Width, opts.Height); i >= 0 {
+		g.settings.resolutionIndex = i
+	}
+	g.fieldWidth, g.fieldHeight = ScreenWidth, ScreenHeight
+	if opts.FieldWidth > 0 {
+		g.fieldWidth = opts.FieldWidth
+	}
+	if opts.FieldHeight > 0 {
+		g.fieldHeight = opts.FieldHeight
+	}
+	if opts.SystemTimings {
+		g.timings = NewSystemTimings()
+	}
+	if opts.DevTuning != "" {
+		g.dev = &devTuning{watcher: tuning.NewWatcher(opts.DevTuning)}
+		g.pollTuning()
+	}
+	g.applyMods()
+	if g.autoStart {
+		g.reset()
+	}
+	if opts.Stress > 0 {
+		g.openStress(opts.Stress)
+	}
 	return g
 }
 
@@ -61,30 +369,112 @@ func (g *Game) ensureSound() {
 	if g.sound == nil {
 		g.sound = NewSoundManager()
 		g.sound.SetMasterVolume(float64(g.settings.volume) / 10.0)
+		g.sound.SetOverrides(g.modContent.Sounds)
+	}
+}
+
+// newSeed is the seed for a new game: the fixed one if set, otherwise
+// the time.
+func (g *Game) newSeed() int64 {
+	if g.seed != 0 {
+		return g.seed
 	}
+	return g.clock.Now().UnixNano()
+}
+
+// runRules is the ruleset of a run on rules, flying ship under mutators s,
+// difficulty d and mode m, each applied in turn, and on the game's
+// playfield.
+func (g *Game) runRules(rules Ruleset, ship int, s MutatorSet, d Difficulty, m GameMode) Ruleset {
+	return g.withField(applyMode(applyDifficulty(applyMutators(applyCosmetics(applyShipType(rules, ship), g.profile), s), d), m))
 }
 
 func (g *Game) reset() {
+	g.closeNet()
 	g.ensureSound()
 	g.sound.Reset()
 	g.sound.SetMasterVolume(float64(g.settings.volume) / 10.0)
-	g.world = NewWorld()
+	seed := g.newSeed()
+	rules := g.rules()
+	g.world = NewModdedWorld(seed, g.runRules(rules, g.shipType, g.mutators, g.difficulty, g.mode))
 	g.state = statePlaying
-	w := g.world
-	w.Score = 0
-	w.Lives = 3
-	w.NextExtraLifeAt = 10_000
-	w.Level = 1
-	w.SaucerSpawnTimer = saucerInitialDelay
-	w.SaucerActive = 0
-	w.Player = SpawnPlayer(w, ScreenWidth/2, ScreenHeight/2)
-	spawnWave(w)
+	g.stepAccum = 0
+	g.buttons.Clear()
+	g.recorder = nil
+	g.newUnlocks = nil
+	g.drills.active = nil
+	g.tutorial = nil
+	// Modded games neither count toward the career nor get recorded:
+	// replays only hold seed, ship and inputs, so a game played under a
+	// mod could not be reproduced without it.
+	g.countsForCareer = rules.Standard() && g.standardField()
+	g.runMutators = g.mutators
+	g.runDifficulty = g.difficulty
+	g.runMode = g.mode
+	g.survivalIn = survivalInterval(0)
+	g.assisted = false
+	// Replays do not know the mode, and survival's asteroids come from
+	// here rather than the world, so only classic runs are recorded.
+	if g.world.Config == ShipTypes[g.world.ShipType].Stats(DefaultConfig()) && g.world.Hooks == nil && g.mode == ModeClassic {
+		g.recorder = NewReplayRecorder(g.world)
+	}
+}
+
+// finishRecording stops the current recording and saves it to the replay
+// directory. Failing to save only loses the replay, so errors are logged.
+func (g *Game) finishRecording() {
+	if g.recorder == nil {
+		return
+	}
+	g.lastReplay = g.recorder.Finish(g.world)
+	g.recorder = nil
+
+	dir, err := ReplayDir()
+	if err != nil {
+		logger.Warn("replay not saved", "err", err)
+		return
+	}
+	name := time.Unix(g.lastReplay.RecordedAt, 0).Format("20060102-150405") + ".replay"
+	path := filepath.Join(dir, name)
+	if err := SaveReplay(path, g.lastReplay); err != nil {
+		logger.Warn("replay not saved", "path", path, "err", err)
+		return
+	}
+	logger.Debug("replay saved", "path", path, "ticks", g.lastReplay.Ticks)
+}
+
+// Status summarises the running game for external monitoring.
+type Status struct {
+	State    string
+	Score    int
+	Lives    int
+	Level    int
+	Tick     int
+	Entities int
+}
+
+// Status reports the current state and, once a game exists, its progress.
+func (g *Game) Status() Status {
+	st := Status{State: g.state.String()}
+	if w := g.world; w != nil {
+		st.Score, st.Lives, st.Level, st.Tick = w.Score, w.Lives, w.Level, w.Tick
+		st.Entities = len(w.entities)
+	}
+	return st
 }
 
 func (g *Game) Update() error {
+	start := g.clock.Now()
+	defer func() { g.updateTime = since(g.clock, start) }()
 	if g.quit {
 		return ebiten.Termination
 	}
+	g.pollTuning()
+	g.buttons.Frame(g.readButtons())
+	defer g.endFrame()
+	if g.updateFocus(ebiten.IsFocused()) {
+		return nil
+	}
 	switch g.state {
 	case stateMenu:
 		g.updateMenu()
@@ -95,111 +485,353 @@ func (g *Game) Update() error {
 	case statePaused:
 		g.updatePaused()
 	case stateGameOver:
+		if g.autoStart {
+			g.restartIn--
+			if g.restartIn <= 0 {
+				g.reset()
+			}
+			break
+		}
 		if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
 			g.sound.PlayConfirm()
+			g.closeNet()
 			g.state = stateMenu
 		}
+	case stateReplay:
+		g.updateReplay()
+	case stateCoop:
+		g.updateCoop()
+	case stateStats:
+		g.updateStats()
+	case stateMods:
+		g.updateMods()
+	case stateCareer:
+		g.updateCareer()
+	case stateShipSelect:
+		g.updateShipSelect()
+	case stateStress:
+		g.updateStress()
+	case stateDrills:
+		g.updateDrills()
+	case stateAccessibility:
+		g.updateAccessibility()
+	case stateControls:
+		g.updateControls()
+	case stateAttract:
+		g.updateAttract()
 	}
+	g.notifyPresence()
 	return nil
 }
 
+// endFrame forgets button presses latched on a frame that leaves the game
+// anywhere but playing. A press on the frame that resumes play is kept for
+// the first tick back; presses in menus and while paused are not.
+func (g *Game) endFrame() {
+	if g.state != statePlaying {
+		g.buttons.Clear()
+	}
+}
+
+// updateFocus pauses a keyboard game when the window loses focus, if auto
+// pause is on. It reports whether to skip this frame: the first one after
+// focus returns to a paused game, so the key or click that brought the
+// window back cannot resume it by accident. Co-op games and games played by
+// agents keep running.
+func (g *Game) updateFocus(focused bool) (skip bool) {
+	refocused := focused && g.unfocused
+	g.unfocused = !focused
+	if !focused && g.settings.autoPause && g.state == statePlaying && g.net == nil {
+		if _, ok := g.input.(KeyboardInput); ok {
+			g.pause()
+		}
+	}
+	return refocused && g.state == statePaused
+}
+
+// pause freezes the game and opens the pause menu.
+func (g *Game) pause() {
+	g.sound.PauseAll()
+	g.state = statePaused
+	g.pauseCursor = 0
+}
+
 func (g *Game) updatePlaying() {
-	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
-		g.sound.PauseAll()
-		g.state = statePaused
-		g.pauseCursor = 0
+	if g.keys.JustPressed(ActionPause) {
+		g.pause()
 		return
 	}
-	w := g.world
+	if inpututil.IsKeyJustPressed(ebiten.KeyF3) {
+		g.debug = !g.debug
+		if g.debug && g.timings == nil {
+			g.timings = NewSystemTimings()
+		}
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyF4) {
+		g.settings.aimGuide = !g.settings.aimGuide
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyF5) {
+		g.collisionDebug = !g.collisionDebug
+	}
+	g.world.RecordContacts = g.collisionDebug
+	if g.net != nil {
+		g.updateNetPlaying()
+		return
+	}
+	// The game speed decides how many ticks this frame runs: two per frame
+	// at 200%, one every other frame at 50%. Presses on a frame with no
+	// tick stay latched in g.buttons for the next one.
+	g.stepAccum += g.speed()
+	if g.stepAccum < 1 {
+		return
+	}
+	if kb, ok := g.input.(KeyboardInput); ok && kb.AimAssist > 0 {
+		g.assisted = true
+		g.countsForCareer = false
+	}
+	for g.stepAccum >= 1 && g.state == statePlaying {
+		g.stepAccum--
+		g.StepPlaying(g.input.NextInput(g.world))
+		g.skipHitStop()
+	}
+}
+
+// speed is how many ticks a frame runs: the game speed, or the collision
+// debug view's slow rate.
+func (g *Game) speed() float64 {
+	if g.collisionDebug {
+		return collisionDebugSpeed
+	}
+	return g.settings.speed
+}
+
+// playAlpha is how far a slowed game has got towards its next tick, so
+// entities glide between ticks instead of juddering.
+func (g *Game) playAlpha() float64 {
+	if g.net != nil || g.speed() >= 1 {
+		return 1
+	}
+	return g.stepAccum
+}
 
-	InputSystem(w)
-	PhysicsSystem(w)
-	WrapSystem(w)
-	InvulnerabilitySystem(w)
-	LifetimeSystem(w)
-	SaucerSpawnSystem(w)
-	SaucerAISystem(w)
-	SaucerBulletLifetimeSystem(w)
-	SaucerDespawnSystem(w)
-	HyperspaceSystem(w, rand.Float64())
-	ShootingSystem(w)
-	events := CollisionSystem(w)
-	CollisionResponseSystem(w, events)
-	WaveClearSystem(w)
+// StepPlaying advances a single-player game by exactly one tick with the
+// given input, as Update does each frame: the tick is recorded for the
+// replay, its sounds are played and a game over ends the game. It does
+// nothing outside a game.
+func (g *Game) StepPlaying(in InputState) {
+	if g.state != statePlaying || g.net != nil {
+		return
+	}
+	w := g.world
+	if g.recorder != nil {
+		g.recorder.Input(w, in)
+	}
+	StepTimed(w, in, g.timings)
+	if g.recorder != nil {
+		g.recorder.Check(w)
+	}
 
 	SoundSystem(g.sound, w)
 
-	if w.Lives <= 0 {
+	if g.drills.active != nil {
+		g.checkDrill()
+		return
+	}
+	if g.tutorial != nil {
+		g.checkTutorial()
+		return
+	}
+	g.stepMode()
+	if w.GameOver() || g.timeUp() {
 		g.sound.StopAll()
+		g.finishRecording()
+		g.recordTelemetry()
+		g.recordLeaderboards()
+		g.recordCareer()
 		g.state = stateGameOver
+		g.restartIn = autoRestartDelay
+	}
+}
+
+// drawInputOverlay shows the input source's overlay in the top-right corner.
+func (g *Game) drawInputOverlay(screen canvas.Canvas) {
+	o, ok := g.input.(InputOverlay)
+	if !ok {
+		return
+	}
+	lines := o.OverlayLines()
+	scale := 1.5
+	width := 0.0
+	for _, l := range lines {
+		width = math.Max(width, TextWidth(l, scale))
 	}
+	for i, l := range lines {
+		DrawText(screen, l, g.world.Config.FieldWidth-width-10, 10+float64(i)*16, scale, color.RGBA{255, 255, 0, 255})
+	}
+}
+
+// capLabel formats a count against its cap, where 0 means no cap.
+func capLabel(n, limit int) string {
+	if limit == 0 {
+		return fmt.Sprint(n)
+	}
+	return fmt.Sprintf("%d/%d", n, limit)
 }
 
-func (g *Game) drawHUD(screen *ebiten.Image) {
-	hudScale := 2.0
-	hudColor := color.RGBA{255, 255, 255, 255}
+// debugSystems is how many of the slowest systems the debug overlay lists.
+const debugSystems = 6
 
-	DrawText(screen, fmt.Sprintf("SCORE: %d", g.world.Score), 10, 10, hudScale, hudColor)
+// SystemTimings is the per-system timing of play so far, or nil when it is
+// not being collected; see Options.SystemTimings. It is updated by Update
+// and must not be read concurrently with it.
+func (g *Game) SystemTimings() *SystemTimings {
+	return g.timings
+}
 
-	// Lives as "LIVES:" label followed by ship icons, aligned with numbers
-	livesLabel := "LIVES: "
-	DrawText(screen, livesLabel, 10, 32, hudScale, hudColor)
-	iconWing := playerRadius * 0.6 * hudIconScale
-	iconStartX := 10.0 + TextWidth(livesLabel, hudScale) + iconWing
-	iconY := 32.0 + 7.0*hudScale/2 // vertically center with text
-	count := g.world.Lives - 1
-	if count < 0 {
-		count = 0
+// drawDebugOverlay shows entity counts against their caps, the average
+// frame time and, when timings are collected, the slowest systems, below
+// the HUD.
+func (g *Game) drawDebugOverlay(screen canvas.Canvas) {
+	if !g.debug {
+		return
 	}
-	for i := 0; i < count; i++ {
-		iconX := iconStartX + float64(i)*(iconWing*2+6)
-		drawPolygon(screen, &Position{X: iconX, Y: iconY}, -math.Pi/2, shipIconVerts, hudColor)
+	w := g.world
+	lines := []string{
+		fmt.Sprintf("ENTITIES %d", len(w.entities)),
+		"ASTEROIDS " + capLabel(len(w.asteroids), w.Config.MaxAsteroids),
+		"PARTICLES " + capLabel(len(w.particles), w.Config.MaxParticles),
+		fmt.Sprintf("SKIPPED %d  EVICTED %d", w.AsteroidsSkipped, w.ParticlesEvicted),
+		fmt.Sprintf("FRAME %.1fMS", g.watchdog.average().Seconds()*1000),
+	}
+	if g.watchdog.degraded {
+		lines = append(lines, "LOW DETAIL")
+	}
+	if t := g.timings; t != nil && t.Ticks > 0 {
+		lines = append(lines, "", "SYSTEMS NS/TICK")
+		for _, i := range t.Slowest(debugSystems) {
+			lines = append(lines, fmt.Sprintf("%-20s %.0f", strings.ToUpper(t.Names[i]), t.Recent[i]))
+		}
+	}
+	for i, l := range lines {
+		DrawText(screen, l, 10, 130+float64(i)*16, 1.5, color.RGBA{255, 255, 0, 255})
 	}
-
-	DrawText(screen, fmt.Sprintf("LEVEL: %d", g.world.Level), 10, 54, hudScale, hudColor)
 }
 
 func (g *Game) Draw(screen *ebiten.Image) {
+	start := g.clock.Now()
+	if g.world != nil {
+		g.world.LowDetail = g.watchdog.degraded
+		g.world.ReducedMotion = g.profile.ReducedMotion
+	}
+	g.draw(ebitenCanvas{screen})
+	g.watchdog.record(g.updateTime + since(g.clock, start))
+}
+
+// draw renders the current screen. It is separate from Draw so tests can
+// render to a software canvas.
+func (g *Game) draw(screen canvas.Canvas) {
 	screen.Fill(color.Black)
+	// Menus and messages are laid out for the standard screen and centred
+	// on the playfield; the world and HUD use all of it.
+	ui := g.menuCanvas(screen)
 
 	switch g.state {
 	case stateMenu:
-		g.drawMenu(screen)
+		g.drawMenu(ui)
 	case stateSettings:
-		g.drawSettings(screen)
+		g.drawSettings(ui)
 	case statePlaying:
-		RenderSystem(g.world, screen)
-		DrawThrust(g.world, screen)
-		DrawSaucerDetail(g.world, screen)
-		g.drawHUD(screen)
+		screen.Fill(g.world.Palette.Background)
+		field := g.worldCanvas(screen, g.world)
+		DrawWorldAt(g.world, field, g.playAlpha())
+		if g.practice && g.net == nil {
+			DrawTrajectories(g.world, field)
+			drawPracticeOverlay(g.world, field)
+		}
+		if g.settings.aimGuide && g.net == nil {
+			drawAimGuide(g.world, field)
+		}
+		if g.collisionDebug && g.net == nil {
+			drawCollisionDebug(g.world, field, g.playAlpha())
+		}
+		g.drawHUD(field)
+		g.drawDrillStatus(ui)
+		g.drawModeStatus(ui)
+		g.drawTutorial(ui, field)
+		g.drawNetStatus(ui)
+		g.drawInputOverlay(field)
+		g.drawDebugOverlay(field)
 	case statePaused:
 		g.drawPaused(screen)
+	case stateReplay:
+		g.drawReplay(g.worldCanvas(screen, g.replay.runner.World))
+	case stateCoop:
+		g.drawCoop(ui)
+	case stateStats:
+		g.drawStats(ui)
+	case stateMods:
+		g.drawMods(ui)
+	case stateCareer:
+		g.drawCareer(ui)
+	case stateShipSelect:
+		g.drawShipSelect(ui)
+	case stateStress:
+		g.drawStress(ui)
+	case stateDrills:
+		g.drawDrills(ui)
+	case stateAccessibility:
+		g.drawAccessibility(ui)
+	case stateControls:
+		g.drawControls(ui)
+	case stateAttract:
+		g.drawAttract(screen)
 	case stateGameOver:
-		RenderSystem(g.world, screen)
-		DrawThrust(g.world, screen)
-		DrawSaucerDetail(g.world, screen)
-		g.drawHUD(screen)
+		screen.Fill(g.world.Palette.Background)
+		field := g.worldCanvas(screen, g.world)
+		DrawWorldAt(g.world, field, g.playAlpha())
+		g.drawHUD(field)
 
 		titleScale := 5.0
 		titleText := "GAME OVER"
+		if g.timeUp() && !g.world.GameOver() {
+			titleText = "TIME UP"
+		}
 		titleW := TextWidth(titleText, titleScale)
 		titleX := (ScreenWidth - titleW) / 2
-		DrawText(screen, titleText, titleX, float64(ScreenHeight)/2-60, titleScale, color.RGBA{255, 0, 0, 255})
+		DrawText(ui, titleText, titleX, float64(ScreenHeight)/2-60, titleScale, color.RGBA{255, 0, 0, 255})
 
 		scoreScale := 2.5
 		scoreText := fmt.Sprintf("FINAL SCORE: %d", g.world.Score)
 		scoreW := TextWidth(scoreText, scoreScale)
 		scoreX := (ScreenWidth - scoreW) / 2
-		DrawText(screen, scoreText, scoreX, float64(ScreenHeight)/2+10, scoreScale, color.RGBA{255, 255, 255, 255})
+		DrawText(ui, scoreText, scoreX, float64(ScreenHeight)/2+10, scoreScale, color.RGBA{255, 255, 255, 255})
 
 		hintScale := 2.0
 		hintText := "PRESS ENTER"
 		hintW := TextWidth(hintText, hintScale)
 		hintX := (ScreenWidth - hintW) / 2
-		DrawText(screen, hintText, hintX, float64(ScreenHeight)/2+55, hintScale, color.RGBA{150, 150, 150, 255})
+		DrawText(ui, hintText, hintX, float64(ScreenHeight)/2+55, hintScale, color.RGBA{150, 150, 150, 255})
+
+		for i, line := range coopScoreboard(g.world) {
+			drawCentered(ui, line, float64(ScreenHeight)/2+100+float64(i)*24, 2, color.RGBA{255, 255, 255, 255})
+		}
+		for i, u := range g.newUnlocks {
+			drawCentered(ui, "UNLOCKED: "+u.Name, float64(ScreenHeight)/2+100+float64(i)*24, 2, color.RGBA{255, 210, 60, 255})
+		}
+		if key := runKey(g.runMode, g.runDifficulty, g.runMutators); key != "" && !g.assisted {
+			name := runName(g.runMode, g.runDifficulty, g.runMutators)
+			text := fmt.Sprintf("%s BEST: %d", name, g.profile.MutatorBests[key])
+			if g.newBest {
+				text = name + " - NEW BEST!"
+			}
+			drawCentered(ui, text, float64(ScreenHeight)/2+100, 2, color.RGBA{255, 210, 60, 255})
+		}
+		if g.assisted {
+			drawCentered(ui, "AIM ASSIST ON - NOT COUNTED IN CAREER", float64(ScreenHeight)/2+100, 2, color.RGBA{150, 150, 150, 255})
+		}
 	}
 }
 
 func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
-	return ScreenWidth, ScreenHeight
+	return int(g.fieldWidth), int(g.fieldHeight)
 }
@@ -2,9 +2,26 @@ package game
 
 import (
 	"math"
+	"os"
 	"testing"
+
+	"github.com/matheus3301/asteroids/internal/storage"
 )
 
+// TestMain keeps replays and other saved files out of the real user
+// directories.
+func TestMain(m *testing.M) {
+	root, err := os.MkdirTemp("", "asteroids-test-")
+	if err != nil {
+		panic(err)
+	}
+	restore := storage.Override(storage.At(root))
+	code := m.Run()
+	restore()
+	os.RemoveAll(root)
+	os.Exit(code)
+}
+
 // newPlaying creates a Game and transitions it to the playing state via reset().
 func newPlaying() *Game {
 	g := New()
@@ -120,12 +137,13 @@ func TestBulletAsteroidCollision_ScoreIncremented(t *testing.T) {
 	// Place a large asteroid right on top of a bullet
 	asteroid := g.world.Spawn()
 	g.world.positions[asteroid] = &Position{X: 100, Y: 100}
-	g.world.colliders[asteroid] = &Collider{Radius: 40}
+	g.world.colliders[asteroid] = &Collider{Radius: 40, Layer: LayerHazard}
 	g.world.asteroids[asteroid] = &AsteroidTag{Size: SizeLarge}
 
 	bullet := g.world.Spawn()
 	g.world.positions[bullet] = &Position{X: 100, Y: 100}
 	g.world.bullets[bullet] = &BulletTag{Life: 10}
+	g.world.colliders[bullet] = &Collider{Radius: 2, Layer: LayerPlayerBullet}
 
 	events := CollisionSystem(g.world)
 
@@ -145,12 +163,13 @@ func TestBulletAsteroidCollision_AsteroidSplits(t *testing.T) {
 
 	asteroid := g.world.Spawn()
 	g.world.positions[asteroid] = &Position{X: 100, Y: 100}
-	g.world.colliders[asteroid] = &Collider{Radius: 40}
+	g.world.colliders[asteroid] = &Collider{Radius: 40, Layer: LayerHazard}
 	g.world.asteroids[asteroid] = &AsteroidTag{Size: SizeLarge}
 
 	bullet := g.world.Spawn()
 	g.world.positions[bullet] = &Position{X: 100, Y: 100}
 	g.world.bullets[bullet] = &BulletTag{Life: 10}
+	g.world.colliders[bullet] = &Collider{Radius: 2, Layer: LayerPlayerBullet}
 
 	events := CollisionSystem(g.world)
 	CollisionResponseSystem(g.world, events)
@@ -175,12 +194,13 @@ func TestSmallAsteroidDoesNotSplit(t *testing.T) {
 
 	asteroid := g.world.Spawn()
 	g.world.positions[asteroid] = &Position{X: 100, Y: 100}
-	g.world.colliders[asteroid] = &Collider{Radius: 10}
+	g.world.colliders[asteroid] = &Collider{Radius: 10, Layer: LayerHazard}
 	g.world.asteroids[asteroid] = &AsteroidTag{Size: SizeSmall}
 
 	bullet := g.world.Spawn()
 	g.world.positions[bullet] = &Position{X: 100, Y: 100}
 	g.world.bullets[bullet] = &BulletTag{Life: 10}
+	g.world.colliders[bullet] = &Collider{Radius: 2, Layer: LayerPlayerBullet}
 
 	events := CollisionSystem(g.world)
 	CollisionResponseSystem(g.world, events)
@@ -204,7 +224,7 @@ func TestPlayerAsteroidCollision_LifeLost(t *testing.T) {
 	asteroid := g.world.Spawn()
 	playerPos := g.world.positions[g.world.Player]
 	g.world.positions[asteroid] = &Position{X: playerPos.X, Y: playerPos.Y}
-	g.world.colliders[asteroid] = &Collider{Radius: 40}
+	g.world.colliders[asteroid] = &Collider{Radius: 40, Layer: LayerHazard}
 	g.world.asteroids[asteroid] = &AsteroidTag{Size: SizeLarge}
 
 	oldLives := g.world.Lives
@@ -234,7 +254,7 @@ func TestPlayerRespawnsWithInvulnerability(t *testing.T) {
 	asteroid := g.world.Spawn()
 	playerPos := g.world.positions[g.world.Player]
 	g.world.positions[asteroid] = &Position{X: playerPos.X, Y: playerPos.Y}
-	g.world.colliders[asteroid] = &Collider{Radius: 40}
+	g.world.colliders[asteroid] = &Collider{Radius: 40, Layer: LayerHazard}
 	g.world.asteroids[asteroid] = &AsteroidTag{Size: SizeLarge}
 
 	events := CollisionSystem(g.world)
@@ -271,7 +291,7 @@ func TestGameOver_TriggeredAtZeroLives(t *testing.T) {
 	asteroid := g.world.Spawn()
 	playerPos := g.world.positions[g.world.Player]
 	g.world.positions[asteroid] = &Position{X: playerPos.X, Y: playerPos.Y}
-	g.world.colliders[asteroid] = &Collider{Radius: 40}
+	g.world.colliders[asteroid] = &Collider{Radius: 40, Layer: LayerHazard}
 	g.world.asteroids[asteroid] = &AsteroidTag{Size: SizeLarge}
 
 	events := CollisionSystem(g.world)
@@ -318,106 +338,38 @@ func TestWaveCleared_TriggersNextLevel(t *testing.T) {
 func TestReset_ClearsSaucerState(t *testing.T) {
 	g := newPlaying()
 
-	// Simulate a saucer being active
-	g.world.SaucerActive = SpawnSaucer(g.world, SaucerLarge)
-	g.world.SaucerSpawnTimer = 100
+	SpawnSaucer(g.world, SaucerLarge)
+	g.world.Saucer.SpawnTimer = 100
 
 	g.reset()
 
-	if g.world.SaucerActive != 0 {
-		t.Error("saucerActive should be 0 after reset")
-	}
-	if g.world.SaucerSpawnTimer != saucerInitialDelay {
-		t.Errorf("expected saucerSpawnTimer=%d, got %d", saucerInitialDelay, g.world.SaucerSpawnTimer)
-	}
-}
-
-func TestSaucerSpawnTimer_Decrements(t *testing.T) {
-	g := newPlaying()
-	g.world.SaucerSpawnTimer = 5
-	g.world.SaucerActive = 0
-
-	// Clear asteroids to prevent wave-clear interference, add one to keep level
-	for e := range g.world.asteroids {
-		g.world.Destroy(e)
-	}
-	SpawnAsteroid(g.world, 700, 700, SizeLarge)
-
-	// Make player invulnerable to prevent death from saucer
-	pc := g.world.players[g.world.Player]
-	pc.Invulnerable = true
-	pc.InvulnerableTimer = 9999
-
-	initial := g.world.SaucerSpawnTimer
-	// Tick once via system
-	SaucerSpawnSystem(g.world)
-
-	if g.world.SaucerSpawnTimer != initial-1 {
-		t.Errorf("expected timer %d, got %d", initial-1, g.world.SaucerSpawnTimer)
-	}
-}
-
-func TestSaucerSpawnTimer_SpawnsAtZero(t *testing.T) {
-	g := newPlaying()
-	g.world.SaucerSpawnTimer = 1
-	g.world.SaucerActive = 0
-
-	SaucerSpawnSystem(g.world)
-
-	if g.world.SaucerActive == 0 {
-		t.Error("saucer should have been spawned")
+	if g.world.SaucerCount() != 0 {
+		t.Error("no saucer should be in play after reset")
 	}
-	if !g.world.Alive(g.world.SaucerActive) {
-		t.Error("spawned saucer should be alive")
-	}
-}
-
-func TestSaucerSpawnTimer_NoSpawnWhileActive(t *testing.T) {
-	g := newPlaying()
-	saucer := SpawnSaucer(g.world, SaucerLarge)
-	g.world.SaucerActive = saucer
-	g.world.SaucerSpawnTimer = 0
-
-	// Should NOT spawn another saucer when one is active
-	if g.world.SaucerActive != 0 && g.world.Alive(g.world.SaucerActive) {
-		// Timer should not tick
-	} else {
-		t.Error("saucer should still be active")
+	if g.world.Saucer.SpawnTimer != saucerInitialDelay {
+		t.Errorf("expected spawn timer %d, got %d", saucerInitialDelay, g.world.Saucer.SpawnTimer)
 	}
 }
 
 func TestChooseSaucerSize_LowScore(t *testing.T) {
-	for i := 0; i < 20; i++ {
-		size := chooseSaucerSize(0)
-		if size != SaucerLarge {
-			t.Error("score 0 should always give SaucerLarge")
-		}
+	if size := chooseSaucerSize(&fixedRNG{rolls: []float64{0}}, 0); size != SaucerLarge {
+		t.Error("score 0 should always give SaucerLarge")
 	}
 }
 
 func TestChooseSaucerSize_HighScore(t *testing.T) {
-	for i := 0; i < 20; i++ {
-		size := chooseSaucerSize(50000)
-		if size != SaucerSmall {
-			t.Error("score 50000 should always give SaucerSmall")
-		}
+	if size := chooseSaucerSize(&fixedRNG{rolls: []float64{0.99}}, 50000); size != SaucerSmall {
+		t.Error("score 50000 should always give SaucerSmall")
 	}
 }
 
 func TestChooseSaucerSize_MidScore(t *testing.T) {
-	largeCount := 0
-	smallCount := 0
-	for i := 0; i < 200; i++ {
-		size := chooseSaucerSize(25000) // 50% chance
-		if size == SaucerLarge {
-			largeCount++
-		} else {
-			smallCount++
-		}
+	// At 25000 the chance of a small saucer is 50%.
+	if size := chooseSaucerSize(&fixedRNG{rolls: []float64{0.49}}, 25000); size != SaucerSmall {
+		t.Errorf("a roll under the chance should give SaucerSmall, got %v", size)
 	}
-	// Both should appear
-	if largeCount == 0 || smallCount == 0 {
-		t.Errorf("mid score should produce both sizes, got large=%d small=%d", largeCount, smallCount)
+	if size := chooseSaucerSize(&fixedRNG{rolls: []float64{0.51}}, 25000); size != SaucerLarge {
+		t.Errorf("a roll over the chance should give SaucerLarge, got %v", size)
 	}
 }
 
@@ -429,12 +381,12 @@ func TestSaucerDestruction_ScoreAndCleanup(t *testing.T) {
 	SpawnAsteroid(g.world, 700, 700, SizeLarge) // keep a wave alive
 
 	saucer := SpawnSaucer(g.world, SaucerLarge)
-	g.world.SaucerActive = saucer
 
 	spos := g.world.positions[saucer]
 	bullet := g.world.Spawn()
 	g.world.positions[bullet] = &Position{X: spos.X, Y: spos.Y}
 	g.world.bullets[bullet] = &BulletTag{Life: 10}
+	g.world.colliders[bullet] = &Collider{Radius: 2, Layer: LayerPlayerBullet}
 
 	events := CollisionSystem(g.world)
 
@@ -444,8 +396,14 @@ func TestSaucerDestruction_ScoreAndCleanup(t *testing.T) {
 	if g.world.Score != oldScore+200 {
 		t.Errorf("expected score %d, got %d", oldScore+200, g.world.Score)
 	}
-	if g.world.SaucerActive != 0 {
-		t.Error("saucerActive should be cleared after destruction")
+	if g.world.Alive(saucer) {
+		t.Error("saucer should be removed after destruction")
+	}
+	if ev := g.world.Saucer.Events; len(ev) != 1 || ev[0].Kind != SaucerDestroyed {
+		t.Errorf("expected a destroyed event, got %+v", ev)
+	}
+	if g.world.Saucer.SpawnTimer != saucerRespawnDelay {
+		t.Errorf("expected spawn timer %d, got %d", saucerRespawnDelay, g.world.Saucer.SpawnTimer)
 	}
 }
 
@@ -453,14 +411,16 @@ func TestPlayerDeath_ClearsSaucerAndBullets(t *testing.T) {
 	g := newPlaying()
 
 	saucer := SpawnSaucer(g.world, SaucerLarge)
-	g.world.SaucerActive = saucer
 	SpawnSaucerBullet(g.world, saucer, 400, 300)
 	SpawnSaucerBullet(g.world, saucer, 400, 300)
 
 	destroySaucerAndBullets(g.world)
 
-	if g.world.SaucerActive != 0 {
-		t.Error("saucerActive should be 0 after player death")
+	if g.world.SaucerCount() != 0 {
+		t.Error("no saucer should be left after player death")
+	}
+	if ev := g.world.Saucer.Events; len(ev) != 1 || ev[0].Kind != SaucerCleared {
+		t.Errorf("expected a cleared event, got %+v", ev)
 	}
 	if len(g.world.saucerBullets) != 0 {
 		t.Errorf("expected 0 saucer bullets, got %d", len(g.world.saucerBullets))
@@ -471,7 +431,6 @@ func TestWaveClear_SaucerSurvives(t *testing.T) {
 	g := newPlaying()
 
 	saucer := SpawnSaucer(g.world, SaucerLarge)
-	g.world.SaucerActive = saucer
 
 	// Clear all asteroids
 	for e := range g.world.asteroids {
@@ -484,9 +443,6 @@ func TestWaveClear_SaucerSurvives(t *testing.T) {
 	if !g.world.Alive(saucer) {
 		t.Error("saucer should survive wave clear")
 	}
-	if g.world.SaucerActive != saucer {
-		t.Error("saucerActive should still reference the saucer")
-	}
 }
 
 func TestBulletLimit_BlocksWhenAtMax(t *testing.T) {
@@ -624,12 +580,13 @@ func TestScoreValues(t *testing.T) {
 
 			asteroid := g.world.Spawn()
 			g.world.positions[asteroid] = &Position{X: 100, Y: 100}
-			g.world.colliders[asteroid] = &Collider{Radius: 40}
+			g.world.colliders[asteroid] = &Collider{Radius: 40, Layer: LayerHazard}
 			g.world.asteroids[asteroid] = &AsteroidTag{Size: tt.size}
 
 			bullet := g.world.Spawn()
 			g.world.positions[bullet] = &Position{X: 100, Y: 100}
 			g.world.bullets[bullet] = &BulletTag{Life: 10}
+			g.world.colliders[bullet] = &Collider{Radius: 2, Layer: LayerPlayerBullet}
 
 			events := CollisionSystem(g.world)
 			CollisionResponseSystem(g.world, events)
@@ -773,6 +730,42 @@ func TestHyperspace_GameOverOnLastLife(t *testing.T) {
 	}
 }
 
+func TestStepPlaying_AdvancesOneTick(t *testing.T) {
+	g := newPlaying()
+	tick := g.world.Tick
+
+	g.StepPlaying(InputState{Thrust: true})
+
+	if g.world.Tick != tick+1 {
+		t.Errorf("expected tick %d, got %d", tick+1, g.world.Tick)
+	}
+	if !g.world.players[g.world.Player].Thrusting {
+		t.Error("the given input should reach the ship")
+	}
+	if in := g.recorder.rep.Inputs; len(in) != 1 || !InputFromBits(in[0].Buttons).Thrust {
+		t.Errorf("the input should be recorded, got %+v", in)
+	}
+}
+
+func TestStepPlaying_EndsGame(t *testing.T) {
+	g := newPlaying()
+	g.world.Lives = 1
+	pc := g.world.players[g.world.Player]
+	pc.HyperspaceCooldown = 0
+	g.world.Config.HyperspaceRisk = 1
+
+	g.StepPlaying(InputState{Hyperspace: true})
+
+	if g.state != stateGameOver {
+		t.Errorf("expected stateGameOver, got %v", g.state)
+	}
+	tick := g.world.Tick
+	g.StepPlaying(InputState{})
+	if g.world.Tick != tick {
+		t.Error("StepPlaying should do nothing outside a game")
+	}
+}
+
 func TestShipIconVerts_HasThreeVertices(t *testing.T) {
 	if len(shipIconVerts) != 3 {
 		t.Errorf("expected 3 vertices, got %d", len(shipIconVerts))
@@ -818,3 +811,207 @@ func TestHyperspace_SpawnsDepartureParticles(t *testing.T) {
 		t.Errorf("expected at least 12 new particles, got %d", spawned)
 	}
 }
+
+func TestNewWithOptions_FixedSeed(t *testing.T) {
+	g := NewWithOptions(Options{Seed: 4242})
+	g.reset()
+	first := g.world.Checksum()
+	g.reset()
+
+	if g.world.Seed != 4242 {
+		t.Errorf("expected seed 4242, got %d", g.world.Seed)
+	}
+	if g.world.Checksum() != first {
+		t.Error("a fixed seed should reproduce the same starting layout")
+	}
+}
+
+func TestNewWithOptions_MuteAndFullscreen(t *testing.T) {
+	g := NewWithOptions(Options{Mute: true, Fullscreen: true})
+
+	if g.settings.volume != 0 {
+		t.Errorf("mute should set volume 0, got %d", g.settings.volume)
+	}
+	if !g.settings.fullscreen {
+		t.Error("fullscreen option should carry into settings")
+	}
+}
+
+func TestNewWithOptions_MatchesResolutionPreset(t *testing.T) {
+	g := NewWithOptions(Options{Width: 1280, Height: 720})
+	if g.settings.resolutionIndex != 1 {
+		t.Errorf("expected resolution index 1, got %d", g.settings.resolutionIndex)
+	}
+
+	g = NewWithOptions(Options{Width: 1000, Height: 1000})
+	if g.settings.resolutionIndex != 0 {
+		t.Errorf("unknown sizes should keep the default preset, got %d", g.settings.resolutionIndex)
+	}
+}
+
+func TestStatus_MenuHasNoWorld(t *testing.T) {
+	st := New().Status()
+	if st.State != "menu" {
+		t.Errorf("expected state menu, got %q", st.State)
+	}
+	if st.Score != 0 || st.Entities != 0 {
+		t.Errorf("menu status should be empty, got %+v", st)
+	}
+}
+
+func TestStatus_ReflectsWorld(t *testing.T) {
+	g := newPlaying()
+	g.world.Score = 1234
+	g.world.Level = 3
+
+	st := g.Status()
+	if st.State != "playing" || st.Score != 1234 || st.Level != 3 || st.Lives != 3 {
+		t.Errorf("unexpected status %+v", st)
+	}
+	if st.Entities != len(g.world.entities) {
+		t.Errorf("expected %d entities, got %d", len(g.world.entities), st.Entities)
+	}
+}
+
+type countingInput struct {
+	calls int
+	in    InputState
+}
+
+func (c *countingInput) NextInput(*World) InputState {
+	c.calls++
+	return c.in
+}
+
+func TestNewWithOptions_AutoStartUsesInputSource(t *testing.T) {
+	src := &countingInput{in: InputState{RotateRight: true}}
+	g := NewWithOptions(Options{Seed: 1, Input: src, AutoStart: true})
+	if g.state != statePlaying {
+		t.Fatalf("AutoStart should skip the menu, state is %s", g.state)
+	}
+	angle := g.world.rotations[g.world.Player].Angle
+
+	if err := g.Update(); err != nil {
+		t.Fatal(err)
+	}
+	if src.calls != 1 {
+		t.Errorf("expected 1 call to the input source, got %d", src.calls)
+	}
+	if g.world.rotations[g.world.Player].Angle <= angle {
+		t.Error("the ship should turn with the source's input")
+	}
+}
+
+func TestUpdatePlaying_GameSpeed(t *testing.T) {
+	for _, tc := range []struct {
+		speed float64
+		ticks int
+	}{{0.5, 5}, {1, 10}, {1.5, 15}, {2, 20}} {
+		src := &countingInput{}
+		g := NewWithOptions(Options{Seed: 1, Input: src, AutoStart: true, Speed: tc.speed})
+		for i := 0; i < 10; i++ {
+			g.updatePlaying()
+		}
+		if g.world.Tick != tc.ticks || src.calls != tc.ticks {
+			t.Errorf("speed %v: expected %d ticks in 10 frames, got %d ticks and %d inputs",
+				tc.speed, tc.ticks, g.world.Tick, src.calls)
+		}
+	}
+}
+
+func TestSetSpeed_Clamps(t *testing.T) {
+	g := NewWithOptions(Options{Speed: 5})
+	if g.settings.speed != MaxGameSpeed {
+		t.Errorf("expected the speed clamped to %v, got %v", MaxGameSpeed, g.settings.speed)
+	}
+	g.settingsCursor = 6
+	g.settingsSelect()
+	if g.settings.speed != MinGameSpeed {
+		t.Errorf("cycling past the top should wrap to %v, got %v", MinGameSpeed, g.settings.speed)
+	}
+	if New().settings.speed != 1 {
+		t.Error("the default speed should be normal")
+	}
+}
+
+func TestAutoStart_RestartsAfterGameOver(t *testing.T) {
+	g := NewWithOptions(Options{Seed: 1, Input: &countingInput{}, AutoStart: true})
+	old := g.world
+	g.state = stateGameOver
+	g.restartIn = 2
+
+	for i := 0; i < 2; i++ {
+		if err := g.Update(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if g.state != statePlaying || g.world == old {
+		t.Errorf("expected a fresh game after the restart delay, state %s", g.state)
+	}
+}
+
+func TestExtraLife_QueuesSound(t *testing.T) {
+	g := newPlaying()
+	g.world.Lives = 1
+	g.world.Score = 10_000
+	g.world.NextExtraLifeAt = 10_000
+	g.world.SoundQueue = nil
+
+	checkExtraLife(g.world)
+
+	if g.world.Lives != 2 {
+		t.Fatalf("expected a life to be awarded, lives %d", g.world.Lives)
+	}
+	if len(g.world.SoundQueue) != 1 || g.world.SoundQueue[0] != SoundExtraLife {
+		t.Errorf("expected the extra life sound, got %v", g.world.SoundQueue)
+	}
+}
+
+func TestExtraLife_CappedThresholdsPayPoints(t *testing.T) {
+	g := newPlaying()
+	g.world.Score = 29950
+	g.world.Lives = maxLives - 1
+	g.world.NextExtraLifeAt = 10_000
+
+	g.world.Score += 100 // crosses 10k, 20k and 30k: one life, then two bonuses
+	checkExtraLife(g.world)
+
+	if g.world.Lives != maxLives {
+		t.Errorf("expected lives capped at %d, got %d", maxLives, g.world.Lives)
+	}
+	if want := 30050 + 2*lifeBonusPoints; g.world.Score != want {
+		t.Errorf("expected score %d, got %d", want, g.world.Score)
+	}
+	if g.world.NextExtraLifeAt != 40_000 {
+		t.Errorf("expected nextExtraLifeAt 40000, got %d", g.world.NextExtraLifeAt)
+	}
+	if g.world.LifeBonusFlash == 0 {
+		t.Error("a converted life should flash in the HUD")
+	}
+	bonuses := 0
+	for _, s := range g.world.SoundQueue {
+		if s == SoundLifeBonus {
+			bonuses++
+		}
+	}
+	if bonuses != 2 {
+		t.Errorf("expected 2 life bonus sounds, got %d", bonuses)
+	}
+}
+
+func TestExtraLife_BonusCanCrossNextThreshold(t *testing.T) {
+	g := newPlaying()
+	g.world.Lives = maxLives
+	g.world.Score = 19_000
+	g.world.NextExtraLifeAt = 10_000
+
+	// 10k pays 2000 points, taking the score past 20k, which pays again.
+	checkExtraLife(g.world)
+
+	if want := 19_000 + 2*lifeBonusPoints; g.world.Score != want {
+		t.Errorf("expected score %d, got %d", want, g.world.Score)
+	}
+	if g.world.NextExtraLifeAt != 30_000 {
+		t.Errorf("expected nextExtraLifeAt 30000, got %d", g.world.NextExtraLifeAt)
+	}
+}
@@ -0,0 +1,133 @@
+package game
+
+import (
+	"flag"
+	"image/color"
+	"path/filepath"
+	"testing"
+
+	"github.com/matheus3301/asteroids/internal/canvas"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden images in testdata")
+
+// goldenTolerance is the fraction of pixels allowed to differ from a golden
+// image, to absorb floating-point differences between architectures.
+const goldenTolerance = 0.001
+
+// checkGolden compares r with testdata/golden/<name>.png, or rewrites it
+// when the -update flag is set.
+func checkGolden(t *testing.T, name string, r *canvas.Raster) {
+	t.Helper()
+	path := filepath.Join("testdata", "golden", name+".png")
+	if *update {
+		if err := r.SavePNG(path); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := canvas.LoadPNG(path)
+	if err != nil {
+		t.Fatalf("%v (run go test -update to create it)", err)
+	}
+	n, err := canvas.Diff(r.Img, want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if limit := int(float64(len(want.Pix)/4) * goldenTolerance); n > limit {
+		t.Errorf("%s: %d pixels differ from the golden image (limit %d); run go test -update if the change is intended", name, n, limit)
+	}
+}
+
+func newScreen() *canvas.Raster {
+	return canvas.NewRaster(ScreenWidth, ScreenHeight)
+}
+
+func TestGolden_Entities(t *testing.T) {
+	w := NewGameWorld(7)
+	SpawnSaucer(w, SaucerLarge)
+	for i := 0; i < 40; i++ {
+		Step(w, InputState{Thrust: true, RotateLeft: i < 10})
+	}
+	screen := newScreen()
+	screen.Fill(color.Black)
+	DrawWorld(w, screen)
+	checkGolden(t, "entities", screen)
+}
+
+func TestGolden_HUD(t *testing.T) {
+	g := newPlaying()
+	g.world.Score = 12340
+	g.world.Level = 4
+	screen := newScreen()
+	screen.Fill(color.Black)
+	g.drawHUD(screen)
+	checkGolden(t, "hud", screen)
+}
+
+func TestGolden_Menu(t *testing.T) {
+	g := New()
+	g.menuCursor = 2
+	screen := newScreen()
+	g.draw(screen)
+	checkGolden(t, "menu", screen)
+}
+
+func TestGolden_Settings(t *testing.T) {
+	g := New()
+	g.state = stateSettings
+	screen := newScreen()
+	g.draw(screen)
+	checkGolden(t, "settings", screen)
+}
+
+func TestGolden_Accessibility(t *testing.T) {
+	g := New()
+	g.openAccessibility()
+	g.accessibilityCursor = 2
+	screen := newScreen()
+	g.draw(screen)
+	checkGolden(t, "accessibility", screen)
+}
+
+func TestGolden_Controls(t *testing.T) {
+	g := New()
+	g.openControls()
+	g.controls = controlsPage{cursor: int(ActionShoot), waiting: true}
+	screen := newScreen()
+	g.draw(screen)
+	checkGolden(t, "controls", screen)
+}
+
+func TestGolden_ShipSelect(t *testing.T) {
+	g := New()
+	g.state = stateShipSelect
+	g.shipType = 1
+	screen := newScreen()
+	g.draw(screen)
+	checkGolden(t, "shipselect", screen)
+}
+
+func TestGolden_PracticeOverlay(t *testing.T) {
+	g := NewWithOptions(Options{Seed: 5, Practice: true})
+	g.reset()
+	screen := newScreen()
+	g.draw(screen)
+	checkGolden(t, "practice", screen)
+}
+
+func TestDrawHUD_ShipIconPerSpareLife(t *testing.T) {
+	g := newPlaying()
+	var base canvas.Recording
+	g.world.Lives = 1
+	g.drawHUD(&base)
+
+	var rec canvas.Recording
+	g.world.Lives = 3
+	g.drawHUD(&rec)
+
+	// Each spare life is a ship icon outlined with one line per vertex.
+	if got, want := rec.Count("line")-base.Count("line"), 2*len(shipIconVerts); got != want {
+		t.Errorf("two spare lives added %d lines, want %d", got, want)
+	}
+}
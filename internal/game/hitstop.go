@@ -0,0 +1,20 @@
+package game
+
+// hitStopTicks is how long the game freezes when the player dies or a
+// saucer is destroyed.
+const hitStopTicks = 3
+
+// startHitStop freezes the world for the configured number of ticks. A
+// freeze already running is extended, not stacked.
+func startHitStop(w *World) {
+	w.HitStop = max(w.HitStop, w.Config.HitStopTicks)
+}
+
+// TimeScale is how fast gameplay runs this tick: 0 during a hit-stop and 1
+// otherwise. Step skips every gameplay system while it is 0.
+func (w *World) TimeScale() float64 {
+	if w.HitStop > 0 {
+		return 0
+	}
+	return 1
+}
@@ -0,0 +1,50 @@
+package game
+
+import "testing"
+
+func TestHitStop_FreezesGameplay(t *testing.T) {
+	w := NewGameWorld(1)
+	killPlayer(w, w.Player, DeathAsteroid)
+	if w.HitStop != hitStopTicks {
+		t.Fatalf("dying should start a %d tick hit-stop, got %d", hitStopTicks, w.HitStop)
+	}
+
+	ast := sortedEntities(w.asteroids)[0]
+	x, y := w.positions[ast].X, w.positions[ast].Y
+	for i := 0; i < hitStopTicks; i++ {
+		Step(w, InputState{})
+	}
+
+	if p := w.positions[ast]; p.X != x || p.Y != y {
+		t.Error("asteroids should not move during a hit-stop")
+	}
+	if w.Tick != hitStopTicks {
+		t.Errorf("frozen ticks should still count, tick = %d", w.Tick)
+	}
+
+	Step(w, InputState{})
+	if p := w.positions[ast]; p.X == x && p.Y == y {
+		t.Error("asteroids should move again after the hit-stop")
+	}
+}
+
+func TestHitStop_SaucerKill(t *testing.T) {
+	w := NewGameWorld(1)
+	s := SpawnSaucer(w, SaucerLarge)
+	b := SpawnBullet(w, w.Player)
+	CollisionResponseSystem(w, CollisionEvent{SaucerBulletHits: []saucerHit{{Bullet: b, Saucer: s}}})
+
+	if w.HitStop != hitStopTicks {
+		t.Errorf("destroying a saucer should start a hit-stop, got %d", w.HitStop)
+	}
+}
+
+func TestHitStop_Disabled(t *testing.T) {
+	w := NewGameWorld(1)
+	w.Config.HitStopTicks = 0
+	killPlayer(w, w.Player, DeathAsteroid)
+
+	if w.TimeScale() != 1 {
+		t.Error("a zero hit_stop_ticks should turn the freeze off")
+	}
+}
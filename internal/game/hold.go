@@ -0,0 +1,55 @@
+package game
+
+// ActionHold enforces a minimum hold time on the steering buttons, so an
+// agent that flips between left and right every tick turns in steady
+// strokes instead. Once rotate or thrust is pressed it stays pressed for at
+// least MinTicks ticks, and the opposite turn is ignored meanwhile. Shoot,
+// hyperspace and missile act on the tick they are pressed and pass through
+// unchanged. The zero value, or a MinTicks of 1 or less, changes nothing.
+type ActionHold struct {
+	MinTicks int
+
+	// left, right and thrust are the ticks each button must still be held.
+	left, right, thrust int
+}
+
+// Apply returns the input to use this tick for the agent's choice in.
+func (h *ActionHold) Apply(in InputState) InputState {
+	if h.MinTicks <= 1 {
+		return in
+	}
+	if h.right > 0 {
+		in.RotateLeft = false
+	}
+	if h.left > 0 {
+		in.RotateRight = false
+	}
+	in.RotateLeft = h.hold(&h.left, in.RotateLeft)
+	in.RotateRight = h.hold(&h.right, in.RotateRight)
+	in.Thrust = h.hold(&h.thrust, in.Thrust)
+	return in
+}
+
+// hold applies the minimum to one button, counting down the ticks it must
+// still be held.
+func (h *ActionHold) hold(remaining *int, pressed bool) bool {
+	switch {
+	case *remaining > 0:
+		*remaining--
+		return true
+	case pressed:
+		*remaining = h.MinTicks - 1
+	}
+	return pressed
+}
+
+// HoldInput applies an ActionHold to another input source.
+type HoldInput struct {
+	Source InputSource
+	Hold   ActionHold
+}
+
+// NextInput implements InputSource.
+func (h *HoldInput) NextInput(w *World) InputState {
+	return h.Hold.Apply(h.Source.NextInput(w))
+}
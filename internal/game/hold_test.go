@@ -0,0 +1,45 @@
+package game
+
+import "testing"
+
+func TestActionHold_MinimumTurn(t *testing.T) {
+	h := ActionHold{MinTicks: 3}
+	var got []bool
+	for _, left := range []bool{true, false, false, false, true} {
+		got = append(got, h.Apply(InputState{RotateLeft: left}).RotateLeft)
+	}
+	want := []bool{true, true, true, false, true}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("left held %v, want %v", got, want)
+		}
+	}
+}
+
+func TestActionHold_BlocksOppositeTurn(t *testing.T) {
+	h := ActionHold{MinTicks: 3}
+	h.Apply(InputState{RotateLeft: true})
+	in := h.Apply(InputState{RotateRight: true})
+	if !in.RotateLeft || in.RotateRight {
+		t.Errorf("expected the left turn to continue, got %+v", in)
+	}
+	h.Apply(InputState{})
+	if in := h.Apply(InputState{RotateRight: true}); in.RotateLeft || !in.RotateRight {
+		t.Errorf("expected a right turn once the hold ran out, got %+v", in)
+	}
+}
+
+func TestActionHold_PassesThroughOneShotButtons(t *testing.T) {
+	h := ActionHold{MinTicks: 5}
+	h.Apply(InputState{Shoot: true, Hyperspace: true, Missile: true})
+	if in := h.Apply(InputState{}); in != (InputState{}) {
+		t.Errorf("shoot, hyperspace and missile should not be held, got %+v", in)
+	}
+	var off ActionHold
+	if in := off.Apply(InputState{RotateLeft: true}); !in.RotateLeft {
+		t.Error("the zero value should change nothing")
+	}
+	if in := off.Apply(InputState{}); in.RotateLeft {
+		t.Error("the zero value should not hold buttons")
+	}
+}
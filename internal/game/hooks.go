@@ -0,0 +1,114 @@
+package game
+
+import "math"
+
+// RuleHooks lets a mod observe and change the rules of a running game. The
+// methods are called from inside Step, so implementations must be
+// deterministic for a seed to keep reproducing the same game.
+type RuleHooks interface {
+	// WaveStart is called before wave n is spawned with the default number
+	// of large asteroids, and returns the number to spawn instead.
+	WaveStart(w *World, wave, count int) int
+	// AsteroidDestroyed is called when a bullet destroys an asteroid with
+	// the default points for it, and returns the points to award instead.
+	AsteroidDestroyed(w *World, size AsteroidSize, x, y float64, points int) int
+	// Tick is called at the end of every tick.
+	Tick(w *World)
+}
+
+// maxWaveAsteroids caps what a hook can ask a wave to spawn.
+const maxWaveAsteroids = 50
+
+// NewModdedWorld is NewGameWorld under the given ruleset, installed before
+// the first wave spawns.
+func NewModdedWorld(seed int64, rules Ruleset) *World {
+	w := NewWorldWithSeed(seed)
+	w.Config = rules.Config
+	w.Palette = rules.Palette
+	w.Hooks = rules.Hooks
+	w.Ship = rules.Ship
+	w.ShipType = rules.ShipType
+	setupGame(w)
+	return w
+}
+
+// ChainHooks combines hooks so each sees the result of the one before it.
+// Nil entries are skipped; it returns nil when there is nothing to chain.
+func ChainHooks(hooks ...RuleHooks) RuleHooks {
+	var c hookChain
+	for _, h := range hooks {
+		switch h := h.(type) {
+		case nil:
+		case hookChain:
+			c = append(c, h...)
+		default:
+			c = append(c, h)
+		}
+	}
+	switch len(c) {
+	case 0:
+		return nil
+	case 1:
+		return c[0]
+	}
+	return c
+}
+
+type hookChain []RuleHooks
+
+func (c hookChain) WaveStart(w *World, wave, count int) int {
+	for _, h := range c {
+		count = h.WaveStart(w, wave, count)
+	}
+	return count
+}
+
+func (c hookChain) AsteroidDestroyed(w *World, size AsteroidSize, x, y float64, points int) int {
+	for _, h := range c {
+		points = h.AsteroidDestroyed(w, size, x, y, points)
+	}
+	return points
+}
+
+func (c hookChain) Tick(w *World) {
+	for _, h := range c {
+		h.Tick(w)
+	}
+}
+
+// waveSize returns how many large asteroids the current wave starts with.
+func waveSize(w *World) int {
+	count := max(int(math.Round(float64(3+w.Level)*w.Config.WaveScale)), 1)
+	if w.Hooks != nil {
+		count = min(max(w.Hooks.WaveStart(w, w.Level, count), 0), maxWaveAsteroids)
+	}
+	return count
+}
+
+// asteroidPoints returns the score for shooting an asteroid of the given
+// size and variant at (x, y).
+func asteroidPoints(w *World, size AsteroidSize, variant AsteroidVariant, x, y float64) int {
+	var points int
+	switch size {
+	case SizeLarge:
+		points = 20
+	case SizeMedium:
+		points = 50
+	case SizeSmall:
+		points = 100
+	}
+	if variant == VariantGolden {
+		points *= w.Config.GoldenMultiplier
+	}
+	if w.Hooks != nil {
+		points = w.Hooks.AsteroidDestroyed(w, size, x, y, points)
+	}
+	return points
+}
+
+// HooksSystem runs the per-tick hook.
+func HooksSystem(w *World) {
+	if w.Hooks != nil {
+		w.Hooks.Tick(w)
+	}
+}
@@ -0,0 +1,76 @@
+package game
+
+import "testing"
+
+type fakeHooks struct {
+	waveCount int
+	points    int
+	ticks     int
+}
+
+func (h *fakeHooks) WaveStart(w *World, wave, count int) int { return h.waveCount }
+func (h *fakeHooks) AsteroidDestroyed(w *World, size AsteroidSize, x, y float64, points int) int {
+	return h.points
+}
+func (h *fakeHooks) Tick(w *World) { h.ticks++ }
+
+func withHooks(h RuleHooks) Ruleset {
+	r := StandardRules()
+	r.Hooks = h
+	return r
+}
+
+func TestNewModdedWorld_WaveHook(t *testing.T) {
+	w := NewModdedWorld(1, withHooks(&fakeHooks{waveCount: 1}))
+	if w.AsteroidCount() != 1 {
+		t.Errorf("expected 1 asteroid, got %d", w.AsteroidCount())
+	}
+
+	w = NewModdedWorld(1, withHooks(&fakeHooks{waveCount: 1000}))
+	if w.AsteroidCount() != maxWaveAsteroids {
+		t.Errorf("wave size should be capped at %d, got %d", maxWaveAsteroids, w.AsteroidCount())
+	}
+}
+
+func TestCollisionResponse_ScoreHook(t *testing.T) {
+	w := NewWorld()
+	w.NextExtraLifeAt = 10_000
+	w.Hooks = &fakeHooks{points: 7}
+	asteroid := w.Spawn()
+	w.positions[asteroid] = &Position{X: 100, Y: 100}
+	w.colliders[asteroid] = &Collider{Radius: 40, Layer: LayerHazard}
+	w.asteroids[asteroid] = &AsteroidTag{Size: SizeLarge}
+
+	bullet := w.Spawn()
+	w.positions[bullet] = &Position{X: 100, Y: 100}
+	w.bullets[bullet] = &BulletTag{Life: 10}
+	w.colliders[bullet] = &Collider{Radius: 2, Layer: LayerPlayerBullet}
+
+	CollisionResponseSystem(w, CollisionSystem(w))
+
+	if w.Score != 7 {
+		t.Errorf("expected the hook's 7 points, got %d", w.Score)
+	}
+}
+
+func TestStep_TickHook(t *testing.T) {
+	h := &fakeHooks{waveCount: 4}
+	w := NewModdedWorld(1, withHooks(h))
+	for i := 0; i < 3; i++ {
+		Step(w, InputState{})
+	}
+	if h.ticks != 3 {
+		t.Errorf("tick hook ran %d times, want 3", h.ticks)
+	}
+}
+
+func TestReset_ModdedGamesAreNotRecorded(t *testing.T) {
+	g := NewWithOptions(Options{Rules: &fakeHooks{waveCount: 2}})
+	g.reset()
+	if g.recorder != nil {
+		t.Error("games with mod hooks should not be recorded")
+	}
+	if g.world.Hooks == nil || g.world.AsteroidCount() != 2 {
+		t.Error("the hooks should be installed in the new world")
+	}
+}
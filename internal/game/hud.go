@@ -0,0 +1,155 @@
+package game
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/matheus3301/asteroids/internal/canvas"
+)
+
+const hudIconScale = 0.52
+
+// shipIconVerts is the classic ship as drawn in the HUD's lives row.
+var shipIconVerts = classicShip.vertices(playerRadius * hudIconScale)
+
+// HUDCorner is the screen corner the HUD is drawn in.
+type HUDCorner int
+
+const (
+	HUDTopLeft HUDCorner = iota
+	HUDTopRight
+	HUDBottomLeft
+	HUDBottomRight
+)
+
+var hudCornerNames = []string{"top-left", "top-right", "bottom-left", "bottom-right"}
+
+func (c HUDCorner) String() string {
+	if c < 0 || int(c) >= len(hudCornerNames) {
+		return "unknown"
+	}
+	return hudCornerNames[c]
+}
+
+// ParseHUDCorner turns a name such as "top-right" into a HUDCorner.
+func ParseHUDCorner(s string) (HUDCorner, error) {
+	for i, name := range hudCornerNames {
+		if s == name {
+			return HUDCorner(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown HUD corner %q (want one of %s)", s, strings.Join(hudCornerNames, ", "))
+}
+
+// HUDLayout places the HUD. The zero value is the standard top-left HUD.
+type HUDLayout struct {
+	Corner HUDCorner
+	// Scale is the text size; 0 means the standard 2.
+	Scale float64
+}
+
+func (l HUDLayout) scale() float64 {
+	if l.Scale <= 0 {
+		return 2
+	}
+	return l.Scale
+}
+
+// hudRow is one line of the HUD: text, optionally followed by ship icons,
+// a cooldown bar and a flashed note.
+type hudRow struct {
+	text  string
+	icons int
+	// bar is the filled fraction of a cooldown bar; negative means none.
+	bar  float64
+	note string
+}
+
+// hudRows describes the HUD for w: score, spare lives, level, each ship's
+// share of the score in co-op, missiles, the weapon upgrade, the combo
+// multiplier, the hyperspace cooldown and the shield meter. Rows for mechanics that are idle
+// are left out.
+func hudRows(w *World) []hudRow {
+	lives := hudRow{text: "LIVES: ", icons: max(w.Lives-1, 0), bar: -1}
+	// A life paid out as points flashes its value after the icons, or holds
+	// it in reduced motion.
+	if f := w.LifeBonusFlash; f > 0 && (w.ReducedMotion || (f/8)%2 == 1) {
+		lives.note = fmt.Sprintf("+%d", w.Config.LifeBonusPoints)
+	}
+	rows := []hudRow{
+		{text: fmt.Sprintf("SCORE: %d", w.Score), bar: -1},
+		lives,
+		{text: fmt.Sprintf("LEVEL: %d", w.Level), bar: -1},
+	}
+	if len(w.players) > 1 {
+		for _, e := range sortedEntities(w.players) {
+			pc := w.players[e]
+			rows = append(rows, hudRow{text: fmt.Sprintf("P%d: %d", pc.Slot+1, pc.Score), bar: -1})
+		}
+	}
+	if pc := w.players[w.Player]; pc != nil {
+		rows = append(rows, hudRow{text: fmt.Sprintf("MISSILES: %d", pc.Missiles), bar: -1})
+		if pc.Weapon > WeaponSingle {
+			rows = append(rows, hudRow{text: "WEAPON: " + pc.Weapon.String(), bar: -1})
+		}
+	}
+	if m := w.ComboMultiplier(); m > 1 {
+		rows = append(rows, hudRow{text: fmt.Sprintf("COMBO X%d", m), bar: -1})
+	}
+	if pc := w.players[w.Player]; pc != nil && pc.HyperspaceCooldown > 0 {
+		rows = append(rows, hudRow{text: "HYPERSPACE ", bar: float64(pc.HyperspaceCooldown) / hyperspaceCooldown})
+	}
+	// A full meter is idle; the bar shows once the shield has been used.
+	if pc := w.players[w.Player]; pc != nil && w.Config.ShieldTicks > 0 && (pc.ShieldUp || pc.Shield < w.Config.ShieldTicks) {
+		rows = append(rows, hudRow{text: "SHIELD ", bar: float64(pc.Shield) / float64(w.Config.ShieldTicks)})
+	}
+	return rows
+}
+
+// hudBarWidth is the length of a cooldown bar at the standard scale.
+const hudBarWidth = 60.0
+
+func (g *Game) drawHUD(screen canvas.Canvas) {
+	w := g.world
+	s := g.hud.scale()
+	k := s / 2 // icon and bar size relative to the standard HUD
+	clr := w.Palette.HUD
+
+	iconVerts := shipIconVerts
+	if w.Ship != nil || k != 1 {
+		iconVerts = w.Ship.vertices(playerRadius * hudIconScale * k)
+	}
+	iconWing := playerRadius * 0.6 * hudIconScale * k
+	iconStep := iconWing*2 + 6*k
+
+	rows := hudRows(w)
+	lineHeight := 11 * s
+	for i, r := range rows {
+		width := TextWidth(r.text, s) + float64(r.icons)*iconStep + TextWidth(r.note, s)
+		if r.bar >= 0 {
+			width += hudBarWidth * k
+		}
+		x, y := 10.0, 10+float64(i)*lineHeight
+		if g.hud.Corner == HUDTopRight || g.hud.Corner == HUDBottomRight {
+			x = w.Config.FieldWidth - 10 - width
+		}
+		if g.hud.Corner == HUDBottomLeft || g.hud.Corner == HUDBottomRight {
+			y = w.Config.FieldHeight - 10 - 7*s - float64(len(rows)-1-i)*lineHeight
+		}
+
+		DrawText(screen, r.text, x, y, s, clr)
+		x += TextWidth(r.text, s)
+		for j := 0; j < r.icons; j++ {
+			drawPolygon(screen, &Position{X: x + iconWing + float64(j)*iconStep, Y: y + 7*s/2}, -math.Pi/2, iconVerts, clr)
+		}
+		x += float64(r.icons) * iconStep
+		if r.note != "" {
+			DrawText(screen, r.note, x+iconWing, y, s, w.Palette.Bonus)
+		}
+		if r.bar >= 0 {
+			screen.StrokeRect(x, y, hudBarWidth*k, 7*s, 1, clr)
+			screen.FillRect(x, y, hudBarWidth*k*r.bar, 7*s, clr)
+		}
+	}
+}
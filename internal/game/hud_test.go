@@ -0,0 +1,67 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/matheus3301/asteroids/internal/canvas"
+)
+
+func TestHUDRows_ShowActiveMechanics(t *testing.T) {
+	w := NewGameWorld(1)
+	base := len(hudRows(w))
+
+	w.Combo = comboStep
+	w.ComboTimer = 10
+	w.players[w.Player].HyperspaceCooldown = hyperspaceCooldown / 2
+	rows := hudRows(w)
+
+	if len(rows) != base+2 {
+		t.Fatalf("expected combo and hyperspace rows, got %+v", rows)
+	}
+	if rows[base].text != "COMBO X2" {
+		t.Errorf("expected the combo row, got %q", rows[base].text)
+	}
+	if bar := rows[base+1].bar; bar != 0.5 {
+		t.Errorf("expected a half-full hyperspace bar, got %v", bar)
+	}
+}
+
+func TestHUDRows_CoopShares(t *testing.T) {
+	w := NewCoopWorld(1)
+	for _, pc := range w.players {
+		pc.Score = 100 * (pc.Slot + 1)
+	}
+	rows := hudRows(w)
+	if rows[3].text != "P1: 100" || rows[4].text != "P2: 200" {
+		t.Errorf("expected each ship's share after the level, got %q and %q", rows[3].text, rows[4].text)
+	}
+}
+
+func TestParseHUDCorner(t *testing.T) {
+	for _, c := range []HUDCorner{HUDTopLeft, HUDTopRight, HUDBottomLeft, HUDBottomRight} {
+		if got, err := ParseHUDCorner(c.String()); err != nil || got != c {
+			t.Errorf("ParseHUDCorner(%q) = %v, %v", c.String(), got, err)
+		}
+	}
+	if _, err := ParseHUDCorner("middle"); err == nil {
+		t.Error("expected an error for an unknown corner")
+	}
+}
+
+func TestDrawHUD_BottomRightCorner(t *testing.T) {
+	g := newPlaying()
+	g.hud = HUDLayout{Corner: HUDBottomRight, Scale: 1.5}
+	g.world.players[g.world.Player].HyperspaceCooldown = 10
+
+	var rec canvas.Recording
+	g.drawHUD(&rec)
+
+	if len(rec.Ops) == 0 {
+		t.Fatal("nothing drawn")
+	}
+	for _, op := range rec.Ops {
+		if op.Args[0] < ScreenWidth/2 || op.Args[1] < ScreenHeight/2 {
+			t.Fatalf("%s drawn outside the bottom-right quarter: %v", op.Kind, op.Args)
+		}
+	}
+}
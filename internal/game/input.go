@@ -0,0 +1,133 @@
+package game
+
+// InputState is the player's intent for a single simulation tick.
+// Shoot, Hyperspace and Missile are edge-triggered: they are true only on the tick
+// the button went down. Shield, like Thrust, is true for as long as it is held.
+type InputState struct {
+	RotateLeft  bool
+	RotateRight bool
+	Thrust      bool
+	Shoot       bool
+	Hyperspace  bool
+	Missile     bool
+	Shield      bool
+}
+
+// MaxPlayers is the number of ships a world can hold.
+const MaxPlayers = 2
+
+// Inputs holds one InputState per player slot.
+type Inputs [MaxPlayers]InputState
+
+const (
+	inputRotateLeft uint8 = 1 << iota
+	inputRotateRight
+	inputThrust
+	inputShoot
+	inputHyperspace
+	inputMissile
+	inputShield
+)
+
+// Bits packs the input into a single byte for compact storage.
+func (in InputState) Bits() uint8 {
+	var b uint8
+	if in.RotateLeft {
+		b |= inputRotateLeft
+	}
+	if in.RotateRight {
+		b |= inputRotateRight
+	}
+	if in.Thrust {
+		b |= inputThrust
+	}
+	if in.Shoot {
+		b |= inputShoot
+	}
+	if in.Hyperspace {
+		b |= inputHyperspace
+	}
+	if in.Missile {
+		b |= inputMissile
+	}
+	if in.Shield {
+		b |= inputShield
+	}
+	return b
+}
+
+// InputFromBits is the inverse of InputState.Bits.
+func InputFromBits(b uint8) InputState {
+	return InputState{
+		RotateLeft:  b&inputRotateLeft != 0,
+		RotateRight: b&inputRotateRight != 0,
+		Thrust:      b&inputThrust != 0,
+		Shoot:       b&inputShoot != 0,
+		Hyperspace:  b&inputHyperspace != 0,
+		Missile:     b&inputMissile != 0,
+		Shield:      b&inputShield != 0,
+	}
+}
+
+// InputSource supplies the player input for each gameplay tick. The world
+// is passed so that agents can observe it before deciding.
+type InputSource interface {
+	NextInput(w *World) InputState
+}
+
+// InputOverlay is implemented by input sources that have something to show
+// on screen while playing, such as live vote counts.
+type InputOverlay interface {
+	OverlayLines() []string
+}
+
+// KeyboardInput is the default InputSource: the local keyboard.
+type KeyboardInput struct {
+	// Autofire fires while Space is held, at the weapon's fire rate,
+	// instead of once per press.
+	Autofire bool
+	// AimAssist turns the ship onto the lead angle when it is nearly there,
+	// from 0 (off) to MaxAimAssist.
+	AimAssist int
+
+	// buttons, when set, supplies the one-shot buttons latched frame by
+	// frame by the game instead of polling them each tick.
+	buttons *buttonLatch
+	// keys, when set, are the game's key bindings; nil uses the defaults.
+	keys *KeyBindings
+}
+
+// NextInput implements InputSource.
+func (k KeyboardInput) NextInput(w *World) InputState {
+	keys := k.keys
+	if keys == nil {
+		d := DefaultKeyBindings()
+		keys = &d
+	}
+	in := keys.Read()
+	held := keys.Pressed(ActionShoot)
+	if k.buttons != nil {
+		p := k.buttons.Take()
+		in.Shoot, in.Hyperspace, in.Missile = p.Shoot, p.Hyperspace, p.Missile
+		held = k.buttons.held.Shoot
+	}
+	in.Shoot = fireButton(in.Shoot, held, k.Autofire)
+	if w != nil {
+		in = assistAim(w, in, k.AimAssist)
+	}
+	return in
+}
+
+// fireButton maps a fire button to the Shoot input: a fresh press fires,
+// and with autofire so does holding it. ShootingSystem's cooldown sets the
+// pace either way.
+func fireButton(justPressed, held, autofire bool) bool {
+	return justPressed || (autofire && held)
+}
+
+// ReadKeyboard polls the keyboard with the default key bindings and
+// returns the current InputState.
+func ReadKeyboard() InputState {
+	keys := DefaultKeyBindings()
+	return keys.Read()
+}
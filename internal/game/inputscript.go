@@ -0,0 +1,120 @@
+package game
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// InputScript is an InputSource that plays back a fixed sequence of
+// inputs, one per gameplay tick, in place of the keyboard. Once the script
+// runs out the ship is left idle. Integration tests use it to drive the real
+// game loop through a known game.
+type InputScript struct {
+	Ticks []InputState
+	next  int
+}
+
+// NextInput implements InputSource.
+func (s *InputScript) NextInput(*World) InputState {
+	if s.next >= len(s.Ticks) {
+		return InputState{}
+	}
+	in := s.Ticks[s.next]
+	s.next++
+	return in
+}
+
+// Done reports whether every scripted tick has been played.
+func (s *InputScript) Done() bool {
+	return s.next >= len(s.Ticks)
+}
+
+// inputScriptWords names the inputs in the text format.
+var inputScriptWords = []struct {
+	word string
+	bit  uint8
+}{
+	{"left", inputRotateLeft},
+	{"right", inputRotateRight},
+	{"thrust", inputThrust},
+	{"shoot", inputShoot},
+	{"hyper", inputHyperspace},
+	{"missile", inputMissile},
+	{"shield", inputShield},
+}
+
+// ParseInputScript reads the text format: one line per run of identical
+// ticks, a tick count followed by the inputs held, for example
+//
+//	# turn, then fire twice
+//	20 left
+//	1 shoot
+//	8
+//	1 shoot
+//
+// A count with no inputs idles. Blank lines and lines starting with # are
+// ignored.
+func ParseInputScript(r io.Reader) (*InputScript, error) {
+	s := &InputScript{}
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		n, err := strconv.Atoi(fields[0])
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("line %d: %q is not a tick count", line, fields[0])
+		}
+		var bits uint8
+	words:
+		for _, f := range fields[1:] {
+			for _, w := range inputScriptWords {
+				if f == w.word {
+					bits |= w.bit
+					continue words
+				}
+			}
+			return nil, fmt.Errorf("line %d: unknown input %q", line, f)
+		}
+		for i := 0; i < n; i++ {
+			s.Ticks = append(s.Ticks, InputFromBits(bits))
+		}
+	}
+	return s, sc.Err()
+}
+
+// LoadInputScript reads an input script file.
+func LoadInputScript(path string) (*InputScript, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseInputScript(f)
+}
+
+// Encode writes s in the text format ParseInputScript reads.
+func (s *InputScript) Encode(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	for i := 0; i < len(s.Ticks); {
+		bits := s.Ticks[i].Bits()
+		n := 1
+		for i+n < len(s.Ticks) && s.Ticks[i+n].Bits() == bits {
+			n++
+		}
+		fmt.Fprint(bw, n)
+		for _, w := range inputScriptWords {
+			if bits&w.bit != 0 {
+				fmt.Fprint(bw, " ", w.word)
+			}
+		}
+		fmt.Fprintln(bw)
+		i += n
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,137 @@
+package game
+
+import (
+	"bytes"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseInputScript(t *testing.T) {
+	s, err := ParseInputScript(strings.NewReader("# warm up\n2 left thrust\n\n1 shoot\n1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []InputState{
+		{RotateLeft: true, Thrust: true},
+		{RotateLeft: true, Thrust: true},
+		{Shoot: true},
+		{},
+	}
+	if !reflect.DeepEqual(s.Ticks, want) {
+		t.Errorf("got %+v, want %+v", s.Ticks, want)
+	}
+
+	for _, bad := range []string{"x left", "0 left", "3 jump"} {
+		if _, err := ParseInputScript(strings.NewReader(bad)); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
+
+func TestInputScript_EncodeRoundTrip(t *testing.T) {
+	s := &InputScript{}
+	for tick := 0; tick < 500; tick++ {
+		s.Ticks = append(s.Ticks, ScriptedInput(tick))
+	}
+	var buf bytes.Buffer
+	if err := s.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ParseInputScript(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.Ticks, s.Ticks) {
+		t.Error("script changed in a round trip")
+	}
+}
+
+func TestInputScript_IdlesWhenDone(t *testing.T) {
+	s := &InputScript{Ticks: []InputState{{Thrust: true}}}
+	w := NewWorld()
+	if in := s.NextInput(w); !in.Thrust || !s.Done() {
+		t.Fatal("expected the one scripted tick")
+	}
+	if in := s.NextInput(w); in != (InputState{}) {
+		t.Errorf("expected idle input after the script, got %+v", in)
+	}
+}
+
+// aimBot turns towards the nearest target and fires when lined up. It only
+// generates the recorded script in testdata.
+func aimBot(w *World) InputState {
+	pos, rot := w.positions[w.Player], w.rotations[w.Player]
+	if pos == nil || rot == nil {
+		return InputState{}
+	}
+	tx, ty, ok := nearestTarget(w, pos)
+	if !ok {
+		return InputState{}
+	}
+	diff := math.Remainder(math.Atan2(ty-pos.Y, tx-pos.X)-rot.Angle, 2*math.Pi)
+	return InputState{
+		RotateLeft:  diff < -0.05,
+		RotateRight: diff > 0.05,
+		Shoot:       math.Abs(diff) < 0.15 && w.Tick%4 == 0,
+	}
+}
+
+// The recorded wave-one game: its seed and where it must end up.
+const (
+	wave1Seed  = 7
+	wave1Ticks = 4714
+	wave1Score = 3080
+)
+
+// TestInputScript_ClearsWaveOne plays a recorded input script through the
+// real game loop. Any change to system order or rules that alters the game
+// shows up as a different score or clear time. Run with -update to
+// re-record the script after an intended change, then update the expected
+// values above.
+func TestInputScript_ClearsWaveOne(t *testing.T) {
+	path := filepath.Join("testdata", "inputs", "wave1.txt")
+	if *update {
+		recordWaveOne(t, path)
+	}
+	script, err := LoadInputScript(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := NewWithOptions(Options{Seed: wave1Seed, Input: script})
+	g.reset()
+	for !script.Done() && g.state == statePlaying {
+		g.updatePlaying()
+	}
+
+	w := g.world
+	if w.Level != 2 || w.Tick != wave1Ticks || w.Score != wave1Score {
+		t.Errorf("script ended at level %d, tick %d, score %d; want level 2, tick %d, score %d",
+			w.Level, w.Tick, w.Score, wave1Ticks, wave1Score)
+	}
+}
+
+func recordWaveOne(t *testing.T, path string) {
+	t.Helper()
+	w := NewGameWorld(wave1Seed)
+	s := &InputScript{}
+	for w.Level == 1 && !w.GameOver() && w.Tick < 20_000 {
+		in := aimBot(w)
+		s.Ticks = append(s.Ticks, in)
+		Step(w, in)
+	}
+	if w.Level != 2 {
+		t.Fatalf("the bot did not clear wave one (level %d, tick %d)", w.Level, w.Tick)
+	}
+	t.Logf("recorded wave one: tick %d, score %d", w.Tick, w.Score)
+	var buf bytes.Buffer
+	if err := s.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
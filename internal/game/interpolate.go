@@ -0,0 +1,47 @@
+package game
+
+import "math"
+
+// maxInterpolateStep is the largest move drawn as motion between two ticks.
+// Anything further, such as wrapping round the screen, hyperspace or a
+// respawn, is a jump and is drawn at its new position.
+const maxInterpolateStep = 40.0
+
+// recordPrevious stores every entity's pose before a tick runs, so drawing
+// can blend it with the pose after the tick.
+func recordPrevious(w *World) {
+	for e, pos := range w.positions {
+		p := w.previous[e]
+		if p == nil {
+			p = &Previous{}
+			w.previous[e] = p
+		}
+		p.X, p.Y = pos.X, pos.Y
+		if rot := w.rotations[e]; rot != nil {
+			p.Angle = rot.Angle
+		}
+	}
+}
+
+// poseAt returns where to draw e at alpha between the previous tick (0) and
+// the current one (1). Entities spawned during the last tick are drawn where
+// they are. pos is nil if e has no position.
+func (w *World) poseAt(e Entity, alpha float64) (pos *Position, angle float64) {
+	cur := w.positions[e]
+	if cur == nil {
+		return nil, 0
+	}
+	if rot := w.rotations[e]; rot != nil {
+		angle = rot.Angle
+	}
+	prev := w.previous[e]
+	if prev == nil || alpha >= 1 {
+		return cur, angle
+	}
+	dx, dy := cur.X-prev.X, cur.Y-prev.Y
+	if math.Hypot(dx, dy) > maxInterpolateStep {
+		return cur, angle
+	}
+	da := math.Remainder(angle-prev.Angle, 2*math.Pi)
+	return &Position{X: prev.X + dx*alpha, Y: prev.Y + dy*alpha}, prev.Angle + da*alpha
+}
@@ -0,0 +1,54 @@
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPoseAt_BlendsBetweenTicks(t *testing.T) {
+	w := NewWorld()
+	e := SpawnAsteroid(w, 100, 100, SizeLarge)
+	w.velocities[e].X, w.velocities[e].Y = 4, 0
+	Step(w, InputState{})
+
+	pos, _ := w.poseAt(e, 0.5)
+	if math.Abs(pos.X-102) > 1e-9 || pos.Y != 100 {
+		t.Errorf("expected the asteroid halfway at (102, 100), got (%v, %v)", pos.X, pos.Y)
+	}
+	if cur, _ := w.poseAt(e, 1); cur != w.positions[e] {
+		t.Error("alpha 1 should draw the current position")
+	}
+}
+
+func TestPoseAt_JumpsAreNotBlended(t *testing.T) {
+	w := NewWorld()
+	e := SpawnAsteroid(w, 100, 100, SizeLarge)
+	recordPrevious(w)
+	w.positions[e].X = ScreenWidth - 5 // wrapped round the left edge
+
+	if pos, _ := w.poseAt(e, 0.5); pos.X != ScreenWidth-5 {
+		t.Errorf("a wrap should be drawn at the new position, got x=%v", pos.X)
+	}
+}
+
+func TestPoseAt_AngleTakesShortArc(t *testing.T) {
+	w := NewWorld()
+	e := SpawnPlayer(w, 100, 100)
+	w.rotations[e].Angle = math.Pi - 0.1
+	recordPrevious(w)
+	w.rotations[e].Angle = -math.Pi + 0.1
+
+	if _, a := w.poseAt(e, 0.5); math.Abs(math.Abs(a)-math.Pi) > 1e-9 {
+		t.Errorf("expected the angle to pass through pi, got %v", a)
+	}
+}
+
+func TestPoseAt_NewEntityDrawnWhereItIs(t *testing.T) {
+	w := NewWorld()
+	recordPrevious(w)
+	e := SpawnAsteroid(w, 100, 100, SizeLarge)
+
+	if pos, _ := w.poseAt(e, 0.25); pos != w.positions[e] {
+		t.Error("an entity spawned this tick has no previous pose to blend from")
+	}
+}
@@ -0,0 +1,155 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// Action is something the keyboard player does during play.
+type Action int
+
+const (
+	ActionRotateLeft Action = iota
+	ActionRotateRight
+	ActionThrust
+	ActionShoot
+	ActionHyperspace
+	ActionMissile
+	ActionShield
+	ActionPause
+	NumActions
+)
+
+// actionNames name each action in the profile.
+var actionNames = [NumActions]string{"rotate_left", "rotate_right", "thrust", "shoot", "hyperspace", "missile", "shield", "pause"}
+
+func (a Action) String() string {
+	if a < 0 || a >= NumActions {
+		return fmt.Sprintf("Action(%d)", int(a))
+	}
+	return actionNames[a]
+}
+
+// KeyBindings maps each action to the keys that perform it; any one of
+// them will do.
+type KeyBindings [NumActions][]ebiten.Key
+
+// DefaultKeyBindings are the standard keys: the arrows or WASD to fly,
+// Space to shoot, Shift for hyperspace, X for a missile, C for the shield
+// and Escape to pause.
+func DefaultKeyBindings() KeyBindings {
+	return KeyBindings{
+		ActionRotateLeft:  {ebiten.KeyLeft, ebiten.KeyA},
+		ActionRotateRight: {ebiten.KeyRight, ebiten.KeyD},
+		ActionThrust:      {ebiten.KeyUp, ebiten.KeyW},
+		ActionShoot:       {ebiten.KeySpace},
+		ActionHyperspace:  {ebiten.KeyShiftLeft, ebiten.KeyShiftRight},
+		ActionMissile:     {ebiten.KeyX},
+		ActionShield:      {ebiten.KeyC},
+		ActionPause:       {ebiten.KeyEscape},
+	}
+}
+
+// Pressed reports whether a key for a is down.
+func (b *KeyBindings) Pressed(a Action) bool {
+	return slices.ContainsFunc(b[a], ebiten.IsKeyPressed)
+}
+
+// JustPressed reports whether a key for a went down this frame.
+func (b *KeyBindings) JustPressed(a Action) bool {
+	return slices.ContainsFunc(b[a], inpututil.IsKeyJustPressed)
+}
+
+// Read polls the keyboard and returns the current InputState.
+func (b *KeyBindings) Read() InputState {
+	return InputState{
+		RotateLeft:  b.Pressed(ActionRotateLeft),
+		RotateRight: b.Pressed(ActionRotateRight),
+		Thrust:      b.Pressed(ActionThrust),
+		Shoot:       b.JustPressed(ActionShoot),
+		Hyperspace:  b.JustPressed(ActionHyperspace),
+		Missile:     b.JustPressed(ActionMissile),
+		Shield:      b.Pressed(ActionShield),
+	}
+}
+
+// heldButtons polls the keyboard for the one-shot buttons.
+func (b *KeyBindings) heldButtons() InputState {
+	return InputState{
+		Shoot:      b.Pressed(ActionShoot),
+		Hyperspace: b.Pressed(ActionHyperspace),
+		Missile:    b.Pressed(ActionMissile),
+	}
+}
+
+// Bind makes k the only key for a. Any other action k was bound to loses
+// it, and one left with no keys takes a's old ones, so binding a key that
+// is in use swaps the two.
+func (b *KeyBindings) Bind(a Action, k ebiten.Key) {
+	old := b[a]
+	b[a] = []ebiten.Key{k}
+	for other := range b {
+		if Action(other) == a || !slices.Contains(b[other], k) {
+			continue
+		}
+		b[other] = slices.DeleteFunc(slices.Clone(b[other]), func(o ebiten.Key) bool { return o == k })
+		if len(b[other]) == 0 {
+			b[other] = slices.DeleteFunc(slices.Clone(old), func(o ebiten.Key) bool { return o == k })
+		}
+	}
+}
+
+// Names returns the bindings by action and key name, as kept in the
+// profile.
+func (b *KeyBindings) Names() map[string][]string {
+	m := make(map[string][]string, NumActions)
+	for a, keys := range b {
+		names := make([]string, len(keys))
+		for i, k := range keys {
+			names[i] = k.String()
+		}
+		m[Action(a).String()] = names
+	}
+	return m
+}
+
+// KeyBindingsFromNames reads bindings kept with Names. Actions missing
+// from m, or whose keys are all unknown, keep their default keys, except
+// any the player has bound to another action: an action added since the
+// profile was saved must not take over a key already in use.
+func KeyBindingsFromNames(m map[string][]string) KeyBindings {
+	b := DefaultKeyBindings()
+	var saved [NumActions]bool
+	var taken []ebiten.Key
+	for a := range b {
+		var keys []ebiten.Key
+		for _, name := range m[Action(a).String()] {
+			var k ebiten.Key
+			if err := k.UnmarshalText([]byte(name)); err != nil {
+				logger.Warn("unknown key in bindings", "action", Action(a), "key", name)
+				continue
+			}
+			keys = append(keys, k)
+		}
+		if len(keys) > 0 {
+			b[a] = keys
+			saved[a] = true
+			taken = append(taken, keys...)
+		}
+	}
+	for a := range b {
+		if !saved[a] {
+			b[a] = slices.DeleteFunc(b[a], func(k ebiten.Key) bool { return slices.Contains(taken, k) })
+		}
+	}
+	return b
+}
+
+// keyLabel is how a key is shown on the controls page.
+func keyLabel(k ebiten.Key) string {
+	return strings.ToUpper(strings.TrimPrefix(k.String(), "Arrow"))
+}
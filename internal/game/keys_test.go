@@ -0,0 +1,106 @@
+package game
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/matheus3301/asteroids/internal/storage"
+)
+
+func TestKeyBindings_BindSwapsATakenKey(t *testing.T) {
+	b := DefaultKeyBindings()
+	b.Bind(ActionThrust, ebiten.KeyW)
+	if !slices.Equal(b[ActionThrust], []ebiten.Key{ebiten.KeyW}) {
+		t.Errorf("thrust = %v, want only W", b[ActionThrust])
+	}
+
+	b.Bind(ActionShoot, ebiten.KeyX)
+	if !slices.Equal(b[ActionShoot], []ebiten.Key{ebiten.KeyX}) {
+		t.Errorf("shoot = %v, want X", b[ActionShoot])
+	}
+	if !slices.Equal(b[ActionMissile], []ebiten.Key{ebiten.KeySpace}) {
+		t.Errorf("missile = %v, want the Space key shoot gave up", b[ActionMissile])
+	}
+
+	b.Bind(ActionPause, ebiten.KeyA)
+	if !slices.Equal(b[ActionRotateLeft], []ebiten.Key{ebiten.KeyLeft}) {
+		t.Errorf("rotate left = %v, want it to keep Left", b[ActionRotateLeft])
+	}
+}
+
+func TestKeyBindings_NamesRoundTrip(t *testing.T) {
+	b := DefaultKeyBindings()
+	b.Bind(ActionHyperspace, ebiten.KeyH)
+	b.Bind(ActionPause, ebiten.KeyP)
+
+	got := KeyBindingsFromNames(b.Names())
+	for a := range got {
+		if !slices.Equal(got[a], b[a]) {
+			t.Errorf("%v = %v, want %v", Action(a), got[a], b[a])
+		}
+	}
+}
+
+func TestKeyBindingsFromNames_KeepsDefaultsForBadEntries(t *testing.T) {
+	b := KeyBindingsFromNames(map[string][]string{
+		"shoot":  {"NoSuchKey"},
+		"thrust": {"K"},
+		"dance":  {"D"},
+	})
+	def := DefaultKeyBindings()
+	if !slices.Equal(b[ActionShoot], def[ActionShoot]) {
+		t.Errorf("shoot = %v, want the default", b[ActionShoot])
+	}
+	if !slices.Equal(b[ActionThrust], []ebiten.Key{ebiten.KeyK}) {
+		t.Errorf("thrust = %v, want K", b[ActionThrust])
+	}
+}
+
+func TestKeyBindingsFromNames_NewActionSkipsTakenDefaults(t *testing.T) {
+	// A profile saved before the shield existed, with C rebound to shoot.
+	def := DefaultKeyBindings()
+	m := def.Names()
+	delete(m, "shield")
+	m["shoot"] = []string{"C"}
+	b := KeyBindingsFromNames(m)
+	if len(b[ActionShield]) != 0 {
+		t.Errorf("the shield should not take C from shoot, got %v", b[ActionShield])
+	}
+	if !slices.Equal(b[ActionShoot], []ebiten.Key{ebiten.KeyC}) {
+		t.Errorf("shoot = %v, want C", b[ActionShoot])
+	}
+
+	delete(m, "shoot")
+	if b := KeyBindingsFromNames(m); !slices.Equal(b[ActionShield], []ebiten.Key{ebiten.KeyC}) {
+		t.Errorf("with C free the shield should keep it, got %v", b[ActionShield])
+	}
+}
+
+func TestControls_RebindSavedInProfile(t *testing.T) {
+	restore := storage.Override(storage.At(t.TempDir()))
+	defer restore()
+
+	g := New()
+	g.settingsCursor = 9
+	g.settingsSelect()
+	if g.state != stateControls {
+		t.Fatalf("expected the controls page, got %v", g.state)
+	}
+	g.controls.cursor = int(ActionMissile)
+	g.controlsSelect()
+	if !g.controls.waiting {
+		t.Fatal("Enter on an action should wait for a key")
+	}
+	g.bindKey(ActionMissile, ebiten.KeyM)
+
+	if got := New().keys[ActionMissile]; !slices.Equal(got, []ebiten.Key{ebiten.KeyM}) {
+		t.Errorf("a new game has missile on %v, want M", got)
+	}
+
+	g.controls.cursor = controlsReset
+	g.controlsSelect()
+	if got := New().keys[ActionMissile]; !slices.Equal(got, []ebiten.Key{ebiten.KeyX}) {
+		t.Errorf("after reset missile is on %v, want X", got)
+	}
+}
@@ -0,0 +1,68 @@
+package game
+
+// KillKind is what a player's shot destroyed.
+type KillKind int
+
+const (
+	KillAsteroid KillKind = iota
+	KillSaucer
+	// KillSaucerBullet is a saucer bullet shot down.
+	KillSaucerBullet
+	NumKillKinds
+)
+
+func (k KillKind) String() string {
+	switch k {
+	case KillAsteroid:
+		return "asteroid"
+	case KillSaucer:
+		return "saucer"
+	case KillSaucerBullet:
+		return "saucer_bullet"
+	}
+	return "unknown"
+}
+
+// Kill is one thing a player's shot destroyed. Asteroids caught in an
+// explosion are credited to the shot that set it off.
+type Kill struct {
+	Kind   KillKind
+	Target Entity
+	// Shot is the bullet or missile that made the kill and Owner the ship
+	// that fired it.
+	Shot, Owner Entity
+	// Points is what the kill scored, before any extra life paid out as
+	// points, and Multiplier the combo multiplier it was made under.
+	Points     int
+	Multiplier int
+}
+
+// recordKill adds k to the tick's kills and to its ship's tally. Call it
+// before the kill extends the combo.
+func recordKill(w *World, k Kill) {
+	k.Multiplier = w.ComboMultiplier()
+	w.Kills = append(w.Kills, k)
+	if pc := w.players[k.Owner]; pc != nil {
+		pc.Kills[k.Kind]++
+	}
+}
+
+// credit gives the ship that fired a shot its share of the points the shot
+// scored, and counts the hit toward its accuracy.
+func credit(w *World, owner Entity, points int) {
+	w.Stats.ShotsHit++
+	if pc := w.players[owner]; pc != nil {
+		pc.Score += points
+		pc.Hits++
+	}
+}
+
+// missed counts a shot by owner that ran out of life without hitting
+// anything against its accuracy. Shots still in flight when the game
+// ends count neither way.
+func missed(w *World, owner Entity) {
+	w.Stats.ShotsMissed++
+	if pc := w.players[owner]; pc != nil {
+		pc.Misses++
+	}
+}
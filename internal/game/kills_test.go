@@ -0,0 +1,134 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+// secondShip is the ship in slot 1 of a co-op world.
+func secondShip(w *World) Entity {
+	for e, pc := range w.players {
+		if pc.Slot == 1 {
+			return e
+		}
+	}
+	return 0
+}
+
+func TestKills_AttributedToTheShooter(t *testing.T) {
+	w := NewCoopWorld(1)
+	for _, e := range sortedEntities(w.asteroids) {
+		w.Destroy(e)
+	}
+	p2 := secondShip(w)
+	w.Combo = w.Config.ComboStep * 2
+
+	b := SpawnBullet(w, p2)
+	bpos := w.positions[b]
+	a := SpawnAsteroid(w, bpos.X, bpos.Y, SizeSmall)
+	SpawnAsteroid(w, 100, 100, SizeLarge) // keeps the wave from ending
+	CollisionResponseSystem(w, CollisionSystem(w))
+
+	want := Kill{Kind: KillAsteroid, Target: a, Shot: b, Owner: p2, Points: 100, Multiplier: 3}
+	if len(w.Kills) != 1 || w.Kills[0] != want {
+		t.Fatalf("kills = %+v, want [%+v]", w.Kills, want)
+	}
+	if n := w.players[p2].Kills[KillAsteroid]; n != 1 {
+		t.Errorf("the second ship should have 1 asteroid kill, has %d", n)
+	}
+	if n := w.players[w.Player].Kills[KillAsteroid]; n != 0 {
+		t.Errorf("the first ship should have no kills, has %d", n)
+	}
+
+	StepCoop(w, Inputs{{}, {}})
+	if len(w.Kills) != 0 {
+		t.Errorf("kills should only list the tick's, got %+v", w.Kills)
+	}
+}
+
+func TestKills_ExplosionCreditsTheShot(t *testing.T) {
+	w := NewWorld()
+	p := SpawnPlayer(w, 400, 300)
+	bomb := spawnAsteroidVariant(w, 100, 100, SizeSmall, VariantExplosive)
+	near := SpawnAsteroid(w, 150, 100, SizeSmall)
+
+	shootAsteroid(w, bomb, 99, p)
+
+	if len(w.Kills) != 2 || w.Kills[0].Target != bomb || w.Kills[1].Target != near {
+		t.Fatalf("expected the bomb and its neighbour, got %+v", w.Kills)
+	}
+	for _, k := range w.Kills {
+		if k.Shot != 99 || k.Owner != p {
+			t.Errorf("every kill in the blast should go to the shot, got %+v", k)
+		}
+	}
+	if w.Kills[0].Multiplier != 1 || w.Kills[1].Multiplier != 1 {
+		t.Errorf("the combo had not built up yet: %+v", w.Kills)
+	}
+}
+
+func TestKills_SaucerAndShootDown(t *testing.T) {
+	w := NewWorld()
+	w.Player = SpawnPlayer(w, 400, 300)
+	s := SpawnSaucer(w, SaucerSmall)
+	b := SpawnBullet(w, w.Player)
+	CollisionResponseSystem(w, CollisionEvent{SaucerBulletHits: []saucerHit{{Bullet: b, Saucer: s, Owner: w.Player}}})
+
+	if len(w.Kills) != 1 || w.Kills[0].Kind != KillSaucer || w.Kills[0].Points != 1000 || w.Kills[0].Owner != w.Player {
+		t.Errorf("kills = %+v", w.Kills)
+	}
+	if pc := w.players[w.Player]; pc.Kills[KillSaucer] != 1 || pc.Hits != 1 {
+		t.Errorf("the ship should have a saucer kill and a hit: %+v", pc)
+	}
+}
+
+func TestAccuracy_MissesOnlyWhenShotsExpire(t *testing.T) {
+	w := NewWorld()
+	w.Player = SpawnPlayer(w, 400, 300)
+	pc := w.players[w.Player]
+
+	hit := SpawnBullet(w, w.Player)
+	credit(w, w.Player, 20)
+	w.Destroy(hit)
+	expiring := SpawnBullet(w, w.Player)
+	w.bullets[expiring].Life = 1
+	SpawnBullet(w, w.Player) // still in flight
+	pc.Shots = 3
+	LifetimeSystem(w)
+
+	if pc.Hits != 1 || pc.Misses != 1 || w.Stats.ShotsHit != 1 || w.Stats.ShotsMissed != 1 {
+		t.Fatalf("hits %d misses %d, stats %+v", pc.Hits, pc.Misses, w.Stats)
+	}
+	if pc.Accuracy() != 0.5 || w.Stats.Accuracy() != 0.5 {
+		t.Errorf("accuracy = %v and %v, want 0.5: the shot in flight counts neither way", pc.Accuracy(), w.Stats.Accuracy())
+	}
+}
+
+func TestAccuracy_ExpiredMissile(t *testing.T) {
+	w := NewWorld()
+	w.Player = SpawnPlayer(w, 400, 300)
+	m := SpawnMissile(w, w.Player)
+	w.missiles[m].Life = 1
+	MissileSystem(w)
+	if w.Alive(m) || w.players[w.Player].Misses != 1 {
+		t.Errorf("a missile running out should count as a miss, misses %d", w.players[w.Player].Misses)
+	}
+}
+
+func TestCoopScoreboard(t *testing.T) {
+	if lines := coopScoreboard(NewGameWorld(1)); lines != nil {
+		t.Errorf("a single-player game has no scoreboard, got %q", lines)
+	}
+	w := NewCoopWorld(1)
+	p2 := w.players[secondShip(w)]
+	p2.Score, p2.Hits, p2.Misses = 1200, 3, 1
+	p2.Kills[KillAsteroid], p2.Kills[KillSaucer] = 4, 1
+
+	lines := coopScoreboard(w)
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "P1 ") {
+		t.Fatalf("expected a line per ship in slot order, got %q", lines)
+	}
+	if want := "P2  1200 PTS  5 KILLS  3/4 HIT"; lines[1] != want {
+		t.Errorf("got %q, want %q", lines[1], want)
+	}
+}
@@ -6,17 +6,42 @@ import (
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
-	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/matheus3301/asteroids/internal/canvas"
+)
+
+type menuAction int
+
+const (
+	actionStart menuAction = iota
+	actionMode
+	actionCoop
+	actionReplay
+	actionStats
+	actionCareer
+	actionDrills
+	actionTutorial
+	actionMods
+	actionSettings
+	actionQuit
 )
 
 type menuItem struct {
-	label string
+	label  string
+	action menuAction
 }
 
 var mainMenuItems = []menuItem{
-	{label: "START GAME"},
-	{label: "SETTINGS"},
-	{label: "QUIT"},
+	{label: "START GAME", action: actionStart},
+	{label: "MODE", action: actionMode},
+	{label: "CO-OP", action: actionCoop},
+	{label: "WATCH REPLAY", action: actionReplay},
+	{label: "STATS", action: actionStats},
+	{label: "CAREER", action: actionCareer},
+	{label: "DRILLS", action: actionDrills},
+	{label: "TUTORIAL", action: actionTutorial},
+	{label: "MODS", action: actionMods},
+	{label: "SETTINGS", action: actionSettings},
+	{label: "QUIT", action: actionQuit},
 }
 
 var pauseMenuItems = []menuItem{
@@ -28,12 +53,22 @@ var settingsLabels = []string{
 	"RESOLUTION",
 	"FULLSCREEN",
 	"VOLUME",
+	"STATS LOGGING",
+	"AUTOFIRE",
+	"AUTO PAUSE",
+	"GAME SPEED",
+	"DIFFICULTY",
+	"ACCESSIBILITY",
+	"CONTROLS",
 	"BACK",
 }
 
 // --- Main Menu ---
 
 func (g *Game) updateMenu() {
+	if g.idleMenu(len(inpututil.AppendPressedKeys(nil)) > 0) {
+		return
+	}
 	if inpututil.IsKeyJustPressed(ebiten.KeyUp) {
 		g.menuCursor--
 		if g.menuCursor < 0 {
@@ -55,21 +90,52 @@ func (g *Game) updateMenu() {
 		g.sound.PlayConfirm()
 		g.menuSelect()
 	}
+	if mainMenuItems[g.menuCursor].action == actionMode {
+		if inpututil.IsKeyJustPressed(ebiten.KeyLeft) {
+			g.setMode(g.mode - 1)
+			g.ensureSound()
+			g.sound.PlayBlip()
+		}
+		if inpututil.IsKeyJustPressed(ebiten.KeyRight) {
+			g.setMode(g.mode + 1)
+			g.ensureSound()
+			g.sound.PlayBlip()
+		}
+	}
 }
 
 func (g *Game) menuSelect() {
-	switch g.menuCursor {
-	case 0: // Start Game
-		g.reset()
-	case 1: // Settings
+	switch mainMenuItems[g.menuCursor].action {
+	case actionStart:
+		g.openShipSelect()
+	case actionMode:
+		g.setMode(g.mode + 1)
+	case actionCoop:
+		g.openCoop()
+	case actionReplay:
+		g.watchLatestReplay()
+	case actionStats:
+		g.statsPage = statsSummary
+		g.deathMapCause = NumDeathCauses
+		g.state = stateStats
+	case actionCareer:
+		g.careerCursor = 0
+		g.state = stateCareer
+	case actionDrills:
+		g.openDrills()
+	case actionTutorial:
+		g.startTutorial()
+	case actionMods:
+		g.openMods()
+	case actionSettings:
 		g.state = stateSettings
 		g.settingsCursor = 0
-	case 2: // Quit
+	case actionQuit:
 		g.quit = true
 	}
 }
 
-func (g *Game) drawMenu(screen *ebiten.Image) {
+func (g *Game) drawMenu(screen canvas.Canvas) {
 	screen.Fill(color.Black)
 
 	// Title
@@ -81,18 +147,22 @@ func (g *Game) drawMenu(screen *ebiten.Image) {
 
 	// Menu items
 	itemScale := 3.0
-	startY := 300.0
-	spacing := 50.0
+	startY := 210.0
+	spacing := 35.0
 
 	for i, item := range mainMenuItems {
 		clr := color.RGBA{255, 255, 255, 255}
 		if i == g.menuCursor {
 			clr = color.RGBA{0, 255, 0, 255}
 		}
-		w := TextWidth(item.label, itemScale)
+		label := item.label
+		if item.action == actionMode {
+			label = fmt.Sprintf("%s: %s", label, g.mode)
+		}
+		w := TextWidth(label, itemScale)
 		x := (ScreenWidth - w) / 2
 		y := startY + float64(i)*spacing
-		DrawText(screen, item.label, x, y, itemScale, clr)
+		DrawText(screen, label, x, y, itemScale, clr)
 	}
 }
 
@@ -149,7 +219,25 @@ func (g *Game) settingsSelect() {
 	case 1: // Fullscreen — toggle
 		g.settings.fullscreen = !g.settings.fullscreen
 	case 2: // Volume — no-op on Enter
-	case 3: // Back
+	case 3: // Stats logging — toggle
+		g.setTelemetry(!g.telemetry.Enabled)
+	case 4: // Autofire — toggle
+		g.setAutofire(!g.settings.autofire)
+	case 5: // Auto pause — toggle
+		g.settings.autoPause = !g.settings.autoPause
+	case 6: // Game speed — cycle forward
+		speed := g.settings.speed + gameSpeedStep
+		if speed > MaxGameSpeed {
+			speed = MinGameSpeed
+		}
+		g.setSpeed(speed)
+	case 7: // Difficulty — cycle forward
+		g.setDifficulty((g.difficulty + 1) % Difficulty(len(Difficulties)))
+	case 8: // Accessibility — open
+		g.openAccessibility()
+	case 9: // Controls — open
+		g.openControls()
+	case 10: // Back
 		g.state = stateMenu
 	}
 }
@@ -169,6 +257,16 @@ func (g *Game) settingsLeft() {
 			g.settings.volume = 0
 		}
 		g.sound.SetMasterVolume(float64(g.settings.volume) / 10.0)
+	case 3:
+		g.setTelemetry(!g.telemetry.Enabled)
+	case 4:
+		g.setAutofire(!g.settings.autofire)
+	case 5:
+		g.settings.autoPause = !g.settings.autoPause
+	case 6:
+		g.setSpeed(g.settings.speed - gameSpeedStep)
+	case 7:
+		g.setDifficulty(max(g.difficulty-1, 0))
 	}
 }
 
@@ -184,10 +282,20 @@ func (g *Game) settingsRight() {
 			g.settings.volume = 10
 		}
 		g.sound.SetMasterVolume(float64(g.settings.volume) / 10.0)
+	case 3:
+		g.setTelemetry(!g.telemetry.Enabled)
+	case 4:
+		g.setAutofire(!g.settings.autofire)
+	case 5:
+		g.settings.autoPause = !g.settings.autoPause
+	case 6:
+		g.setSpeed(g.settings.speed + gameSpeedStep)
+	case 7:
+		g.setDifficulty(min(g.difficulty+1, Difficulty(len(Difficulties)-1)))
 	}
 }
 
-func (g *Game) drawSettings(screen *ebiten.Image) {
+func (g *Game) drawSettings(screen canvas.Canvas) {
 	screen.Fill(color.Black)
 
 	// Title
@@ -198,8 +306,8 @@ func (g *Game) drawSettings(screen *ebiten.Image) {
 	DrawText(screen, titleText, titleX, 100, titleScale, color.RGBA{255, 255, 255, 255})
 
 	itemScale := 2.5
-	startY := 230.0
-	spacing := 60.0
+	startY := 170.0
+	spacing := 34.0
 
 	for i, label := range settingsLabels {
 		clr := color.RGBA{255, 255, 255, 255}
@@ -220,6 +328,28 @@ func (g *Game) drawSettings(screen *ebiten.Image) {
 			text = fmt.Sprintf("%s: %s", label, val)
 		case 2:
 			text = fmt.Sprintf("%s: %d%%", label, g.settings.volume*10)
+		case 3:
+			val := "OFF"
+			if g.telemetry.Enabled {
+				val = "ON"
+			}
+			text = fmt.Sprintf("%s: %s", label, val)
+		case 4:
+			val := "OFF"
+			if g.settings.autofire {
+				val = "ON"
+			}
+			text = fmt.Sprintf("%s: %s", label, val)
+		case 5:
+			val := "OFF"
+			if g.settings.autoPause {
+				val = "ON"
+			}
+			text = fmt.Sprintf("%s: %s", label, val)
+		case 6:
+			text = fmt.Sprintf("%s: X%g", label, g.settings.speed)
+		case 7:
+			text = fmt.Sprintf("%s: %s", label, g.difficulty)
 		default:
 			text = label
 		}
@@ -235,7 +365,7 @@ func (g *Game) drawSettings(screen *ebiten.Image) {
 	hint := "LEFT-RIGHT TO CHANGE . ENTER TO TOGGLE . ESC TO GO BACK"
 	hintW := TextWidth(hint, hintScale)
 	hintX := (ScreenWidth - hintW) / 2
-	DrawText(screen, hint, hintX, 500, hintScale, color.RGBA{100, 100, 100, 255})
+	DrawText(screen, hint, hintX, 560, hintScale, color.RGBA{100, 100, 100, 255})
 }
 
 // --- Pause ---
@@ -274,20 +404,24 @@ func (g *Game) pauseSelect() {
 		g.state = statePlaying
 	case 1: // Quit to Menu
 		g.sound.StopAll()
+		g.finishRecording()
+		g.closeNet()
 		g.state = stateMenu
 	}
 }
 
-func (g *Game) drawPaused(screen *ebiten.Image) {
+func (g *Game) drawPaused(screen canvas.Canvas) {
 	screen.Fill(color.Black)
 
 	// Draw the frozen game world
-	RenderSystem(g.world, screen)
-	DrawThrust(g.world, screen)
-	g.drawHUD(screen)
+	field := g.worldCanvas(screen, g.world)
+	RenderSystem(g.world, field, 1)
+	DrawThrust(g.world, field, 1)
+	g.drawHUD(field)
 
 	// Dark overlay
-	vector.FillRect(screen, 0, 0, ScreenWidth, ScreenHeight, color.RGBA{0, 0, 0, 150}, false)
+	screen.FillRect(0, 0, g.fieldWidth, g.fieldHeight, color.RGBA{0, 0, 0, 150})
+	screen = g.menuCanvas(screen)
 
 	// Title
 	titleScale := 5.0
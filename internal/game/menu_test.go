@@ -8,6 +8,10 @@ func TestMenuSelect_StartGame(t *testing.T) {
 	g := New()
 	g.menuCursor = 0
 	g.menuSelect()
+	if g.state != stateShipSelect {
+		t.Fatalf("expected stateShipSelect, got %v", g.state)
+	}
+	g.reset() // Enter on the ship selection screen
 
 	if g.state != statePlaying {
 		t.Errorf("expected statePlaying, got %v", g.state)
@@ -28,7 +32,7 @@ func TestMenuSelect_StartGame(t *testing.T) {
 
 func TestMenuSelect_Settings(t *testing.T) {
 	g := New()
-	g.menuCursor = 1
+	g.menuCursor = 9
 	g.menuSelect()
 
 	if g.state != stateSettings {
@@ -41,7 +45,7 @@ func TestMenuSelect_Settings(t *testing.T) {
 
 func TestMenuSelect_Quit(t *testing.T) {
 	g := New()
-	g.menuCursor = 2
+	g.menuCursor = 10
 	g.menuSelect()
 
 	if !g.quit {
@@ -135,6 +139,7 @@ func TestPauseToMenu_ThenStartNewGame(t *testing.T) {
 	// Start a new game from menu
 	g.menuCursor = 0
 	g.menuSelect()
+	g.reset()
 
 	if g.world.Score != 0 {
 		t.Errorf("new game should reset score, got %d", g.world.Score)
@@ -198,7 +203,7 @@ func TestSettingsSelect_FullscreenToggles(t *testing.T) {
 func TestSettingsSelect_Back(t *testing.T) {
 	g := New()
 	g.state = stateSettings
-	g.settingsCursor = 3
+	g.settingsCursor = 10
 	g.settingsSelect()
 
 	if g.state != stateMenu {
@@ -206,6 +211,37 @@ func TestSettingsSelect_Back(t *testing.T) {
 	}
 }
 
+func TestSettingsSelect_AutofireToggle(t *testing.T) {
+	g := New()
+	g.state = stateSettings
+	g.settingsCursor = 4
+	g.settingsSelect()
+
+	if kb, ok := g.input.(KeyboardInput); !ok || !kb.Autofire {
+		t.Errorf("autofire should reach the keyboard input, got %#v", g.input)
+	}
+	g.settingsSelect()
+	if kb := g.input.(KeyboardInput); kb.Autofire {
+		t.Error("autofire should be off after second toggle")
+	}
+}
+
+func TestFireButton(t *testing.T) {
+	cases := []struct {
+		justPressed, held, autofire, want bool
+	}{
+		{true, true, false, true},
+		{false, true, false, false},
+		{false, true, true, true},
+		{false, false, true, false},
+	}
+	for _, c := range cases {
+		if got := fireButton(c.justPressed, c.held, c.autofire); got != c.want {
+			t.Errorf("fireButton(%v, %v, %v) = %v, want %v", c.justPressed, c.held, c.autofire, got, c.want)
+		}
+	}
+}
+
 func TestSettingsLeft_ResolutionCyclesBackward(t *testing.T) {
 	g := New()
 	g.state = stateSettings
@@ -266,6 +302,55 @@ func TestPlaying_EscapeSetsStatePaused(t *testing.T) {
 	}
 }
 
+func TestUpdateFocus_PausesKeyboardGame(t *testing.T) {
+	g := newPlaying()
+
+	if g.updateFocus(false) {
+		t.Error("losing focus should not skip the frame")
+	}
+	if g.state != statePaused {
+		t.Fatalf("expected statePaused, got %v", g.state)
+	}
+	if !g.updateFocus(true) {
+		t.Error("the first frame back should be skipped")
+	}
+	if g.updateFocus(true) || g.state != statePaused {
+		t.Error("later frames should run, with the game still paused")
+	}
+}
+
+func TestUpdateFocus_Disabled(t *testing.T) {
+	g := NewWithOptions(Options{NoAutoPause: true})
+	g.reset()
+	g.updateFocus(false)
+	if g.state != statePlaying {
+		t.Errorf("expected the game to keep running, got %v", g.state)
+	}
+	if g.updateFocus(true) {
+		t.Error("a running game should not skip the frame focus returns")
+	}
+
+	g = NewWithOptions(Options{Input: Autopilot{}})
+	g.reset()
+	g.updateFocus(false)
+	if g.state != statePlaying {
+		t.Errorf("games played by an agent should not pause, got %v", g.state)
+	}
+}
+
+func TestSettingsSelect_AutoPauseToggle(t *testing.T) {
+	g := New()
+	if !g.settings.autoPause {
+		t.Fatal("auto pause should be on by default")
+	}
+	g.state = stateSettings
+	g.settingsCursor = 5
+	g.settingsSelect()
+	if g.settings.autoPause {
+		t.Error("auto pause should be off after toggling")
+	}
+}
+
 func TestGameOver_TransitionsToMenu(t *testing.T) {
 	g := newPlaying()
 	g.state = stateGameOver
@@ -287,6 +372,7 @@ func TestFullFlow_MenuToPlayToPauseToMenuToPlay(t *testing.T) {
 	// Start game
 	g.menuCursor = 0
 	g.menuSelect()
+	g.reset()
 	if g.state != statePlaying {
 		t.Fatalf("expected statePlaying, got %v", g.state)
 	}
@@ -305,6 +391,7 @@ func TestFullFlow_MenuToPlayToPauseToMenuToPlay(t *testing.T) {
 	// Start new game again
 	g.menuCursor = 0
 	g.menuSelect()
+	g.reset()
 	if g.state != statePlaying {
 		t.Fatalf("expected statePlaying on second start, got %v", g.state)
 	}
@@ -0,0 +1,113 @@
+package game
+
+import (
+	"image/color"
+	"math"
+)
+
+const (
+	missileAmmo     = 3
+	missileSpeed    = 5.0
+	missileTurnRate = 0.08 // radians per tick
+	missileLife     = 150
+	missileTrailGap = 3 // ticks between trail particles
+)
+
+var missileVerts = [][2]float64{
+	{6, 0},
+	{-4, -3},
+	{-4, 3},
+}
+
+var missileTrailColor = color.RGBA{180, 180, 255, 255}
+
+// SpawnMissile launches a homing missile from the player's nose.
+func SpawnMissile(w *World, playerEntity Entity) Entity {
+	e := w.Spawn()
+
+	pos := w.positions[playerEntity]
+	angle := w.rotations[playerEntity].Angle
+
+	w.positions[e] = &Position{
+		X: pos.X + math.Cos(angle)*playerRadius,
+		Y: pos.Y + math.Sin(angle)*playerRadius,
+	}
+	w.velocities[e] = &Velocity{
+		X: math.Cos(angle) * w.Config.MissileSpeed,
+		Y: math.Sin(angle) * w.Config.MissileSpeed,
+	}
+	w.rotations[e] = &Rotation{Angle: angle}
+	w.colliders[e] = &Collider{Radius: 3, Layer: LayerPlayerBullet}
+	w.wrappers[e] = true
+
+	w.renderables[e] = &Renderable{
+		Kind:     ShapeTriangle,
+		Vertices: missileVerts,
+		Color:    w.Palette.Bullet,
+		Scale:    1,
+	}
+
+	w.missiles[e] = &MissileTag{Life: w.Config.MissileLife, Owner: playerEntity}
+
+	return e
+}
+
+// MissileSystem launches missiles for players pressing the missile key and
+// steers every missile toward its nearest target.
+func MissileSystem(w *World) {
+	for _, e := range sortedEntities(w.players) {
+		pc := w.players[e]
+		if pc.MissilePressed && pc.Missiles > 0 {
+			pc.Missiles--
+			SpawnMissile(w, e)
+			w.Stats.ShotsFired++
+			pc.Shots++
+			w.SoundQueue = append(w.SoundQueue, SoundFire)
+		}
+	}
+
+	for _, e := range sortedEntities(w.missiles) {
+		m := w.missiles[e]
+		m.Life--
+		if m.Life <= 0 {
+			missed(w, m.Owner)
+			w.Destroy(e)
+			continue
+		}
+		pos, vel, rot := w.positions[e], w.velocities[e], w.rotations[e]
+		if tx, ty, ok := nearestTarget(w, pos); ok {
+			want := math.Atan2(ty-pos.Y, tx-pos.X)
+			turn := math.Remainder(want-rot.Angle, 2*math.Pi)
+			rot.Angle += math.Max(-w.Config.MissileTurnRate, math.Min(w.Config.MissileTurnRate, turn))
+			vel.X = math.Cos(rot.Angle) * w.Config.MissileSpeed
+			vel.Y = math.Sin(rot.Angle) * w.Config.MissileSpeed
+		}
+		if m.Life%missileTrailGap == 0 {
+			p := SpawnParticle(w, pos.X, pos.Y)
+			w.renderables[p].Color = missileTrailColor
+		}
+	}
+}
+
+// nearestTarget returns the closest asteroid or saucer to pos, measured
+// across the screen edges since everything wraps.
+func nearestTarget(w *World, pos *Position) (x, y float64, ok bool) {
+	best := math.Inf(1)
+	consider := func(e Entity) {
+		tp := w.positions[e]
+		if tp == nil {
+			return
+		}
+		dx, dy := w.Field().Delta(pos.X, pos.Y, tp.X, tp.Y)
+		if d := dx*dx + dy*dy; d < best {
+			best, x, y, ok = d, pos.X+dx, pos.Y+dy, true
+		}
+	}
+	for _, e := range sortedEntities(w.asteroids) {
+		consider(e)
+	}
+	for _, e := range sortedEntities(w.saucers) {
+		consider(e)
+	}
+	return x, y, ok
+}
@@ -0,0 +1,110 @@
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMissileSystem_LaunchUsesAmmo(t *testing.T) {
+	w := NewWorld()
+	e := SpawnPlayer(w, 400, 300)
+	w.players[e].MissilePressed = true
+
+	MissileSystem(w)
+
+	if len(w.missiles) != 1 {
+		t.Fatalf("expected 1 missile, got %d", len(w.missiles))
+	}
+	if got := w.players[e].Missiles; got != missileAmmo-1 {
+		t.Errorf("expected %d missiles left, got %d", missileAmmo-1, got)
+	}
+	if w.BulletCount() != 0 {
+		t.Error("missiles must not count against the bullet cap")
+	}
+}
+
+func TestMissileSystem_NoAmmo(t *testing.T) {
+	w := NewWorld()
+	e := SpawnPlayer(w, 400, 300)
+	w.players[e].Missiles = 0
+	w.players[e].MissilePressed = true
+
+	MissileSystem(w)
+
+	if len(w.missiles) != 0 {
+		t.Errorf("expected no missile without ammo, got %d", len(w.missiles))
+	}
+}
+
+func TestMissileSystem_TurnsTowardTarget(t *testing.T) {
+	w := NewWorld()
+	p := SpawnPlayer(w, 400, 300)
+	w.rotations[p].Angle = 0 // facing right
+	m := SpawnMissile(w, p)
+	SpawnAsteroid(w, 400, 100, SizeLarge) // straight up
+
+	MissileSystem(w)
+
+	got := w.rotations[m].Angle
+	if got >= 0 {
+		t.Errorf("missile should turn toward the asteroid above, angle %v", got)
+	}
+	if math.Abs(got) > missileTurnRate+1e-9 {
+		t.Errorf("turn %v exceeds the turn rate %v", got, missileTurnRate)
+	}
+	v := w.velocities[m]
+	if speed := math.Hypot(v.X, v.Y); math.Abs(speed-missileSpeed) > 1e-9 {
+		t.Errorf("missile speed should stay %v, got %v", missileSpeed, speed)
+	}
+}
+
+func TestMissileSystem_Expires(t *testing.T) {
+	w := NewWorld()
+	p := SpawnPlayer(w, 400, 300)
+	m := SpawnMissile(w, p)
+	w.missiles[m].Life = 1
+
+	MissileSystem(w)
+
+	if w.Alive(m) {
+		t.Error("missile should be destroyed when its life runs out")
+	}
+}
+
+func TestCollision_MissileDestroysAsteroidForPoints(t *testing.T) {
+	w := NewWorld()
+	p := SpawnPlayer(w, 100, 100)
+	m := SpawnMissile(w, p)
+	a := SpawnAsteroid(w, 0, 0, SizeSmall)
+	*w.positions[a] = *w.positions[m]
+
+	CollisionResponseSystem(w, CollisionSystem(w))
+
+	if w.Alive(m) || w.Alive(a) {
+		t.Error("missile and asteroid should both be destroyed")
+	}
+	if w.Score != 100 {
+		t.Errorf("expected normal small-asteroid score of 100, got %d", w.Score)
+	}
+}
+
+func TestWaveClear_RestocksMissiles(t *testing.T) {
+	w := NewGameWorld(1)
+	w.players[w.Player].Missiles = 0
+	for e := range w.asteroids {
+		w.Destroy(e)
+	}
+
+	WaveClearSystem(w)
+
+	if got := w.players[w.Player].Missiles; got != missileAmmo {
+		t.Errorf("expected missiles restocked to %d, got %d", missileAmmo, got)
+	}
+}
+
+func TestInputBits_Missile(t *testing.T) {
+	in := InputState{Missile: true, Shoot: true}
+	if InputFromBits(in.Bits()) != in {
+		t.Error("missile input should survive a bits round trip")
+	}
+}
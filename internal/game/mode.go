@@ -0,0 +1,137 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strings"
+
+	"github.com/matheus3301/asteroids/internal/canvas"
+)
+
+// GameMode is the set of rules a single-player run is played under.
+type GameMode int
+
+// The game modes, in the order the main menu cycles through them.
+const (
+	// ModeClassic is the standard game: waves until the last life is lost.
+	ModeClassic GameMode = iota
+	// ModeTimeAttack scores as much as possible before a countdown runs
+	// out.
+	ModeTimeAttack
+	// ModeSurvival has a single life and no extra ones, and keeps adding
+	// asteroids faster and faster on top of the waves.
+	ModeSurvival
+	numGameModes
+)
+
+// gameModeIDs name each mode in best-score keys, so they must not change.
+var gameModeIDs = [numGameModes]string{"classic", "timeattack", "survival"}
+
+var gameModeNames = [numGameModes]string{"CLASSIC", "TIME ATTACK", "SURVIVAL"}
+
+func (m GameMode) String() string {
+	if m < 0 || m >= numGameModes {
+		return fmt.Sprintf("GameMode(%d)", int(m))
+	}
+	return gameModeNames[m]
+}
+
+// ParseGameMode looks a mode up by its ID: classic, timeattack or
+// survival.
+func ParseGameMode(id string) (GameMode, error) {
+	for i, m := range gameModeIDs {
+		if m == id {
+			return GameMode(i), nil
+		}
+	}
+	return ModeClassic, fmt.Errorf("unknown mode %q (want one of %s)", id, strings.Join(gameModeIDs[:], ", "))
+}
+
+const (
+	// timeAttackTicks is how long a time attack run lasts: three minutes.
+	timeAttackTicks = 3 * 60 * 60
+
+	// A survival run adds a large asteroid every survivalStartInterval
+	// ticks at first, one tick sooner for every survivalRampTicks played,
+	// down to one every survivalMinInterval.
+	survivalStartInterval = 480
+	survivalRampTicks     = 20
+	survivalMinInterval   = 60
+)
+
+// applyMode changes the rules of a run by its mode. Survival starts with
+// one life and caps lives there, so extra lives are paid out as points.
+func applyMode(r Ruleset, m GameMode) Ruleset {
+	if m == ModeSurvival {
+		r.Config.StartingLives = 1
+		r.Config.MaxLives = 1
+	}
+	return r
+}
+
+// survivalInterval is how long after tick the next survival asteroid
+// arrives.
+func survivalInterval(tick int) int {
+	return max(survivalStartInterval-tick/survivalRampTicks, survivalMinInterval)
+}
+
+// spawnSurvivalAsteroid adds a large asteroid outside the safe radius
+// around the ship.
+func spawnSurvivalAsteroid(w *World) {
+	if !asteroidRoom(w) {
+		return
+	}
+	player := w.positions[w.Player]
+	var p [2]float64
+	for attempt := 0; attempt < maxSpawnAttempts; attempt++ {
+		p = spawnPoint(w.Config, w.rng, attempt)
+		if player == nil || w.Field().Distance(p[0], p[1], player.X, player.Y) > spawnSafeRadius {
+			break
+		}
+	}
+	spawnAsteroidVariant(w, p[0], p[1], SizeLarge, rollVariant(w))
+}
+
+// stepMode applies the rules of the run's mode after each tick.
+func (g *Game) stepMode() {
+	switch g.runMode {
+	case ModeSurvival:
+		g.survivalIn--
+		if g.survivalIn <= 0 {
+			spawnSurvivalAsteroid(g.world)
+			g.survivalIn = survivalInterval(g.world.Tick)
+		}
+	}
+}
+
+// timeUp reports whether a time attack run has run out of time.
+func (g *Game) timeUp() bool {
+	return g.runMode == ModeTimeAttack && g.world.Tick >= timeAttackTicks
+}
+
+// setMode chooses the mode of the next run.
+func (g *Game) setMode(m GameMode) {
+	g.mode = (m + numGameModes) % numGameModes
+}
+
+// drawModeStatus shows the time left in a time attack run, red over the
+// last ten seconds, and how long a survival run has lasted.
+func (g *Game) drawModeStatus(screen canvas.Canvas) {
+	clr := color.RGBA{255, 210, 60, 255}
+	var ticks int
+	switch g.runMode {
+	case ModeTimeAttack:
+		ticks = max(timeAttackTicks-g.world.Tick, 0)
+		if ticks <= 10*60 {
+			clr = color.RGBA{255, 60, 60, 255}
+		}
+	case ModeSurvival:
+		ticks = g.world.Tick
+	default:
+		return
+	}
+	secs := int(math.Ceil(float64(ticks) / 60))
+	text := fmt.Sprintf("%s  %d:%02d", g.runMode, secs/60, secs%60)
+	drawCentered(screen, text, 20, 2, clr)
+}
@@ -0,0 +1,122 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/matheus3301/asteroids/internal/profile"
+	"github.com/matheus3301/asteroids/internal/storage"
+)
+
+func TestMenu_ModeCycles(t *testing.T) {
+	g := New()
+	g.menuCursor = 1
+	for _, want := range []GameMode{ModeTimeAttack, ModeSurvival, ModeClassic} {
+		g.menuSelect()
+		if g.mode != want {
+			t.Fatalf("expected %v, got %v", want, g.mode)
+		}
+	}
+	if g.state != stateMenu {
+		t.Errorf("choosing a mode should stay on the menu, got %v", g.state)
+	}
+	g.setMode(g.mode - 1)
+	if g.mode != ModeSurvival {
+		t.Errorf("going back from classic should wrap to survival, got %v", g.mode)
+	}
+}
+
+func TestParseGameMode(t *testing.T) {
+	if m, err := ParseGameMode("timeattack"); err != nil || m != ModeTimeAttack {
+		t.Errorf("got %v, %v", m, err)
+	}
+	if _, err := ParseGameMode("practice"); err == nil {
+		t.Error("expected an error for an unknown mode")
+	}
+	if g := NewWithOptions(Options{Mode: "survival"}); g.mode != ModeSurvival {
+		t.Errorf("Options.Mode should pick the menu's mode, got %v", g.mode)
+	}
+}
+
+func TestTimeAttack_EndsAfterThreeMinutes(t *testing.T) {
+	restore := storage.Override(storage.At(t.TempDir()))
+	defer restore()
+
+	g := New()
+	g.profile = &profile.Profile{}
+	g.mode = ModeTimeAttack
+	g.reset()
+	if g.recorder != nil {
+		t.Error("a time attack run should not be recorded")
+	}
+	g.world.Tick = timeAttackTicks - 2
+	g.StepPlaying(InputState{})
+	if g.state != statePlaying {
+		t.Fatalf("the run should go on until time is up, state %v", g.state)
+	}
+	g.world.Score = 1_200
+	g.StepPlaying(InputState{})
+	if g.state != stateGameOver {
+		t.Fatalf("the run should end when time is up, state %v", g.state)
+	}
+	if g.world.Lives == 0 {
+		t.Error("running out of time should not cost lives")
+	}
+	if g.profile.Games != 0 || g.profile.MutatorBests["timeattack"] != 1_200 {
+		t.Errorf("a time attack run should keep its own best: %+v", g.profile)
+	}
+}
+
+func TestSurvival_OneLifeAndRampingAsteroids(t *testing.T) {
+	g := New()
+	g.mode = ModeSurvival
+	g.reset()
+	w := g.world
+	if w.Lives != 1 || w.Config.MaxLives != 1 {
+		t.Fatalf("survival should start with one life and no room for more, lives %d max %d", w.Lives, w.Config.MaxLives)
+	}
+	w.Score = w.NextExtraLifeAt
+	checkExtraLife(w)
+	if w.Lives != 1 {
+		t.Errorf("an extra life should be paid out as points, lives %d", w.Lives)
+	}
+
+	before := len(w.asteroids)
+	for i := 0; i < survivalInterval(0)-1; i++ {
+		g.stepMode()
+	}
+	if len(w.asteroids) != before {
+		t.Fatal("no asteroid should arrive before the interval is up")
+	}
+	g.stepMode()
+	if len(w.asteroids) != before+1 {
+		t.Errorf("an asteroid should have been added, had %d, now %d", before, len(w.asteroids))
+	}
+}
+
+func TestSurvivalInterval_Ramps(t *testing.T) {
+	if got := survivalInterval(0); got != survivalStartInterval {
+		t.Errorf("interval at the start = %d, want %d", got, survivalStartInterval)
+	}
+	if a, b := survivalInterval(60*60), survivalInterval(2*60*60); b >= a || a >= survivalStartInterval {
+		t.Errorf("the interval should keep shrinking: %d then %d", a, b)
+	}
+	if got := survivalInterval(60 * 60 * 60); got != survivalMinInterval {
+		t.Errorf("interval after an hour = %d, want %d", got, survivalMinInterval)
+	}
+}
+
+func TestRunKey_Modes(t *testing.T) {
+	for _, tc := range []struct {
+		m         GameMode
+		d         Difficulty
+		key, name string
+	}{
+		{ModeClassic, DifficultyNormal, "", ""},
+		{ModeTimeAttack, DifficultyNormal, "timeattack", "TIME ATTACK"},
+		{ModeSurvival, DifficultyHard, "survival+hard", "SURVIVAL+HARD"},
+	} {
+		if key, name := runKey(tc.m, tc.d, 0), runName(tc.m, tc.d, 0); key != tc.key || name != tc.name {
+			t.Errorf("%v %v: got %q %q, want %q %q", tc.m, tc.d, key, name, tc.key, tc.name)
+		}
+	}
+}
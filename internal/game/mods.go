@@ -0,0 +1,232 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/matheus3301/asteroids/internal/canvas"
+)
+
+// Ruleset is everything that changes how a single-player world plays and
+// looks.
+type Ruleset struct {
+	Config  GameConfig
+	Palette Palette
+	Hooks   RuleHooks
+	// Ship is the player ship's outline; nil is the classic triangle.
+	Ship ShipShape
+	// ShipType is the index of the chosen ShipTypes entry.
+	ShipType int
+}
+
+// StandardRules returns the unmodified game.
+func StandardRules() Ruleset {
+	return Ruleset{Config: DefaultConfig(), Palette: DefaultPalette()}
+}
+
+// Standard reports whether worlds built from r simulate exactly like the
+// standard game, so they can be recorded and replayed. Colours and ship
+// outlines do not count.
+func (r Ruleset) Standard() bool {
+	return r.Config == DefaultConfig() && r.Hooks == nil
+}
+
+// ModInfo describes an installed mod pack.
+type ModInfo struct {
+	Name        string
+	Version     string
+	Description string
+	Enabled     bool
+	// Err explains why the pack cannot be used. Broken packs stay listed so
+	// the problem can be seen, but they cannot be enabled.
+	Err error
+}
+
+// ModContent is what the enabled mod packs add up to.
+type ModContent struct {
+	Ruleset
+	// Sounds replaces one-shot effects with 16-bit stereo PCM at
+	// SampleRate.
+	Sounds map[SoundEvent][]byte
+}
+
+// ModCatalog finds and loads mod packs. Package mods provides the
+// implementation.
+type ModCatalog interface {
+	// Dir is where packs are installed.
+	Dir() string
+	// Mods lists the installed packs in load order.
+	Mods() []ModInfo
+	// SetEnabled switches a pack on or off and remembers the choice.
+	SetEnabled(name string, on bool) error
+	// Content merges the enabled packs in load order. The error describes
+	// packs that failed to load; the content of the others is still
+	// returned.
+	Content() (ModContent, error)
+}
+
+// modsScreen is the state of the MODS screen.
+type modsScreen struct {
+	cursor int
+	err    string
+}
+
+// applyMods loads the enabled packs' content for the next game.
+func (g *Game) applyMods() {
+	g.modContent = ModContent{Ruleset: StandardRules()}
+	g.mods.err = ""
+	if g.modCatalog != nil {
+		content, err := g.modCatalog.Content()
+		if err != nil {
+			logger.Warn("mods not fully loaded", "err", err)
+			g.mods.err = err.Error()
+		}
+		g.modContent = content
+	}
+	if g.sound != nil {
+		g.sound.SetOverrides(g.modContent.Sounds)
+	}
+}
+
+// rules returns the ruleset for the next single-player game.
+func (g *Game) rules() Ruleset {
+	r := g.modContent.Ruleset
+	r.Hooks = ChainHooks(r.Hooks, g.scriptRules)
+	r.Config = g.dev.apply(r.Config)
+	return r
+}
+
+func (g *Game) openMods() {
+	g.mods.cursor = 0
+	g.state = stateMods
+}
+
+func (g *Game) updateMods() {
+	var list []ModInfo
+	if g.modCatalog != nil {
+		list = g.modCatalog.Mods()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.sound.PlayBlip()
+		g.state = stateMenu
+		return
+	}
+	if len(list) == 0 {
+		return
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyUp) {
+		g.mods.cursor = (g.mods.cursor + len(list) - 1) % len(list)
+		g.sound.PlayBlip()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyDown) {
+		g.mods.cursor = (g.mods.cursor + 1) % len(list)
+		g.sound.PlayBlip()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+		g.sound.PlayConfirm()
+		g.toggleMod(list, g.mods.cursor)
+	}
+}
+
+// toggleMod switches the pack at index i and reloads the content.
+func (g *Game) toggleMod(list []ModInfo, i int) {
+	if i < 0 || i >= len(list) {
+		return
+	}
+	m := list[i]
+	if m.Err != nil && !m.Enabled {
+		g.mods.err = fmt.Sprintf("%s cannot be enabled: %v", m.Name, m.Err)
+		return
+	}
+	if err := g.modCatalog.SetEnabled(m.Name, !m.Enabled); err != nil {
+		g.mods.err = err.Error()
+		return
+	}
+	g.applyMods()
+}
+
+func (g *Game) drawMods(screen canvas.Canvas) {
+	white := color.RGBA{255, 255, 255, 255}
+	grey := color.RGBA{100, 100, 100, 255}
+	red := color.RGBA{255, 0, 0, 255}
+	drawCentered(screen, "MODS", 60, 4, white)
+
+	if g.modCatalog == nil {
+		drawCentered(screen, "MODS ARE NOT AVAILABLE IN THIS BUILD", 280, 2, white)
+		drawCentered(screen, "ESC TO GO BACK", 560, 1.5, grey)
+		return
+	}
+	list := g.modCatalog.Mods()
+	if len(list) == 0 {
+		drawCentered(screen, "NO MODS INSTALLED", 260, 2, white)
+		drawCentered(screen, "PUT MOD FOLDERS OR ZIP FILES IN", 310, 1.5, grey)
+		drawCentered(screen, strings.ToUpper(g.modCatalog.Dir()), 335, 1.5, grey)
+	}
+
+	y := 130.0
+	for i, m := range list {
+		clr := white
+		if i == g.mods.cursor {
+			clr = color.RGBA{0, 255, 0, 255}
+		}
+		box := "[ ]"
+		if m.Enabled {
+			box = "[X]"
+		}
+		DrawText(screen, fmt.Sprintf("%s %s %s", box, m.Name, m.Version), 60, y, 2, clr)
+		y += 22
+		detail, detailClr := m.Description, grey
+		if m.Err != nil {
+			detail, detailClr = m.Err.Error(), red
+		}
+		for _, line := range wrapText(detail, 80) {
+			DrawText(screen, line, 100, y, 1.2, detailClr)
+			y += 14
+		}
+		y += 10
+	}
+
+	if g.mods.err != "" {
+		ey := 480.0
+		for _, line := range wrapText(g.mods.err, 80) {
+			drawCentered(screen, line, ey, 1.2, red)
+			ey += 14
+		}
+	}
+	drawCentered(screen, "ENTER TO TOGGLE . LATER MODS OVERRIDE EARLIER ONES . ESC TO GO BACK", 560, 1.5, grey)
+}
+
+// wrapText splits s into upper-case lines of at most width characters,
+// breaking at spaces where possible.
+func wrapText(s string, width int) []string {
+	var lines []string
+	for _, para := range strings.Split(strings.ToUpper(s), "\n") {
+		line := ""
+		for _, word := range strings.Fields(para) {
+			for len(word) > width {
+				if line != "" {
+					lines = append(lines, line)
+					line = ""
+				}
+				lines = append(lines, word[:width])
+				word = word[width:]
+			}
+			switch {
+			case line == "":
+				line = word
+			case len(line)+1+len(word) <= width:
+				line += " " + word
+			default:
+				lines = append(lines, line)
+				line = word
+			}
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,88 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+// fakeCatalog serves fixed packs, each with its own content.
+type fakeCatalog struct {
+	list    []ModInfo
+	content map[string]ModContent
+}
+
+func (c *fakeCatalog) Dir() string     { return "mods" }
+func (c *fakeCatalog) Mods() []ModInfo { return c.list }
+func (c *fakeCatalog) SetEnabled(name string, on bool) error {
+	for i := range c.list {
+		if c.list[i].Name == name {
+			c.list[i].Enabled = on
+			return nil
+		}
+	}
+	return errors.New("no such mod")
+}
+
+func (c *fakeCatalog) Content() (ModContent, error) {
+	out := ModContent{Ruleset: StandardRules()}
+	for _, m := range c.list {
+		if m.Enabled {
+			out = c.content[m.Name]
+		}
+	}
+	return out, nil
+}
+
+func TestMods_ToggleChangesNextGame(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.StartingLives = 7
+	cat := &fakeCatalog{
+		list:    []ModInfo{{Name: "lives"}},
+		content: map[string]ModContent{"lives": {Ruleset: Ruleset{Config: cfg, Palette: DefaultPalette()}}},
+	}
+	g := NewWithOptions(Options{Mute: true, Mods: cat})
+	g.openMods()
+	g.toggleMod(cat.Mods(), 0)
+
+	if !cat.list[0].Enabled {
+		t.Fatal("toggle should enable the pack")
+	}
+	g.reset()
+	if g.world.Lives != 7 {
+		t.Errorf("expected the pack's 7 lives, got %d", g.world.Lives)
+	}
+	if g.recorder != nil {
+		t.Error("modded games should not be recorded")
+	}
+
+	g.toggleMod(cat.Mods(), 0)
+	g.reset()
+	if g.world.Lives != 3 || g.recorder == nil {
+		t.Errorf("disabling the pack should restore the standard game, lives=%d", g.world.Lives)
+	}
+}
+
+func TestMods_BrokenPackCannotBeEnabled(t *testing.T) {
+	cat := &fakeCatalog{list: []ModInfo{{Name: "broken", Err: errors.New("bad config")}}}
+	g := NewWithOptions(Options{Mute: true, Mods: cat})
+	g.toggleMod(cat.Mods(), 0)
+
+	if cat.list[0].Enabled {
+		t.Error("a broken pack must not be enabled")
+	}
+	if g.mods.err == "" {
+		t.Error("expected an explanation on the MODS screen")
+	}
+}
+
+func TestGameConfig_Validate(t *testing.T) {
+	if err := DefaultConfig().Validate(); err != nil {
+		t.Errorf("default config should be valid: %v", err)
+	}
+	c := DefaultConfig()
+	c.MaxBullets = 0
+	c.Friction = 2
+	if err := c.Validate(); err == nil {
+		t.Error("expected out-of-range values to be rejected")
+	}
+}
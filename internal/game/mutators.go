@@ -0,0 +1,123 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Mutator is an optional rule change picked before a run. It only changes
+// the GameConfig, so mutators combine with ships, modes and each other.
+type Mutator struct {
+	ID   string
+	Name string
+	Desc string
+
+	apply func(c GameConfig) GameConfig
+}
+
+// Mutators are the run mutators, in the order they are listed and keyed.
+// Profiles keep best scores by ID, so IDs must not change.
+var Mutators = []Mutator{
+	{ID: "fog", Name: "FOG", Desc: "SEE ONLY 180PX AROUND THE SHIP",
+		apply: func(c GameConfig) GameConfig {
+			c.FogRadius = 180
+			return c
+		}},
+	{ID: "giant", Name: "GIANT", Desc: "HUGE ASTEROIDS THAT NEVER SPLIT, TWICE AS MANY",
+		apply: func(c GameConfig) GameConfig {
+			c.WaveScale *= 2
+			c.AsteroidScale *= 1.5
+			c.AsteroidFragments = 0
+			return c
+		}},
+	{ID: "swarm", Name: "SWARM", Desc: "TWICE THE ASTEROIDS AT HALF THE SIZE",
+		apply: func(c GameConfig) GameConfig {
+			c.WaveScale *= 2
+			c.AsteroidScale *= 0.5
+			return c
+		}},
+	{ID: "nohyper", Name: "NO HYPERSPACE", Desc: "HYPERSPACE DOES NOTHING",
+		apply: func(c GameConfig) GameConfig {
+			c.NoHyperspace = true
+			return c
+		}},
+}
+
+// MutatorSet is a choice of Mutators, one bit per index.
+type MutatorSet uint
+
+// Has reports whether Mutators[i] is chosen.
+func (s MutatorSet) Has(i int) bool { return s&(1<<i) != 0 }
+
+// Toggle adds or removes Mutators[i].
+func (s MutatorSet) Toggle(i int) MutatorSet { return s ^ 1<<i }
+
+// Apply changes c by every chosen mutator, in list order.
+func (s MutatorSet) Apply(c GameConfig) GameConfig {
+	for i, m := range Mutators {
+		if s.Has(i) {
+			c = m.apply(c)
+		}
+	}
+	return c
+}
+
+// Key is the high-score table for runs with s, such as "fog+swarm", or ""
+// with no mutators.
+func (s MutatorSet) Key() string {
+	var ids []string
+	for i, m := range Mutators {
+		if s.Has(i) {
+			ids = append(ids, m.ID)
+		}
+	}
+	return strings.Join(ids, "+")
+}
+
+// String names the chosen mutators for the screen, such as "FOG+SWARM".
+func (s MutatorSet) String() string {
+	var names []string
+	for i, m := range Mutators {
+		if s.Has(i) {
+			names = append(names, m.Name)
+		}
+	}
+	return strings.Join(names, "+")
+}
+
+// ParseMutators turns a comma-separated list of mutator IDs, such as
+// "fog,swarm", into a MutatorSet. An empty string chooses none.
+func ParseMutators(list string) (MutatorSet, error) {
+	var s MutatorSet
+	for _, id := range strings.Split(list, ",") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		i := mutatorIndex(id)
+		if i < 0 {
+			var ids []string
+			for _, m := range Mutators {
+				ids = append(ids, m.ID)
+			}
+			return 0, fmt.Errorf("unknown mutator %q (want any of %s)", id, strings.Join(ids, ", "))
+		}
+		s |= 1 << i
+	}
+	return s, nil
+}
+
+func mutatorIndex(id string) int {
+	for i, m := range Mutators {
+		if m.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// applyMutators changes the rules of a run by the chosen mutators.
+func applyMutators(r Ruleset, s MutatorSet) Ruleset {
+	r.Config = s.Apply(r.Config)
+	return r
+}
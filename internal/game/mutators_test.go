@@ -0,0 +1,119 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/matheus3301/asteroids/internal/canvas"
+	"github.com/matheus3301/asteroids/internal/profile"
+	"github.com/matheus3301/asteroids/internal/storage"
+)
+
+// mutated starts a run of seed under the mutators with the given IDs.
+func mutated(t *testing.T, seed int64, ids string) *World {
+	t.Helper()
+	s, err := ParseMutators(ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewModdedWorld(seed, applyMutators(StandardRules(), s))
+}
+
+func TestParseMutators(t *testing.T) {
+	s, err := ParseMutators("swarm, fog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Key() != "fog+swarm" || s.String() != "FOG+SWARM" {
+		t.Errorf("key %q and name %q should follow the list order", s.Key(), s.String())
+	}
+	if s, _ := ParseMutators(""); s != 0 || s.Key() != "" {
+		t.Error("an empty list should choose no mutators")
+	}
+	if _, err := ParseMutators("fog,lava"); err == nil {
+		t.Error("expected an error for an unknown mutator")
+	}
+}
+
+func TestMutators_ConfigsAreValid(t *testing.T) {
+	all := MutatorSet(1<<len(Mutators) - 1)
+	if err := all.Apply(DefaultConfig()).Validate(); err != nil {
+		t.Errorf("every mutator at once should be playable: %v", err)
+	}
+}
+
+func TestMutators_Swarm(t *testing.T) {
+	plain, swarm := NewGameWorld(1), mutated(t, 1, "swarm")
+	if len(swarm.asteroids) != 2*len(plain.asteroids) {
+		t.Errorf("expected twice the %d asteroids, got %d", len(plain.asteroids), len(swarm.asteroids))
+	}
+	for e := range swarm.asteroids {
+		if r := swarm.colliders[e].Radius; r != asteroidRadius[SizeLarge]/2 {
+			t.Fatalf("swarm asteroid radius %v, want half of %v", r, asteroidRadius[SizeLarge])
+		}
+	}
+}
+
+func TestMutators_GiantNeverSplits(t *testing.T) {
+	w := mutated(t, 1, "giant")
+	n := len(w.asteroids)
+	shootAsteroid(w, sortedEntities(w.asteroids)[0], 0, 0)
+	if len(w.asteroids) != n-1 {
+		t.Errorf("a giant asteroid should break up with no pieces, %d left of %d", len(w.asteroids), n)
+	}
+}
+
+func TestMutators_NoHyperspace(t *testing.T) {
+	w := mutated(t, 1, "nohyper")
+	before := *w.positions[w.Player]
+	Step(w, InputState{Hyperspace: true})
+	if w.Stats.HyperspaceJumps != 0 || w.positions[w.Player].X != before.X {
+		t.Error("hyperspace should do nothing")
+	}
+}
+
+func TestMutators_FogHidesDistantObjects(t *testing.T) {
+	w := NewWorldWithSeed(1)
+	w.Config.FogRadius = 100
+	w.Player = SpawnPlayer(w, 400, 300)
+	SpawnAsteroid(w, 450, 300, SizeSmall)
+	SpawnAsteroid(w, 700, 300, SizeSmall)
+
+	// farLines counts lines drawn around the far asteroid.
+	farLines := func() int {
+		var rec canvas.Recording
+		RenderSystem(w, &rec, 1)
+		n := 0
+		for _, op := range rec.Ops {
+			if op.Kind == "line" && op.Args[0] > 600 {
+				n++
+			}
+		}
+		return n
+	}
+	if farLines() != 0 {
+		t.Error("the far asteroid should be hidden by the fog")
+	}
+	w.Config.FogRadius = 0
+	if farLines() == 0 {
+		t.Error("without fog the far asteroid should be drawn")
+	}
+}
+
+func TestRecordCareer_MutatedRunsKeepTheirOwnBest(t *testing.T) {
+	restore := storage.Override(storage.At(t.TempDir()))
+	defer restore()
+
+	g := NewWithOptions(Options{Mutators: 1 << mutatorIndex("fog")})
+	g.reset()
+	g.profile = &profile.Profile{BestScore: 100}
+	g.world.Score = 5_000
+
+	g.recordCareer()
+
+	if g.profile.Games != 0 || g.profile.BestScore != 100 {
+		t.Error("a mutated run should not count toward the career")
+	}
+	if !g.newBest || g.profile.MutatorBests["fog"] != 5_000 {
+		t.Errorf("expected a new fog best, got %v", g.profile.MutatorBests)
+	}
+}
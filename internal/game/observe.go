@@ -0,0 +1,135 @@
+package game
+
+import "github.com/matheus3301/asteroids/internal/geom"
+
+// ShipObservation describes the player ship.
+type ShipObservation struct {
+	X                  float64 `json:"x"`
+	Y                  float64 `json:"y"`
+	VX                 float64 `json:"vx"`
+	VY                 float64 `json:"vy"`
+	Angle              float64 `json:"angle"`
+	Invulnerable       bool    `json:"invulnerable"`
+	HyperspaceCooldown int     `json:"hyperspace_cooldown"`
+	// ShotCooldown is the ticks until the weapon can fire again.
+	ShotCooldown int `json:"shot_cooldown"`
+	WeaponTier   int `json:"weapon_tier"`
+	Missiles     int `json:"missiles"`
+	// Shield is the ticks left in the shield meter and ShieldUp whether
+	// it is raised.
+	Shield   int  `json:"shield"`
+	ShieldUp bool `json:"shield_up"`
+}
+
+// ObjectObservation describes any other moving entity.
+type ObjectObservation struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	VX     float64 `json:"vx"`
+	VY     float64 `json:"vy"`
+	Radius float64 `json:"radius"`
+	Size   int     `json:"size"`
+	// Variant is the AsteroidVariant for asteroids and 0 for everything
+	// else.
+	Variant int `json:"variant"`
+	// DX and DY are the shortest offset from the player ship, which may
+	// cross a screen edge. CPATicks and CPADist are when and how close the
+	// object passes the ship if neither changes course. All four are 0
+	// without a ship.
+	DX       float64 `json:"dx"`
+	DY       float64 `json:"dy"`
+	CPATicks float64 `json:"cpa_ticks"`
+	CPADist  float64 `json:"cpa_dist"`
+}
+
+// Observation is a serialisable snapshot of everything an agent can see.
+// Lists are ordered by entity ID so identical worlds give identical output.
+type Observation struct {
+	Tick          int                 `json:"tick"`
+	Score         int                 `json:"score"`
+	Lives         int                 `json:"lives"`
+	Level         int                 `json:"level"`
+	Width         float64             `json:"width"`
+	Height        float64             `json:"height"`
+	Player        *ShipObservation    `json:"player"`
+	Bullets       []ObjectObservation `json:"bullets"`
+	Missiles      []ObjectObservation `json:"missiles"`
+	Pickups       []ObjectObservation `json:"pickups"`
+	Asteroids     []ObjectObservation `json:"asteroids"`
+	Saucers       []ObjectObservation `json:"saucers"`
+	SaucerBullets []ObjectObservation `json:"saucer_bullets"`
+}
+
+// Observe captures the current state of w.
+func Observe(w *World) Observation {
+	obs := Observation{
+		Tick:   w.Tick,
+		Score:  w.Score,
+		Lives:  w.Lives,
+		Level:  w.Level,
+		Width:  w.Config.FieldWidth,
+		Height: w.Config.FieldHeight,
+	}
+
+	if pc, ok := w.players[w.Player]; ok {
+		ship := &ShipObservation{
+			Invulnerable:       pc.Invulnerable,
+			HyperspaceCooldown: pc.HyperspaceCooldown,
+			ShotCooldown:       pc.ShotCooldown,
+			WeaponTier:         int(pc.Weapon),
+			Missiles:           pc.Missiles,
+			Shield:             pc.Shield,
+			ShieldUp:           pc.ShieldUp,
+		}
+		if pos := w.positions[w.Player]; pos != nil {
+			ship.X, ship.Y = pos.X, pos.Y
+		}
+		if vel := w.velocities[w.Player]; vel != nil {
+			ship.VX, ship.VY = vel.X, vel.Y
+		}
+		if rot := w.rotations[w.Player]; rot != nil {
+			ship.Angle = rot.Angle
+		}
+		obs.Player = ship
+	}
+
+	for _, e := range sortedEntities(w.bullets) {
+		obs.Bullets = append(obs.Bullets, observeObject(w, obs.Player, e, 0))
+	}
+	for _, e := range sortedEntities(w.missiles) {
+		obs.Missiles = append(obs.Missiles, observeObject(w, obs.Player, e, 0))
+	}
+	for _, e := range sortedEntities(w.asteroids) {
+		o := observeObject(w, obs.Player, e, int(w.asteroids[e].Size))
+		o.Variant = int(w.asteroids[e].Variant)
+		obs.Asteroids = append(obs.Asteroids, o)
+	}
+	for _, e := range sortedEntities(w.saucers) {
+		obs.Saucers = append(obs.Saucers, observeObject(w, obs.Player, e, int(w.saucers[e].Size)))
+	}
+	for _, e := range sortedEntities(w.pickups) {
+		obs.Pickups = append(obs.Pickups, observeObject(w, obs.Player, e, 0))
+	}
+	for _, e := range sortedEntities(w.saucerBullets) {
+		obs.SaucerBullets = append(obs.SaucerBullets, observeObject(w, obs.Player, e, 0))
+	}
+	return obs
+}
+
+func observeObject(w *World, ship *ShipObservation, e Entity, size int) ObjectObservation {
+	o := ObjectObservation{Size: size}
+	if pos := w.positions[e]; pos != nil {
+		o.X, o.Y = pos.X, pos.Y
+	}
+	if vel := w.velocities[e]; vel != nil {
+		o.VX, o.VY = vel.X, vel.Y
+	}
+	if col := w.colliders[e]; col != nil {
+		o.Radius = col.Radius
+	}
+	if ship != nil {
+		o.DX, o.DY = w.Field().Delta(ship.X, ship.Y, o.X, o.Y)
+		o.CPATicks, o.CPADist = geom.ClosestApproach(o.DX, o.DY, o.VX-ship.VX, o.VY-ship.VY)
+	}
+	return o
+}
@@ -0,0 +1,38 @@
+package game
+
+import "image/color"
+
+// Palette holds the colours used in play. Menus keep the standard colours
+// so they stay readable whatever a mod picks.
+type Palette struct {
+	Background        color.RGBA
+	HUD               color.RGBA
+	Ship              color.RGBA
+	Asteroid          color.RGBA
+	GoldenAsteroid    color.RGBA
+	ExplosiveAsteroid color.RGBA
+	ArmoredAsteroid   color.RGBA
+	Bullet            color.RGBA
+	Saucer            color.RGBA
+	SaucerBullet      color.RGBA
+	Particle          color.RGBA
+	Bonus             color.RGBA
+}
+
+// DefaultPalette returns the standard colours.
+func DefaultPalette() Palette {
+	return Palette{
+		Background:        color.RGBA{0, 0, 0, 255},
+		HUD:               color.RGBA{255, 255, 255, 255},
+		Ship:              color.RGBA{0, 255, 0, 255},
+		Asteroid:          color.RGBA{200, 200, 200, 255},
+		GoldenAsteroid:    color.RGBA{255, 210, 60, 255},
+		ExplosiveAsteroid: color.RGBA{255, 90, 40, 255},
+		ArmoredAsteroid:   color.RGBA{120, 150, 190, 255},
+		Bullet:            color.RGBA{255, 255, 255, 255},
+		Saucer:            color.RGBA{255, 0, 0, 255},
+		SaucerBullet:      color.RGBA{255, 100, 100, 255},
+		Particle:          color.RGBA{255, 200, 50, 255},
+		Bonus:             color.RGBA{255, 255, 120, 255},
+	}
+}
@@ -0,0 +1,67 @@
+package game
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/matheus3301/asteroids/internal/canvas"
+)
+
+// Presence is told what the player is doing, for integrations such as a
+// window title or a Discord rich-presence plugin wired in from outside the
+// game. PresenceChanged runs on the game loop after any update that changed
+// the state, score, lives or level, so it should return quickly.
+type Presence interface {
+	PresenceChanged(st Status)
+}
+
+// PresenceFunc adapts a function to the Presence interface.
+type PresenceFunc func(st Status)
+
+// PresenceChanged implements Presence.
+func (f PresenceFunc) PresenceChanged(st Status) { f(st) }
+
+// notifyPresence tells the presence hooks about the status if it changed
+// since they were last told. The tick and entity count change every frame
+// and are not compared.
+func (g *Game) notifyPresence() {
+	if len(g.presence) == 0 {
+		return
+	}
+	st := g.Status()
+	key := st
+	key.Tick, key.Entities = 0, 0
+	if g.presenceSent && key == g.lastPresence {
+		return
+	}
+	g.lastPresence, g.presenceSent = key, true
+	for _, p := range g.presence {
+		p.PresenceChanged(st)
+	}
+}
+
+// WindowTitle is the window title for a status: the game name, followed by
+// the score and level while a game is on screen.
+func WindowTitle(st Status) string {
+	switch st.State {
+	case "playing", "paused", "gameover":
+		return fmt.Sprintf("Asteroids - %d - Level %d", st.Score, st.Level)
+	}
+	return "Asteroids"
+}
+
+// WindowIcons draws the classic ship at the sizes desktops ask for, for
+// ebiten.SetWindowIcon.
+func WindowIcons() []image.Image {
+	var icons []image.Image
+	for _, size := range []int{16, 32, 48} {
+		r := canvas.NewRaster(size, size)
+		r.Fill(color.Black)
+		c := float64(size) / 2
+		drawPolygon(r, &Position{X: c, Y: c + float64(size)*0.05}, -math.Pi/2, classicShip.vertices(c*0.8), DefaultPalette().Ship)
+		icons = append(icons, r.Img)
+	}
+	return icons
+}
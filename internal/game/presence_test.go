@@ -0,0 +1,60 @@
+package game
+
+import "testing"
+
+func TestNotifyPresence_OnlyOnChange(t *testing.T) {
+	var got []Status
+	g := NewWithOptions(Options{Presence: []Presence{PresenceFunc(func(st Status) { got = append(got, st) })}})
+
+	g.notifyPresence()
+	if len(got) != 1 || got[0].State != "menu" {
+		t.Fatalf("expected the menu to be reported first, got %+v", got)
+	}
+
+	g.reset()
+	g.notifyPresence()
+	g.StepPlaying(InputState{})
+	g.notifyPresence()
+	if len(got) != 2 || got[1].State != "playing" {
+		t.Fatalf("ticks alone should not be reported, got %+v", got)
+	}
+
+	g.world.Score += 20
+	g.notifyPresence()
+	if len(got) != 3 || got[2].Score != g.world.Score {
+		t.Errorf("a score change should be reported, got %+v", got)
+	}
+}
+
+func TestWindowTitle(t *testing.T) {
+	if got := WindowTitle(Status{State: "menu", Score: 50}); got != "Asteroids" {
+		t.Errorf("menu title = %q", got)
+	}
+	if got := WindowTitle(Status{State: "playing", Score: 1230, Level: 4}); got != "Asteroids - 1230 - Level 4" {
+		t.Errorf("playing title = %q", got)
+	}
+}
+
+func TestWindowIcons(t *testing.T) {
+	icons := WindowIcons()
+	if len(icons) == 0 {
+		t.Fatal("no icons")
+	}
+	for _, icon := range icons {
+		b := icon.Bounds()
+		if b.Dx() != b.Dy() {
+			t.Errorf("icon is %v, not square", b)
+		}
+		lit := 0
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				if r, g, bl, _ := icon.At(x, y).RGBA(); r+g+bl > 0 {
+					lit++
+				}
+			}
+		}
+		if lit == 0 {
+			t.Errorf("%dpx icon is blank", b.Dx())
+		}
+	}
+}
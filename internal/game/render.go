@@ -4,25 +4,51 @@ import (
 	"image/color"
 	"math"
 
-	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/matheus3301/asteroids/internal/canvas"
 )
 
-func strokeLine(screen *ebiten.Image, x1, y1, x2, y2 float64, clr color.Color) {
-	vector.StrokeLine(screen, float32(x1), float32(y1), float32(x2), float32(y2), 1.5, clr, false)
+func strokeLine(screen canvas.Canvas, x1, y1, x2, y2 float64, clr color.Color) {
+	screen.StrokeLine(x1, y1, x2, y2, 1.5, clr)
 }
 
-// RenderSystem draws all renderable entities.
-func RenderSystem(w *World, screen *ebiten.Image) {
-	for e, r := range w.renderables {
-		pos := w.positions[e]
+// DrawWorld draws every entity in the world along with thrust flames and
+// saucer detail.
+func DrawWorld(w *World, screen canvas.Canvas) {
+	DrawWorldAt(w, screen, 1)
+}
+
+// DrawWorldAt is DrawWorld with every entity drawn at alpha of the way from
+// its pose before the last tick to its current one. Playback that runs
+// slower than the display uses it to avoid judder.
+func DrawWorldAt(w *World, screen canvas.Canvas, alpha float64) {
+	RenderSystem(w, screen, alpha)
+	DrawShieldRings(w, screen, alpha)
+	DrawThrust(w, screen, alpha)
+	DrawSaucerDetail(w, screen, alpha)
+}
+
+// RenderSystem draws all renderable entities, in entity order so overlapping
+// shapes come out the same every frame. In fog, objects fade out towards
+// the edge of the light around the ship.
+func RenderSystem(w *World, screen canvas.Canvas, alpha float64) {
+	ship, _ := w.poseAt(w.Player, alpha)
+	for _, e := range sortedEntities(w.renderables) {
+		r := w.renderables[e]
+		pos, angle := w.poseAt(e, alpha)
 		if pos == nil {
 			continue
 		}
+		visible := 1.0
+		if e != w.Player {
+			visible = fogAlpha(w, ship, pos.X, pos.Y)
+		}
+		if visible <= 0 {
+			continue
+		}
 
 		// Check blink for invulnerable players
 		if pc, ok := w.players[e]; ok {
-			if pc.Invulnerable && (pc.BlinkTimer/8)%2 == 0 {
+			if blinkedOut(w, pc) {
 				continue
 			}
 		}
@@ -30,29 +56,40 @@ func RenderSystem(w *World, screen *ebiten.Image) {
 		// Particle alpha fade
 		clr := r.Color
 		if pt, ok := w.particles[e]; ok {
+			if w.LowDetail && e%2 == 1 {
+				continue
+			}
 			alpha := float64(pt.Life) / float64(pt.MaxLife) * 255
 			if alpha < 0 {
 				alpha = 0
 			}
 			clr.A = uint8(alpha)
 		}
-
-		rot := w.rotations[e]
-		angle := 0.0
-		if rot != nil {
-			angle = rot.Angle
-		}
+		clr = fade(clr, visible)
 
 		switch r.Kind {
 		case ShapeTriangle, ShapePolygon:
-			drawPolygon(screen, pos, angle, r.Vertices, clr)
+			verts := r.Vertices
+			if r.Scale != 1 {
+				verts = scaleVertices(verts, r.Scale)
+			}
+			drawPolygon(screen, pos, angle, verts, clr)
 		case ShapeCircle:
-			vector.FillCircle(screen, float32(pos.X), float32(pos.Y), float32(r.Scale), clr, false)
+			screen.FillCircle(pos.X, pos.Y, r.Scale, clr)
 		}
 	}
 }
 
-func drawPolygon(screen *ebiten.Image, pos *Position, angle float64, verts [][2]float64, clr color.RGBA) {
+// scaleVertices returns a copy of verts scaled by k.
+func scaleVertices(verts [][2]float64, k float64) [][2]float64 {
+	out := make([][2]float64, len(verts))
+	for i, v := range verts {
+		out[i] = [2]float64{v[0] * k, v[1] * k}
+	}
+	return out
+}
+
+func drawPolygon(screen canvas.Canvas, pos *Position, angle float64, verts [][2]float64, clr color.RGBA) {
 	n := len(verts)
 	if n < 2 {
 		return
@@ -91,16 +128,21 @@ func saucerVertices(radius float64) [][2]float64 {
 }
 
 // DrawSaucerDetail draws interior detail lines on saucers (rim + dome base).
-func DrawSaucerDetail(w *World, screen *ebiten.Image) {
+func DrawSaucerDetail(w *World, screen canvas.Canvas, alpha float64) {
+	ship, _ := w.poseAt(w.Player, alpha)
 	for e := range w.saucers {
-		pos := w.positions[e]
+		pos, _ := w.poseAt(e, alpha)
 		r := w.renderables[e]
 		if pos == nil || r == nil {
 			continue
 		}
+		visible := fogAlpha(w, ship, pos.X, pos.Y)
+		if visible <= 0 {
+			continue
+		}
 
 		radius := w.colliders[e].Radius
-		clr := r.Color
+		clr := fade(r.Color, visible)
 
 		// Rim line (full width at Y=0)
 		strokeLine(screen, pos.X-radius, pos.Y-radius*0.1, pos.X+radius, pos.Y-radius*0.1, clr)
@@ -109,24 +151,67 @@ func DrawSaucerDetail(w *World, screen *ebiten.Image) {
 	}
 }
 
+// shieldRingSegments is how many lines a shield ring is drawn with.
+const shieldRingSegments = 20
+
+// blinkedOut reports whether an invulnerable ship is in the hidden half of
+// its blink. Ships do not blink in reduced motion.
+func blinkedOut(w *World, pc *PlayerControl) bool {
+	return pc.Invulnerable && !w.ReducedMotion && (pc.BlinkTimer/8)%2 == 0
+}
+
+// DrawShieldRings rings each ship with a raised shield, fading as its
+// meter runs down, and marks invulnerable ships with a steady ring in
+// reduced motion, where they would otherwise blink.
+func DrawShieldRings(w *World, screen canvas.Canvas, alpha float64) {
+	for _, e := range sortedEntities(w.players) {
+		pc := w.players[e]
+		steady := pc.Invulnerable && w.ReducedMotion
+		if !steady && !pc.ShieldUp {
+			continue
+		}
+		pos, _ := w.poseAt(e, alpha)
+		r := w.renderables[e]
+		if pos == nil || r == nil {
+			continue
+		}
+		if steady {
+			strokeRing(screen, pos.X, pos.Y, playerRadius*1.5, r.Color)
+		}
+		if pc.ShieldUp {
+			left := float64(pc.Shield) / float64(max(w.Config.ShieldTicks, 1))
+			strokeRing(screen, pos.X, pos.Y, shieldRadius, fade(r.Color, 0.4+0.6*left))
+		}
+	}
+}
+
+// strokeRing draws a circle of shieldRingSegments lines.
+func strokeRing(screen canvas.Canvas, x, y, radius float64, clr color.RGBA) {
+	for i := range shieldRingSegments {
+		a1 := 2 * math.Pi * float64(i) / shieldRingSegments
+		a2 := 2 * math.Pi * float64(i+1) / shieldRingSegments
+		strokeLine(screen, x+math.Cos(a1)*radius, y+math.Sin(a1)*radius,
+			x+math.Cos(a2)*radius, y+math.Sin(a2)*radius, clr)
+	}
+}
+
 // DrawThrust draws the flame behind the player ship.
-func DrawThrust(w *World, screen *ebiten.Image) {
+func DrawThrust(w *World, screen canvas.Canvas, alpha float64) {
 	for e, pc := range w.players {
 		if !pc.Thrusting {
 			continue
 		}
-		if pc.Invulnerable && (pc.BlinkTimer/8)%2 == 0 {
+		if blinkedOut(w, pc) {
 			continue
 		}
-		pos := w.positions[e]
-		rot := w.rotations[e]
+		pos, angle := w.poseAt(e, alpha)
 		r := w.renderables[e]
-		if pos == nil || rot == nil || r == nil || len(r.Vertices) < 3 {
+		if pos == nil || r == nil || len(r.Vertices) < 3 {
 			continue
 		}
 
-		cos := math.Cos(rot.Angle)
-		sin := math.Sin(rot.Angle)
+		cos := math.Cos(angle)
+		sin := math.Sin(angle)
 		transform := func(v [2]float64) (float64, float64) {
 			return pos.X + v[0]*cos - v[1]*sin,
 				pos.Y + v[0]*sin + v[1]*cos
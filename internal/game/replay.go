@@ -0,0 +1,379 @@
+package game
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/matheus3301/asteroids/internal/storage"
+)
+
+const (
+	replayMagic = "ASTR"
+	// ReplayVersion is bumped whenever the on-disk replay layout changes.
+	ReplayVersion = 2
+
+	// checksumInterval is how often (in ticks) a recording stores a world
+	// checksum that playback compares against.
+	checksumInterval = 60
+
+	// maxReplayEntries bounds slice allocations when decoding untrusted files.
+	maxReplayEntries = 1 << 24
+)
+
+var (
+	ErrNotReplay          = errors.New("not a replay file")
+	ErrUnsupportedVersion = errors.New("unsupported replay version")
+)
+
+// InputFrame records that the held input changed to Buttons at Tick.
+type InputFrame struct {
+	Tick    uint32
+	Buttons uint8
+}
+
+// StateChecksum is a world checksum taken after Tick steps.
+type StateChecksum struct {
+	Tick uint32
+	Sum  uint64
+}
+
+// Replay is a recorded game: the seed it started from plus every change in
+// player input, which is enough to re-simulate it exactly.
+type Replay struct {
+	Version    uint16
+	Seed       int64
+	ConfigHash uint64
+	RecordedAt int64 // unix seconds
+	// Ship is the index of the ShipTypes entry that was flown.
+	Ship      uint8
+	Ticks     int
+	Inputs    []InputFrame
+	Checksums []StateChecksum
+}
+
+// Compatible reports whether the replay was recorded with the same gameplay
+// tuning as this build. Replays from other rule sets will desync.
+func (r *Replay) Compatible() bool {
+	return r.ConfigHash == RulesHash()
+}
+
+// Duration returns the replay length at the fixed 60 ticks per second.
+func (r *Replay) Duration() time.Duration {
+	return time.Duration(r.Ticks) * time.Second / 60
+}
+
+type replayHeader struct {
+	Version    uint16
+	Seed       int64
+	ConfigHash uint64
+	RecordedAt int64
+	Ship       uint8
+	Ticks      uint32
+	NumInputs  uint32
+	NumChecks  uint32
+}
+
+// Encode writes the replay in its binary format.
+func (r *Replay) Encode(out io.Writer) error {
+	bw := bufio.NewWriter(out)
+	if _, err := bw.WriteString(replayMagic); err != nil {
+		return err
+	}
+	hdr := replayHeader{
+		Version:    ReplayVersion,
+		Seed:       r.Seed,
+		ConfigHash: r.ConfigHash,
+		RecordedAt: r.RecordedAt,
+		Ship:       r.Ship,
+		Ticks:      uint32(r.Ticks),
+		NumInputs:  uint32(len(r.Inputs)),
+		NumChecks:  uint32(len(r.Checksums)),
+	}
+	if err := binary.Write(bw, binary.LittleEndian, hdr); err != nil {
+		return err
+	}
+	if err := binary.Write(bw, binary.LittleEndian, r.Inputs); err != nil {
+		return err
+	}
+	if err := binary.Write(bw, binary.LittleEndian, r.Checksums); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// DecodeReplay reads a replay written by Encode.
+func DecodeReplay(in io.Reader) (*Replay, error) {
+	br := bufio.NewReader(in)
+	magic := make([]byte, len(replayMagic))
+	if _, err := io.ReadFull(br, magic); err != nil || string(magic) != replayMagic {
+		return nil, ErrNotReplay
+	}
+	var hdr replayHeader
+	if err := binary.Read(br, binary.LittleEndian, &hdr); err != nil {
+		return nil, fmt.Errorf("reading replay header: %w", err)
+	}
+	if hdr.Version != ReplayVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, hdr.Version)
+	}
+	if hdr.NumInputs > maxReplayEntries || hdr.NumChecks > maxReplayEntries {
+		return nil, fmt.Errorf("replay too large")
+	}
+	if int(hdr.Ship) >= len(ShipTypes) {
+		return nil, fmt.Errorf("unknown ship type %d", hdr.Ship)
+	}
+	r := &Replay{
+		Version:    hdr.Version,
+		Seed:       hdr.Seed,
+		ConfigHash: hdr.ConfigHash,
+		RecordedAt: hdr.RecordedAt,
+		Ship:       hdr.Ship,
+		Ticks:      int(hdr.Ticks),
+		Inputs:     make([]InputFrame, hdr.NumInputs),
+		Checksums:  make([]StateChecksum, hdr.NumChecks),
+	}
+	if err := binary.Read(br, binary.LittleEndian, r.Inputs); err != nil {
+		return nil, fmt.Errorf("reading replay inputs: %w", err)
+	}
+	if err := binary.Read(br, binary.LittleEndian, r.Checksums); err != nil {
+		return nil, fmt.Errorf("reading replay checksums: %w", err)
+	}
+	return r, nil
+}
+
+// SaveReplay writes r to path, creating parent directories as needed.
+func SaveReplay(path string, r *Replay) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := r.Encode(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// LoadReplay reads a replay file from disk.
+func LoadReplay(path string) (*Replay, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return DecodeReplay(f)
+}
+
+// ReplayDir returns the directory recorded games are saved to.
+func ReplayDir() (string, error) {
+	dirs, err := storage.Default()
+	if err != nil {
+		return "", err
+	}
+	return dirs.Replays(), nil
+}
+
+// LatestReplay returns the path of the most recently recorded replay in dir.
+func LatestReplay(dir string) (string, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.replay"))
+	if err != nil {
+		return "", err
+	}
+	if len(matches) == 0 {
+		return "", os.ErrNotExist
+	}
+	// File names start with a sortable timestamp.
+	sort.Strings(matches)
+	return matches[len(matches)-1], nil
+}
+
+// ReplayRecorder captures the inputs and periodic checksums of a game.
+type ReplayRecorder struct {
+	rep  *Replay
+	last uint8
+}
+
+// NewReplayRecorder starts recording a game played in w, which must not have
+// been stepped yet.
+func NewReplayRecorder(w *World) *ReplayRecorder {
+	return &ReplayRecorder{
+		rep: &Replay{
+			Version:    ReplayVersion,
+			Seed:       w.Seed,
+			ConfigHash: RulesHash(),
+			RecordedAt: time.Now().Unix(),
+			Ship:       uint8(w.ShipType),
+		},
+	}
+}
+
+// Input records the input about to be applied on the world's current tick.
+func (rec *ReplayRecorder) Input(w *World, in InputState) {
+	b := in.Bits()
+	if b == rec.last {
+		return
+	}
+	rec.rep.Inputs = append(rec.rep.Inputs, InputFrame{Tick: uint32(w.Tick), Buttons: b})
+	rec.last = b
+}
+
+// Check stores a checksum of w after a step when one is due.
+func (rec *ReplayRecorder) Check(w *World) {
+	if w.Tick%checksumInterval == 0 {
+		rec.rep.Checksums = append(rec.rep.Checksums, StateChecksum{Tick: uint32(w.Tick), Sum: w.Checksum()})
+	}
+}
+
+// Finish ends the recording and returns the replay.
+func (rec *ReplayRecorder) Finish(w *World) *Replay {
+	rec.rep.Ticks = w.Tick
+	return rec.rep
+}
+
+// ReplayRunner re-simulates a replay one tick at a time.
+type ReplayRunner struct {
+	Replay *Replay
+	World  *World
+	// DesyncTick is the first checksum tick that did not match, or -1.
+	DesyncTick int
+
+	next      int
+	nextCheck int
+	held      InputState
+}
+
+// NewReplayRunner creates a runner positioned at the start of r.
+func NewReplayRunner(r *Replay) *ReplayRunner {
+	p := &ReplayRunner{Replay: r}
+	p.Restart()
+	return p
+}
+
+// Restart rewinds the runner to tick zero.
+func (p *ReplayRunner) Restart() {
+	p.World = NewModdedWorld(p.Replay.Seed, applyShipType(StandardRules(), int(p.Replay.Ship)))
+	p.DesyncTick = -1
+	p.next = 0
+	p.nextCheck = 0
+	p.held = InputState{}
+}
+
+// Done reports whether every recorded tick has been played.
+func (p *ReplayRunner) Done() bool {
+	return p.World.Tick >= p.Replay.Ticks
+}
+
+// Input returns the input applied on the most recent tick.
+func (p *ReplayRunner) Input() InputState {
+	return p.held
+}
+
+// Advance plays a single tick. It is a no-op once the replay is done.
+func (p *ReplayRunner) Advance() {
+	if p.Done() {
+		return
+	}
+	w := p.World
+	inputs := p.Replay.Inputs
+	if p.next < len(inputs) && int(inputs[p.next].Tick) == w.Tick {
+		p.held = InputFromBits(inputs[p.next].Buttons)
+		p.next++
+	}
+	Step(w, p.held)
+
+	checks := p.Replay.Checksums
+	if p.nextCheck < len(checks) && int(checks[p.nextCheck].Tick) == w.Tick {
+		if checks[p.nextCheck].Sum != w.Checksum() && p.DesyncTick < 0 {
+			p.DesyncTick = w.Tick
+		}
+		p.nextCheck++
+	}
+}
+
+// Seek moves playback to the given tick, re-simulating from the start when
+// seeking backwards. Sounds produced while seeking are discarded.
+func (p *ReplayRunner) Seek(tick int) {
+	if tick < p.World.Tick {
+		p.Restart()
+	}
+	for p.World.Tick < tick && !p.Done() {
+		p.Advance()
+		p.World.SoundQueue = p.World.SoundQueue[:0]
+	}
+}
+
+// VerifyReplay re-simulates the whole replay headlessly and returns the first
+// tick whose checksum differed from the recording, or -1 if it matched.
+func VerifyReplay(r *Replay) int {
+	p := NewReplayRunner(r)
+	p.Seek(r.Ticks)
+	return p.DesyncTick
+}
+
+// Checksum hashes the gameplay-relevant state of the world. Two worlds with
+// the same checksum are, for replay purposes, in the same state.
+func (w *World) Checksum() uint64 {
+	h := fnv.New64a()
+	var buf [8]byte
+	put := func(v uint64) {
+		binary.LittleEndian.PutUint64(buf[:], v)
+		h.Write(buf[:])
+	}
+	putF := func(f float64) { put(math.Float64bits(f)) }
+
+	put(uint64(w.Tick))
+	put(uint64(w.Score))
+	put(uint64(w.Lives))
+	put(uint64(w.Level))
+	put(uint64(w.HitStop))
+	for _, e := range sortedEntities(w.entities) {
+		put(uint64(e))
+		if pos := w.positions[e]; pos != nil {
+			putF(pos.X)
+			putF(pos.Y)
+		}
+		if vel := w.velocities[e]; vel != nil {
+			putF(vel.X)
+			putF(vel.Y)
+		}
+		if rot := w.rotations[e]; rot != nil {
+			putF(rot.Angle)
+		}
+	}
+	return h.Sum64()
+}
+
+// RulesHash fingerprints the tuning constants that affect simulation, so a
+// replay or network peer running different rules can be detected before it
+// desyncs.
+func RulesHash() uint64 {
+	h := fnv.New64a()
+	fmt.Fprint(h,
+		ScreenWidth, ScreenHeight,
+		rotationSpeed, thrustPower, maxSpeed, friction, particleDrag, hyperspaceRisk, hyperspaceCooldown,
+		shieldTicks, shieldHitCost,
+		playerRadius, bulletSpeed, bulletLife, MaxPlayerBullets,
+		weaponRapidScore, weaponSpreadScore, rapidMaxBullets, spreadAngle, shotCooldown, rapidShotCooldown,
+		missileAmmo, missileSpeed, missileTurnRate, missileLife,
+		asteroidShapePool, goldenChance, explosiveChance, armoredChance, goldenMultiplier, explosionRadius, armoredHits,
+		goldenFleeRange, goldenFleeAccel, goldenMaxSpeed,
+		starDropChance, starLife, starPoints, starRadius, starSpeed, comboWindow, comboStep, comboMaxMultiplier,
+		saucerLargeRadius, saucerSmallRadius, saucerLargeSpeed, saucerSmallSpeed,
+		saucerShootCooldownMin, saucerShootCooldownMax, saucerBulletSpeed, saucerBulletLife,
+		saucerVerticalTimerMin, saucerVerticalTimerMax, saucerVerticalSpeed, saucerLargeAimError, saucerSmallAimErrorEasy, saucerSmallAimError, saucerHardScore,
+		maxSaucers, saucerInitialDelay, saucerRespawnDelay, saucerClearance, saucerLeadLevel, shootDownRadius, shootDownPoints, maxAsteroids, maxParticles,
+		hitStopTicks, maxLives, lifeBonusPoints, spawnSafeRadius, maxSpawnAttempts, spawnRingInset, spawnCornerInset, spawnCornerRange,
+	)
+	return h.Sum64()
+}
@@ -0,0 +1,184 @@
+package game
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// recordGame plays ticks steps of scripted input on a seeded world.
+func recordGame(seed int64, ticks int) *Replay {
+	w := NewGameWorld(seed)
+	rec := NewReplayRecorder(w)
+	for w.Tick < ticks && !w.GameOver() {
+		in := ScriptedInput(w.Tick)
+		rec.Input(w, in)
+		Step(w, in)
+		rec.Check(w)
+		w.SoundQueue = w.SoundQueue[:0]
+	}
+	return rec.Finish(w)
+}
+
+func TestInputState_BitsRoundTrip(t *testing.T) {
+	for b := 0; b < 32; b++ {
+		in := InputFromBits(uint8(b))
+		if in.Bits() != uint8(b) {
+			t.Errorf("bits %05b round-tripped to %05b", b, in.Bits())
+		}
+	}
+}
+
+func TestReplay_EncodeDecodeRoundTrip(t *testing.T) {
+	r := recordGame(42, 600)
+
+	var buf bytes.Buffer
+	if err := r.Encode(&buf); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	got, err := DecodeReplay(&buf)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	if got.Seed != r.Seed || got.Ticks != r.Ticks || got.ConfigHash != r.ConfigHash {
+		t.Errorf("header mismatch: got seed=%d ticks=%d hash=%x", got.Seed, got.Ticks, got.ConfigHash)
+	}
+	if len(got.Inputs) != len(r.Inputs) || len(got.Checksums) != len(r.Checksums) {
+		t.Fatalf("expected %d inputs/%d checksums, got %d/%d",
+			len(r.Inputs), len(r.Checksums), len(got.Inputs), len(got.Checksums))
+	}
+	for i := range r.Inputs {
+		if got.Inputs[i] != r.Inputs[i] {
+			t.Errorf("input %d: expected %+v, got %+v", i, r.Inputs[i], got.Inputs[i])
+		}
+	}
+}
+
+func TestDecodeReplay_RejectsGarbage(t *testing.T) {
+	_, err := DecodeReplay(bytes.NewReader([]byte("not a replay at all")))
+	if !errors.Is(err, ErrNotReplay) {
+		t.Errorf("expected ErrNotReplay, got %v", err)
+	}
+}
+
+func TestDecodeReplay_RejectsNewerVersion(t *testing.T) {
+	var buf bytes.Buffer
+	if err := recordGame(1, 60).Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	data[len(replayMagic)] = ReplayVersion + 1
+
+	_, err := DecodeReplay(bytes.NewReader(data))
+	if !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("expected ErrUnsupportedVersion, got %v", err)
+	}
+}
+
+func TestReplay_OnlyInputChangesRecorded(t *testing.T) {
+	w := NewGameWorld(1)
+	rec := NewReplayRecorder(w)
+	held := InputState{Thrust: true}
+	for i := 0; i < 10; i++ {
+		rec.Input(w, held)
+		Step(w, held)
+	}
+	r := rec.Finish(w)
+
+	if len(r.Inputs) != 1 {
+		t.Errorf("holding one input should record 1 frame, got %d", len(r.Inputs))
+	}
+	if r.Ticks != 10 {
+		t.Errorf("expected 10 ticks, got %d", r.Ticks)
+	}
+}
+
+func TestVerifyReplay_Deterministic(t *testing.T) {
+	r := recordGame(1234, 1800)
+	if len(r.Checksums) == 0 {
+		t.Fatal("recording should contain checksums")
+	}
+	if tick := VerifyReplay(r); tick != -1 {
+		t.Errorf("replay desynced at tick %d", tick)
+	}
+}
+
+func TestVerifyReplay_DetectsTamperedInput(t *testing.T) {
+	r := recordGame(99, 1200)
+	// Insert a hyperspace jump early on; the game must diverge from the recording.
+	r.Inputs = append([]InputFrame{{Tick: 5, Buttons: InputState{Hyperspace: true}.Bits()}}, r.Inputs...)
+
+	if tick := VerifyReplay(r); tick < 0 {
+		t.Error("tampered replay should desync")
+	}
+}
+
+func TestReplayRunner_SeekBackwardsRestarts(t *testing.T) {
+	r := recordGame(7, 600)
+	p := NewReplayRunner(r)
+
+	p.Seek(300)
+	sumAt300 := p.World.Checksum()
+	p.Seek(500)
+	p.Seek(300)
+
+	if p.World.Tick != 300 {
+		t.Fatalf("expected tick 300, got %d", p.World.Tick)
+	}
+	if p.World.Checksum() != sumAt300 {
+		t.Error("seeking back should reproduce the same state")
+	}
+}
+
+func TestReplayRunner_StopsAtEnd(t *testing.T) {
+	r := recordGame(7, 120)
+	p := NewReplayRunner(r)
+	p.Seek(10_000)
+
+	if !p.Done() {
+		t.Error("runner should be done")
+	}
+	if p.World.Tick != r.Ticks {
+		t.Errorf("expected to stop at tick %d, got %d", r.Ticks, p.World.Tick)
+	}
+}
+
+func TestMenuSelect_WatchReplay(t *testing.T) {
+	g := New()
+	g.lastReplay = recordGame(3, 120)
+	g.menuCursor = 3
+	g.menuSelect()
+
+	if g.state != stateReplay {
+		t.Errorf("expected stateReplay, got %v", g.state)
+	}
+	if g.world == nil || g.world.Seed != 3 {
+		t.Error("replay world should be created from the replay seed")
+	}
+}
+
+func TestFinishRecording_SavesToReplayDir(t *testing.T) {
+	g := New()
+	g.reset()
+	for i := 0; i < 30; i++ {
+		Step(g.world, ScriptedInput(i))
+	}
+	g.finishRecording()
+
+	dir, err := ReplayDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	path, err := LatestReplay(dir)
+	if err != nil {
+		t.Fatalf("no replay saved in %s: %v", dir, err)
+	}
+	r, err := LoadReplay(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Seed != g.lastReplay.Seed {
+		t.Errorf("saved replay has seed %d, want %d", r.Seed, g.lastReplay.Seed)
+	}
+}
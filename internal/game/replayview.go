@@ -0,0 +1,179 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/matheus3301/asteroids/internal/canvas"
+)
+
+const replaySeekTicks = 5 * 60
+
+var replaySpeeds = []float64{0.25, 0.5, 1, 2, 4}
+
+const replayNormalSpeed = 2 // index of 1x in replaySpeeds
+
+// replayViewer holds playback state for the replay screen.
+type replayViewer struct {
+	runner *ReplayRunner
+	paused bool
+	speed  int
+	accum  float64
+}
+
+// alpha is how far playback has got towards the next tick, for drawing
+// between ticks. A paused or finished replay is drawn as it stands.
+func (v *replayViewer) alpha() float64 {
+	if v.paused || v.runner.Done() {
+		return 1
+	}
+	return v.accum
+}
+
+func newReplayViewer(r *Replay) *replayViewer {
+	return &replayViewer{
+		runner: NewReplayRunner(r),
+		speed:  replayNormalSpeed,
+	}
+}
+
+// PlayReplay switches the game to the replay screen and starts playing r.
+func (g *Game) PlayReplay(r *Replay) {
+	g.ensureSound()
+	g.sound.Reset()
+	g.replay = newReplayViewer(r)
+	g.world = g.replay.runner.World
+	g.state = stateReplay
+}
+
+// watchLatestReplay plays the latest replay, if there is one.
+func (g *Game) watchLatestReplay() {
+	if r := g.latestReplay(); r != nil {
+		g.PlayReplay(r)
+	}
+}
+
+// latestReplay is the replay of the last game, falling back to the newest
+// replay on disk, or nil if there is none.
+func (g *Game) latestReplay() *Replay {
+	if g.lastReplay != nil {
+		return g.lastReplay
+	}
+	dir, err := ReplayDir()
+	if err != nil {
+		return nil
+	}
+	path, err := LatestReplay(dir)
+	if err != nil {
+		return nil
+	}
+	r, err := LoadReplay(path)
+	if err != nil {
+		logger.Warn("loading replay", "path", path, "err", err)
+		return nil
+	}
+	return r
+}
+
+func (g *Game) updateReplay() {
+	v := g.replay
+	p := v.runner
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.sound.StopAll()
+		g.state = stateMenu
+		return
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+		v.paused = !v.paused
+		if v.paused {
+			g.sound.PauseAll()
+		} else {
+			g.sound.ResumeAll()
+		}
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyUp) && v.speed < len(replaySpeeds)-1 {
+		v.speed++
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyDown) && v.speed > 0 {
+		v.speed--
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyRight) {
+		g.seekReplay(p.World.Tick + replaySeekTicks)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyLeft) {
+		g.seekReplay(p.World.Tick - replaySeekTicks)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyR) {
+		g.seekReplay(0)
+	}
+
+	if v.paused || p.Done() {
+		g.sound.StopAll()
+		return
+	}
+	v.accum += replaySpeeds[v.speed]
+	for v.accum >= 1 && !p.Done() {
+		v.accum--
+		p.Advance()
+	}
+	if v.speed == replayNormalSpeed {
+		SoundSystem(g.sound, p.World)
+	} else {
+		p.World.SoundQueue = p.World.SoundQueue[:0]
+	}
+}
+
+func (g *Game) seekReplay(tick int) {
+	p := g.replay.runner
+	if tick < 0 {
+		tick = 0
+	}
+	g.sound.Reset()
+	p.Seek(tick)
+	g.world = p.World
+	g.replay.accum = 0
+}
+
+func (g *Game) drawReplay(screen canvas.Canvas) {
+	v := g.replay
+	p := v.runner
+
+	DrawWorldAt(p.World, screen, v.alpha())
+	g.drawHUD(screen)
+
+	barY := float64(ScreenHeight - 40)
+	barW := float64(ScreenWidth - 20)
+	progress := 1.0
+	if p.Replay.Ticks > 0 {
+		progress = float64(p.World.Tick) / float64(p.Replay.Ticks)
+	}
+	screen.StrokeRect(10, barY, barW, 6, 1, color.RGBA{150, 150, 150, 255})
+	screen.FillRect(10, barY, barW*progress, 6, color.RGBA{0, 255, 0, 255})
+
+	status := fmt.Sprintf("REPLAY  %s / %s  X%g", formatTicks(p.World.Tick), formatTicks(p.Replay.Ticks), replaySpeeds[v.speed])
+	switch {
+	case p.Done():
+		status += "  END"
+	case v.paused:
+		status += "  PAUSED"
+	}
+	DrawText(screen, status, 10, barY+12, 1.5, color.RGBA{200, 200, 200, 255})
+
+	warnY := 80.0
+	if !p.Replay.Compatible() {
+		DrawText(screen, "RECORDED WITH DIFFERENT RULES", 10, warnY, 1.5, color.RGBA{255, 165, 0, 255})
+		warnY += 16
+	}
+	if p.DesyncTick >= 0 {
+		DrawText(screen, fmt.Sprintf("DESYNC AT %s", formatTicks(p.DesyncTick)), 10, warnY, 1.5, color.RGBA{255, 0, 0, 255})
+	}
+}
+
+// formatTicks renders a tick count as minutes and seconds.
+func formatTicks(ticks int) string {
+	secs := ticks / 60
+	return fmt.Sprintf("%02d:%02d", secs/60, secs%60)
+}
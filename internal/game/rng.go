@@ -0,0 +1,11 @@
+package game
+
+// RNG is the random source the simulation draws from. Every random choice
+// in a game goes through the world's RNG so that a seed reproduces it;
+// *rand.Rand satisfies it, and tests can substitute a fixed sequence.
+type RNG interface {
+	// Float64 returns a number in [0, 1).
+	Float64() float64
+	// Intn returns a number in [0, n).
+	Intn(n int) int
+}
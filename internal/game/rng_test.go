@@ -0,0 +1,23 @@
+package game
+
+// fixedRNG is an RNG that returns the given rolls in turn and then keeps
+// repeating the last one. Intn scales the same roll to [0, n), so a roll of
+// 0.5 is the middle of any range.
+type fixedRNG struct {
+	rolls []float64
+	next  int
+}
+
+func (r *fixedRNG) Float64() float64 {
+	v := r.rolls[min(r.next, len(r.rolls)-1)]
+	r.next++
+	return v
+}
+
+func (r *fixedRNG) Intn(n int) int { return int(r.Float64() * float64(n)) }
+
+// withRolls makes w draw rolls instead of random numbers.
+func withRolls(w *World, rolls ...float64) *World {
+	w.rng = &fixedRNG{rolls: rolls}
+	return w
+}
@@ -0,0 +1,97 @@
+package game
+
+// SaucerEventKind is what happened to a saucer.
+type SaucerEventKind int
+
+const (
+	// SaucerSpawned is a saucer entering the screen.
+	SaucerSpawned SaucerEventKind = iota
+	// SaucerEscaped is a saucer leaving past the far edge.
+	SaucerEscaped
+	// SaucerDestroyed is a saucer shot by a player.
+	SaucerDestroyed
+	// SaucerCleared is a saucer removed because a ship died.
+	SaucerCleared
+)
+
+func (k SaucerEventKind) String() string {
+	switch k {
+	case SaucerSpawned:
+		return "spawned"
+	case SaucerEscaped:
+		return "escaped"
+	case SaucerDestroyed:
+		return "destroyed"
+	case SaucerCleared:
+		return "cleared"
+	}
+	return "unknown"
+}
+
+// SaucerEvent is one saucer spawning or leaving play.
+type SaucerEvent struct {
+	Kind   SaucerEventKind
+	Saucer Entity
+	Size   SaucerSize
+}
+
+// SaucerState is the saucer lifecycle: the countdown to the next saucer
+// and what happened to saucers this tick.
+type SaucerState struct {
+	// SpawnTimer counts down to the next saucer while there is room for
+	// one under Config.MaxSaucers.
+	SpawnTimer int
+	// Events lists saucer spawns and removals since the tick began, for
+	// systems later in the pipeline to react to.
+	Events []SaucerEvent
+}
+
+// SaucerLifecycleSystem owns when saucers come and go: it removes saucers
+// that have crossed the screen, then counts down to and spawns the next one
+// while fewer than Config.MaxSaucers are in play. Every removal, here or
+// elsewhere, goes through removeSaucer and restarts the countdown.
+func SaucerLifecycleSystem(w *World) {
+	for _, e := range sortedEntities(w.saucers) {
+		if saucerOffScreen(w, e) {
+			removeSaucer(w, e, SaucerEscaped)
+		}
+	}
+
+	if len(w.saucers) >= w.Config.MaxSaucers {
+		return
+	}
+	w.Saucer.SpawnTimer--
+	if w.Saucer.SpawnTimer <= 0 {
+		size := chooseSaucerSize(w.rng, w.Score)
+		e := SpawnSaucer(w, size)
+		w.Saucer.Events = append(w.Saucer.Events, SaucerEvent{Kind: SaucerSpawned, Saucer: e, Size: size})
+		w.Saucer.SpawnTimer = w.Config.SaucerRespawnDelay
+	}
+}
+
+// saucerOffScreen reports whether saucer e has passed the edge it flies
+// towards.
+func saucerOffScreen(w *World, e Entity) bool {
+	st, pos := w.saucers[e], w.positions[e]
+	if pos == nil {
+		return false
+	}
+	radius := 0.0
+	if col := w.colliders[e]; col != nil {
+		radius = col.Radius
+	}
+	return (st.DirectionX > 0 && pos.X > w.Config.FieldWidth+radius) ||
+		(st.DirectionX < 0 && pos.X < -radius)
+}
+
+// removeSaucer takes saucer e out of play, records why and restarts the
+// countdown to the next one.
+func removeSaucer(w *World, e Entity, kind SaucerEventKind) {
+	st := w.saucers[e]
+	if st == nil {
+		return
+	}
+	w.Saucer.Events = append(w.Saucer.Events, SaucerEvent{Kind: kind, Saucer: e, Size: st.Size})
+	w.Destroy(e)
+	w.Saucer.SpawnTimer = w.Config.SaucerRespawnDelay
+}
@@ -0,0 +1,102 @@
+package game
+
+import (
+	"fmt"
+	"math"
+)
+
+// SaucerProfile is how one size of saucer fights.
+type SaucerProfile struct {
+	// ShootCooldownMin and ShootCooldownMax bound the ticks between
+	// shots; each wait is picked at random in [min, max).
+	ShootCooldownMin int `json:"shoot_cooldown_min"`
+	ShootCooldownMax int `json:"shoot_cooldown_max"`
+	// VerticalSpeed is how fast the saucer drifts up or down after a
+	// change of course.
+	VerticalSpeed float64 `json:"vertical_speed"`
+	BulletSpeed   float64 `json:"bullet_speed"`
+	// AimError is how far, in radians either way, a shot may miss the
+	// point the saucer aims at. 0 shoots dead on; pi or more fires in any
+	// direction.
+	AimError float64 `json:"aim_error"`
+}
+
+// SaucerBehavior tunes one size of saucer as the game goes on: Easy at a
+// score of 0 and Hard from HardScore on, with every value blended by score
+// in between.
+type SaucerBehavior struct {
+	Easy      SaucerProfile `json:"easy"`
+	Hard      SaucerProfile `json:"hard"`
+	HardScore int           `json:"hard_score"`
+}
+
+// At is the profile for a game at score.
+func (b SaucerBehavior) At(score int) SaucerProfile {
+	t := 1.0
+	if b.HardScore > 0 {
+		t = min(max(float64(score)/float64(b.HardScore), 0), 1)
+	}
+	lerp := func(a, b float64) float64 { return a + (b-a)*t }
+	lerpInt := func(a, b int) int { return int(math.Round(lerp(float64(a), float64(b)))) }
+	e, h := b.Easy, b.Hard
+	return SaucerProfile{
+		ShootCooldownMin: lerpInt(e.ShootCooldownMin, h.ShootCooldownMin),
+		ShootCooldownMax: lerpInt(e.ShootCooldownMax, h.ShootCooldownMax),
+		VerticalSpeed:    lerp(e.VerticalSpeed, h.VerticalSpeed),
+		BulletSpeed:      lerp(e.BulletSpeed, h.BulletSpeed),
+		AimError:         lerp(e.AimError, h.AimError),
+	}
+}
+
+// validate reports what is wrong with the profile, naming fields under
+// prefix, such as "small_saucer.easy".
+func (p SaucerProfile) validate(prefix string) []error {
+	var errs []error
+	check := func(ok bool, field, msg string) {
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s.%s: %s", prefix, field, msg))
+		}
+	}
+	check(p.ShootCooldownMin >= 1, "shoot_cooldown_min", "must be at least 1")
+	check(p.ShootCooldownMax > p.ShootCooldownMin, "shoot_cooldown_max", "must be more than shoot_cooldown_min")
+	check(p.VerticalSpeed >= 0, "vertical_speed", "cannot be negative")
+	check(p.BulletSpeed > 0, "bullet_speed", "must be positive")
+	check(p.AimError >= 0, "aim_error", "cannot be negative")
+	return errs
+}
+
+// validate reports what is wrong with either profile or the score.
+func (b SaucerBehavior) validate(prefix string) []error {
+	errs := append(b.Easy.validate(prefix+".easy"), b.Hard.validate(prefix+".hard")...)
+	if b.HardScore < 0 {
+		errs = append(errs, fmt.Errorf("%s.hard_score: cannot be negative", prefix))
+	}
+	return errs
+}
+
+// saucerProfile is how a saucer of size fights at the world's score.
+func saucerProfile(w *World, size SaucerSize) SaucerProfile {
+	if size == SaucerSmall {
+		return w.Config.SmallSaucer.At(w.Score)
+	}
+	return w.Config.LargeSaucer.At(w.Score)
+}
+
+// saucerCooldown picks the ticks until a saucer fighting by p shoots next.
+func saucerCooldown(w *World, p SaucerProfile) int {
+	return p.ShootCooldownMin + w.rng.Intn(max(p.ShootCooldownMax-p.ShootCooldownMin, 1))
+}
+
+// standardSaucer is the standard behavior for a saucer that misses by up
+// to easyAim at a score of 0 and by up to hardAim from saucerHardScore on.
+func standardSaucer(easyAim, hardAim float64) SaucerBehavior {
+	p := SaucerProfile{
+		ShootCooldownMin: saucerShootCooldownMin,
+		ShootCooldownMax: saucerShootCooldownMax,
+		VerticalSpeed:    saucerVerticalSpeed,
+		BulletSpeed:      saucerBulletSpeed,
+	}
+	easy, hard := p, p
+	easy.AimError, hard.AimError = easyAim, hardAim
+	return SaucerBehavior{Easy: easy, Hard: hard, HardScore: saucerHardScore}
+}
@@ -0,0 +1,167 @@
+package game
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestSaucerBehavior_BlendsByScore(t *testing.T) {
+	b := standardSaucer(0, 0)
+	b.Hard.ShootCooldownMin, b.Hard.ShootCooldownMax = 20, 40
+	b.Hard.BulletSpeed = 8
+	b.Hard.AimError = 0.2
+	b.HardScore = 10_000
+
+	if got := b.At(0); got != b.Easy {
+		t.Errorf("at 0 expected the easy profile, got %+v", got)
+	}
+	if got := b.At(50_000); got != b.Hard {
+		t.Errorf("past hard_score expected the hard profile, got %+v", got)
+	}
+	mid := b.At(5_000)
+	if mid.ShootCooldownMin != 40 || mid.ShootCooldownMax != 95 || mid.BulletSpeed != 6 || math.Abs(mid.AimError-0.1) > 1e-9 {
+		t.Errorf("halfway expected values halfway between, got %+v", mid)
+	}
+}
+
+func TestSaucerBehavior_Validate(t *testing.T) {
+	c := DefaultConfig()
+	c.SmallSaucer.Hard.ShootCooldownMax = c.SmallSaucer.Hard.ShootCooldownMin
+	c.LargeSaucer.Easy.BulletSpeed = 0
+	err := c.Validate()
+	if err == nil {
+		t.Fatal("expected errors")
+	}
+	for _, field := range []string{"small_saucer.hard.shoot_cooldown_max", "large_saucer.easy.bullet_speed"} {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("expected %s in %v", field, err)
+		}
+	}
+}
+
+func TestSaucerBehavior_FromConfigJSON(t *testing.T) {
+	c := DefaultConfig()
+	if err := json.Unmarshal([]byte(`{"small_saucer": {"hard": {"bullet_speed": 7}}}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	w := NewWorldWithSeed(1)
+	w.Config = c
+	SpawnPlayer(w, 400, 100)
+	e := SpawnSaucer(w, SaucerSmall)
+	*w.positions[e] = Position{X: 400, Y: 300}
+
+	speed := func(score int) float64 {
+		w.Score = score
+		b := SpawnSaucerBullet(w, e, 400, 100)
+		v := w.velocities[b]
+		return math.Hypot(v.X, v.Y)
+	}
+	if got := speed(0); math.Abs(got-saucerBulletSpeed) > 1e-9 {
+		t.Errorf("at 0 expected the standard bullet speed, got %v", got)
+	}
+	if got := speed(saucerHardScore); math.Abs(got-7) > 1e-9 {
+		t.Errorf("at hard_score expected the modded bullet speed, got %v", got)
+	}
+}
+
+func TestSpawnSaucerBullet_AimError(t *testing.T) {
+	w := NewWorldWithSeed(1)
+	w.Config.SmallSaucer = standardSaucer(0.3, 0.3)
+	e := SpawnSaucer(w, SaucerSmall)
+	*w.positions[e] = Position{X: 400, Y: 300}
+
+	missed := false
+	for range 20 {
+		v := w.velocities[SpawnSaucerBullet(w, e, 500, 300)]
+		off := math.Abs(math.Atan2(v.Y, v.X))
+		if off > 0.3+1e-9 {
+			t.Fatalf("shot %.3f rad off, more than the 0.3 aim error", off)
+		}
+		missed = missed || off > 0.01
+	}
+	if !missed {
+		t.Error("expected some shots to miss with an aim error")
+	}
+}
+
+// aimOffsets fires n shots from a small saucer at a ship straight to its
+// right in a game at score, returning how far off each one flies.
+func aimOffsets(c GameConfig, score, n int) []float64 {
+	w := NewWorldWithSeed(1)
+	w.Config = c
+	w.Score = score
+	e := SpawnSaucer(w, SaucerSmall)
+	*w.positions[e] = Position{X: 400, Y: 300}
+	offs := make([]float64, n)
+	for i := range offs {
+		v := w.velocities[SpawnSaucerBullet(w, e, 500, 300)]
+		offs[i] = math.Atan2(v.Y, v.X)
+	}
+	return offs
+}
+
+func TestSpawnSaucerBullet_SmallAimShrinksWithScore(t *testing.T) {
+	const n = 2000
+	prev := math.Inf(1)
+	for _, score := range []int{0, 10_000, 20_000, 30_000} {
+		bound := DefaultConfig().SmallSaucer.At(score).AimError
+		if bound >= prev {
+			t.Fatalf("at %d the aim error %v should be below %v", score, bound, prev)
+		}
+		prev = bound
+
+		// Shots spread evenly across [-bound, bound]: each quarter of it
+		// takes about a quarter of them, and they miss by bound/2 on
+		// average, either way alike.
+		var quarters [4]int
+		var sum, sumAbs float64
+		for _, off := range aimOffsets(DefaultConfig(), score, n) {
+			if math.Abs(off) > bound+1e-9 {
+				t.Fatalf("at %d a shot flew %.3f rad off, more than the %.3f aim error", score, off, bound)
+			}
+			quarters[min(int((off+bound)/(2*bound)*4), 3)]++
+			sum += off
+			sumAbs += math.Abs(off)
+		}
+		for i, q := range quarters {
+			if q < n/4-100 || q > n/4+100 {
+				t.Errorf("at %d quarter %d took %d of %d shots: %v", score, i, q, n, quarters)
+			}
+		}
+		if mean := sum / n; math.Abs(mean) > bound*0.05 {
+			t.Errorf("at %d shots should miss either way alike, mean %.4f", score, mean)
+		}
+		if meanAbs := sumAbs / n; math.Abs(meanAbs-bound/2) > bound*0.05 {
+			t.Errorf("at %d the mean miss is %.4f, want about %.4f", score, meanAbs, bound/2)
+		}
+	}
+	for _, off := range aimOffsets(DefaultConfig(), saucerHardScore, 50) {
+		if math.Abs(off) > 1e-9 {
+			t.Fatalf("from hard_score small saucers should shoot dead on, %.4f off", off)
+		}
+	}
+}
+
+func TestSpawnSaucerBullet_DifficultyScalesAim(t *testing.T) {
+	spread := func(d Difficulty, score int) float64 {
+		var worst float64
+		for _, off := range aimOffsets(Difficulties[d].Apply(DefaultConfig()), score, 500) {
+			worst = max(worst, math.Abs(off))
+		}
+		return worst
+	}
+	if easy, normal := spread(DifficultyEasy, 0), spread(DifficultyNormal, 0); easy <= normal {
+		t.Errorf("easy small saucers should miss by more: %.3f vs %.3f", easy, normal)
+	}
+	if easy := spread(DifficultyEasy, saucerHardScore); easy == 0 {
+		t.Error("on easy small saucers should still miss at the normal hard_score")
+	}
+	if hard := spread(DifficultyHard, saucerHardScore*3/4); hard != 0 {
+		t.Errorf("on hard small saucers should shoot dead on sooner, %.3f off", hard)
+	}
+}
@@ -13,10 +13,65 @@ var resolutions = []resolution{
 	{1920, 1080, "1920X1080"},
 }
 
+// resolutionIndexFor returns the preset matching width x height, or -1.
+func resolutionIndexFor(width, height int) int {
+	for i, r := range resolutions {
+		if r.Width == width && r.Height == height {
+			return i
+		}
+	}
+	return -1
+}
+
 type settings struct {
 	resolutionIndex int
 	fullscreen      bool
 	volume          int // 0-10, default 10
+	autofire        bool
+	// autoPause pauses keyboard games when the window loses focus.
+	autoPause bool
+	// speed scales how many gameplay ticks run per frame.
+	speed float64
+	// aimGuide draws the lead needle and threat bar agents see; F4
+	// toggles it during play.
+	aimGuide bool
+	// aimAssist is the keyboard aim assist strength, 0 to MaxAimAssist.
+	aimAssist int
+}
+
+// Game speed limits and the step the settings screen changes it by.
+const (
+	MinGameSpeed  = 0.5
+	MaxGameSpeed  = 2.0
+	gameSpeedStep = 0.25
+)
+
+// setSpeed changes the game speed, clamped to the allowed range.
+func (g *Game) setSpeed(speed float64) {
+	g.settings.speed = min(max(speed, MinGameSpeed), MaxGameSpeed)
+}
+
+// setAutofire turns autofire on or off for the keyboard. Other input
+// sources decide for themselves when to shoot.
+func (g *Game) setAutofire(on bool) {
+	g.settings.autofire = on
+	if kb, ok := g.input.(KeyboardInput); ok {
+		kb.Autofire = on
+		g.input = kb
+	}
+}
+
+// aimAssistLabels name each aim assist strength on the settings screen.
+var aimAssistLabels = [MaxAimAssist + 1]string{"OFF", "LOW", "MEDIUM", "HIGH"}
+
+// setAimAssist sets the keyboard's aim assist strength, clamped to the
+// allowed range.
+func (g *Game) setAimAssist(strength int) {
+	g.settings.aimAssist = min(max(strength, 0), MaxAimAssist)
+	if kb, ok := g.input.(KeyboardInput); ok {
+		kb.AimAssist = g.settings.aimAssist
+		g.input = kb
+	}
 }
 
 func (s *settings) apply() {
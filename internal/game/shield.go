@@ -0,0 +1,72 @@
+package game
+
+import "math"
+
+const (
+	// shieldTicks is how long a full shield meter can be held up.
+	shieldTicks = 180
+	// shieldHitCost is what absorbing a collision takes from the meter,
+	// on top of the ticks it was held.
+	shieldHitCost = 60
+	// shieldRadius is how far from the ship a raised shield is drawn.
+	shieldRadius = playerRadius * 2
+)
+
+// ShieldSystem raises the shield of each ship holding the shield button
+// while its meter lasts, draining a tick of it per tick. The meter refills
+// at the start of each wave.
+func ShieldSystem(w *World) {
+	for _, pc := range w.players {
+		pc.ShieldUp = pc.ShieldPressed && pc.Shield > 0
+		if pc.ShieldUp {
+			pc.Shield--
+		}
+	}
+}
+
+// absorbHit spends a raised shield on the collision between ship e and
+// hazard instead of destroying the ship. A saucer bullet is destroyed; an
+// asteroid or saucer survives and the ship bounces off it, moved clear so
+// they do not touch again next tick.
+func absorbHit(w *World, e, hazard Entity) {
+	pc, pos, vel := w.players[e], w.positions[e], w.velocities[e]
+	pc.Shield = max(pc.Shield-w.Config.ShieldHitCost, 0)
+	w.SoundQueue = append(w.SoundQueue, SoundExplosionSmall)
+	for range 6 {
+		SpawnParticle(w, pos.X, pos.Y)
+	}
+	if w.saucerBullets[hazard] != nil {
+		w.Destroy(hazard)
+		return
+	}
+
+	hpos := w.positions[hazard]
+	if hpos == nil {
+		return
+	}
+	// n is the unit normal from the hazard to the ship.
+	nx, ny := w.Field().Delta(hpos.X, hpos.Y, pos.X, pos.Y)
+	d := math.Hypot(nx, ny)
+	if d == 0 {
+		nx, ny, d = 0, -1, 1
+	}
+	nx, ny = nx/d, ny/d
+
+	// The hazard is far heavier than the ship, so only the ship's velocity
+	// relative to it is reflected.
+	rx, ry := vel.X, vel.Y
+	if hvel := w.velocities[hazard]; hvel != nil {
+		rx, ry = rx-hvel.X, ry-hvel.Y
+	}
+	if along := rx*nx + ry*ny; along < 0 {
+		vel.X -= 2 * along * nx
+		vel.Y -= 2 * along * ny
+	}
+	if speed := math.Hypot(vel.X, vel.Y); speed > w.Config.MaxSpeed {
+		vel.X = vel.X / speed * w.Config.MaxSpeed
+		vel.Y = vel.Y / speed * w.Config.MaxSpeed
+	}
+
+	clear := w.colliders[e].Radius + w.colliders[hazard].Radius + 1
+	pos.X, pos.Y = w.Field().Wrap(hpos.X+nx*clear, hpos.Y+ny*clear)
+}
@@ -0,0 +1,121 @@
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+func TestShieldSystem_DrainsWhileHeld(t *testing.T) {
+	w := NewWorld()
+	e := SpawnPlayer(w, 400, 300)
+	pc := w.players[e]
+	if pc.Shield != shieldTicks {
+		t.Fatalf("a new ship should have a full meter, got %d", pc.Shield)
+	}
+
+	ShieldSystem(w)
+	if pc.ShieldUp || pc.Shield != shieldTicks {
+		t.Errorf("the shield should stay down and full until held, up %v meter %d", pc.ShieldUp, pc.Shield)
+	}
+
+	pc.ShieldPressed = true
+	ShieldSystem(w)
+	if !pc.ShieldUp || pc.Shield != shieldTicks-1 {
+		t.Errorf("holding should raise the shield and drain a tick, up %v meter %d", pc.ShieldUp, pc.Shield)
+	}
+
+	pc.Shield = 0
+	ShieldSystem(w)
+	if pc.ShieldUp {
+		t.Error("an empty meter should not raise the shield")
+	}
+}
+
+// shieldedShip is a vulnerable ship at (400, 300) flying right into a
+// still large asteroid, holding the shield.
+func shieldedShip(t *testing.T) (*World, Entity, Entity) {
+	t.Helper()
+	w := NewWorld()
+	p := SpawnPlayer(w, 400, 300)
+	w.players[p].Invulnerable = false
+	w.velocities[p].X = 3
+	a := SpawnAsteroid(w, 440, 300, SizeLarge)
+	w.velocities[a].X, w.velocities[a].Y = 0, 0
+	return w, p, a
+}
+
+func TestShield_BouncesOffAsteroid(t *testing.T) {
+	w, p, a := shieldedShip(t)
+	lives := w.Lives
+
+	Step(w, InputState{Shield: true})
+
+	if w.Lives != lives {
+		t.Fatalf("a raised shield should save the ship, lives %d -> %d", lives, w.Lives)
+	}
+	if w.asteroids[a] == nil {
+		t.Error("the asteroid should survive a shield bounce")
+	}
+	if v := w.velocities[p]; v.X >= 0 {
+		t.Errorf("the ship should bounce back to the left, velocity %+v", *v)
+	}
+	if got, want := w.players[p].Shield, shieldTicks-1-shieldHitCost; got != want {
+		t.Errorf("meter = %d, want %d after a tick held and a hit", got, want)
+	}
+	ppos, apos := w.positions[p], w.positions[a]
+	if d := math.Hypot(ppos.X-apos.X, ppos.Y-apos.Y); d <= w.colliders[p].Radius+w.colliders[a].Radius {
+		t.Errorf("the ship should be moved clear of the asteroid, distance %v", d)
+	}
+
+	// Clear of the asteroid and flying away, it is not hit again.
+	Step(w, InputState{Shield: true})
+	if w.Lives != lives {
+		t.Error("the ship should not be hit again the tick after a bounce")
+	}
+}
+
+func TestShield_DownMeansDeath(t *testing.T) {
+	w, _, _ := shieldedShip(t)
+	lives := w.Lives
+
+	Step(w, InputState{})
+
+	if w.Lives != lives-1 {
+		t.Errorf("without the shield the collision should cost a life, lives %d -> %d", lives, w.Lives)
+	}
+}
+
+func TestShield_AbsorbsSaucerBullet(t *testing.T) {
+	w := NewWorldWithSeed(1)
+	w.Player = SpawnPlayer(w, 400, 300)
+	pc := w.players[w.Player]
+	pc.Invulnerable = false
+	pc.ShieldUp = true
+	b := SpawnSaucerBullet(w, SpawnSaucer(w, SaucerLarge), 400, 300)
+	*w.positions[b] = Position{X: 403, Y: 302}
+	lives := w.Lives
+
+	CollisionResponseSystem(w, CollisionSystem(w))
+
+	if w.Lives != lives {
+		t.Errorf("a raised shield should stop a saucer bullet, lives %d -> %d", lives, w.Lives)
+	}
+	if w.saucerBullets[b] != nil {
+		t.Error("the saucer bullet should be destroyed")
+	}
+	if pc.Shield != shieldTicks-shieldHitCost {
+		t.Errorf("meter = %d, want %d", pc.Shield, shieldTicks-shieldHitCost)
+	}
+}
+
+func TestWaveClear_RefillsShield(t *testing.T) {
+	w := NewWorld()
+	p := SpawnPlayer(w, 400, 300)
+	w.players[p].Shield = 10
+
+	WaveClearSystem(w)
+
+	if got := w.players[p].Shield; got != shieldTicks {
+		t.Errorf("a new wave should refill the meter, got %d", got)
+	}
+}
@@ -0,0 +1,127 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/matheus3301/asteroids/internal/canvas"
+)
+
+// ShipType is a selectable ship: an outline plus the handling it changes.
+type ShipType struct {
+	ID          string
+	Name        string
+	Description string
+	Shape       ShipShape
+	// Stats adjusts the rules for this ship.
+	Stats func(GameConfig) GameConfig
+}
+
+// ShipTypes are the ships offered before a game, balanced first. Replays
+// store the index, so append new ships rather than reordering.
+var ShipTypes = []ShipType{
+	{
+		ID: "balanced", Name: "BALANCED", Description: "THE CLASSIC SHIP",
+		Shape: classicShip,
+		Stats: func(c GameConfig) GameConfig { return c },
+	},
+	{
+		ID: "heavy", Name: "HEAVY", Description: "SLOWER TURNS . 5 BULLETS",
+		Shape: ShipShape{{1, 0}, {0.2, -0.6}, {-0.8, -0.8}, {-0.8, 0.8}, {0.2, 0.6}},
+		Stats: func(c GameConfig) GameConfig {
+			c.RotationSpeed *= 0.7
+			c.MaxBullets = 5
+			return c
+		},
+	},
+	{
+		ID: "scout", Name: "SCOUT", Description: "FASTER THRUST . RISKIER HYPERSPACE",
+		Shape: ShipShape{{1.1, 0}, {-0.8, -0.45}, {-0.8, 0.45}},
+		Stats: func(c GameConfig) GameConfig {
+			c.ThrustPower *= 1.5
+			c.HyperspaceRisk = 1.0 / 6.0
+			return c
+		},
+	},
+}
+
+// applyShipType changes r to fly ship i. The balanced ship, like an
+// out-of-range index, leaves r alone.
+func applyShipType(r Ruleset, i int) Ruleset {
+	if i <= 0 || i >= len(ShipTypes) {
+		return r
+	}
+	s := ShipTypes[i]
+	r.Config = s.Stats(r.Config)
+	r.Ship = s.Shape
+	r.ShipType = i
+	return r
+}
+
+// --- Ship selection screen ---
+
+// openShipSelect shows the ship selection screen before a game.
+func (g *Game) openShipSelect() {
+	g.state = stateShipSelect
+}
+
+func (g *Game) updateShipSelect() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.sound.PlayBlip()
+		g.state = stateMenu
+		return
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyLeft) {
+		g.shipType = (g.shipType + len(ShipTypes) - 1) % len(ShipTypes)
+		g.sound.PlayBlip()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyRight) {
+		g.shipType = (g.shipType + 1) % len(ShipTypes)
+		g.sound.PlayBlip()
+	}
+	for i := range Mutators {
+		if inpututil.IsKeyJustPressed(ebiten.KeyDigit1 + ebiten.Key(i)) {
+			g.mutators = g.mutators.Toggle(i)
+			g.sound.PlayBlip()
+		}
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+		g.sound.PlayConfirm()
+		g.reset()
+	}
+}
+
+func (g *Game) drawShipSelect(screen canvas.Canvas) {
+	white := color.RGBA{255, 255, 255, 255}
+	grey := color.RGBA{100, 100, 100, 255}
+	green := color.RGBA{0, 255, 0, 255}
+	drawCentered(screen, "CHOOSE YOUR SHIP", 80, 4, white)
+
+	for i, s := range ShipTypes {
+		x := 200 + float64(i)*200
+		clr := grey
+		if i == g.shipType {
+			clr = green
+		}
+		drawPolygon(screen, &Position{X: x, Y: 260}, -math.Pi/2, s.Shape.vertices(playerRadius*3), clr)
+		DrawText(screen, s.Name, x-TextWidth(s.Name, 2)/2, 340, 2, clr)
+	}
+
+	s := ShipTypes[g.shipType]
+	c := s.Stats(DefaultConfig())
+	drawCentered(screen, s.Description, 400, 2, white)
+	drawCentered(screen, fmt.Sprintf("TURN X%.1f   THRUST X%.1f   BULLETS %d   HYPERSPACE RISK 1 IN %.0f",
+		c.RotationSpeed/rotationSpeed, c.ThrustPower/thrustPower, c.MaxBullets, 1/c.HyperspaceRisk), 440, 1.5, grey)
+
+	for i, m := range Mutators {
+		box, clr := "[ ]", grey
+		if g.mutators.Has(i) {
+			box, clr = "[X]", white
+		}
+		DrawText(screen, fmt.Sprintf("%d %s %-14s %s", i+1, box, m.Name, m.Desc), 110, 476+float64(i)*20, 1.5, clr)
+	}
+	drawCentered(screen, "LEFT/RIGHT TO CHOOSE . 1-4 FOR MUTATORS . ENTER TO START . ESC TO GO BACK", 570, 1.5, grey)
+}
@@ -0,0 +1,84 @@
+package game
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestApplyShipType_Stats(t *testing.T) {
+	heavy := applyShipType(StandardRules(), 1)
+	if heavy.Config.MaxBullets != 5 || heavy.Config.RotationSpeed >= rotationSpeed {
+		t.Errorf("heavy ship should turn slower and carry 5 bullets, got %+v", heavy.Config)
+	}
+	scout := applyShipType(StandardRules(), 2)
+	if scout.Config.ThrustPower <= thrustPower || scout.Config.HyperspaceRisk <= hyperspaceRisk {
+		t.Errorf("scout should thrust harder and risk more in hyperspace, got %+v", scout.Config)
+	}
+	if balanced := applyShipType(StandardRules(), 0); !balanced.Standard() {
+		t.Error("the balanced ship should be the standard game")
+	}
+	for i, s := range ShipTypes {
+		if err := s.Stats(DefaultConfig()).Validate(); err != nil {
+			t.Errorf("ship %d (%s) has invalid stats: %v", i, s.ID, err)
+		}
+	}
+}
+
+func TestHyperspace_UsesConfiguredRisk(t *testing.T) {
+	w := NewModdedWorld(1, applyShipType(StandardRules(), 2))
+	pc := w.players[w.Player]
+	pc.HyperspacePressed = true
+	lives := w.Lives
+
+	// Safe with the standard risk, fatal for the scout.
+	HyperspaceSystem(w, 0.1)
+
+	if w.Lives != lives-1 {
+		t.Error("the scout should be lost at a risk roll of 0.1")
+	}
+}
+
+func TestReplay_RecordsShipType(t *testing.T) {
+	w := NewModdedWorld(42, applyShipType(StandardRules(), 1))
+	rec := NewReplayRecorder(w)
+	for w.Tick < 600 && !w.GameOver() {
+		in := ScriptedInput(w.Tick)
+		rec.Input(w, in)
+		Step(w, in)
+		rec.Check(w)
+	}
+	r := rec.Finish(w)
+
+	var buf bytes.Buffer
+	if err := r.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	got, err := DecodeReplay(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Ship != 1 {
+		t.Fatalf("expected ship 1, got %d", got.Ship)
+	}
+
+	p := NewReplayRunner(got)
+	for !p.Done() {
+		p.Advance()
+	}
+	if p.DesyncTick >= 0 {
+		t.Errorf("replay of a heavy ship desynced at tick %d", p.DesyncTick)
+	}
+}
+
+func TestReset_UsesChosenShip(t *testing.T) {
+	g := New()
+	g.shipType = 1
+	g.reset()
+
+	if g.world.Config.MaxBullets != 5 {
+		t.Errorf("expected the heavy ship's 5 bullets, got %d", g.world.Config.MaxBullets)
+	}
+	if g.recorder == nil {
+		t.Error("games with any ship type should be recorded")
+	}
+}
@@ -0,0 +1,220 @@
+package game
+
+import (
+	"sort"
+	"time"
+)
+
+// NewGameWorld creates a world set up for the start of a new game: three
+// lives, level one, a player at the centre and the first wave of asteroids.
+func NewGameWorld(seed int64) *World {
+	w := NewWorldWithSeed(seed)
+	setupGame(w)
+	return w
+}
+
+// NewWaveWorld is a world with c at the start of wave level of a game with
+// seed, as if the ship were at the centre when the wave began. The
+// asteroids are where that wave puts them in a real game; their shapes and
+// velocities come from the world's random source, which depends on how the
+// game went, so those are only representative.
+func NewWaveWorld(seed int64, level int, c GameConfig) *World {
+	w := NewWorldWithSeed(seed)
+	w.Config = c
+	w.Level = max(level, 1)
+	w.Player = SpawnPlayer(w, c.FieldWidth/2, c.FieldHeight/2)
+	spawnWave(w)
+	return w
+}
+
+// setupGame puts an empty world into the state a single-player game starts
+// in.
+func setupGame(w *World) {
+	w.Score = 0
+	w.Lives = w.Config.StartingLives
+	w.NextExtraLifeAt = w.Config.ExtraLifeEvery
+	w.Level = 1
+	w.Saucer = SaucerState{SpawnTimer: w.Config.SaucerInitialDelay}
+	w.Player = SpawnPlayer(w, w.Config.FieldWidth/2, w.Config.FieldHeight/2)
+	spawnWave(w)
+}
+
+// coopSpawnOffset is how far right of the centre each extra ship starts.
+const coopSpawnOffset = 60
+
+// NewCoopWorld is NewGameWorld with a ship for every player slot. The
+// players share score and lives.
+func NewCoopWorld(seed int64) *World {
+	w := NewWorldWithSeed(seed)
+	w.Score = 0
+	w.Lives = w.Config.StartingLives
+	w.NextExtraLifeAt = w.Config.ExtraLifeEvery
+	w.Level = 1
+	w.Saucer = SaucerState{SpawnTimer: w.Config.SaucerInitialDelay}
+	cx, cy := w.Config.FieldWidth/2, w.Config.FieldHeight/2
+	w.Player = SpawnPlayer(w, cx, cy)
+	for slot := 1; slot < MaxPlayers; slot++ {
+		e := SpawnPlayer(w, cx+float64(slot)*coopSpawnOffset, cy)
+		w.players[e].Slot = slot
+		w.renderables[e].Color = coopShipColor
+	}
+	spawnWave(w)
+	return w
+}
+
+// tickContext carries per-tick data between pipeline stages.
+type tickContext struct {
+	in     Inputs
+	events CollisionEvent
+}
+
+// stage is one named step of the tick pipeline.
+type stage struct {
+	name string
+	run  func(w *World, ctx *tickContext)
+}
+
+// pipeline is the canonical order in which systems run every tick. The
+// game loop, replays, co-op and headless callers all run it through
+// stepInputs; nothing else calls gameplay systems.
+var pipeline = []stage{
+	{"Input", func(w *World, ctx *tickContext) { InputSystem(w, ctx.in) }},
+	{"AsteroidVariant", func(w *World, _ *tickContext) { AsteroidVariantSystem(w) }},
+	{"Physics", func(w *World, _ *tickContext) { PhysicsSystem(w) }},
+	{"Wrap", func(w *World, _ *tickContext) { WrapSystem(w) }},
+	{"Invulnerability", func(w *World, _ *tickContext) { InvulnerabilitySystem(w) }},
+	{"Lifetime", func(w *World, _ *tickContext) { LifetimeSystem(w) }},
+	{"SaucerLifecycle", func(w *World, _ *tickContext) { SaucerLifecycleSystem(w) }},
+	{"SaucerAI", func(w *World, _ *tickContext) { SaucerAISystem(w) }},
+	{"SaucerBulletLifetime", func(w *World, _ *tickContext) { SaucerBulletLifetimeSystem(w) }},
+	{"Hyperspace", func(w *World, _ *tickContext) { HyperspaceSystem(w, w.rng.Float64()) }},
+	{"Shield", func(w *World, _ *tickContext) { ShieldSystem(w) }},
+	{"Shooting", func(w *World, _ *tickContext) { ShootingSystem(w) }},
+	{"Missile", func(w *World, _ *tickContext) { MissileSystem(w) }},
+	{"Collision", func(w *World, ctx *tickContext) {
+		ctx.events = CollisionSystem(w)
+		if w.RecordContacts {
+			w.Contacts = contactPoints(w, ctx.events)
+		}
+	}},
+	{"CollisionResponse", func(w *World, ctx *tickContext) { CollisionResponseSystem(w, ctx.events) }},
+	{"Bonus", func(w *World, _ *tickContext) { BonusSystem(w) }},
+	{"WaveClear", func(w *World, _ *tickContext) { WaveClearSystem(w) }},
+	{"Hooks", func(w *World, _ *tickContext) { HooksSystem(w) }},
+}
+
+// Step advances the simulation by exactly one tick using the given input.
+// It runs every gameplay system in order but leaves the sound queue for the
+// caller to drain, so it can be used without an audio device.
+func Step(w *World, in InputState) {
+	stepInputs(w, Inputs{in}, nil)
+}
+
+// StepTimed is Step that also adds the time spent in each system to t.
+// A nil t skips timing entirely.
+func StepTimed(w *World, in InputState, t *SystemTimings) {
+	stepInputs(w, Inputs{in}, t)
+}
+
+// StepCoop is Step for a world with one ship per slot.
+func StepCoop(w *World, inputs Inputs) {
+	stepInputs(w, inputs, nil)
+}
+
+func stepInputs(w *World, inputs Inputs, t *SystemTimings) {
+	recordPrevious(w)
+	w.Saucer.Events = w.Saucer.Events[:0]
+	w.Kills = w.Kills[:0]
+	if w.TimeScale() == 0 {
+		w.HitStop--
+		w.Tick++
+		return
+	}
+	ctx := tickContext{in: inputs}
+	for i, s := range pipeline {
+		if t == nil {
+			s.run(w, &ctx)
+			continue
+		}
+		start := time.Now()
+		s.run(w, &ctx)
+		t.add(i, time.Since(start))
+	}
+	if t != nil {
+		t.Ticks++
+	}
+	w.Tick++
+}
+
+// timingWindow is roughly how many ticks SystemTimings.Recent averages
+// over: a second of play.
+const timingWindow = 60
+
+// SystemTimings accumulates wall-clock time spent in each pipeline stage.
+type SystemTimings struct {
+	Names []string
+	Total []time.Duration
+	Ticks int
+	// Recent is each stage's rolling average in nanoseconds per tick, an
+	// exponential moving average over about timingWindow ticks.
+	Recent []float64
+}
+
+func (t *SystemTimings) add(i int, d time.Duration) {
+	t.Total[i] += d
+	ns := float64(d.Nanoseconds())
+	if t.Ticks == 0 {
+		t.Recent[i] = ns
+		return
+	}
+	t.Recent[i] += (ns - t.Recent[i]) / timingWindow
+}
+
+// Slowest returns up to n stage indices, slowest first by Recent.
+func (t *SystemTimings) Slowest(n int) []int {
+	order := make([]int, len(t.Recent))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return t.Recent[order[a]] > t.Recent[order[b]] })
+	return order[:min(n, len(order))]
+}
+
+// NewSystemTimings returns empty timings for the current pipeline.
+func NewSystemTimings() *SystemTimings {
+	t := &SystemTimings{
+		Names:  make([]string, len(pipeline)),
+		Total:  make([]time.Duration, len(pipeline)),
+		Recent: make([]float64, len(pipeline)),
+	}
+	for i, s := range pipeline {
+		t.Names[i] = s.name
+	}
+	return t
+}
+
+// ScriptedInput is a fixed, input-only autopilot: it sweeps the ship around,
+// thrusts in bursts and fires steadily. It ignores the world entirely, which
+// makes it a stable workload for benchmarks and determinism tests.
+func ScriptedInput(tick int) InputState {
+	return InputState{
+		RotateLeft:  (tick/40)%3 == 0,
+		RotateRight: (tick/40)%3 == 1,
+		Thrust:      (tick/25)%4 == 0,
+		Shoot:       tick%9 == 0,
+		Hyperspace:  tick%500 == 499,
+	}
+}
+
+// Autopilot is an InputSource that flies the ship with ScriptedInput.
+type Autopilot struct{}
+
+// NextInput implements InputSource.
+func (Autopilot) NextInput(w *World) InputState {
+	return ScriptedInput(w.Tick)
+}
+
+// GameOver reports whether the player has run out of lives.
+func (w *World) GameOver() bool {
+	return w.Lives <= 0
+}
@@ -0,0 +1,193 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matheus3301/asteroids/internal/canvas"
+)
+
+func TestNewGameWorld_Defaults(t *testing.T) {
+	w := NewGameWorld(1)
+
+	if w.Lives != 3 || w.Level != 1 || w.Score != 0 {
+		t.Errorf("expected lives=3 level=1 score=0, got lives=%d level=%d score=%d", w.Lives, w.Level, w.Score)
+	}
+	if !w.Alive(w.Player) {
+		t.Error("player should be alive")
+	}
+	if len(w.asteroids) != 4 {
+		t.Errorf("expected first wave of 4 asteroids, got %d", len(w.asteroids))
+	}
+}
+
+func TestNewGameWorld_SameSeedSameLayout(t *testing.T) {
+	a := NewGameWorld(2024)
+	b := NewGameWorld(2024)
+
+	if a.Checksum() != b.Checksum() {
+		t.Error("worlds created from the same seed should be identical")
+	}
+	if NewGameWorld(2025).Checksum() == a.Checksum() {
+		t.Error("different seeds should produce different layouts")
+	}
+}
+
+func TestStep_AdvancesTick(t *testing.T) {
+	w := NewGameWorld(1)
+	Step(w, InputState{})
+	Step(w, InputState{})
+
+	if w.Tick != 2 {
+		t.Errorf("expected tick 2, got %d", w.Tick)
+	}
+}
+
+func TestStep_AppliesInput(t *testing.T) {
+	w := NewGameWorld(1)
+	before := w.rotations[w.Player].Angle
+
+	Step(w, InputState{RotateRight: true})
+
+	if w.rotations[w.Player].Angle <= before {
+		t.Error("RotateRight should increase the player angle")
+	}
+}
+
+func TestStep_SameInputsSameState(t *testing.T) {
+	a := NewGameWorld(77)
+	b := NewGameWorld(77)
+	for i := 0; i < 900; i++ {
+		in := ScriptedInput(i)
+		Step(a, in)
+		Step(b, in)
+	}
+
+	if a.Checksum() != b.Checksum() {
+		t.Error("identical seeds and inputs should produce identical worlds")
+	}
+}
+
+func TestStepTimed_RecordsEveryStage(t *testing.T) {
+	w := NewGameWorld(1)
+	timings := NewSystemTimings()
+	for i := 0; i < 10; i++ {
+		StepTimed(w, ScriptedInput(i), timings)
+	}
+
+	if timings.Ticks != 10 {
+		t.Errorf("expected 10 timed ticks, got %d", timings.Ticks)
+	}
+	if len(timings.Names) != len(pipeline) || len(timings.Total) != len(pipeline) {
+		t.Fatalf("expected %d stages, got %d names/%d totals", len(pipeline), len(timings.Names), len(timings.Total))
+	}
+	if timings.Names[0] != "Input" || timings.Names[len(timings.Names)-1] != "Hooks" {
+		t.Errorf("unexpected stage order: %v", timings.Names)
+	}
+}
+
+func TestStepTimed_MatchesStep(t *testing.T) {
+	a := NewGameWorld(5)
+	b := NewGameWorld(5)
+	timings := NewSystemTimings()
+	for i := 0; i < 300; i++ {
+		Step(a, ScriptedInput(i))
+		StepTimed(b, ScriptedInput(i), timings)
+	}
+
+	if a.Checksum() != b.Checksum() {
+		t.Error("timing a step must not change its outcome")
+	}
+}
+
+func TestSystemTimings_RecentIsARollingAverage(t *testing.T) {
+	timings := NewSystemTimings()
+	record := func(d time.Duration) {
+		timings.add(0, d)
+		timings.Ticks++
+	}
+	record(1000)
+	if timings.Recent[0] != 1000 {
+		t.Fatalf("expected the first tick to seed the average, got %v", timings.Recent[0])
+	}
+	for i := 0; i < 10*timingWindow; i++ {
+		record(5000)
+	}
+	if r := timings.Recent[0]; r < 4990 || r > 5000 {
+		t.Errorf("expected the average to settle near 5000ns, got %v", r)
+	}
+	if timings.Total[0] != 1000+10*timingWindow*5000 {
+		t.Errorf("expected the total to keep every tick, got %v", timings.Total[0])
+	}
+}
+
+func TestSystemTimings_Slowest(t *testing.T) {
+	timings := NewSystemTimings()
+	timings.Recent[3] = 300
+	timings.Recent[7] = 700
+	timings.Recent[1] = 100
+
+	got := timings.Slowest(3)
+	if len(got) != 3 || got[0] != 7 || got[1] != 3 || got[2] != 1 {
+		t.Errorf("expected stages 7, 3, 1, got %v", got)
+	}
+	if n := len(timings.Slowest(100)); n != len(pipeline) {
+		t.Errorf("expected at most every stage, got %d", n)
+	}
+}
+
+func TestGame_SystemTimings(t *testing.T) {
+	g := newPlaying()
+	g.StepPlaying(InputState{})
+	if g.SystemTimings() != nil {
+		t.Error("expected no timing until asked for")
+	}
+
+	g = NewWithOptions(Options{Seed: 1, SystemTimings: true})
+	g.reset()
+	for i := 0; i < 5; i++ {
+		g.StepPlaying(ScriptedInput(i))
+	}
+	if timings := g.SystemTimings(); timings == nil || timings.Ticks != 5 {
+		t.Fatalf("expected 5 timed ticks, got %+v", timings)
+	}
+
+	g.debug = true
+	var rec canvas.Recording
+	g.drawDebugOverlay(&rec)
+	var plain canvas.Recording
+	g.timings = nil
+	g.drawDebugOverlay(&plain)
+	if len(rec.Ops) <= len(plain.Ops) {
+		t.Error("expected the debug overlay to list the slowest systems")
+	}
+}
+
+// TestPipeline_GameLoopMatchesStep checks that the interactive game loop,
+// headless Step and replay playback all advance the world identically.
+func TestPipeline_GameLoopMatchesStep(t *testing.T) {
+	const seed, ticks = 77, 1200
+	g := NewWithOptions(Options{Seed: seed, Input: Autopilot{}})
+	g.reset()
+	headless := NewGameWorld(seed)
+
+	for i := 0; i < ticks && g.state == statePlaying; i++ {
+		g.updatePlaying()
+		Step(headless, ScriptedInput(headless.Tick))
+		if got, want := g.world.Checksum(), headless.Checksum(); got != want {
+			t.Fatalf("game loop diverged from Step at tick %d", headless.Tick)
+		}
+	}
+
+	rep := g.lastReplay // saved if the game ended
+	if g.recorder != nil {
+		rep = g.recorder.Finish(g.world)
+	}
+	p := NewReplayRunner(rep)
+	for !p.Done() {
+		p.Advance()
+	}
+	if p.DesyncTick >= 0 || p.World.Checksum() != g.world.Checksum() {
+		t.Errorf("replay of the game loop diverged (desync tick %d)", p.DesyncTick)
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"bytes"
 
 	"github.com/hajimehoshi/ebiten/v2/audio"
+	"github.com/matheus3301/asteroids/internal/logging"
 )
 
+var soundLog = logging.For("sound")
+
 // SoundEvent represents a one-shot sound to be played.
 type SoundEvent int
 
@@ -16,6 +19,8 @@ const (
 	SoundExplosionLarge
 	SoundPlayerDeath
 	SoundExtraLife
+	SoundPickup
+	SoundLifeBonus
 )
 
 // soundForSize maps an AsteroidSize to the corresponding SoundEvent.
@@ -48,33 +53,41 @@ type SoundManager struct {
 	masterVolume      float64
 	blipBuf           []byte
 	confirmBuf        []byte
+	lifeBonusBuf      []byte
+	extraLifeBuf      []byte
+	overrides         map[SoundEvent][]byte
+	voices            voicePool
 }
 
 // NewSoundManager creates a SoundManager and pre-generates all audio buffers.
 func NewSoundManager() *SoundManager {
 	ctx := audio.CurrentContext()
 	if ctx == nil {
-		ctx = audio.NewContext(sampleRate)
+		ctx = audio.NewContext(SampleRate)
 	}
 	sm := &SoundManager{
 		ctx:               ctx,
-		fireBuf:           generateFire(sampleRate),
-		explosionSmallBuf: generateExplosion(sampleRate, SizeSmall),
-		explosionMedBuf:   generateExplosion(sampleRate, SizeMedium),
-		explosionLargeBuf: generateExplosion(sampleRate, SizeLarge),
-		deathBuf:          generateDeath(sampleRate),
-		beatLowBuf:        generateBeatTone(sampleRate, 55),
-		beatHighBuf:       generateBeatTone(sampleRate, 70),
+		fireBuf:           generateFire(SampleRate),
+		explosionSmallBuf: generateExplosion(SampleRate, SizeSmall),
+		explosionMedBuf:   generateExplosion(SampleRate, SizeMedium),
+		explosionLargeBuf: generateExplosion(SampleRate, SizeLarge),
+		deathBuf:          generateDeath(SampleRate),
+		beatLowBuf:        generateBeatTone(SampleRate, 55),
+		beatHighBuf:       generateBeatTone(SampleRate, 70),
 		masterVolume:      1.0,
 		beatInterval:      60,
-		blipBuf:           generateBlip(sampleRate),
-		confirmBuf:        generateConfirm(sampleRate),
+		blipBuf:           generateBlip(SampleRate),
+		confirmBuf:        generateConfirm(SampleRate),
+		lifeBonusBuf:      generateLifeBonus(SampleRate),
+		extraLifeBuf:      generateExtraLife(SampleRate),
 	}
 
-	thrustBuf := generateThrustLoop(sampleRate)
+	thrustBuf := generateThrustLoop(SampleRate)
 	loop := audio.NewInfiniteLoop(bytes.NewReader(thrustBuf), int64(len(thrustBuf)))
 	p, err := ctx.NewPlayer(loop)
-	if err == nil {
+	if err != nil {
+		soundLog.Warn("thrust loop unavailable", "err", err)
+	} else {
 		sm.thrustPlayer = p
 		sm.thrustPlayer.SetVolume(sm.masterVolume)
 	}
@@ -82,23 +95,30 @@ func NewSoundManager() *SoundManager {
 	return sm
 }
 
-func (sm *SoundManager) playOneShot(buf []byte) {
+// playOneShot plays buf if a voice is free or can be taken from a less
+// important sound.
+func (sm *SoundManager) playOneShot(buf []byte, priority int) {
 	if sm == nil || sm.ctx == nil {
 		return
 	}
+	if !sm.voices.claim(priority) {
+		return
+	}
 	p, err := sm.ctx.NewPlayer(bytes.NewReader(buf))
 	if err != nil {
+		soundLog.Debug("one-shot player", "err", err)
 		return
 	}
 	p.SetVolume(sm.masterVolume)
 	p.Play()
+	sm.voices.add(p, priority)
 }
 
 func (sm *SoundManager) playFire() {
 	if sm == nil {
 		return
 	}
-	sm.playOneShot(sm.fireBuf)
+	sm.playOneShot(sm.fireBuf, priorityLow)
 }
 
 func (sm *SoundManager) playExplosion(size AsteroidSize) {
@@ -107,11 +127,11 @@ func (sm *SoundManager) playExplosion(size AsteroidSize) {
 	}
 	switch size {
 	case SizeLarge:
-		sm.playOneShot(sm.explosionLargeBuf)
+		sm.playOneShot(sm.explosionLargeBuf, priorityNormal)
 	case SizeMedium:
-		sm.playOneShot(sm.explosionMedBuf)
+		sm.playOneShot(sm.explosionMedBuf, priorityNormal)
 	default:
-		sm.playOneShot(sm.explosionSmallBuf)
+		sm.playOneShot(sm.explosionSmallBuf, priorityLow)
 	}
 }
 
@@ -119,7 +139,7 @@ func (sm *SoundManager) playDeath() {
 	if sm == nil {
 		return
 	}
-	sm.playOneShot(sm.deathBuf)
+	sm.playOneShot(sm.deathBuf, priorityTop)
 }
 
 // PlayBlip plays a short navigation blip for menu cursor movement.
@@ -127,7 +147,7 @@ func (sm *SoundManager) PlayBlip() {
 	if sm == nil {
 		return
 	}
-	sm.playOneShot(sm.blipBuf)
+	sm.playOneShot(sm.blipBuf, priorityLow)
 }
 
 // PlayConfirm plays a confirmation tone for menu selection.
@@ -135,7 +155,7 @@ func (sm *SoundManager) PlayConfirm() {
 	if sm == nil {
 		return
 	}
-	sm.playOneShot(sm.confirmBuf)
+	sm.playOneShot(sm.confirmBuf, priorityLow)
 }
 
 func (sm *SoundManager) startThrust() {
@@ -169,9 +189,9 @@ func (sm *SoundManager) updateBeat(asteroidCount int) {
 	sm.beatTimer--
 	if sm.beatTimer <= 0 {
 		if sm.beatHigh {
-			sm.playOneShot(sm.beatHighBuf)
+			sm.playOneShot(sm.beatHighBuf, priorityNormal)
 		} else {
-			sm.playOneShot(sm.beatLowBuf)
+			sm.playOneShot(sm.beatLowBuf, priorityNormal)
 		}
 		sm.beatHigh = !sm.beatHigh
 		sm.beatTimer = sm.beatInterval
@@ -227,6 +247,16 @@ func (sm *SoundManager) SetMasterVolume(v float64) {
 	}
 }
 
+// SetOverrides replaces the sounds of the given one-shot events, for mod
+// sound packs. Buffers are 16-bit stereo PCM at SampleRate. A nil map
+// restores the generated sounds.
+func (sm *SoundManager) SetOverrides(m map[SoundEvent][]byte) {
+	if sm == nil {
+		return
+	}
+	sm.overrides = m
+}
+
 // SoundSystem drains the sound event queue and manages continuous sounds.
 func SoundSystem(sm *SoundManager, w *World) {
 	if sm == nil {
@@ -234,6 +264,13 @@ func SoundSystem(sm *SoundManager, w *World) {
 	}
 
 	for _, event := range w.SoundQueue {
+		if buf, ok := sm.overrides[event]; ok {
+			sm.playOneShot(buf, soundPriority(event))
+			if event == SoundPlayerDeath {
+				sm.stopThrust()
+			}
+			continue
+		}
 		switch event {
 		case SoundFire:
 			sm.playFire()
@@ -246,6 +283,12 @@ func SoundSystem(sm *SoundManager, w *World) {
 		case SoundPlayerDeath:
 			sm.playDeath()
 			sm.stopThrust()
+		case SoundPickup:
+			sm.playOneShot(sm.confirmBuf, soundPriority(event))
+		case SoundExtraLife:
+			sm.playOneShot(sm.extraLifeBuf, soundPriority(event))
+		case SoundLifeBonus:
+			sm.playOneShot(sm.lifeBonusBuf, soundPriority(event))
 		}
 	}
 	w.SoundQueue = w.SoundQueue[:0]
@@ -6,7 +6,8 @@ import (
 	"math/rand"
 )
 
-const sampleRate = 44100
+// SampleRate is the rate, in Hz, of all game audio.
+const SampleRate = 44100
 
 // clampF clamps v between min and max.
 func clampF(v, min, max float64) float64 {
@@ -141,6 +142,41 @@ func generateConfirm(sr int) []byte {
 	return buf
 }
 
+// generateExtraLife returns four short 1760 Hz beeps, 40ms apart, played
+// when an extra life is awarded.
+func generateExtraLife(sr int) []byte {
+	const beeps = 4
+	beepFrames := int(float64(sr) * 0.06)
+	periodFrames := int(float64(sr) * 0.1)
+	buf := make([]byte, periodFrames*beeps*4)
+	for n := 0; n < beeps; n++ {
+		for i := 0; i < beepFrames; i++ {
+			t := float64(i) / float64(beepFrames)
+			envelope := math.Exp(-t * 3)
+			sample := math.Sin(2*math.Pi*1760*float64(i)/float64(sr)) * envelope * 0.3
+			writeStereoSample(buf, (n*periodFrames+i)*4, sample)
+		}
+	}
+	return buf
+}
+
+// generateLifeBonus returns a 240ms rising three-note arpeggio (660, 880,
+// 1320 Hz), played when an extra life is paid out as points.
+func generateLifeBonus(sr int) []byte {
+	notes := []float64{660, 880, 1320}
+	noteFrames := int(float64(sr) * 0.08)
+	buf := make([]byte, noteFrames*len(notes)*4)
+	for n, freq := range notes {
+		for i := 0; i < noteFrames; i++ {
+			t := float64(i) / float64(noteFrames)
+			envelope := math.Exp(-t * 4)
+			sample := math.Sin(2*math.Pi*freq*float64(i)/float64(sr)) * envelope * 0.35
+			writeStereoSample(buf, (n*noteFrames+i)*4, sample)
+		}
+	}
+	return buf
+}
+
 // beatIntervalFromAsteroidCount returns the beat interval in ticks.
 // Fewer asteroids → faster heartbeat.
 func beatIntervalFromAsteroidCount(count int) int {
@@ -14,15 +14,15 @@ func readSample(buf []byte, frame int) (left, right int16) {
 }
 
 func TestGenerateFire_Length(t *testing.T) {
-	buf := generateFire(sampleRate)
-	expectedFrames := int(float64(sampleRate) * 0.08)
+	buf := generateFire(SampleRate)
+	expectedFrames := int(float64(SampleRate) * 0.08)
 	if len(buf) != expectedFrames*4 {
 		t.Errorf("expected %d bytes, got %d", expectedFrames*4, len(buf))
 	}
 }
 
 func TestGenerateFire_SampleRange(t *testing.T) {
-	buf := generateFire(sampleRate)
+	buf := generateFire(SampleRate)
 	frames := len(buf) / 4
 	for i := 0; i < frames; i++ {
 		l, r := readSample(buf, i)
@@ -33,7 +33,7 @@ func TestGenerateFire_SampleRange(t *testing.T) {
 }
 
 func TestGenerateFire_NotSilent(t *testing.T) {
-	buf := generateFire(sampleRate)
+	buf := generateFire(SampleRate)
 	frames := len(buf) / 4
 	hasLoud := false
 	for i := 0; i < frames; i++ {
@@ -49,7 +49,7 @@ func TestGenerateFire_NotSilent(t *testing.T) {
 }
 
 func TestGenerateFire_StereoSymmetry(t *testing.T) {
-	buf := generateFire(sampleRate)
+	buf := generateFire(SampleRate)
 	frames := len(buf) / 4
 	for i := 0; i < frames; i++ {
 		l, r := readSample(buf, i)
@@ -60,7 +60,7 @@ func TestGenerateFire_StereoSymmetry(t *testing.T) {
 }
 
 func TestGenerateFire_EnvelopeDecay(t *testing.T) {
-	buf := generateFire(sampleRate)
+	buf := generateFire(SampleRate)
 	frames := len(buf) / 4
 	tenPct := frames / 10
 
@@ -83,9 +83,9 @@ func TestGenerateFire_EnvelopeDecay(t *testing.T) {
 }
 
 func TestGenerateExplosion_SizeOrdering(t *testing.T) {
-	small := generateExplosion(sampleRate, SizeSmall)
-	med := generateExplosion(sampleRate, SizeMedium)
-	large := generateExplosion(sampleRate, SizeLarge)
+	small := generateExplosion(SampleRate, SizeSmall)
+	med := generateExplosion(SampleRate, SizeMedium)
+	large := generateExplosion(SampleRate, SizeLarge)
 
 	if len(large) <= len(med) {
 		t.Errorf("large (%d) should be longer than med (%d)", len(large), len(med))
@@ -97,7 +97,7 @@ func TestGenerateExplosion_SizeOrdering(t *testing.T) {
 
 func TestGenerateExplosion_NotSilent(t *testing.T) {
 	for _, size := range []AsteroidSize{SizeLarge, SizeMedium, SizeSmall} {
-		buf := generateExplosion(sampleRate, size)
+		buf := generateExplosion(SampleRate, size)
 		frames := len(buf) / 4
 		hasLoud := false
 		for i := 0; i < frames; i++ {
@@ -114,7 +114,7 @@ func TestGenerateExplosion_NotSilent(t *testing.T) {
 }
 
 func TestGenerateExplosion_StereoSymmetry(t *testing.T) {
-	buf := generateExplosion(sampleRate, SizeLarge)
+	buf := generateExplosion(SampleRate, SizeLarge)
 	frames := len(buf) / 4
 	for i := 0; i < frames; i++ {
 		l, r := readSample(buf, i)
@@ -125,7 +125,7 @@ func TestGenerateExplosion_StereoSymmetry(t *testing.T) {
 }
 
 func TestGenerateExplosion_EnvelopeDecay(t *testing.T) {
-	buf := generateExplosion(sampleRate, SizeLarge)
+	buf := generateExplosion(SampleRate, SizeLarge)
 	frames := len(buf) / 4
 	tenPct := frames / 10
 
@@ -148,15 +148,15 @@ func TestGenerateExplosion_EnvelopeDecay(t *testing.T) {
 }
 
 func TestGenerateDeath_Length(t *testing.T) {
-	buf := generateDeath(sampleRate)
-	expectedFrames := int(float64(sampleRate) * 0.8)
+	buf := generateDeath(SampleRate)
+	expectedFrames := int(float64(SampleRate) * 0.8)
 	if len(buf) != expectedFrames*4 {
 		t.Errorf("expected %d bytes, got %d", expectedFrames*4, len(buf))
 	}
 }
 
 func TestGenerateDeath_NotSilent(t *testing.T) {
-	buf := generateDeath(sampleRate)
+	buf := generateDeath(SampleRate)
 	frames := len(buf) / 4
 	hasLoud := false
 	for i := 0; i < frames; i++ {
@@ -172,7 +172,7 @@ func TestGenerateDeath_NotSilent(t *testing.T) {
 }
 
 func TestGenerateDeath_StereoSymmetry(t *testing.T) {
-	buf := generateDeath(sampleRate)
+	buf := generateDeath(SampleRate)
 	frames := len(buf) / 4
 	for i := 0; i < frames; i++ {
 		l, r := readSample(buf, i)
@@ -183,15 +183,15 @@ func TestGenerateDeath_StereoSymmetry(t *testing.T) {
 }
 
 func TestGenerateThrustLoop_Length(t *testing.T) {
-	buf := generateThrustLoop(sampleRate)
-	expectedFrames := int(float64(sampleRate) * 0.2)
+	buf := generateThrustLoop(SampleRate)
+	expectedFrames := int(float64(SampleRate) * 0.2)
 	if len(buf) != expectedFrames*4 {
 		t.Errorf("expected %d bytes, got %d", expectedFrames*4, len(buf))
 	}
 }
 
 func TestGenerateThrustLoop_NotSilent(t *testing.T) {
-	buf := generateThrustLoop(sampleRate)
+	buf := generateThrustLoop(SampleRate)
 	frames := len(buf) / 4
 	hasLoud := false
 	for i := 0; i < frames; i++ {
@@ -207,15 +207,15 @@ func TestGenerateThrustLoop_NotSilent(t *testing.T) {
 }
 
 func TestGenerateBeatTone_Length(t *testing.T) {
-	buf := generateBeatTone(sampleRate, 55)
-	expectedFrames := int(float64(sampleRate) * 0.06)
+	buf := generateBeatTone(SampleRate, 55)
+	expectedFrames := int(float64(SampleRate) * 0.06)
 	if len(buf) != expectedFrames*4 {
 		t.Errorf("expected %d bytes, got %d", expectedFrames*4, len(buf))
 	}
 }
 
 func TestGenerateBeatTone_NotSilent(t *testing.T) {
-	buf := generateBeatTone(sampleRate, 55)
+	buf := generateBeatTone(SampleRate, 55)
 	frames := len(buf) / 4
 	hasLoud := false
 	for i := 0; i < frames; i++ {
@@ -231,7 +231,7 @@ func TestGenerateBeatTone_NotSilent(t *testing.T) {
 }
 
 func TestGenerateBeatTone_EnvelopeDecay(t *testing.T) {
-	buf := generateBeatTone(sampleRate, 55)
+	buf := generateBeatTone(SampleRate, 55)
 	frames := len(buf) / 4
 	tenPct := frames / 10
 
@@ -254,15 +254,15 @@ func TestGenerateBeatTone_EnvelopeDecay(t *testing.T) {
 }
 
 func TestGenerateBlip_Length(t *testing.T) {
-	buf := generateBlip(sampleRate)
-	expectedFrames := int(float64(sampleRate) * 0.03)
+	buf := generateBlip(SampleRate)
+	expectedFrames := int(float64(SampleRate) * 0.03)
 	if len(buf) != expectedFrames*4 {
 		t.Errorf("expected %d bytes, got %d", expectedFrames*4, len(buf))
 	}
 }
 
 func TestGenerateBlip_NotSilent(t *testing.T) {
-	buf := generateBlip(sampleRate)
+	buf := generateBlip(SampleRate)
 	frames := len(buf) / 4
 	hasLoud := false
 	for i := 0; i < frames; i++ {
@@ -278,15 +278,15 @@ func TestGenerateBlip_NotSilent(t *testing.T) {
 }
 
 func TestGenerateConfirm_Length(t *testing.T) {
-	buf := generateConfirm(sampleRate)
-	expectedFrames := int(float64(sampleRate) * 0.06)
+	buf := generateConfirm(SampleRate)
+	expectedFrames := int(float64(SampleRate) * 0.06)
 	if len(buf) != expectedFrames*4 {
 		t.Errorf("expected %d bytes, got %d", expectedFrames*4, len(buf))
 	}
 }
 
 func TestGenerateConfirm_NotSilent(t *testing.T) {
-	buf := generateConfirm(sampleRate)
+	buf := generateConfirm(SampleRate)
 	frames := len(buf) / 4
 	hasLoud := false
 	for i := 0; i < frames; i++ {
@@ -302,8 +302,8 @@ func TestGenerateConfirm_NotSilent(t *testing.T) {
 }
 
 func TestGenerateConfirm_LongerThanBlip(t *testing.T) {
-	blip := generateBlip(sampleRate)
-	confirm := generateConfirm(sampleRate)
+	blip := generateBlip(SampleRate)
+	confirm := generateConfirm(SampleRate)
 	if len(confirm) <= len(blip) {
 		t.Errorf("confirm (%d) should be longer than blip (%d)", len(confirm), len(blip))
 	}
@@ -0,0 +1,118 @@
+package game
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+	"strings"
+
+	"github.com/matheus3301/asteroids/internal/canvas"
+)
+
+// Wave placement patterns, selected by GameConfig.SpawnPattern.
+const (
+	// SpawnUniform places asteroids anywhere on screen.
+	SpawnUniform = "uniform"
+	// SpawnRing places asteroids on a ring just inside the screen edges.
+	SpawnRing = "ring"
+	// SpawnCorners groups asteroids into clusters near the four corners.
+	SpawnCorners = "corners"
+)
+
+const (
+	// spawnSafeRadius is how close to the ship a wave asteroid may appear.
+	spawnSafeRadius = 150.0
+	// maxSpawnAttempts bounds the search for a point outside the safe
+	// radius; after that the last candidate is used.
+	maxSpawnAttempts = 100
+	spawnRingInset   = 40.0
+	spawnCornerInset = 110.0
+	spawnCornerRange = 70.0
+)
+
+// spawnPatterns lists every valid GameConfig.SpawnPattern.
+var spawnPatterns = []string{SpawnUniform, SpawnRing, SpawnCorners}
+
+// waveRNG returns the random source that places the asteroids of a wave.
+// It depends only on the world seed and the level, so the practice overlay
+// can show where the next wave will appear without disturbing the world's
+// own random source.
+func waveRNG(w *World, level int) *rand.Rand {
+	return rand.New(rand.NewSource(int64(uint64(w.Seed) ^ uint64(level)*0x9e3779b97f4a7c15)))
+}
+
+// wavePositions returns where the count asteroids of a wave at level spawn,
+// keeping them outside the safe radius around player (which may be nil).
+func wavePositions(w *World, level, count int, player *Position) [][2]float64 {
+	rng := waveRNG(w, level)
+	points := make([][2]float64, count)
+	for i := range points {
+		var p [2]float64
+		for attempt := 0; attempt < maxSpawnAttempts; attempt++ {
+			// Retries move round the corners, so a ship parked in one
+			// pushes that cluster to the next.
+			p = spawnPoint(w.Config, rng, i+attempt)
+			if player == nil || math.Hypot(p[0]-player.X, p[1]-player.Y) > spawnSafeRadius {
+				break
+			}
+		}
+		points[i] = p
+	}
+	return points
+}
+
+// spawnPoint picks a candidate position for the i-th asteroid of a wave
+// under c's spawn pattern and playfield.
+func spawnPoint(c GameConfig, rng RNG, i int) [2]float64 {
+	fw, fh := c.FieldWidth, c.FieldHeight
+	switch c.SpawnPattern {
+	case SpawnRing:
+		a := rng.Float64() * 2 * math.Pi
+		return [2]float64{
+			fw/2 + math.Cos(a)*(fw/2-spawnRingInset),
+			fh/2 + math.Sin(a)*(fh/2-spawnRingInset),
+		}
+	case SpawnCorners:
+		cx, cy := spawnCornerInset, spawnCornerInset
+		if i%2 == 1 {
+			cx = fw - spawnCornerInset
+		}
+		if (i/2)%2 == 1 {
+			cy = fh - spawnCornerInset
+		}
+		return [2]float64{
+			cx + (rng.Float64()*2-1)*spawnCornerRange,
+			cy + (rng.Float64()*2-1)*spawnCornerRange,
+		}
+	}
+	return [2]float64{rng.Float64() * fw, rng.Float64() * fh}
+}
+
+// plannedWave returns where the next wave would spawn if the current one
+// were cleared now. It assumes the standard wave size, since asking a mod's
+// hooks could change its state.
+func plannedWave(w *World) [][2]float64 {
+	level := w.Level + 1
+	return wavePositions(w, level, 3+level, w.positions[w.Player])
+}
+
+// drawPracticeOverlay shows the safe radius around the ship and the planned
+// spawn points of the next wave.
+func drawPracticeOverlay(w *World, screen canvas.Canvas) {
+	clr := color.RGBA{100, 100, 100, 255}
+	if pos := w.positions[w.Player]; pos != nil {
+		const segments = 48
+		for i := 0; i < segments; i++ {
+			a1 := float64(i) / segments * 2 * math.Pi
+			a2 := float64(i+1) / segments * 2 * math.Pi
+			strokeLine(screen,
+				pos.X+math.Cos(a1)*spawnSafeRadius, pos.Y+math.Sin(a1)*spawnSafeRadius,
+				pos.X+math.Cos(a2)*spawnSafeRadius, pos.Y+math.Sin(a2)*spawnSafeRadius, clr)
+		}
+	}
+	for _, p := range plannedWave(w) {
+		strokeLine(screen, p[0]-5, p[1]-5, p[0]+5, p[1]+5, clr)
+		strokeLine(screen, p[0]-5, p[1]+5, p[0]+5, p[1]-5, clr)
+	}
+	DrawText(screen, "NEXT WAVE: "+strings.ToUpper(w.Config.SpawnPattern), 10, w.Config.FieldHeight-20, 1.5, clr)
+}
@@ -0,0 +1,99 @@
+package game
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestWavePositions_OutsideSafeRadius(t *testing.T) {
+	for _, pattern := range spawnPatterns {
+		w := NewWorldWithSeed(7)
+		w.Config.SpawnPattern = pattern
+		player := &Position{X: spawnCornerInset, Y: spawnCornerInset}
+
+		for _, p := range wavePositions(w, 3, 12, player) {
+			if d := math.Hypot(p[0]-player.X, p[1]-player.Y); d <= spawnSafeRadius {
+				t.Errorf("%s: asteroid at %v is only %.0f from the ship", pattern, p, d)
+			}
+		}
+	}
+}
+
+func TestWavePositions_Patterns(t *testing.T) {
+	w := NewWorldWithSeed(7)
+
+	w.Config.SpawnPattern = SpawnRing
+	for _, p := range wavePositions(w, 1, 8, nil) {
+		nx := (p[0] - ScreenWidth/2) / (ScreenWidth/2 - spawnRingInset)
+		ny := (p[1] - ScreenHeight/2) / (ScreenHeight/2 - spawnRingInset)
+		if r := math.Hypot(nx, ny); math.Abs(r-1) > 1e-9 {
+			t.Errorf("ring: %v is off the ring", p)
+		}
+	}
+
+	w.Config.SpawnPattern = SpawnCorners
+	for _, p := range wavePositions(w, 1, 8, nil) {
+		dx := min(p[0], ScreenWidth-p[0])
+		dy := min(p[1], ScreenHeight-p[1])
+		if dx > spawnCornerInset+spawnCornerRange || dy > spawnCornerInset+spawnCornerRange {
+			t.Errorf("corners: %v is not near a corner", p)
+		}
+	}
+}
+
+func TestPlannedWave_MatchesNextWave(t *testing.T) {
+	w := NewGameWorld(11)
+	want := plannedWave(w)
+
+	for _, e := range sortedEntities(w.asteroids) {
+		w.Destroy(e)
+	}
+	WaveClearSystem(w)
+
+	got := 0
+	for _, e := range sortedEntities(w.asteroids) {
+		pos := w.positions[e]
+		for _, p := range want {
+			if pos.X == p[0] && pos.Y == p[1] {
+				got++
+				break
+			}
+		}
+	}
+	if got != len(want) {
+		t.Errorf("%d of %d asteroids spawned at the planned points", got, len(want))
+	}
+}
+
+func TestConfigValidate_SpawnPattern(t *testing.T) {
+	c := DefaultConfig()
+	c.SpawnPattern = "spiral"
+	if c.Validate() == nil {
+		t.Error("an unknown spawn pattern should be rejected")
+	}
+}
+
+func TestNewWaveWorld_MatchesGame(t *testing.T) {
+	game := NewGameWorld(5)
+	wave := NewWaveWorld(5, 1, DefaultConfig())
+	if got, want := asteroidPositions(wave), asteroidPositions(game); !slices.Equal(got, want) {
+		t.Errorf("wave 1 asteroids at %v, want %v", got, want)
+	}
+
+	w := NewWaveWorld(5, 4, DefaultConfig())
+	want := wavePositions(w, 4, waveSize(w), w.positions[w.Player])
+	if got := asteroidPositions(w); !slices.Equal(got, want) {
+		t.Errorf("wave 4 asteroids at %v, want %v", got, want)
+	}
+}
+
+// asteroidPositions lists where w's asteroids are, in spawn order.
+func asteroidPositions(w *World) [][2]float64 {
+	var ps [][2]float64
+	for _, e := range sortedEntities(w.asteroids) {
+		p := w.positions[e]
+		ps = append(ps, [2]float64{p.X, p.Y})
+	}
+	return ps
+}
@@ -0,0 +1,303 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/matheus3301/asteroids/internal/canvas"
+	"github.com/matheus3301/asteroids/internal/leaderboard"
+	"github.com/matheus3301/asteroids/internal/telemetry"
+)
+
+// DeathCause says what destroyed a player ship.
+type DeathCause int
+
+const (
+	DeathAsteroid DeathCause = iota
+	DeathSaucer
+	DeathSaucerBullet
+	DeathHyperspace
+	NumDeathCauses
+)
+
+var deathCauseNames = [NumDeathCauses]string{"asteroid", "saucer", "saucer bullet", "hyperspace"}
+
+func (c DeathCause) String() string {
+	if c < 0 || c >= NumDeathCauses {
+		return fmt.Sprintf("DeathCause(%d)", int(c))
+	}
+	return deathCauseNames[c]
+}
+
+// GameStats counts balance-relevant events over one game.
+type GameStats struct {
+	Deaths             [NumDeathCauses]int
+	HyperspaceJumps    int
+	ShotsFired         int
+	AsteroidsDestroyed int
+	SaucersDestroyed   int
+	// BulletsShotDown counts saucer bullets destroyed by player bullets.
+	BulletsShotDown int
+	// ShotsHit and ShotsMissed count player bullets and missiles that hit
+	// something and that ran out of life first.
+	ShotsHit, ShotsMissed int
+	// PowerUps counts pickups collected, such as bonus stars.
+	PowerUps int
+	// DeathSpots are where ships were lost, in order.
+	DeathSpots []DeathSpot
+}
+
+// Accuracy is the fraction of the game's shots that hit something, out of
+// those that have hit or missed.
+func (s *GameStats) Accuracy() float64 {
+	return accuracy(s.ShotsHit, s.ShotsMissed)
+}
+
+// accuracy is hits out of the shots that have hit or missed, or 0 before
+// any have.
+func accuracy(hits, misses int) float64 {
+	if hits+misses == 0 {
+		return 0
+	}
+	return float64(hits) / float64(hits+misses)
+}
+
+// DeathSpot is where a ship was lost, in world pixels, and to what.
+type DeathSpot struct {
+	X, Y  float64
+	Cause DeathCause
+}
+
+// telemetryGame converts a finished world into a telemetry record.
+func telemetryGame(w *World) telemetry.Game {
+	deaths := make(map[string]int, NumDeathCauses)
+	for c, n := range w.Stats.Deaths {
+		if n > 0 {
+			deaths[DeathCause(c).String()] = n
+		}
+	}
+	spots := make([]telemetry.DeathSpot, len(w.Stats.DeathSpots))
+	for i, d := range w.Stats.DeathSpots {
+		spots[i] = telemetry.DeathSpot{X: d.X / w.Config.FieldWidth, Y: d.Y / w.Config.FieldHeight, Cause: d.Cause.String()}
+	}
+	return telemetry.Game{
+		Score:              w.Score,
+		Level:              w.Level,
+		Ticks:              w.Tick,
+		Deaths:             deaths,
+		HyperspaceJumps:    w.Stats.HyperspaceJumps,
+		ShotsFired:         w.Stats.ShotsFired,
+		ShotsHit:           w.Stats.ShotsHit,
+		ShotsMissed:        w.Stats.ShotsMissed,
+		AsteroidsDestroyed: w.Stats.AsteroidsDestroyed,
+		SaucersDestroyed:   w.Stats.SaucersDestroyed,
+		PowerUps:           w.Stats.PowerUps,
+		DeathSpots:         spots,
+	}
+}
+
+// loadTelemetry reads the saved summary. Problems only cost the stats, so
+// they are logged and an empty summary is used.
+func loadTelemetry() *telemetry.Summary {
+	path, err := telemetry.Path()
+	if err == nil {
+		var s *telemetry.Summary
+		if s, err = telemetry.Load(path); err == nil {
+			return s
+		}
+	}
+	logger.Warn("telemetry not loaded", "err", err)
+	return &telemetry.Summary{}
+}
+
+func (g *Game) saveTelemetry() {
+	path, err := telemetry.Path()
+	if err == nil {
+		err = g.telemetry.Save(path)
+	}
+	if err != nil {
+		logger.Warn("telemetry not saved", "err", err)
+	}
+}
+
+// recordTelemetry adds the finished game to the summary when the player has
+// opted in.
+func (g *Game) recordTelemetry() {
+	if !g.telemetry.Enabled || g.world == nil {
+		return
+	}
+	g.telemetry.Add(telemetryGame(g.world))
+	g.saveTelemetry()
+}
+
+// loadLeaderboards reads the saved leaderboards. Problems only cost the
+// boards, so they are logged and empty ones are used.
+func loadLeaderboards() *leaderboard.Boards {
+	path, err := leaderboard.Path()
+	if err == nil {
+		var b *leaderboard.Boards
+		if b, err = leaderboard.Load(path); err == nil {
+			return b
+		}
+	}
+	logger.Warn("leaderboards not loaded", "err", err)
+	return &leaderboard.Boards{}
+}
+
+// recordLeaderboards enters the finished game on the leaderboards. Like
+// the career totals, they only take standard single-player runs: classic
+// mode on normal difficulty, with no mutators, mods or aim assist.
+func (g *Game) recordLeaderboards() {
+	w := g.world
+	if !g.countsForCareer || w == nil || runKey(g.runMode, g.runDifficulty, g.runMutators) != "" {
+		return
+	}
+	g.leaderboards.Add(leaderboard.Game{
+		Score:       w.Score,
+		Level:       w.Level,
+		Ticks:       w.Tick,
+		ShotsHit:    w.Stats.ShotsHit,
+		ShotsMissed: w.Stats.ShotsMissed,
+	})
+	path, err := leaderboard.Path()
+	if err == nil {
+		err = g.leaderboards.Save(path)
+	}
+	if err != nil {
+		logger.Warn("leaderboards not saved", "err", err)
+	}
+}
+
+// setTelemetry records the player's opt-in choice.
+func (g *Game) setTelemetry(on bool) {
+	g.telemetry.Enabled = on
+	g.saveTelemetry()
+}
+
+// --- Stats screen ---
+
+// The stats screen's pages.
+const (
+	statsSummary = iota
+	statsLeaderboards
+	statsDeathMap
+	statsPages
+)
+
+// statsHint is the key hint at the bottom of every stats page.
+const statsHint = "LEFT/RIGHT TO CHANGE PAGE . ESC TO GO BACK"
+
+func (g *Game) updateStats() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) || inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+		g.sound.PlayBlip()
+		g.state = stateMenu
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyLeft) {
+		g.statsPage = (g.statsPage + statsPages - 1) % statsPages
+		g.sound.PlayBlip()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyRight) {
+		g.statsPage = (g.statsPage + 1) % statsPages
+		g.sound.PlayBlip()
+	}
+	if g.statsPage == statsDeathMap {
+		g.updateDeathMap()
+	}
+}
+
+func (g *Game) drawStats(screen canvas.Canvas) {
+	white := color.RGBA{255, 255, 255, 255}
+	grey := color.RGBA{100, 100, 100, 255}
+	drawCentered(screen, "STATS", 60, 4, white)
+
+	// The leaderboards are kept with stats logging off too.
+	if g.statsPage == statsLeaderboards {
+		g.drawLeaderboards(screen)
+		return
+	}
+	s := g.telemetry
+	if s.Games == 0 {
+		msg := "NO GAMES RECORDED YET"
+		if !s.Enabled {
+			msg = "STATS LOGGING IS OFF - TURN IT ON IN SETTINGS"
+		}
+		drawCentered(screen, msg, 280, 2, white)
+		drawCentered(screen, "ESC TO GO BACK", 540, 1.5, grey)
+		return
+	}
+	if g.statsPage == statsDeathMap {
+		g.drawDeathMap(screen)
+		return
+	}
+
+	lines := []string{
+		fmt.Sprintf("GAMES PLAYED    %d", s.Games),
+		fmt.Sprintf("AVERAGE WAVE    %.1f  (BEST %d)", s.PerGame(int64(s.TotalWaves)), s.BestWave),
+		fmt.Sprintf("AVERAGE SCORE   %.0f  (BEST %d)", s.PerGame(s.TotalScore), s.BestScore),
+		fmt.Sprintf("AVERAGE TIME    %s", formatTicks(int(s.PerGame(s.Ticks)))),
+		fmt.Sprintf("HIT RATE        %.0f%%", s.HitRate()*100),
+		fmt.Sprintf("HYPERSPACE/GAME %.1f", s.PerGame(int64(s.HyperspaceJumps))),
+		fmt.Sprintf("POWER-UPS       %d", s.PowerUps),
+	}
+	for i, l := range lines {
+		DrawText(screen, l, 180, 130+float64(i)*26, 2, white)
+	}
+
+	// Deaths per cause as a bar chart.
+	y := 130 + float64(len(lines))*26 + 20
+	DrawText(screen, "DEATHS", 180, y, 2, white)
+	most := 1
+	for c := DeathCause(0); c < NumDeathCauses; c++ {
+		most = max(most, s.Deaths[c.String()])
+	}
+	for c := DeathCause(0); c < NumDeathCauses; c++ {
+		y += 24
+		n := s.Deaths[c.String()]
+		DrawText(screen, strings.ToUpper(c.String()), 200, y, 1.5, white)
+		barW := float64(n) / float64(most) * 220
+		screen.FillRect(400, y, barW, 10, color.RGBA{0, 255, 0, 255})
+		DrawText(screen, fmt.Sprintf("%d", n), 410+barW, y, 1.5, white)
+	}
+
+	drawCentered(screen, statsHint, 560, 1.5, grey)
+}
+
+// boardTable is one table on the leaderboards page.
+type boardTable struct {
+	title   string
+	entries []leaderboard.Entry
+	format  func(v float64) string
+}
+
+// drawLeaderboards is the stats page of the best standard games by time
+// survived, wave reached and accuracy.
+func (g *Game) drawLeaderboards(screen canvas.Canvas) {
+	white := color.RGBA{255, 255, 255, 255}
+	grey := color.RGBA{100, 100, 100, 255}
+	green := color.RGBA{0, 255, 0, 255}
+	b := g.leaderboards
+	boards := []boardTable{
+		{"LONGEST SURVIVAL", b.Survival, func(v float64) string { return formatTicks(int(v)) }},
+		{"HIGHEST WAVE", b.Wave, func(v float64) string { return fmt.Sprintf("WAVE %d", int(v)) }},
+		{fmt.Sprintf("BEST ACCURACY - %d+ SHOTS", leaderboard.MinAccuracyShots), b.Accuracy,
+			func(v float64) string { return fmt.Sprintf("%.0f%%", v*100) }},
+	}
+	y := 110.0
+	for _, board := range boards {
+		DrawText(screen, board.title, 200, y, 2, green)
+		y += 26
+		if len(board.entries) == 0 {
+			DrawText(screen, "NO GAMES YET", 220, y, 1.5, grey)
+			y += 20
+		}
+		for i, e := range board.entries {
+			DrawText(screen, fmt.Sprintf("%d. %-10s SCORE %d", i+1, board.format(e.Value), e.Score), 220, y, 1.5, white)
+			y += 20
+		}
+		y += 16
+	}
+	drawCentered(screen, statsHint, 560, 1.5, grey)
+}
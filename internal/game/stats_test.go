@@ -0,0 +1,190 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/matheus3301/asteroids/internal/canvas"
+	"github.com/matheus3301/asteroids/internal/leaderboard"
+	"github.com/matheus3301/asteroids/internal/storage"
+	"github.com/matheus3301/asteroids/internal/telemetry"
+)
+
+func TestCollisionResponse_RecordsDeathCause(t *testing.T) {
+	w := NewWorld()
+	w.Lives = 3
+	w.Player = SpawnPlayer(w, 100, 100)
+
+	CollisionResponseSystem(w, CollisionEvent{PlayerHit: true, PlayerEntity: w.Player, PlayerHitBy: DeathSaucerBullet})
+
+	if w.Stats.Deaths[DeathSaucerBullet] != 1 {
+		t.Errorf("deaths = %v, want one saucer bullet death", w.Stats.Deaths)
+	}
+}
+
+func TestCollisionSystem_ReportsSaucerBullet(t *testing.T) {
+	w := NewWorld()
+	w.Player = SpawnPlayer(w, 100, 100)
+	w.players[w.Player].Invulnerable = false
+	SpawnSaucerBullet(w, SpawnSaucer(w, SaucerLarge), 100, 100)
+	for e := range w.saucerBullets {
+		w.positions[e].X, w.positions[e].Y = 100, 100
+	}
+	for e := range w.saucers {
+		w.positions[e].X, w.positions[e].Y = 400, 400
+	}
+
+	ev := CollisionSystem(w)
+	if !ev.PlayerHit || ev.PlayerHitBy != DeathSaucerBullet {
+		t.Errorf("expected a saucer bullet hit, got %+v", ev)
+	}
+}
+
+func TestStats_CountShotsAndJumps(t *testing.T) {
+	w := NewGameWorld(1)
+	Step(w, InputState{Shoot: true, Hyperspace: true})
+
+	if w.Stats.ShotsFired != 1 || w.Stats.HyperspaceJumps != 1 {
+		t.Errorf("stats = %+v, want one shot and one jump", w.Stats)
+	}
+}
+
+func TestTelemetry_OnlyRecordedWhenEnabled(t *testing.T) {
+	restore := storage.Override(storage.At(t.TempDir()))
+	defer restore()
+
+	g := New()
+	g.reset()
+	g.recordTelemetry()
+	if g.telemetry.Games != 0 {
+		t.Fatal("games should not be recorded before opting in")
+	}
+
+	g.settingsCursor = 3
+	g.settingsSelect()
+	if !g.telemetry.Enabled {
+		t.Fatal("the settings toggle should opt in")
+	}
+	g.world.Stats.Deaths[DeathHyperspace] = 2
+	g.recordTelemetry()
+
+	path, _ := telemetry.Path()
+	saved, err := telemetry.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !saved.Enabled || saved.Games != 1 || saved.Deaths["hyperspace"] != 2 {
+		t.Errorf("saved summary = %+v", saved)
+	}
+	if !New().telemetry.Enabled {
+		t.Error("the opt-in should be remembered")
+	}
+}
+
+func TestNewWithOptions_TelemetryOptIn(t *testing.T) {
+	restore := storage.Override(storage.At(t.TempDir()))
+	defer restore()
+
+	if New().telemetry.Enabled {
+		t.Fatal("telemetry must be off by default")
+	}
+	if !NewWithOptions(Options{Telemetry: true}).telemetry.Enabled {
+		t.Error("the Telemetry option should opt in")
+	}
+}
+
+func TestMenuSelect_Stats(t *testing.T) {
+	g := New()
+	g.menuCursor = 4
+	g.menuSelect()
+	if g.state != stateStats {
+		t.Errorf("expected stateStats, got %v", g.state)
+	}
+}
+
+func TestRecordLeaderboards_StandardRunsOnly(t *testing.T) {
+	restore := storage.Override(storage.At(t.TempDir()))
+	defer restore()
+
+	g := New()
+	if g.telemetry.Enabled {
+		t.Fatal("telemetry should be off by default")
+	}
+	g.reset()
+	g.world.Tick, g.world.Level, g.world.Score = 5400, 7, 12000
+	g.world.Stats.ShotsFired, g.world.Stats.AsteroidsDestroyed = 80, 60
+	g.world.Stats.ShotsHit, g.world.Stats.ShotsMissed = 45, 15
+	g.recordLeaderboards()
+
+	// Neither a run off normal difficulty nor an assisted one is ranked.
+	g.difficulty = DifficultyEasy
+	g.reset()
+	g.world.Tick = 9000
+	g.recordLeaderboards()
+	g.difficulty = DifficultyNormal
+	g.reset()
+	g.countsForCareer = false
+	g.world.Tick = 9000
+	g.recordLeaderboards()
+
+	path, _ := leaderboard.Path()
+	b, err := leaderboard.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b.Survival) != 1 || b.Survival[0].Value != 5400 || b.Survival[0].Score != 12000 {
+		t.Errorf("survival board = %+v", b.Survival)
+	}
+	if len(b.Wave) != 1 || b.Wave[0].Value != 7 {
+		t.Errorf("wave board = %+v", b.Wave)
+	}
+	if len(b.Accuracy) != 1 || b.Accuracy[0].Value != 0.75 {
+		t.Errorf("accuracy board = %+v", b.Accuracy)
+	}
+	if g.telemetry.Games != 0 {
+		t.Errorf("the leaderboards should not turn telemetry on, %d games recorded", g.telemetry.Games)
+	}
+
+	var off, rec canvas.Recording
+	g.drawStats(&off)
+	g.statsPage = statsLeaderboards
+	g.drawStats(&rec)
+	if rec.Count("fillrect") != 0 {
+		t.Error("the leaderboards page should not draw the deaths chart")
+	}
+	if len(rec.Ops) <= len(off.Ops) {
+		t.Error("the leaderboards page should be drawn with stats logging off")
+	}
+}
+
+func TestDeathMap_RecordsWhereShipsAreLost(t *testing.T) {
+	restore := storage.Override(storage.At(t.TempDir()))
+	defer restore()
+
+	g := NewWithOptions(Options{Telemetry: true})
+	g.reset()
+	w := g.world
+	w.positions[w.Player].X, w.positions[w.Player].Y = 200, 150
+	killPlayer(w, w.Player, DeathSaucer)
+	if len(w.Stats.DeathSpots) != 1 || w.Stats.DeathSpots[0] != (DeathSpot{200, 150, DeathSaucer}) {
+		t.Fatalf("death spots = %+v", w.Stats.DeathSpots)
+	}
+	g.recordTelemetry()
+
+	cell := (telemetry.DeathMapRows/4)*telemetry.DeathMapCols + telemetry.DeathMapCols/4
+	g.statsPage = statsDeathMap
+	g.deathMapCause = DeathSaucer
+	if n := g.deathMapCells()[cell]; n != 1 {
+		t.Errorf("expected the saucer death a quarter of the way in, got %d there", n)
+	}
+	g.deathMapCause = DeathAsteroid
+	if n := g.deathMapCells()[cell]; n != 0 {
+		t.Errorf("expected no asteroid deaths there, got %d", n)
+	}
+
+	g.deathMapCause = NumDeathCauses
+	var rec canvas.Recording
+	g.drawStats(&rec)
+	if rec.Count("fillrect") != 2 {
+		t.Errorf("expected the field and one death cell, drew %d filled rects", rec.Count("fillrect"))
+	}
+}
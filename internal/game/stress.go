@@ -0,0 +1,139 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/matheus3301/asteroids/internal/canvas"
+)
+
+const (
+	// stressParticleShare is how many particles are spawned each tick per
+	// asteroid in the scene, so the particle load scales with it.
+	stressParticleShare = 0.1
+	// stressReportEvery is how many ticks the timing breakdown averages
+	// over before it is refreshed.
+	stressReportEvery = 60
+	// stressTopStages is how many pipeline stages the breakdown lists.
+	stressTopStages = 8
+)
+
+// stressScene is a profiling scene: a world kept full of asteroids and
+// particles, flown by the scripted autopilot, with a timing breakdown.
+type stressScene struct {
+	world     *World
+	asteroids int
+	timings   *SystemTimings
+	drawTime  time.Duration
+	frames    int
+	// report is the last breakdown shown on screen.
+	report []string
+}
+
+// newStressWorld creates a world for the stress scene with the entity caps
+// lifted and n asteroids in play.
+func newStressWorld(seed int64, n int) *World {
+	w := NewWorldWithSeed(seed)
+	w.Config.MaxAsteroids = 0
+	w.Config.MaxParticles = 0
+	w.Config.HyperspaceRisk = 0
+	w.Lives = 1
+	w.Level = 1
+	w.Saucer.SpawnTimer = w.Config.SaucerInitialDelay
+	w.Player = SpawnPlayer(w, ScreenWidth/2, ScreenHeight/2)
+	stressTopUp(w, n)
+	return w
+}
+
+// stressTopUp refills w to n asteroids, keeps the ship invulnerable and adds
+// this tick's particles.
+func stressTopUp(w *World, n int) {
+	for w.AsteroidCount() < n {
+		SpawnAsteroid(w, w.rng.Float64()*ScreenWidth, w.rng.Float64()*ScreenHeight, SizeLarge)
+	}
+	for i := 0; i < int(float64(n)*stressParticleShare); i++ {
+		SpawnParticle(w, w.rng.Float64()*ScreenWidth, w.rng.Float64()*ScreenHeight)
+	}
+	for _, pc := range w.players {
+		pc.Invulnerable = true
+		pc.InvulnerableTimer = 120
+	}
+}
+
+// openStress starts the stress scene with n asteroids.
+func (g *Game) openStress(n int) {
+	seed := g.newSeed()
+	g.stress = &stressScene{
+		world:     newStressWorld(seed, n),
+		asteroids: n,
+		timings:   NewSystemTimings(),
+	}
+	g.state = stateStress
+}
+
+func (g *Game) updateStress() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.stress = nil
+		g.state = stateMenu
+		return
+	}
+	s := g.stress
+	w := s.world
+	stressTopUp(w, s.asteroids)
+	StepTimed(w, ScriptedInput(w.Tick), s.timings)
+	w.SoundQueue = w.SoundQueue[:0]
+	if s.timings.Ticks >= stressReportEvery {
+		s.report = s.breakdown()
+		s.timings = NewSystemTimings()
+		s.drawTime, s.frames = 0, 0
+	}
+}
+
+// breakdown summarises the timings gathered since the last report: the
+// average tick and draw, then the most expensive stages.
+func (s *stressScene) breakdown() []string {
+	t := s.timings
+	perTick := func(d time.Duration) string {
+		return fmt.Sprintf("%.3fMS", float64(d)/float64(t.Ticks)/float64(time.Millisecond))
+	}
+	var total time.Duration
+	order := make([]int, len(t.Total))
+	for i, d := range t.Total {
+		total += d
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return t.Total[order[a]] > t.Total[order[b]] })
+
+	draw := "-"
+	if s.frames > 0 {
+		draw = fmt.Sprintf("%.3fMS", float64(s.drawTime)/float64(s.frames)/float64(time.Millisecond))
+	}
+	lines := []string{"TICK " + perTick(total) + "   DRAW " + draw}
+	for _, i := range order[:min(stressTopStages, len(order))] {
+		lines = append(lines, fmt.Sprintf("%-20s %s", strings.ToUpper(t.Names[i]), perTick(t.Total[i])))
+	}
+	return lines
+}
+
+func (g *Game) drawStress(screen canvas.Canvas) {
+	s := g.stress
+	w := s.world
+	screen.Fill(w.Palette.Background)
+	start := g.clock.Now()
+	DrawWorld(w, screen)
+	s.drawTime += since(g.clock, start)
+	s.frames++
+
+	yellow := color.RGBA{255, 255, 0, 255}
+	DrawText(screen, fmt.Sprintf("STRESS   ENTITIES %d   ASTEROIDS %d   PARTICLES %d",
+		len(w.entities), len(w.asteroids), len(w.particles)), 10, 10, 1.5, yellow)
+	for i, l := range s.report {
+		DrawText(screen, l, 10, 34+float64(i)*16, 1.5, yellow)
+	}
+	DrawText(screen, "ESC TO LEAVE", 10, ScreenHeight-20, 1.5, yellow)
+}
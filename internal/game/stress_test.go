@@ -0,0 +1,39 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewStressWorld_LiftsCaps(t *testing.T) {
+	w := newStressWorld(1, 300)
+
+	if w.AsteroidCount() != 300 {
+		t.Errorf("expected 300 asteroids, got %d", w.AsteroidCount())
+	}
+	if w.Config.MaxAsteroids != 0 || w.Config.MaxParticles != 0 {
+		t.Errorf("the stress scene should lift the caps, got %d and %d", w.Config.MaxAsteroids, w.Config.MaxParticles)
+	}
+}
+
+func TestStress_ReportsBreakdown(t *testing.T) {
+	g := NewWithOptions(Options{Seed: 1, Stress: 50})
+	if g.state != stateStress {
+		t.Fatalf("expected stateStress, got %v", g.state)
+	}
+
+	for i := 0; i < stressReportEvery; i++ {
+		g.updateStress()
+	}
+
+	w := g.stress.world
+	if w.AsteroidCount() < 50 {
+		t.Errorf("asteroids should be topped up to 50, got %d", w.AsteroidCount())
+	}
+	if w.GameOver() {
+		t.Error("the ship should survive the stress scene")
+	}
+	if len(g.stress.report) != 1+stressTopStages || !strings.HasPrefix(g.stress.report[0], "TICK ") {
+		t.Errorf("unexpected report: %q", g.stress.report)
+	}
+}
@@ -2,51 +2,70 @@ package game
 
 import (
 	"math"
-	"math/rand"
+	"slices"
 
-	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/matheus3301/asteroids/internal/geom"
 )
 
+// maxFieldSize is the largest playfield side Config allows.
+const maxFieldSize = 4000
+
+// Field is the playfield as a wrapping space, for distances across edges.
+func (w *World) Field() geom.Torus {
+	return geom.Torus{W: w.Config.FieldWidth, H: w.Config.FieldHeight}
+}
+
 const (
 	rotationSpeed = 0.05
 	thrustPower   = 0.12
 	maxSpeed      = 5.0
 	friction      = 0.99
 	particleDrag  = 0.96
+	// hyperspaceRisk is the standard chance a jump destroys the ship.
+	hyperspaceRisk = 1.0 / 16.0
+	// hyperspaceCooldown is the ticks between hyperspace jumps.
+	hyperspaceCooldown = 30
+	// maxLives caps extra lives; thresholds past it pay lifeBonusPoints.
+	maxLives        = 6
+	lifeBonusPoints = 2000
+	// lifeBonusFlash is how long the HUD shows a life paid out as points.
+	lifeBonusFlash = 90
 )
 
-// InputSystem reads keyboard input and updates player entities.
-func InputSystem(w *World) {
+// InputSystem applies one tick of input to each player entity, using the
+// entry of in that matches the ship's slot.
+func InputSystem(w *World, inputs Inputs) {
 	for e, pc := range w.players {
+		in := inputs[pc.Slot]
 		rot := w.rotations[e]
 		vel := w.velocities[e]
 
-		if ebiten.IsKeyPressed(ebiten.KeyLeft) || ebiten.IsKeyPressed(ebiten.KeyA) {
-			rot.Angle -= rotationSpeed
+		if in.RotateLeft {
+			rot.Angle -= w.Config.RotationSpeed
 		}
-		if ebiten.IsKeyPressed(ebiten.KeyRight) || ebiten.IsKeyPressed(ebiten.KeyD) {
-			rot.Angle += rotationSpeed
+		if in.RotateRight {
+			rot.Angle += w.Config.RotationSpeed
 		}
 
-		pc.Thrusting = ebiten.IsKeyPressed(ebiten.KeyUp) || ebiten.IsKeyPressed(ebiten.KeyW)
+		pc.Thrusting = in.Thrust
 		if pc.Thrusting {
-			vel.X += math.Cos(rot.Angle) * thrustPower
-			vel.Y += math.Sin(rot.Angle) * thrustPower
+			vel.X += math.Cos(rot.Angle) * w.Config.ThrustPower
+			vel.Y += math.Sin(rot.Angle) * w.Config.ThrustPower
 			speed := math.Sqrt(vel.X*vel.X + vel.Y*vel.Y)
-			if speed > maxSpeed {
-				vel.X = vel.X / speed * maxSpeed
-				vel.Y = vel.Y / speed * maxSpeed
+			if speed > w.Config.MaxSpeed {
+				vel.X = vel.X / speed * w.Config.MaxSpeed
+				vel.Y = vel.Y / speed * w.Config.MaxSpeed
 			}
 		}
 
 		// Friction on player
-		vel.X *= friction
-		vel.Y *= friction
+		vel.X *= w.Config.Friction
+		vel.Y *= w.Config.Friction
 
-		pc.ShootPressed = inpututil.IsKeyJustPressed(ebiten.KeySpace)
-		pc.HyperspacePressed = inpututil.IsKeyJustPressed(ebiten.KeyShiftLeft) ||
-			inpututil.IsKeyJustPressed(ebiten.KeyShiftRight)
+		pc.ShootPressed = in.Shoot
+		pc.HyperspacePressed = in.Hyperspace
+		pc.MissilePressed = in.Missile
+		pc.ShieldPressed = in.Shield
 	}
 }
 
@@ -63,22 +82,23 @@ func PhysicsSystem(w *World) {
 	}
 }
 
-// WrapSystem wraps entities around the screen edges.
+// WrapSystem wraps entities around the playfield edges.
 func WrapSystem(w *World) {
+	fw, fh := w.Config.FieldWidth, w.Config.FieldHeight
 	for e := range w.wrappers {
 		pos := w.positions[e]
 		if pos == nil {
 			continue
 		}
 		if pos.X < 0 {
-			pos.X += ScreenWidth
-		} else if pos.X > ScreenWidth {
-			pos.X -= ScreenWidth
+			pos.X += fw
+		} else if pos.X > fw {
+			pos.X -= fw
 		}
 		if pos.Y < 0 {
-			pos.Y += ScreenHeight
-		} else if pos.Y > ScreenHeight {
-			pos.Y -= ScreenHeight
+			pos.Y += fh
+		} else if pos.Y > fh {
+			pos.Y -= fh
 		}
 	}
 }
@@ -88,6 +108,7 @@ func LifetimeSystem(w *World) {
 	for e, b := range w.bullets {
 		b.Life--
 		if b.Life <= 0 {
+			missed(w, b.Owner)
 			w.Destroy(e)
 		}
 	}
@@ -117,15 +138,11 @@ func InvulnerabilitySystem(w *World) {
 	}
 }
 
-// SaucerAISystem updates saucer behavior: shooting, vertical movement, despawn.
+// SaucerAISystem updates saucer behavior: shooting and vertical movement.
+// SaucerLifecycleSystem removes saucers that leave the screen.
 func SaucerAISystem(w *World) {
-	// Find player position
-	var playerPos *Position
-	for pe := range w.players {
-		playerPos = w.positions[pe]
-		break
-	}
-	for e, st := range w.saucers {
+	for _, e := range sortedEntities(w.saucers) {
+		st := w.saucers[e]
 		pos := w.positions[e]
 		vel := w.velocities[e]
 		if pos == nil || vel == nil {
@@ -135,41 +152,69 @@ func SaucerAISystem(w *World) {
 		// Shoot cooldown
 		st.ShootCooldown--
 		if st.ShootCooldown <= 0 {
-			px, py := 0.0, 0.0
-			if playerPos != nil {
-				px, py = playerPos.X, playerPos.Y
-			}
+			px, py := saucerAim(w, pos, saucerProfile(w, st.Size).BulletSpeed)
 			SpawnSaucerBullet(w, e, px, py)
-			st.ShootCooldown = saucerShootCooldownMin + rand.Intn(saucerShootCooldownMax-saucerShootCooldownMin)
+			st.ShootCooldown = saucerCooldown(w, saucerProfile(w, st.Size))
 		}
 
 		// Vertical direction changes
 		st.VerticalTimer--
 		if st.VerticalTimer <= 0 {
-			choices := []float64{-saucerVerticalSpeed, 0, saucerVerticalSpeed}
-			vel.Y = choices[rand.Intn(3)]
-			st.VerticalTimer = saucerVerticalTimerMin + rand.Intn(saucerVerticalTimerMax-saucerVerticalTimerMin)
+			v := saucerProfile(w, st.Size).VerticalSpeed
+			choices := []float64{-v, 0, v}
+			vel.Y = choices[w.rng.Intn(3)]
+			st.VerticalTimer = saucerVerticalTimerMin + w.rng.Intn(saucerVerticalTimerMax-saucerVerticalTimerMin)
 		}
 
 		// Vertical wrap
 		if pos.Y < 0 {
-			pos.Y += ScreenHeight
-		} else if pos.Y > ScreenHeight {
-			pos.Y -= ScreenHeight
+			pos.Y += w.Config.FieldHeight
+		} else if pos.Y > w.Config.FieldHeight {
+			pos.Y -= w.Config.FieldHeight
 		}
 
-		// Despawn at far edge
-		col := w.colliders[e]
-		radius := 0.0
-		if col != nil {
-			radius = col.Radius
+	}
+}
+
+// nearestPlayer returns the player ship closest to pos, measured across the
+// screen edges, and its position, which is nil if there is none. Ties go to
+// the lowest entity so co-op stays deterministic.
+func nearestPlayer(w *World, pos *Position) (Entity, *Position) {
+	var (
+		best    Entity
+		bestPos *Position
+	)
+	bestDist := math.Inf(1)
+	for _, e := range sortedEntities(w.players) {
+		p := w.positions[e]
+		if p == nil {
+			continue
 		}
-		if st.DirectionX > 0 && pos.X > ScreenWidth+radius {
-			w.Destroy(e)
-		} else if st.DirectionX < 0 && pos.X < -radius {
-			w.Destroy(e)
+		if d := w.Field().Distance(pos.X, pos.Y, p.X, p.Y); d < bestDist {
+			best, bestPos, bestDist = e, p, d
+		}
+	}
+	return best, bestPos
+}
+
+// saucerAim returns the point a saucer at pos shoots at: the nearest ship,
+// across a screen edge if that is shorter. From Config.SaucerLeadLevel on it
+// aims where a moving ship will be when a bullet at bulletSpeed arrives.
+func saucerAim(w *World, pos *Position, bulletSpeed float64) (x, y float64) {
+	target, tpos := nearestPlayer(w, pos)
+	if tpos == nil {
+		return 0, 0
+	}
+	dx, dy := w.Field().Delta(pos.X, pos.Y, tpos.X, tpos.Y)
+	if lead := w.Config.SaucerLeadLevel; lead > 0 && w.Level >= lead {
+		if vel := w.velocities[target]; vel != nil {
+			if t, ok := geom.Intercept(dx, dy, vel.X, vel.Y, bulletSpeed); ok {
+				dx += vel.X * t
+				dy += vel.Y * t
+			}
 		}
 	}
+	return pos.X + dx, pos.Y + dy
 }
 
 // SaucerBulletLifetimeSystem decrements saucer bullet lifetimes and destroys expired ones.
@@ -186,132 +231,96 @@ func SaucerBulletLifetimeSystem(w *World) {
 type CollisionEvent struct {
 	BulletHits       []bulletHit
 	SaucerBulletHits []saucerHit
+	ShootDowns       []shootDown
 	PlayerHit        bool
 	PlayerEntity     Entity
+	PlayerHitBy      DeathCause
+	// PlayerHazard is what hit the ship.
+	PlayerHazard Entity
 }
 
 type bulletHit struct {
 	Bullet   Entity
 	Asteroid Entity
+	Owner    Entity // the ship that fired the bullet
 }
 
 type saucerHit struct {
 	Bullet Entity
 	Saucer Entity
+	Owner  Entity
+}
+
+// shootDown is a player bullet meeting a saucer bullet.
+type shootDown struct {
+	Bullet Entity
+	Target Entity
+	Owner  Entity
+}
+
+// playerHazards are what can destroy a ship, in the order they are checked.
+var playerHazards = []struct {
+	layer CollisionLayer
+	cause DeathCause
+}{
+	{LayerHazard, DeathAsteroid},
+	{LayerEnemyBullet, DeathSaucerBullet},
+	{LayerEnemy, DeathSaucer},
 }
 
-// CollisionSystem checks bullet-asteroid and player-asteroid collisions.
+// CollisionSystem finds what player bullets and missiles hit and whether a
+// ship was hit, one collision layer against another.
 func CollisionSystem(w *World) CollisionEvent {
 	var events CollisionEvent
+	layers := collisionLayers(w)
 
-	// Bullet vs Asteroid
-	for be, bt := range w.bullets {
-		if bt.Life <= 0 {
-			continue
-		}
-		bpos := w.positions[be]
-		if bpos == nil {
-			continue
-		}
-		for ae := range w.asteroids {
-			apos := w.positions[ae]
-			acol := w.colliders[ae]
-			if apos == nil || acol == nil {
-				continue
-			}
-			dx := bpos.X - apos.X
-			dy := bpos.Y - apos.Y
-			if dx*dx+dy*dy < acol.Radius*acol.Radius {
-				events.BulletHits = append(events.BulletHits, bulletHit{
-					Bullet:   be,
-					Asteroid: ae,
-				})
-				break
-			}
+	var shots []Entity
+	owners := make(map[Entity]Entity)
+	for _, e := range layers[LayerPlayerBullet] {
+		if b := w.bullets[e]; b != nil && b.Life > 0 {
+			shots = append(shots, e)
+			owners[e] = b.Owner
+		} else if m := w.missiles[e]; m != nil && m.Life > 0 {
+			shots = append(shots, e)
+			owners[e] = m.Owner
 		}
 	}
-
-	// Player Bullet vs Saucer
-	for be, bt := range w.bullets {
-		if bt.Life <= 0 {
-			continue
+	for _, be := range shots {
+		if ae, ok := firstHit(w, be, layers[LayerHazard]); ok {
+			events.BulletHits = append(events.BulletHits, bulletHit{Bullet: be, Asteroid: ae, Owner: owners[be]})
 		}
-		bpos := w.positions[be]
-		if bpos == nil {
-			continue
+	}
+	for _, be := range shots {
+		if se, ok := firstHit(w, be, layers[LayerEnemy]); ok {
+			events.SaucerBulletHits = append(events.SaucerBulletHits, saucerHit{Bullet: be, Saucer: se, Owner: owners[be]})
 		}
-		for se := range w.saucers {
-			spos := w.positions[se]
-			scol := w.colliders[se]
-			if spos == nil || scol == nil {
+	}
+	if w.Config.SaucerBulletShootDown {
+		// Both are points, so they meet within shootDownRadius. Missiles
+		// fly through. A bullet shot down cannot also hit a ship.
+		shotDown := make(map[Entity]bool)
+		for _, be := range shots {
+			if w.bullets[be] == nil {
 				continue
 			}
-			dx := bpos.X - spos.X
-			dy := bpos.Y - spos.Y
-			if dx*dx+dy*dy < scol.Radius*scol.Radius {
-				events.SaucerBulletHits = append(events.SaucerBulletHits, saucerHit{
-					Bullet: be,
-					Saucer: se,
-				})
-				break
+			if se, ok := firstWithin(w, be, layers[LayerEnemyBullet], shootDownRadius); ok && !shotDown[se] {
+				shotDown[se] = true
+				events.ShootDowns = append(events.ShootDowns, shootDown{Bullet: be, Target: se, Owner: owners[be]})
 			}
 		}
+		layers[LayerEnemyBullet] = slices.DeleteFunc(layers[LayerEnemyBullet], func(e Entity) bool { return shotDown[e] })
 	}
 
-	// Player vs Asteroid
-	for pe, pc := range w.players {
-		if pc.Invulnerable {
-			continue
-		}
-		ppos := w.positions[pe]
-		pcol := w.colliders[pe]
-		if ppos == nil || pcol == nil {
+	for _, pe := range layers[LayerPlayer] {
+		if pc := w.players[pe]; pc == nil || pc.Invulnerable {
 			continue
 		}
-		for ae := range w.asteroids {
-			apos := w.positions[ae]
-			acol := w.colliders[ae]
-			if apos == nil || acol == nil {
-				continue
-			}
-			dx := ppos.X - apos.X
-			dy := ppos.Y - apos.Y
-			dist := math.Sqrt(dx*dx + dy*dy)
-			if dist < pcol.Radius+acol.Radius {
-				events.PlayerHit = true
-				events.PlayerEntity = pe
-				return events
-			}
-		}
-
-		// Saucer Bullet vs Player
-		for sbe := range w.saucerBullets {
-			sbpos := w.positions[sbe]
-			if sbpos == nil {
-				continue
-			}
-			dx := ppos.X - sbpos.X
-			dy := ppos.Y - sbpos.Y
-			if dx*dx+dy*dy < pcol.Radius*pcol.Radius {
-				events.PlayerHit = true
-				events.PlayerEntity = pe
-				return events
-			}
-		}
-
-		// Saucer Body vs Player
-		for se := range w.saucers {
-			spos := w.positions[se]
-			scol := w.colliders[se]
-			if spos == nil || scol == nil {
-				continue
-			}
-			dx := ppos.X - spos.X
-			dy := ppos.Y - spos.Y
-			dist := math.Sqrt(dx*dx + dy*dy)
-			if dist < pcol.Radius+scol.Radius {
+		for _, h := range playerHazards {
+			if he, ok := firstHit(w, pe, layers[h.layer]); ok {
 				events.PlayerHit = true
 				events.PlayerEntity = pe
+				events.PlayerHitBy = h.cause
+				events.PlayerHazard = he
 				return events
 			}
 		}
@@ -325,7 +334,10 @@ func CollisionSystem(w *World) CollisionEvent {
 // respawnPlayer resets a player entity to center with invulnerability.
 func respawnPlayer(w *World, e Entity) {
 	pos := w.positions[e]
-	pos.X, pos.Y = ScreenWidth/2, ScreenHeight/2
+	pos.X, pos.Y = w.Config.FieldWidth/2, w.Config.FieldHeight/2
+	if pc := w.players[e]; pc != nil {
+		pos.X += float64(pc.Slot) * coopSpawnOffset
+	}
 	if vel := w.velocities[e]; vel != nil {
 		vel.X, vel.Y = 0, 0
 	}
@@ -340,11 +352,17 @@ func respawnPlayer(w *World, e Entity) {
 }
 
 // killPlayer decrements lives and handles respawn or game-over cleanup.
-func killPlayer(w *World, e Entity) {
+func killPlayer(w *World, e Entity, cause DeathCause) {
+	w.Combo, w.ComboTimer = 0, 0
+	w.Stats.Deaths[cause]++
+	if pos := w.positions[e]; pos != nil {
+		w.Stats.DeathSpots = append(w.Stats.DeathSpots, DeathSpot{X: pos.X, Y: pos.Y, Cause: cause})
+	}
 	w.Lives--
 	w.SoundQueue = append(w.SoundQueue, SoundPlayerDeath)
+	startHitStop(w)
 	destroySaucerAndBullets(w)
-	w.SaucerSpawnTimer = saucerRespawnDelay
+	w.Saucer.SpawnTimer = w.Config.SaucerRespawnDelay
 	if w.Lives <= 0 {
 		w.Destroy(e)
 	} else {
@@ -352,56 +370,58 @@ func killPlayer(w *World, e Entity) {
 	}
 }
 
-// destroySaucerAndBullets removes the active saucer and all saucer bullets.
+// destroySaucerAndBullets removes every saucer and saucer bullet.
 func destroySaucerAndBullets(w *World) {
-	if w.SaucerActive != 0 && w.Alive(w.SaucerActive) {
-		w.Destroy(w.SaucerActive)
+	for _, e := range sortedEntities(w.saucers) {
+		removeSaucer(w, e, SaucerCleared)
 	}
-	w.SaucerActive = 0
 	for e := range w.saucerBullets {
 		w.Destroy(e)
 	}
 }
 
-// checkExtraLife awards extra lives when score crosses 10K thresholds.
+// checkExtraLife awards extra lives when score crosses 10K thresholds. At
+// the lives cap each threshold pays out bonus points instead.
 func checkExtraLife(w *World) {
 	for w.Score >= w.NextExtraLifeAt {
-		w.Lives++
-		w.NextExtraLifeAt += 10_000
+		w.NextExtraLifeAt += w.Config.ExtraLifeEvery
+		if w.Lives < w.Config.MaxLives {
+			w.Lives++
+			w.SoundQueue = append(w.SoundQueue, SoundExtraLife)
+			continue
+		}
+		w.Score += w.Config.LifeBonusPoints
+		w.LifeBonusFlash = lifeBonusFlash
+		w.SoundQueue = append(w.SoundQueue, SoundLifeBonus)
 	}
 }
 
 // spawnWave spawns a wave of large asteroids based on current level.
 func spawnWave(w *World) {
-	count := 3 + w.Level
-	playerPos := w.positions[w.Player]
-
-	for i := 0; i < count; i++ {
-		var x, y float64
-		for {
-			x = rand.Float64() * ScreenWidth
-			y = rand.Float64() * ScreenHeight
-			if playerPos != nil {
-				dx := x - playerPos.X
-				dy := y - playerPos.Y
-				if math.Sqrt(dx*dx+dy*dy) > 150 {
-					break
-				}
-			} else {
-				break
-			}
+	for _, p := range wavePositions(w, w.Level, waveSize(w), w.positions[w.Player]) {
+		if !asteroidRoom(w) {
+			continue
 		}
-		SpawnAsteroid(w, x, y, SizeLarge)
+		spawnAsteroidVariant(w, p[0], p[1], SizeLarge, rollVariant(w))
 	}
 }
 
 // --- New systems ---
 
-// ShootingSystem spawns bullets when the player presses shoot.
+// ShootingSystem fires the player's weapon when shoot is pressed.
 func ShootingSystem(w *World) {
-	for e, pc := range w.players {
-		if pc.ShootPressed && w.BulletCount() < MaxPlayerBullets {
-			SpawnBullet(w, e)
+	for _, e := range sortedEntities(w.players) {
+		pc := w.players[e]
+		if pc.ShotCooldown > 0 {
+			pc.ShotCooldown--
+			continue
+		}
+		if !pc.ShootPressed {
+			continue
+		}
+		if n := fireWeapon(w, e, pc); n > 0 {
+			w.Stats.ShotsFired += n
+			pc.Shots += n
 			w.SoundQueue = append(w.SoundQueue, SoundFire)
 		}
 	}
@@ -409,8 +429,9 @@ func ShootingSystem(w *World) {
 
 // HyperspaceSystem handles hyperspace teleportation and risk.
 func HyperspaceSystem(w *World, rng float64) {
-	for e, pc := range w.players {
-		if !pc.HyperspacePressed || pc.HyperspaceCooldown > 0 {
+	for _, e := range sortedEntities(w.players) {
+		pc := w.players[e]
+		if !pc.HyperspacePressed || pc.HyperspaceCooldown > 0 || w.Config.NoHyperspace {
 			if pc.HyperspaceCooldown > 0 {
 				pc.HyperspaceCooldown--
 			}
@@ -419,29 +440,29 @@ func HyperspaceSystem(w *World, rng float64) {
 
 		pos := w.positions[e]
 		vel := w.velocities[e]
+		w.Stats.HyperspaceJumps++
 
 		// Departure particles
 		for i := 0; i < 12; i++ {
 			SpawnParticle(w, pos.X, pos.Y)
 		}
 
-		// Risk: ~1/16 chance of death
-		if rng < 1.0/16.0 {
-			killPlayer(w, e)
+		if rng < w.Config.HyperspaceRisk {
+			killPlayer(w, e, DeathHyperspace)
 		} else {
 			// Successful teleport
-			pos.X = rand.Float64() * ScreenWidth
-			pos.Y = rand.Float64() * ScreenHeight
+			pos.X = w.rng.Float64() * w.Config.FieldWidth
+			pos.Y = w.rng.Float64() * w.Config.FieldHeight
 			vel.X, vel.Y = 0, 0
 		}
 
-		pc.HyperspaceCooldown = 30
+		pc.HyperspaceCooldown = hyperspaceCooldown
 	}
 }
 
 // chooseSaucerSize picks a saucer size based on score.
 // Large below 10K, small above 40K, linear interpolation between.
-func chooseSaucerSize(score int) SaucerSize {
+func chooseSaucerSize(rng RNG, score int) SaucerSize {
 	if score < 10000 {
 		return SaucerLarge
 	}
@@ -449,34 +470,12 @@ func chooseSaucerSize(score int) SaucerSize {
 		return SaucerSmall
 	}
 	smallChance := float64(score-10000) / 30000.0
-	if rand.Float64() < smallChance {
+	if rng.Float64() < smallChance {
 		return SaucerSmall
 	}
 	return SaucerLarge
 }
 
-// SaucerSpawnSystem manages the saucer spawn timer and spawns saucers.
-func SaucerSpawnSystem(w *World) {
-	if w.SaucerActive != 0 && w.Alive(w.SaucerActive) {
-		return
-	}
-	w.SaucerActive = 0
-	w.SaucerSpawnTimer--
-	if w.SaucerSpawnTimer <= 0 {
-		size := chooseSaucerSize(w.Score)
-		w.SaucerActive = SpawnSaucer(w, size)
-		w.SaucerSpawnTimer = saucerRespawnDelay
-	}
-}
-
-// SaucerDespawnSystem detects when a saucer has been destroyed by AI despawn.
-func SaucerDespawnSystem(w *World) {
-	if w.SaucerActive != 0 && !w.Alive(w.SaucerActive) {
-		w.SaucerActive = 0
-		w.SaucerSpawnTimer = saucerRespawnDelay
-	}
-}
-
 // CollisionResponseSystem processes collision events and updates game state.
 func CollisionResponseSystem(w *World, events CollisionEvent) {
 	// Process bullet hits on asteroids
@@ -487,35 +486,13 @@ func CollisionResponseSystem(w *World, events CollisionEvent) {
 		}
 		destroyed[hit.Asteroid] = true
 
-		ast := w.asteroids[hit.Asteroid]
-		apos := w.positions[hit.Asteroid]
-		if ast == nil || apos == nil {
+		if w.asteroids[hit.Asteroid] == nil || w.positions[hit.Asteroid] == nil {
 			continue
 		}
-
-		switch ast.Size {
-		case SizeLarge:
-			w.Score += 20
-		case SizeMedium:
-			w.Score += 50
-		case SizeSmall:
-			w.Score += 100
-		}
-		checkExtraLife(w)
-
-		for i := 0; i < 8; i++ {
-			SpawnParticle(w, apos.X, apos.Y)
-		}
-
-		if ast.Size != SizeSmall {
-			nextSize := ast.Size + 1
-			SpawnAsteroid(w, apos.X, apos.Y, nextSize)
-			SpawnAsteroid(w, apos.X, apos.Y, nextSize)
-		}
-
-		w.SoundQueue = append(w.SoundQueue, soundForSize(ast.Size))
+		before := w.Score
+		shootAsteroid(w, hit.Asteroid, hit.Bullet, hit.Owner)
+		credit(w, hit.Owner, w.Score-before)
 		w.Destroy(hit.Bullet)
-		w.Destroy(hit.Asteroid)
 	}
 
 	// Process bullet hits on saucers
@@ -526,41 +503,78 @@ func CollisionResponseSystem(w *World, events CollisionEvent) {
 			continue
 		}
 
-		switch st.Size {
-		case SaucerLarge:
-			w.Score += 200
-		case SaucerSmall:
-			w.Score += 1000
+		before := w.Score
+		points := 200
+		if st.Size == SaucerSmall {
+			points = 1000
 		}
+		w.Score += points
+		recordKill(w, Kill{Kind: KillSaucer, Target: hit.Saucer, Shot: hit.Bullet, Owner: hit.Owner, Points: points})
 		checkExtraLife(w)
+		checkWeaponTier(w)
+		credit(w, hit.Owner, w.Score-before)
 
 		for i := 0; i < 12; i++ {
 			SpawnParticle(w, spos.X, spos.Y)
 		}
 
 		w.SoundQueue = append(w.SoundQueue, SoundExplosionLarge)
+		w.Stats.SaucersDestroyed++
+		startHitStop(w)
+		addCombo(w)
+		maybeDropStar(w, st.Size, spos.X, spos.Y)
 		w.Destroy(hit.Bullet)
-		w.Destroy(hit.Saucer)
-		w.SaucerActive = 0
-		w.SaucerSpawnTimer = saucerRespawnDelay
+		removeSaucer(w, hit.Saucer, SaucerDestroyed)
 	}
 
-	// Process player hit
+	// Process saucer bullets shot down; a bullet that already hit
+	// something this tick is gone.
+	for _, hit := range events.ShootDowns {
+		pos := w.positions[hit.Target]
+		if w.bullets[hit.Bullet] == nil || pos == nil {
+			continue
+		}
+		before := w.Score
+		w.Score += shootDownPoints
+		recordKill(w, Kill{Kind: KillSaucerBullet, Target: hit.Target, Shot: hit.Bullet, Owner: hit.Owner, Points: shootDownPoints})
+		checkExtraLife(w)
+		checkWeaponTier(w)
+		credit(w, hit.Owner, w.Score-before)
+
+		for i := 0; i < 4; i++ {
+			SpawnParticle(w, pos.X, pos.Y)
+		}
+		w.SoundQueue = append(w.SoundQueue, SoundExplosionSmall)
+		w.Stats.BulletsShotDown++
+		w.Destroy(hit.Bullet)
+		w.Destroy(hit.Target)
+	}
+
+	// Process player hit; a raised shield takes it instead.
 	if events.PlayerHit {
+		if pc := w.players[events.PlayerEntity]; pc != nil && pc.ShieldUp {
+			absorbHit(w, events.PlayerEntity, events.PlayerHazard)
+			return
+		}
 		ppos := w.positions[events.PlayerEntity]
 		if ppos != nil {
 			for i := 0; i < 15; i++ {
 				SpawnParticle(w, ppos.X, ppos.Y)
 			}
 		}
-		killPlayer(w, events.PlayerEntity)
+		killPlayer(w, events.PlayerEntity, events.PlayerHitBy)
 	}
 }
 
-// WaveClearSystem spawns the next wave when all asteroids are destroyed.
+// WaveClearSystem spawns the next wave when all asteroids are destroyed and
+// restocks the players' missiles.
 func WaveClearSystem(w *World) {
 	if len(w.asteroids) == 0 {
 		w.Level++
+		for _, pc := range w.players {
+			pc.Missiles = max(pc.Missiles, w.Config.MissileAmmo)
+			pc.Shield = w.Config.ShieldTicks
+		}
 		spawnWave(w)
 	}
 }
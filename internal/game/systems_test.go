@@ -274,10 +274,11 @@ func TestCollisionSystem_BulletAsteroidHit(t *testing.T) {
 	bullet := w.Spawn()
 	w.positions[bullet] = &Position{X: 100, Y: 100}
 	w.bullets[bullet] = &BulletTag{Life: 10}
+	w.colliders[bullet] = &Collider{Radius: 2, Layer: LayerPlayerBullet}
 
 	asteroid := w.Spawn()
 	w.positions[asteroid] = &Position{X: 105, Y: 100} // within radius 40
-	w.colliders[asteroid] = &Collider{Radius: 40}
+	w.colliders[asteroid] = &Collider{Radius: 40, Layer: LayerHazard}
 	w.asteroids[asteroid] = &AsteroidTag{Size: SizeLarge}
 
 	events := CollisionSystem(w)
@@ -299,10 +300,11 @@ func TestCollisionSystem_BulletAsteroidMiss(t *testing.T) {
 	bullet := w.Spawn()
 	w.positions[bullet] = &Position{X: 100, Y: 100}
 	w.bullets[bullet] = &BulletTag{Life: 10}
+	w.colliders[bullet] = &Collider{Radius: 2, Layer: LayerPlayerBullet}
 
 	asteroid := w.Spawn()
 	w.positions[asteroid] = &Position{X: 500, Y: 500} // far away
-	w.colliders[asteroid] = &Collider{Radius: 10}
+	w.colliders[asteroid] = &Collider{Radius: 10, Layer: LayerHazard}
 	w.asteroids[asteroid] = &AsteroidTag{Size: SizeSmall}
 
 	events := CollisionSystem(w)
@@ -317,12 +319,12 @@ func TestCollisionSystem_PlayerAsteroidHit(t *testing.T) {
 
 	player := w.Spawn()
 	w.positions[player] = &Position{X: 100, Y: 100}
-	w.colliders[player] = &Collider{Radius: 15}
+	w.colliders[player] = &Collider{Radius: 15, Layer: LayerPlayer}
 	w.players[player] = &PlayerControl{Invulnerable: false}
 
 	asteroid := w.Spawn()
 	w.positions[asteroid] = &Position{X: 110, Y: 100} // distance 10 < 15+40
-	w.colliders[asteroid] = &Collider{Radius: 40}
+	w.colliders[asteroid] = &Collider{Radius: 40, Layer: LayerHazard}
 	w.asteroids[asteroid] = &AsteroidTag{Size: SizeLarge}
 
 	events := CollisionSystem(w)
@@ -340,12 +342,12 @@ func TestCollisionSystem_PlayerSkippedWhenInvulnerable(t *testing.T) {
 
 	player := w.Spawn()
 	w.positions[player] = &Position{X: 100, Y: 100}
-	w.colliders[player] = &Collider{Radius: 15}
+	w.colliders[player] = &Collider{Radius: 15, Layer: LayerPlayer}
 	w.players[player] = &PlayerControl{Invulnerable: true, InvulnerableTimer: 60}
 
 	asteroid := w.Spawn()
 	w.positions[asteroid] = &Position{X: 110, Y: 100}
-	w.colliders[asteroid] = &Collider{Radius: 40}
+	w.colliders[asteroid] = &Collider{Radius: 40, Layer: LayerHazard}
 	w.asteroids[asteroid] = &AsteroidTag{Size: SizeLarge}
 
 	events := CollisionSystem(w)
@@ -360,12 +362,12 @@ func TestCollisionSystem_PlayerAsteroidMiss(t *testing.T) {
 
 	player := w.Spawn()
 	w.positions[player] = &Position{X: 100, Y: 100}
-	w.colliders[player] = &Collider{Radius: 15}
+	w.colliders[player] = &Collider{Radius: 15, Layer: LayerPlayer}
 	w.players[player] = &PlayerControl{Invulnerable: false}
 
 	asteroid := w.Spawn()
 	w.positions[asteroid] = &Position{X: 500, Y: 500} // far away
-	w.colliders[asteroid] = &Collider{Radius: 10}
+	w.colliders[asteroid] = &Collider{Radius: 10, Layer: LayerHazard}
 	w.asteroids[asteroid] = &AsteroidTag{Size: SizeSmall}
 
 	events := CollisionSystem(w)
@@ -382,19 +384,21 @@ func TestCollisionSystem_ReturnsCorrectEventData(t *testing.T) {
 	b1 := w.Spawn()
 	w.positions[b1] = &Position{X: 100, Y: 100}
 	w.bullets[b1] = &BulletTag{Life: 10}
+	w.colliders[b1] = &Collider{Radius: 2, Layer: LayerPlayerBullet}
 
 	a1 := w.Spawn()
 	w.positions[a1] = &Position{X: 100, Y: 100}
-	w.colliders[a1] = &Collider{Radius: 40}
+	w.colliders[a1] = &Collider{Radius: 40, Layer: LayerHazard}
 	w.asteroids[a1] = &AsteroidTag{Size: SizeLarge}
 
 	b2 := w.Spawn()
 	w.positions[b2] = &Position{X: 500, Y: 500}
 	w.bullets[b2] = &BulletTag{Life: 10}
+	w.colliders[b2] = &Collider{Radius: 2, Layer: LayerPlayerBullet}
 
 	a2 := w.Spawn()
 	w.positions[a2] = &Position{X: 500, Y: 500}
-	w.colliders[a2] = &Collider{Radius: 40}
+	w.colliders[a2] = &Collider{Radius: 40, Layer: LayerHazard}
 	w.asteroids[a2] = &AsteroidTag{Size: SizeMedium}
 
 	events := CollisionSystem(w)
@@ -410,10 +414,11 @@ func TestCollisionSystem_ExpiredBulletIgnored(t *testing.T) {
 	bullet := w.Spawn()
 	w.positions[bullet] = &Position{X: 100, Y: 100}
 	w.bullets[bullet] = &BulletTag{Life: 0} // expired
+	w.colliders[bullet] = &Collider{Radius: 2, Layer: LayerPlayerBullet}
 
 	asteroid := w.Spawn()
 	w.positions[asteroid] = &Position{X: 100, Y: 100} // overlapping
-	w.colliders[asteroid] = &Collider{Radius: 40}
+	w.colliders[asteroid] = &Collider{Radius: 40, Layer: LayerHazard}
 	w.asteroids[asteroid] = &AsteroidTag{Size: SizeLarge}
 
 	events := CollisionSystem(w)
@@ -430,21 +435,22 @@ func TestCollisionSystem_BulletAndPlayerHitsInSameFrame(t *testing.T) {
 	bullet := w.Spawn()
 	w.positions[bullet] = &Position{X: 100, Y: 100}
 	w.bullets[bullet] = &BulletTag{Life: 10}
+	w.colliders[bullet] = &Collider{Radius: 2, Layer: LayerPlayerBullet}
 
 	asteroid1 := w.Spawn()
 	w.positions[asteroid1] = &Position{X: 100, Y: 100}
-	w.colliders[asteroid1] = &Collider{Radius: 40}
+	w.colliders[asteroid1] = &Collider{Radius: 40, Layer: LayerHazard}
 	w.asteroids[asteroid1] = &AsteroidTag{Size: SizeLarge}
 
 	// Player hitting a different asteroid
 	player := w.Spawn()
 	w.positions[player] = &Position{X: 400, Y: 400}
-	w.colliders[player] = &Collider{Radius: 15}
+	w.colliders[player] = &Collider{Radius: 15, Layer: LayerPlayer}
 	w.players[player] = &PlayerControl{Invulnerable: false}
 
 	asteroid2 := w.Spawn()
 	w.positions[asteroid2] = &Position{X: 400, Y: 400}
-	w.colliders[asteroid2] = &Collider{Radius: 40}
+	w.colliders[asteroid2] = &Collider{Radius: 40, Layer: LayerHazard}
 	w.asteroids[asteroid2] = &AsteroidTag{Size: SizeLarge}
 
 	events := CollisionSystem(w)
@@ -532,27 +538,57 @@ func TestSaucerAISystem_VerticalWrap(t *testing.T) {
 	}
 }
 
-func TestSaucerAISystem_DespawnAtFarEdge(t *testing.T) {
+func TestSaucerAISystem_NilPlayerSafe(t *testing.T) {
 	w := NewWorld()
-	e := SpawnSaucer(w, SaucerLarge)
-	// Force moving right and past right edge
-	w.saucers[e].DirectionX = 1.0
-	w.positions[e].X = ScreenWidth + 100
-	w.saucers[e].ShootCooldown = 999
-	w.saucers[e].VerticalTimer = 999
-
+	SpawnSaucer(w, SaucerSmall)
+	// Should not panic with no player entity
 	SaucerAISystem(w)
+}
 
-	if w.Alive(e) {
-		t.Error("saucer should have despawned at far edge")
+func TestNearestPlayer_AcrossEdge(t *testing.T) {
+	w := NewWorld()
+	far := SpawnPlayer(w, 300, 300)
+	near := SpawnPlayer(w, 790, 300)
+
+	got, _ := nearestPlayer(w, &Position{X: 10, Y: 300})
+
+	if got != near || got == far {
+		t.Errorf("expected the ship 20px away across the edge, got %+v", got)
 	}
 }
 
-func TestSaucerAISystem_NilPlayerSafe(t *testing.T) {
+func TestSaucerAim_AcrossEdge(t *testing.T) {
 	w := NewWorld()
-	SpawnSaucer(w, SaucerSmall)
-	// Should not panic with no player entity
-	SaucerAISystem(w)
+	SpawnPlayer(w, 790, 300)
+
+	x, y := saucerAim(w, &Position{X: 10, Y: 300}, saucerBulletSpeed)
+
+	if x != -10 || y != 300 {
+		t.Errorf("expected to shoot left across the edge at (-10, 300), got (%v, %v)", x, y)
+	}
+}
+
+func TestSaucerAim_LeadsFromLeadLevel(t *testing.T) {
+	w := NewWorld()
+	p := SpawnPlayer(w, 400, 100)
+	w.velocities[p].X = 2
+	saucer := &Position{X: 400, Y: 300}
+
+	w.Level = saucerLeadLevel - 1
+	if x, y := saucerAim(w, saucer, saucerBulletSpeed); x != 400 || y != 100 {
+		t.Errorf("below the lead level the saucer should aim at the ship, got (%v, %v)", x, y)
+	}
+
+	w.Level = saucerLeadLevel
+	x, y := saucerAim(w, saucer, saucerBulletSpeed)
+	if x <= 400 {
+		t.Fatalf("expected to aim ahead of a ship moving right, got (%v, %v)", x, y)
+	}
+	// The bullet and the ship reach the aim point together.
+	ticks := (x - 400) / 2
+	if d := math.Hypot(x-400, y-300); math.Abs(d-saucerBulletSpeed*ticks) > 1e-6 {
+		t.Errorf("aim point %v away is not reached in %v ticks", d, ticks)
+	}
 }
 
 // --------------- SaucerBulletLifetimeSystem ---------------
@@ -589,10 +625,11 @@ func TestCollisionSystem_BulletHitsSaucer(t *testing.T) {
 	bullet := w.Spawn()
 	w.positions[bullet] = &Position{X: 100, Y: 100}
 	w.bullets[bullet] = &BulletTag{Life: 10}
+	w.colliders[bullet] = &Collider{Radius: 2, Layer: LayerPlayerBullet}
 
 	saucer := w.Spawn()
 	w.positions[saucer] = &Position{X: 105, Y: 100}
-	w.colliders[saucer] = &Collider{Radius: 20}
+	w.colliders[saucer] = &Collider{Radius: 20, Layer: LayerEnemy}
 	w.saucers[saucer] = &SaucerTag{Size: SaucerLarge}
 
 	events := CollisionSystem(w)
@@ -614,10 +651,11 @@ func TestCollisionSystem_BulletMissesSaucer(t *testing.T) {
 	bullet := w.Spawn()
 	w.positions[bullet] = &Position{X: 100, Y: 100}
 	w.bullets[bullet] = &BulletTag{Life: 10}
+	w.colliders[bullet] = &Collider{Radius: 2, Layer: LayerPlayerBullet}
 
 	saucer := w.Spawn()
 	w.positions[saucer] = &Position{X: 500, Y: 500}
-	w.colliders[saucer] = &Collider{Radius: 20}
+	w.colliders[saucer] = &Collider{Radius: 20, Layer: LayerEnemy}
 	w.saucers[saucer] = &SaucerTag{Size: SaucerLarge}
 
 	events := CollisionSystem(w)
@@ -632,12 +670,13 @@ func TestCollisionSystem_SaucerBulletHitsPlayer(t *testing.T) {
 
 	player := w.Spawn()
 	w.positions[player] = &Position{X: 100, Y: 100}
-	w.colliders[player] = &Collider{Radius: 15}
+	w.colliders[player] = &Collider{Radius: 15, Layer: LayerPlayer}
 	w.players[player] = &PlayerControl{Invulnerable: false}
 
 	sb := w.Spawn()
 	w.positions[sb] = &Position{X: 105, Y: 100} // within player radius
 	w.saucerBullets[sb] = &SaucerBulletTag{Life: 10}
+	w.colliders[sb] = &Collider{Radius: 2, Layer: LayerEnemyBullet}
 
 	events := CollisionSystem(w)
 
@@ -651,12 +690,13 @@ func TestCollisionSystem_SaucerBulletMissesInvulnerablePlayer(t *testing.T) {
 
 	player := w.Spawn()
 	w.positions[player] = &Position{X: 100, Y: 100}
-	w.colliders[player] = &Collider{Radius: 15}
+	w.colliders[player] = &Collider{Radius: 15, Layer: LayerPlayer}
 	w.players[player] = &PlayerControl{Invulnerable: true, InvulnerableTimer: 60}
 
 	sb := w.Spawn()
 	w.positions[sb] = &Position{X: 105, Y: 100}
 	w.saucerBullets[sb] = &SaucerBulletTag{Life: 10}
+	w.colliders[sb] = &Collider{Radius: 2, Layer: LayerEnemyBullet}
 
 	events := CollisionSystem(w)
 
@@ -670,12 +710,12 @@ func TestCollisionSystem_SaucerBodyHitsPlayer(t *testing.T) {
 
 	player := w.Spawn()
 	w.positions[player] = &Position{X: 100, Y: 100}
-	w.colliders[player] = &Collider{Radius: 15}
+	w.colliders[player] = &Collider{Radius: 15, Layer: LayerPlayer}
 	w.players[player] = &PlayerControl{Invulnerable: false}
 
 	saucer := w.Spawn()
 	w.positions[saucer] = &Position{X: 110, Y: 100} // within 15+20=35
-	w.colliders[saucer] = &Collider{Radius: 20}
+	w.colliders[saucer] = &Collider{Radius: 20, Layer: LayerEnemy}
 	w.saucers[saucer] = &SaucerTag{Size: SaucerLarge}
 
 	events := CollisionSystem(w)
@@ -691,10 +731,11 @@ func TestCollisionSystem_ExpiredBulletIgnoredForSaucer(t *testing.T) {
 	bullet := w.Spawn()
 	w.positions[bullet] = &Position{X: 100, Y: 100}
 	w.bullets[bullet] = &BulletTag{Life: 0}
+	w.colliders[bullet] = &Collider{Radius: 2, Layer: LayerPlayerBullet}
 
 	saucer := w.Spawn()
 	w.positions[saucer] = &Position{X: 100, Y: 100}
-	w.colliders[saucer] = &Collider{Radius: 20}
+	w.colliders[saucer] = &Collider{Radius: 20, Layer: LayerEnemy}
 	w.saucers[saucer] = &SaucerTag{Size: SaucerLarge}
 
 	events := CollisionSystem(w)
@@ -770,60 +811,85 @@ func TestShootingSystem_RespectsLimit(t *testing.T) {
 	}
 }
 
-func TestSaucerSpawnSystem_TimerDecrement(t *testing.T) {
+func TestSaucerLifecycleSystem_TimerDecrement(t *testing.T) {
 	w := NewWorld()
-	w.SaucerActive = 0
-	w.SaucerSpawnTimer = 10
+	w.Saucer.SpawnTimer = 10
 
-	SaucerSpawnSystem(w)
+	SaucerLifecycleSystem(w)
 
-	if w.SaucerSpawnTimer != 9 {
-		t.Errorf("expected timer 9, got %d", w.SaucerSpawnTimer)
+	if w.Saucer.SpawnTimer != 9 {
+		t.Errorf("expected timer 9, got %d", w.Saucer.SpawnTimer)
 	}
 }
 
-func TestSaucerSpawnSystem_SpawnsAtZero(t *testing.T) {
+func TestSaucerLifecycleSystem_SpawnsAtZero(t *testing.T) {
 	w := NewWorld()
-	w.SaucerActive = 0
-	w.SaucerSpawnTimer = 1
+	w.Saucer.SpawnTimer = 1
 
-	SaucerSpawnSystem(w)
+	SaucerLifecycleSystem(w)
 
-	if w.SaucerActive == 0 {
-		t.Error("saucer should have been spawned")
+	if w.SaucerCount() != 1 {
+		t.Fatalf("expected 1 saucer, got %d", w.SaucerCount())
 	}
-	if !w.Alive(w.SaucerActive) {
-		t.Error("spawned saucer should be alive")
+	ev := w.Saucer.Events
+	if len(ev) != 1 || ev[0].Kind != SaucerSpawned || !w.Alive(ev[0].Saucer) {
+		t.Errorf("expected a spawned event for the new saucer, got %+v", ev)
 	}
 }
 
-func TestSaucerSpawnSystem_NoSpawnWhileActive(t *testing.T) {
+func TestSaucerLifecycleSystem_NoSpawnWhileFull(t *testing.T) {
 	w := NewWorld()
-	saucer := SpawnSaucer(w, SaucerLarge)
-	w.SaucerActive = saucer
-	w.SaucerSpawnTimer = 5
+	SpawnSaucer(w, SaucerLarge)
+	w.Saucer.SpawnTimer = 5
+
+	SaucerLifecycleSystem(w)
+
+	if w.Saucer.SpawnTimer != 5 {
+		t.Errorf("timer should not tick while the saucer limit is reached, got %d", w.Saucer.SpawnTimer)
+	}
+}
 
-	SaucerSpawnSystem(w)
+func TestSaucerLifecycleSystem_MultipleSaucers(t *testing.T) {
+	w := NewWorld()
+	w.Config.MaxSaucers = 2
+	SpawnSaucer(w, SaucerLarge)
+	w.Saucer.SpawnTimer = 1
 
-	// Timer should not have changed
-	if w.SaucerSpawnTimer != 5 {
-		t.Errorf("timer should not tick while saucer active, got %d", w.SaucerSpawnTimer)
+	SaucerLifecycleSystem(w)
+
+	if w.SaucerCount() != 2 {
+		t.Errorf("expected a second saucer, got %d", w.SaucerCount())
 	}
 }
 
-func TestSaucerDespawnSystem_DetectsDeadSaucer(t *testing.T) {
+func TestSaucerLifecycleSystem_EscapeResetsTimer(t *testing.T) {
 	w := NewWorld()
 	saucer := SpawnSaucer(w, SaucerLarge)
-	w.SaucerActive = saucer
-	w.Destroy(saucer) // simulate saucer destroyed by AI despawn
+	w.saucers[saucer].DirectionX = 1
+	w.positions[saucer].X = ScreenWidth + 100
+	w.Saucer.SpawnTimer = 3
 
-	SaucerDespawnSystem(w)
+	SaucerLifecycleSystem(w)
 
-	if w.SaucerActive != 0 {
-		t.Error("SaucerActive should be reset to 0")
+	if w.Alive(saucer) {
+		t.Error("saucer should have been removed past the far edge")
+	}
+	if w.Saucer.SpawnTimer != saucerRespawnDelay-1 {
+		t.Errorf("expected the countdown to restart, got %d", w.Saucer.SpawnTimer)
 	}
-	if w.SaucerSpawnTimer != saucerRespawnDelay {
-		t.Errorf("expected spawn timer %d, got %d", saucerRespawnDelay, w.SaucerSpawnTimer)
+	if ev := w.Saucer.Events; len(ev) != 1 || ev[0].Kind != SaucerEscaped || ev[0].Saucer != saucer {
+		t.Errorf("expected an escaped event, got %+v", ev)
+	}
+}
+
+func TestStep_ClearsSaucerEvents(t *testing.T) {
+	w := NewGameWorld(1)
+	w.Saucer.Events = append(w.Saucer.Events, SaucerEvent{Kind: SaucerEscaped})
+
+	Step(w, InputState{})
+
+	if len(w.Saucer.Events) != 0 {
+		t.Errorf("events should only last one tick, got %+v", w.Saucer.Events)
 	}
 }
 
@@ -834,12 +900,13 @@ func TestCollisionResponseSystem_AsteroidScore(t *testing.T) {
 
 	asteroid := w.Spawn()
 	w.positions[asteroid] = &Position{X: 100, Y: 100}
-	w.colliders[asteroid] = &Collider{Radius: 40}
+	w.colliders[asteroid] = &Collider{Radius: 40, Layer: LayerHazard}
 	w.asteroids[asteroid] = &AsteroidTag{Size: SizeLarge}
 
 	bullet := w.Spawn()
 	w.positions[bullet] = &Position{X: 100, Y: 100}
 	w.bullets[bullet] = &BulletTag{Life: 10}
+	w.colliders[bullet] = &Collider{Radius: 2, Layer: LayerPlayerBullet}
 
 	events := CollisionSystem(w)
 	CollisionResponseSystem(w, events)
@@ -867,12 +934,13 @@ func TestCollisionResponseSystem_AsteroidSplit(t *testing.T) {
 
 			asteroid := w.Spawn()
 			w.positions[asteroid] = &Position{X: 100, Y: 100}
-			w.colliders[asteroid] = &Collider{Radius: 40}
+			w.colliders[asteroid] = &Collider{Radius: 40, Layer: LayerHazard}
 			w.asteroids[asteroid] = &AsteroidTag{Size: tt.size}
 
 			bullet := w.Spawn()
 			w.positions[bullet] = &Position{X: 100, Y: 100}
 			w.bullets[bullet] = &BulletTag{Life: 10}
+			w.colliders[bullet] = &Collider{Radius: 2, Layer: LayerPlayerBullet}
 
 			events := CollisionSystem(w)
 			CollisionResponseSystem(w, events)
@@ -901,12 +969,12 @@ func TestCollisionResponseSystem_SaucerScore(t *testing.T) {
 			w.NextExtraLifeAt = 10_000
 
 			saucer := SpawnSaucer(w, tt.size)
-			w.SaucerActive = saucer
 
 			spos := w.positions[saucer]
 			bullet := w.Spawn()
 			w.positions[bullet] = &Position{X: spos.X, Y: spos.Y}
 			w.bullets[bullet] = &BulletTag{Life: 10}
+			w.colliders[bullet] = &Collider{Radius: 2, Layer: LayerPlayerBullet}
 
 			events := CollisionSystem(w)
 			CollisionResponseSystem(w, events)
@@ -930,7 +998,7 @@ func TestCollisionResponseSystem_PlayerDeath(t *testing.T) {
 
 	asteroid := w.Spawn()
 	w.positions[asteroid] = &Position{X: 100, Y: 100}
-	w.colliders[asteroid] = &Collider{Radius: 40}
+	w.colliders[asteroid] = &Collider{Radius: 40, Layer: LayerHazard}
 	w.asteroids[asteroid] = &AsteroidTag{Size: SizeLarge}
 
 	events := CollisionSystem(w)
@@ -960,7 +1028,7 @@ func TestCollisionResponseSystem_PlayerDeathGameOver(t *testing.T) {
 
 	asteroid := w.Spawn()
 	w.positions[asteroid] = &Position{X: 100, Y: 100}
-	w.colliders[asteroid] = &Collider{Radius: 40}
+	w.colliders[asteroid] = &Collider{Radius: 40, Layer: LayerHazard}
 	w.asteroids[asteroid] = &AsteroidTag{Size: SizeLarge}
 
 	events := CollisionSystem(w)
@@ -1009,7 +1077,7 @@ func TestKillPlayer_Respawn(t *testing.T) {
 	w.Player = player
 	w.players[player].Invulnerable = false
 
-	killPlayer(w, player)
+	killPlayer(w, player, DeathAsteroid)
 
 	if w.Lives != 2 {
 		t.Errorf("expected 2 lives, got %d", w.Lives)
@@ -1032,7 +1100,7 @@ func TestKillPlayer_GameOver(t *testing.T) {
 	player := SpawnPlayer(w, 100, 100)
 	w.Player = player
 
-	killPlayer(w, player)
+	killPlayer(w, player, DeathAsteroid)
 
 	if w.Lives != 0 {
 		t.Errorf("expected 0 lives, got %d", w.Lives)
@@ -0,0 +1,69 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/matheus3301/asteroids/internal/canvas"
+	"github.com/matheus3301/asteroids/internal/geom"
+)
+
+const (
+	// trajectoryTicks is how far ahead the trajectory hints look.
+	trajectoryTicks = 120
+	// trajectoryDotEvery spaces the dots of a hint, in ticks.
+	trajectoryDotEvery = 6
+)
+
+// DrawTrajectories draws where the ship, asteroids, saucers and saucer
+// bullets will be over the next two seconds if nothing changes course, as
+// dotted lines that wrap with them. Objects whose closest approach to the
+// ship, as reported to agents in cpa_ticks and cpa_dist, falls within that
+// time and touches the ship are drawn red, with a cross where they meet.
+func DrawTrajectories(w *World, screen canvas.Canvas) {
+	grey := color.RGBA{90, 90, 90, 255}
+	red := color.RGBA{255, 60, 60, 255}
+	ship := w.positions[w.Player]
+	shipVel := w.velocities[w.Player]
+	if ship != nil && shipVel != nil {
+		drawTrajectory(w, screen, w.Player, trajectoryTicks, grey)
+	}
+
+	threats := sortedEntities(w.asteroids)
+	threats = append(threats, sortedEntities(w.saucers)...)
+	threats = append(threats, sortedEntities(w.saucerBullets)...)
+	for _, e := range threats {
+		clr := grey
+		if ship != nil && shipVel != nil {
+			pos, vel, col := w.positions[e], w.velocities[e], w.colliders[e]
+			if pos != nil && vel != nil && col != nil {
+				dx, dy := w.Field().Delta(ship.X, ship.Y, pos.X, pos.Y)
+				t, dist := geom.ClosestApproach(dx, dy, vel.X-shipVel.X, vel.Y-shipVel.Y)
+				if t <= trajectoryTicks && dist < col.Radius+playerRadius {
+					clr = red
+					x, y := w.Field().Wrap(ship.X+shipVel.X*t, ship.Y+shipVel.Y*t)
+					strokeLine(screen, x-6, y-6, x+6, y+6, red)
+					strokeLine(screen, x-6, y+6, x+6, y-6, red)
+				}
+			}
+		}
+		drawTrajectory(w, screen, e, trajectoryTicks, clr)
+	}
+}
+
+// drawTrajectory dots the straight-line path of e for the given number of
+// ticks. Entities that do not wrap stop at the screen edge.
+func drawTrajectory(w *World, screen canvas.Canvas, e Entity, ticks int, clr color.RGBA) {
+	pos, vel := w.positions[e], w.velocities[e]
+	if pos == nil || vel == nil {
+		return
+	}
+	for t := trajectoryDotEvery; t <= ticks; t += trajectoryDotEvery {
+		x, y := pos.X+vel.X*float64(t), pos.Y+vel.Y*float64(t)
+		if w.wrappers[e] {
+			x, y = w.Field().Wrap(x, y)
+		} else if x < 0 || x > w.Config.FieldWidth || y < 0 || y > w.Config.FieldHeight {
+			return
+		}
+		screen.FillRect(x-1, y-1, 2, 2, clr)
+	}
+}
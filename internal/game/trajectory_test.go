@@ -0,0 +1,51 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/matheus3301/asteroids/internal/canvas"
+)
+
+func TestDrawTrajectories_MarksThreats(t *testing.T) {
+	w := NewWorldWithSeed(1)
+	w.Player = SpawnPlayer(w, 400, 300)
+	// One asteroid on a collision course, one passing well above.
+	hit := SpawnAsteroid(w, 200, 300, SizeSmall)
+	*w.velocities[hit] = Velocity{X: 2}
+	miss := SpawnAsteroid(w, 200, 100, SizeSmall)
+	*w.velocities[miss] = Velocity{X: 2}
+
+	var rec canvas.Recording
+	DrawTrajectories(w, &rec)
+
+	var crosses int
+	for _, op := range rec.Ops {
+		if op.Kind == "line" {
+			crosses++
+			if op.Args[0] < 390 || op.Args[0] > 410 {
+				t.Errorf("threat marked away from the ship: %v", op.Args)
+			}
+		}
+	}
+	if crosses != 2 {
+		t.Errorf("expected a single cross for the threatening asteroid, got %d lines", crosses)
+	}
+}
+
+func TestDrawTrajectories_Wraps(t *testing.T) {
+	w := NewWorldWithSeed(1)
+	e := SpawnAsteroid(w, ScreenWidth-5, 100, SizeSmall)
+	*w.velocities[e] = Velocity{X: 3}
+
+	var rec canvas.Recording
+	DrawTrajectories(w, &rec)
+
+	if rec.Count("fillrect") != trajectoryTicks/trajectoryDotEvery {
+		t.Fatalf("expected a full trajectory, got %d dots", rec.Count("fillrect"))
+	}
+	for _, op := range rec.Ops {
+		if op.Args[0] > ScreenWidth {
+			t.Fatalf("dot drawn off screen at %v", op.Args)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"slices"
+
+	"github.com/matheus3301/asteroids/internal/canvas"
+)
+
+// tutorialLives is what the ship's lives are topped back up to, so the
+// tutorial never ends in a game over.
+const tutorialLives = 3
+
+// tutorialDoneTicks is how long the last prompt stays up before the
+// tutorial returns to the menu.
+const tutorialDoneTicks = 180
+
+// tutorialMarkerRadius is how close the ship must fly to the marker.
+const tutorialMarkerRadius = 40
+
+// tutorialStep is one lesson: a prompt, what to spawn when it begins and
+// the objective that moves on to the next.
+type tutorialStep struct {
+	// prompt is shown at the top of the screen, naming the player's keys.
+	prompt func(k *KeyBindings) []string
+	// setup runs when the step begins.
+	setup func(t *tutorial, w *World)
+	// met reports, at the end of each tick, whether the objective is done.
+	met func(t *tutorial, w *World) bool
+}
+
+// tutorialSteps are the lessons, in order. The last one has no objective
+// and only says goodbye.
+var tutorialSteps = []tutorialStep{
+	{
+		prompt: func(k *KeyBindings) []string {
+			return []string{
+				fmt.Sprintf("TURN WITH %s AND %s", keyName(k, ActionRotateLeft), keyName(k, ActionRotateRight)),
+				"MAKE A FULL TURN",
+			}
+		},
+		met: func(t *tutorial, w *World) bool { return t.turned >= 2*math.Pi },
+	},
+	{
+		prompt: func(k *KeyBindings) []string {
+			return []string{
+				fmt.Sprintf("THRUST WITH %s - THE SHIP KEEPS DRIFTING", keyName(k, ActionThrust)),
+				"AFTER YOU LET GO. FLY THROUGH THE RING",
+			}
+		},
+		setup: func(t *tutorial, w *World) {
+			pos := w.positions[w.Player]
+			t.markerX, t.markerY = w.Field().Wrap(pos.X+250, pos.Y-120)
+			t.marker = true
+		},
+		met: func(t *tutorial, w *World) bool {
+			pos := w.positions[w.Player]
+			if pos == nil || w.Field().Distance(pos.X, pos.Y, t.markerX, t.markerY) > tutorialMarkerRadius {
+				return false
+			}
+			t.marker = false
+			return true
+		},
+	},
+	{
+		prompt: func(k *KeyBindings) []string {
+			return []string{
+				fmt.Sprintf("SHOOT WITH %s. ASTEROIDS MOVE, SO AIM", keyName(k, ActionShoot)),
+				"WHERE THEY WILL BE. HIT THE SMALL ONE",
+			}
+		},
+		setup: func(t *tutorial, w *World) {
+			pos := w.positions[w.Player]
+			x, y := w.Field().Wrap(pos.X-200, pos.Y-180)
+			e := SpawnAsteroid(w, x, y, SizeSmall)
+			*w.velocities[e] = Velocity{X: 2}
+		},
+		met: func(t *tutorial, w *World) bool { return w.Stats.AsteroidsDestroyed > t.begin.AsteroidsDestroyed },
+	},
+	{
+		prompt: func(k *KeyBindings) []string {
+			return []string{
+				fmt.Sprintf("%s JUMPS TO HYPERSPACE, A RANDOM SPOT.", keyName(k, ActionHyperspace)),
+				"1 JUMP IN 16 DESTROYS THE SHIP - NOT HERE. JUMP NOW",
+			}
+		},
+		met: func(t *tutorial, w *World) bool { return w.Stats.HyperspaceJumps > t.begin.HyperspaceJumps },
+	},
+	{
+		prompt: func(*KeyBindings) []string {
+			return []string{
+				"SAUCERS SHOOT BACK: LARGE ONES AT RANDOM,",
+				"SMALL ONES AT YOU. SHOOT ONE DOWN",
+			}
+		},
+		setup: func(t *tutorial, w *World) {
+			w.Config.MaxSaucers = 1
+			w.Config.SaucerRespawnDelay = 120
+			w.Saucer.SpawnTimer = 1
+		},
+		met: func(t *tutorial, w *World) bool {
+			return slices.ContainsFunc(w.Saucer.Events, func(ev SaucerEvent) bool { return ev.Kind == SaucerDestroyed })
+		},
+	},
+	{
+		prompt: func(*KeyBindings) []string {
+			return []string{"THAT IS ALL. GOOD LUCK!"}
+		},
+		setup: func(t *tutorial, w *World) {
+			w.Config.MaxSaucers = 0
+		},
+		met: func(t *tutorial, w *World) bool { return w.Tick-t.beganAt >= tutorialDoneTicks },
+	},
+}
+
+// tutorial runs tutorialSteps in a world of its own. It is installed as
+// the world's RuleHooks, so its objectives are checked inside Step, after
+// every system has run.
+type tutorial struct {
+	step int
+	// begin is the world's stats when the step began and beganAt its tick.
+	begin   GameStats
+	beganAt int
+	// turned is how far the ship has turned, either way, and lastAngle
+	// its heading at the end of the last tick.
+	turned    float64
+	lastAngle float64
+	// marker is set while the ring to fly through is shown.
+	marker           bool
+	markerX, markerY float64
+	done             bool
+}
+
+// newTutorialWorld creates the tutorial: the ship alone at the centre, no
+// waves or saucers until a step brings them, and hyperspace made safe.
+func newTutorialWorld() (*World, *tutorial) {
+	t := &tutorial{}
+	w := NewWorldWithSeed(1)
+	w.Hooks = t
+	w.Level = 1
+	w.Lives = tutorialLives
+	w.Config.MaxSaucers = 0
+	w.Config.HyperspaceRisk = 0
+	w.Player = SpawnPlayer(w, w.Config.FieldWidth/2, w.Config.FieldHeight/2)
+	t.lastAngle = w.rotations[w.Player].Angle
+	t.begin = w.Stats
+	return w, t
+}
+
+// WaveStart implements RuleHooks: steps spawn their own asteroids.
+func (t *tutorial) WaveStart(*World, int, int) int { return 0 }
+
+// AsteroidDestroyed implements RuleHooks.
+func (t *tutorial) AsteroidDestroyed(_ *World, _ AsteroidSize, _, _ float64, points int) int {
+	return points
+}
+
+// Tick implements RuleHooks, moving on to the next step once the current
+// one's objective is met.
+func (t *tutorial) Tick(w *World) {
+	// With no asteroids every tick clears a wave; the tutorial stays on
+	// the first, and its ship cannot run out of lives.
+	w.Level = 1
+	w.Lives = max(w.Lives, tutorialLives)
+	if rot := w.rotations[w.Player]; rot != nil {
+		t.turned += math.Abs(rot.Angle - t.lastAngle)
+		t.lastAngle = rot.Angle
+	}
+	if t.done || !tutorialSteps[t.step].met(t, w) {
+		return
+	}
+	if t.step == len(tutorialSteps)-1 {
+		t.done = true
+		return
+	}
+	t.step++
+	t.begin, t.beganAt = w.Stats, w.Tick
+	if s := tutorialSteps[t.step]; s.setup != nil {
+		s.setup(t, w)
+	}
+}
+
+// keyName is how the first key bound to a is shown in a prompt.
+func keyName(k *KeyBindings, a Action) string {
+	if len(k[a]) == 0 {
+		return "?"
+	}
+	return keyLabel(k[a][0])
+}
+
+// startTutorial begins the tutorial. Like drills, it is not recorded and
+// does not count toward the career.
+func (g *Game) startTutorial() {
+	g.closeNet()
+	g.ensureSound()
+	g.sound.Reset()
+	g.sound.SetMasterVolume(float64(g.settings.volume) / 10.0)
+	g.world, g.tutorial = newTutorialWorld()
+	g.drills.active = nil
+	g.state = statePlaying
+	g.stepAccum = 0
+	g.buttons.Clear()
+	g.recorder = nil
+	g.newUnlocks = nil
+	g.countsForCareer = false
+	g.runMutators = 0
+	g.runDifficulty = DifficultyNormal
+	g.runMode = ModeClassic
+	g.assisted = false
+}
+
+// checkTutorial returns to the menu once the last step is over.
+func (g *Game) checkTutorial() {
+	if !g.tutorial.done {
+		return
+	}
+	g.sound.StopAll()
+	g.tutorial = nil
+	g.state = stateMenu
+}
+
+// drawTutorial shows the current step's prompt, and the ring to fly
+// through on the playfield.
+func (g *Game) drawTutorial(ui, field canvas.Canvas) {
+	t := g.tutorial
+	if t == nil {
+		return
+	}
+	yellow := color.RGBA{255, 210, 60, 255}
+	for i, line := range tutorialSteps[t.step].prompt(&g.keys) {
+		drawCentered(ui, line, 60+float64(i)*22, 2, yellow)
+	}
+	if t.marker {
+		strokeRing(field, t.markerX, t.markerY, tutorialMarkerRadius, yellow)
+	}
+	progress := fmt.Sprintf("STEP %d OF %d", t.step+1, len(tutorialSteps))
+	drawCentered(ui, progress, 30, 1.5, color.RGBA{150, 150, 150, 255})
+}
@@ -0,0 +1,90 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/matheus3301/asteroids/internal/storage"
+)
+
+func TestTutorial_TurnAdvances(t *testing.T) {
+	w, tut := newTutorialWorld()
+	for i := 0; i < 600 && tut.step == 0; i++ {
+		Step(w, InputState{RotateLeft: true})
+	}
+	if tut.step != 1 {
+		t.Fatalf("a full turn should finish the first step, on step %d", tut.step)
+	}
+	if !tut.marker {
+		t.Error("the drift step should show its ring")
+	}
+}
+
+func TestTutorial_ObjectivesAdvance(t *testing.T) {
+	w, tut := newTutorialWorld()
+	tut.step = 2
+	tut.begin = w.Stats
+	Step(w, InputState{})
+	if tut.step != 2 {
+		t.Fatal("the step should wait for its objective")
+	}
+	w.Stats.AsteroidsDestroyed++
+	Step(w, InputState{})
+	if tut.step != 3 {
+		t.Fatalf("destroying an asteroid should finish the shooting step, on step %d", tut.step)
+	}
+	w.Stats.HyperspaceJumps++
+	Step(w, InputState{})
+	if tut.step != 4 || w.Config.MaxSaucers != 1 {
+		t.Fatalf("a jump should bring on the saucer step, on step %d", tut.step)
+	}
+}
+
+func TestTutorial_NeverEnds(t *testing.T) {
+	w, tut := newTutorialWorld()
+	for i := 0; i < 600; i++ {
+		if i%60 == 0 {
+			killPlayer(w, w.Player, DeathAsteroid)
+		}
+		Step(w, InputState{})
+	}
+	if w.GameOver() || w.Lives < tutorialLives {
+		t.Errorf("the tutorial should keep its lives, has %d", w.Lives)
+	}
+	if w.Level != 1 || len(w.asteroids) != 0 {
+		t.Errorf("no wave should spawn: level %d, %d asteroids", w.Level, len(w.asteroids))
+	}
+	if tut.step != 0 {
+		t.Errorf("dying should not finish a step, on step %d", tut.step)
+	}
+}
+
+func TestTutorial_ReturnsToMenu(t *testing.T) {
+	restore := storage.Override(storage.At(t.TempDir()))
+	defer restore()
+
+	g := New()
+	g.menuCursor = 7
+	g.menuSelect()
+	if g.tutorial == nil || g.state != statePlaying {
+		t.Fatalf("expected the tutorial to start, state %v", g.state)
+	}
+	g.tutorial.step = len(tutorialSteps) - 1
+	g.tutorial.beganAt = g.world.Tick
+	for i := 0; i < tutorialDoneTicks+1 && g.state == statePlaying; i++ {
+		g.StepPlaying(InputState{})
+	}
+	if g.state != stateMenu || g.tutorial != nil {
+		t.Errorf("the last step should return to the menu, state %v", g.state)
+	}
+}
+
+func TestTutorial_PromptsNameBoundKeys(t *testing.T) {
+	keys := DefaultKeyBindings()
+	keys.Bind(ActionShoot, ebiten.KeyF)
+	prompt := strings.Join(tutorialSteps[2].prompt(&keys), " ")
+	if !strings.Contains(prompt, "SHOOT WITH F") {
+		t.Errorf("the prompt should name the bound key: %q", prompt)
+	}
+}
@@ -0,0 +1,212 @@
+package game
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+)
+
+// AsteroidVariant is what a wave asteroid is made of.
+type AsteroidVariant int
+
+const (
+	VariantNormal AsteroidVariant = iota
+	// VariantGolden is worth more and drifts away from the player.
+	VariantGolden
+	// VariantExplosive damages nearby asteroids when destroyed.
+	VariantExplosive
+	// VariantArmored takes an extra hit before it breaks.
+	VariantArmored
+)
+
+const (
+	goldenChance     = 0.02
+	explosiveChance  = 0.04
+	armoredChance    = 0.06
+	goldenMultiplier = 5
+	explosionRadius  = 90.0
+	armoredHits      = 2
+	goldenFleeRange  = 200.0
+	goldenFleeAccel  = 0.02
+	goldenMaxSpeed   = 2.0
+)
+
+func (v AsteroidVariant) String() string {
+	switch v {
+	case VariantGolden:
+		return "golden"
+	case VariantExplosive:
+		return "explosive"
+	case VariantArmored:
+		return "armored"
+	}
+	return "normal"
+}
+
+// rollVariant picks the variant of a new wave asteroid.
+func rollVariant(w *World) AsteroidVariant {
+	r := w.rng.Float64()
+	switch {
+	case r < w.Config.GoldenChance:
+		return VariantGolden
+	case r < w.Config.GoldenChance+w.Config.ExplosiveChance:
+		return VariantExplosive
+	case r < w.Config.GoldenChance+w.Config.ExplosiveChance+w.Config.ArmoredChance:
+		return VariantArmored
+	}
+	return VariantNormal
+}
+
+// variantColor returns the palette colour for an asteroid variant.
+func variantColor(p Palette, v AsteroidVariant) color.RGBA {
+	switch v {
+	case VariantGolden:
+		return p.GoldenAsteroid
+	case VariantExplosive:
+		return p.ExplosiveAsteroid
+	case VariantArmored:
+		return p.ArmoredAsteroid
+	}
+	return p.Asteroid
+}
+
+// asteroidShapePool is how many outlines each variant and size share.
+const asteroidShapePool = 16
+
+// asteroidRadius is the collision radius of each asteroid size.
+var asteroidRadius = [...]float64{SizeLarge: 40, SizeMedium: 20, SizeSmall: 10}
+
+// asteroidShapes holds every asteroid outline, indexed by variant, size and
+// pool slot. They are generated once from a fixed seed, so spawning an
+// asteroid costs one random draw and no allocation, and asteroids share
+// their vertex slices: renderers must treat Renderable.Vertices as
+// read-only.
+var asteroidShapes = newAsteroidShapes()
+
+func newAsteroidShapes() [VariantArmored + 1][len(asteroidRadius)][asteroidShapePool][][2]float64 {
+	var shapes [VariantArmored + 1][len(asteroidRadius)][asteroidShapePool][][2]float64
+	rng := rand.New(rand.NewSource(1))
+	for v := range shapes {
+		for size, radius := range asteroidRadius {
+			for i := range shapes[v][size] {
+				jitter := make([]float64, 8+rng.Intn(5))
+				for j := range jitter {
+					jitter[j] = rng.Float64()
+				}
+				shapes[v][size][i] = variantVertices(AsteroidVariant(v), radius, jitter)
+			}
+		}
+	}
+	return shapes
+}
+
+// variantVertices shapes an asteroid outline so variants can be told apart
+// without colour: golden ones are smooth, explosive ones spiky and armored
+// ones blocky. jitter is in [0, 1) per vertex.
+func variantVertices(v AsteroidVariant, radius float64, jitter []float64) [][2]float64 {
+	verts := make([][2]float64, len(jitter))
+	for i, j := range jitter {
+		ang := float64(i) / float64(len(jitter)) * 2 * math.Pi
+		var r float64
+		switch v {
+		case VariantGolden:
+			r = radius * (0.9 + j*0.1)
+		case VariantExplosive:
+			r = radius * (0.55 + 0.45*float64(i%2))
+		case VariantArmored:
+			r = radius * (0.85 + 0.15*float64((i/2)%2))
+		default:
+			r = radius * (0.7 + j*0.3)
+		}
+		verts[i] = [2]float64{math.Cos(ang) * r, math.Sin(ang) * r}
+	}
+	return verts
+}
+
+// AsteroidVariantSystem makes golden asteroids edge away from the nearest
+// ship.
+func AsteroidVariantSystem(w *World) {
+	for _, e := range sortedEntities(w.asteroids) {
+		if w.asteroids[e].Variant != VariantGolden {
+			continue
+		}
+		pos, vel := w.positions[e], w.velocities[e]
+		_, ppos := nearestPlayer(w, pos)
+		if ppos == nil || vel == nil {
+			continue
+		}
+		dx, dy := w.Field().Delta(ppos.X, ppos.Y, pos.X, pos.Y)
+		d := math.Hypot(dx, dy)
+		if d == 0 || d > goldenFleeRange {
+			continue
+		}
+		vel.X += dx / d * goldenFleeAccel
+		vel.Y += dy / d * goldenFleeAccel
+		if speed := math.Hypot(vel.X, vel.Y); speed > goldenMaxSpeed {
+			vel.X = vel.X / speed * goldenMaxSpeed
+			vel.Y = vel.Y / speed * goldenMaxSpeed
+		}
+	}
+}
+
+// shootAsteroid applies a hit from shot, fired by owner, to asteroid e.
+// Armour absorbs a hit; otherwise the asteroid scores, splits and is
+// destroyed, and an explosive one passes a hit on to every asteroid in its
+// blast. Every asteroid destroyed is recorded as a kill by shot.
+func shootAsteroid(w *World, e, shot, owner Entity) {
+	queue := []Entity{e}
+	for len(queue) > 0 {
+		e, queue = queue[0], queue[1:]
+		ast, apos := w.asteroids[e], w.positions[e]
+		if ast == nil || apos == nil {
+			continue
+		}
+
+		if ast.Armor > 0 {
+			ast.Armor--
+			for i := 0; i < 3; i++ {
+				SpawnParticle(w, apos.X, apos.Y)
+			}
+			w.SoundQueue = append(w.SoundQueue, SoundExplosionSmall)
+			continue
+		}
+
+		points := asteroidPoints(w, ast.Size, ast.Variant, apos.X, apos.Y)
+		w.Score += points
+		recordKill(w, Kill{Kind: KillAsteroid, Target: e, Shot: shot, Owner: owner, Points: points})
+		checkExtraLife(w)
+		checkWeaponTier(w)
+
+		particles := 8
+		if ast.Variant == VariantExplosive {
+			particles = 24
+			for _, o := range sortedEntities(w.asteroids) {
+				opos := w.positions[o]
+				if o == e || opos == nil {
+					continue
+				}
+				if w.Field().Distance(apos.X, apos.Y, opos.X, opos.Y) <= w.Config.ExplosionRadius {
+					queue = append(queue, o)
+				}
+			}
+		}
+		for i := 0; i < particles; i++ {
+			SpawnParticle(w, apos.X, apos.Y)
+		}
+
+		// The asteroid goes before its fragments so it does not count
+		// against the cap.
+		w.Destroy(e)
+		if ast.Size != SizeSmall {
+			for i := 0; i < w.Config.AsteroidFragments; i++ {
+				if asteroidRoom(w) {
+					SpawnAsteroid(w, apos.X, apos.Y, ast.Size+1)
+				}
+			}
+		}
+
+		w.SoundQueue = append(w.SoundQueue, soundForSize(ast.Size))
+		w.Stats.AsteroidsDestroyed++
+		addCombo(w)
+	}
+}
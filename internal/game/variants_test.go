@@ -0,0 +1,134 @@
+package game
+
+import "testing"
+
+func TestShootAsteroid_GoldenScoresMore(t *testing.T) {
+	w := NewWorld()
+	a := spawnAsteroidVariant(w, 100, 100, SizeSmall, VariantGolden)
+
+	shootAsteroid(w, a, 0, 0)
+
+	if w.Score != 100*goldenMultiplier {
+		t.Errorf("expected %d points, got %d", 100*goldenMultiplier, w.Score)
+	}
+}
+
+func TestShootAsteroid_ArmoredTakesTwoHits(t *testing.T) {
+	w := NewWorld()
+	a := spawnAsteroidVariant(w, 100, 100, SizeLarge, VariantArmored)
+
+	shootAsteroid(w, a, 0, 0)
+	if !w.Alive(a) || w.Score != 0 {
+		t.Fatal("the first hit should only strip the armour")
+	}
+	shootAsteroid(w, a, 0, 0)
+	if w.Alive(a) {
+		t.Error("the second hit should break the asteroid")
+	}
+	if w.AsteroidCount() != 2 {
+		t.Errorf("expected the asteroid to split in two, got %d", w.AsteroidCount())
+	}
+}
+
+func TestShootAsteroid_ExplosiveDamagesNeighbours(t *testing.T) {
+	w := NewWorld()
+	bomb := spawnAsteroidVariant(w, 100, 100, SizeSmall, VariantExplosive)
+	near := SpawnAsteroid(w, 150, 100, SizeSmall)
+	far := SpawnAsteroid(w, 400, 400, SizeSmall)
+
+	shootAsteroid(w, bomb, 0, 0)
+
+	if w.Alive(bomb) || w.Alive(near) {
+		t.Error("the explosion should destroy the nearby asteroid")
+	}
+	if !w.Alive(far) {
+		t.Error("asteroids outside the blast should survive")
+	}
+	if w.Score != 200 {
+		t.Errorf("both destroyed asteroids should score, got %d", w.Score)
+	}
+}
+
+func TestShootAsteroid_ChainedExplosions(t *testing.T) {
+	w := NewWorld()
+	a := spawnAsteroidVariant(w, 100, 100, SizeSmall, VariantExplosive)
+	b := spawnAsteroidVariant(w, 180, 100, SizeSmall, VariantExplosive)
+	c := SpawnAsteroid(w, 260, 100, SizeSmall)
+
+	shootAsteroid(w, a, 0, 0)
+
+	if w.Alive(b) || w.Alive(c) {
+		t.Error("an explosion should set off explosive neighbours")
+	}
+}
+
+func TestAsteroidVariantSystem_GoldenFlees(t *testing.T) {
+	w := NewWorld()
+	w.Player = SpawnPlayer(w, 100, 100)
+	a := spawnAsteroidVariant(w, 150, 100, SizeLarge, VariantGolden)
+	w.velocities[a].X, w.velocities[a].Y = 0, 0
+
+	AsteroidVariantSystem(w)
+
+	if w.velocities[a].X <= 0 {
+		t.Errorf("golden asteroid should move away from the ship, vx=%v", w.velocities[a].X)
+	}
+}
+
+func TestRollVariant_RespectsChances(t *testing.T) {
+	w := NewWorldWithSeed(1)
+	w.Config.GoldenChance, w.Config.ExplosiveChance, w.Config.ArmoredChance = 0, 0, 0
+	for i := 0; i < 100; i++ {
+		if v := rollVariant(w); v != VariantNormal {
+			t.Fatalf("zero chances should only roll normal asteroids, got %v", v)
+		}
+	}
+	w.Config.ArmoredChance = 1
+	if v := rollVariant(w); v != VariantArmored {
+		t.Errorf("expected armored, got %v", v)
+	}
+}
+
+func TestObserve_OffsetAndClosestApproach(t *testing.T) {
+	w := NewWorld()
+	w.Player = SpawnPlayer(w, 790, 300)
+	a := SpawnAsteroid(w, 40, 330, SizeLarge)
+	w.velocities[a].X, w.velocities[a].Y = -2, 0
+
+	o := Observe(w).Asteroids[0]
+
+	if o.DX != 50 || o.DY != 30 {
+		t.Errorf("expected offset (50, 30) across the edge, got (%v, %v)", o.DX, o.DY)
+	}
+	if o.CPATicks != 25 || o.CPADist != 30 {
+		t.Errorf("expected to pass 30px away in 25 ticks, got %v ticks, %vpx", o.CPATicks, o.CPADist)
+	}
+}
+
+func TestObserve_AsteroidVariant(t *testing.T) {
+	w := NewWorld()
+	spawnAsteroidVariant(w, 100, 100, SizeLarge, VariantExplosive)
+
+	obs := Observe(w)
+
+	if len(obs.Asteroids) != 1 || obs.Asteroids[0].Variant != int(VariantExplosive) {
+		t.Errorf("expected the explosive variant in the observation, got %+v", obs.Asteroids)
+	}
+}
+
+func TestSpawnAsteroid_SharesPooledShapes(t *testing.T) {
+	w := NewWorld()
+	seen := map[*[2]float64]bool{}
+	for i := 0; i < 200; i++ {
+		e := spawnAsteroidVariant(w, 100, 100, SizeMedium, VariantExplosive)
+		seen[&w.renderables[e].Vertices[0]] = true
+	}
+	if len(seen) > asteroidShapePool {
+		t.Errorf("expected at most %d distinct outlines, got %d", asteroidShapePool, len(seen))
+	}
+	for _, shape := range asteroidShapes[VariantExplosive][SizeMedium] {
+		if n := len(shape); n < 8 || n > 12 {
+			t.Errorf("outline has %d vertices, want 8 to 12", n)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package game
+
+// maxVoices is how many one-shot sounds can play at once. Chain explosions
+// can queue dozens in a few ticks, and mixing them all makes the audio
+// break up.
+const maxVoices = 16
+
+// Sound priorities decide which one-shots give way when every voice is
+// busy.
+const (
+	// priorityLow is for the constant stream of shots, small explosions
+	// and menu blips.
+	priorityLow = iota
+	// priorityNormal is for larger explosions, pickups and the beat.
+	priorityNormal
+	// priorityTop is for the death and extra-life sounds, which must
+	// always be heard.
+	priorityTop
+)
+
+// soundPriority is the priority of a one-shot sound event.
+func soundPriority(e SoundEvent) int {
+	switch e {
+	case SoundPlayerDeath, SoundExtraLife, SoundLifeBonus:
+		return priorityTop
+	case SoundFire, SoundExplosionSmall:
+		return priorityLow
+	}
+	return priorityNormal
+}
+
+// voice is a playing one-shot. *audio.Player satisfies it.
+type voice interface {
+	IsPlaying() bool
+	Close() error
+}
+
+// voicePool limits how many one-shots play at once. Voices are kept
+// oldest first.
+type voicePool struct {
+	voices     []voice
+	priorities []int
+}
+
+// claim makes room for a sound of the given priority and reports whether
+// it may play. Finished voices are let go first; if every voice is still
+// busy, the oldest of the lowest priority is stopped, as long as it is
+// not more important than the new sound. Otherwise the new sound is
+// dropped.
+func (vp *voicePool) claim(priority int) bool {
+	vp.prune()
+	if len(vp.voices) < maxVoices {
+		return true
+	}
+	steal := -1
+	for i, p := range vp.priorities {
+		if p <= priority && (steal < 0 || p < vp.priorities[steal]) {
+			steal = i
+		}
+	}
+	if steal < 0 {
+		return false
+	}
+	_ = vp.voices[steal].Close()
+	vp.remove(steal)
+	return true
+}
+
+// add records a voice that has started playing.
+func (vp *voicePool) add(v voice, priority int) {
+	vp.voices = append(vp.voices, v)
+	vp.priorities = append(vp.priorities, priority)
+}
+
+// prune lets go of voices that have finished.
+func (vp *voicePool) prune() {
+	for i := len(vp.voices) - 1; i >= 0; i-- {
+		if !vp.voices[i].IsPlaying() {
+			_ = vp.voices[i].Close()
+			vp.remove(i)
+		}
+	}
+}
+
+func (vp *voicePool) remove(i int) {
+	vp.voices = append(vp.voices[:i], vp.voices[i+1:]...)
+	vp.priorities = append(vp.priorities[:i], vp.priorities[i+1:]...)
+}
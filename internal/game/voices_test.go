@@ -0,0 +1,83 @@
+package game
+
+import "testing"
+
+type fakeVoice struct {
+	playing, closed bool
+}
+
+func (v *fakeVoice) IsPlaying() bool { return v.playing && !v.closed }
+func (v *fakeVoice) Close() error    { v.closed = true; return nil }
+
+// fill starts maxVoices voices of priority and returns them.
+func fill(vp *voicePool, priority int) []*fakeVoice {
+	vs := make([]*fakeVoice, maxVoices)
+	for i := range vs {
+		if !vp.claim(priority) {
+			panic("no voice free while filling")
+		}
+		vs[i] = &fakeVoice{playing: true}
+		vp.add(vs[i], priority)
+	}
+	return vs
+}
+
+func TestVoicePool_ReusesFinishedVoices(t *testing.T) {
+	var vp voicePool
+	vs := fill(&vp, priorityLow)
+
+	vs[3].playing = false
+	if !vp.claim(priorityLow) {
+		t.Fatal("a finished voice should free a slot")
+	}
+	if !vs[3].closed || len(vp.voices) != maxVoices-1 {
+		t.Errorf("finished voice not let go: closed=%v voices=%d", vs[3].closed, len(vp.voices))
+	}
+}
+
+func TestVoicePool_StealsOldestLowestPriority(t *testing.T) {
+	var vp voicePool
+	vs := fill(&vp, priorityNormal)
+	vp.voices[5].Close()
+	vp.remove(5)
+	low := &fakeVoice{playing: true}
+	vp.add(low, priorityLow)
+
+	if !vp.claim(priorityNormal) {
+		t.Fatal("a normal sound should take a low one's voice")
+	}
+	if !low.closed {
+		t.Error("the low-priority voice should have been stolen")
+	}
+	vp.add(&fakeVoice{playing: true}, priorityNormal)
+
+	if !vp.claim(priorityNormal) {
+		t.Fatal("a normal sound should take the oldest normal one's voice")
+	}
+	if !vs[0].closed || vs[1].closed {
+		t.Error("the oldest voice of the lowest priority should have been stolen")
+	}
+}
+
+func TestVoicePool_DropsLessImportantSounds(t *testing.T) {
+	var vp voicePool
+	fill(&vp, priorityTop)
+
+	if vp.claim(priorityLow) {
+		t.Error("a shot should not interrupt a full set of top-priority sounds")
+	}
+	if !vp.claim(priorityTop) {
+		t.Error("a top-priority sound should always play")
+	}
+}
+
+func TestSoundPriority_DeathAndExtraLifeOnTop(t *testing.T) {
+	for _, e := range []SoundEvent{SoundPlayerDeath, SoundExtraLife, SoundLifeBonus} {
+		if soundPriority(e) != priorityTop {
+			t.Errorf("event %d should have top priority", e)
+		}
+	}
+	if soundPriority(SoundFire) >= soundPriority(SoundExplosionLarge) {
+		t.Error("shots should give way to large explosions")
+	}
+}
@@ -0,0 +1,82 @@
+package game
+
+// WeaponTier is how strong a player's gun is. Tiers are earned at score
+// milestones and kept until the game ends.
+type WeaponTier int
+
+const (
+	// WeaponSingle is the standard gun.
+	WeaponSingle WeaponTier = iota + 1
+	// WeaponRapid raises the bullet cap and shortens the shot cooldown.
+	WeaponRapid
+	// WeaponSpread is WeaponRapid firing three bullets in a fan.
+	WeaponSpread
+)
+
+const (
+	weaponRapidScore  = 5_000
+	weaponSpreadScore = 15_000
+	rapidMaxBullets   = 8
+	spreadAngle       = 0.2 // radians between the bullets of a spread
+
+	// shotCooldown and rapidShotCooldown are the ticks a held fire button
+	// waits between shots, so autofire cannot empty the bullet cap at once.
+	shotCooldown      = 8
+	rapidShotCooldown = 4
+)
+
+func (t WeaponTier) String() string {
+	switch t {
+	case WeaponRapid:
+		return "RAPID"
+	case WeaponSpread:
+		return "SPREAD"
+	}
+	return "SINGLE"
+}
+
+// UpgradeWeapon raises e's weapon one tier, up to WeaponSpread.
+func UpgradeWeapon(w *World, e Entity) {
+	if pc := w.players[e]; pc != nil && pc.Weapon < WeaponSpread {
+		pc.Weapon++
+	}
+}
+
+// checkWeaponTier upgrades every player's weapon to the tier the score has
+// reached. A milestone of zero is never reached.
+func checkWeaponTier(w *World) {
+	tier := WeaponSingle
+	if w.Config.WeaponSpreadScore > 0 && w.Score >= w.Config.WeaponSpreadScore {
+		tier = WeaponSpread
+	} else if w.Config.WeaponRapidScore > 0 && w.Score >= w.Config.WeaponRapidScore {
+		tier = WeaponRapid
+	}
+	for _, pc := range w.players {
+		pc.Weapon = max(pc.Weapon, tier)
+	}
+}
+
+// fireWeapon shoots e's weapon, respecting the bullet cap and cooldown, and
+// returns how many bullets were spawned.
+func fireWeapon(w *World, e Entity, pc *PlayerControl) int {
+	limit, cooldown := w.Config.MaxBullets, w.Config.ShotCooldown
+	if pc.Weapon >= WeaponRapid {
+		limit, cooldown = w.Config.RapidMaxBullets, w.Config.RapidShotCooldown
+	}
+	room := limit - w.BulletCount()
+	if room <= 0 {
+		return 0
+	}
+
+	angle := w.rotations[e].Angle
+	angles := []float64{angle}
+	if pc.Weapon >= WeaponSpread {
+		angles = append(angles, angle-w.Config.SpreadAngle, angle+w.Config.SpreadAngle)
+	}
+	n := min(room, len(angles))
+	for _, a := range angles[:n] {
+		spawnBullet(w, e, a)
+	}
+	pc.ShotCooldown = cooldown
+	return n
+}
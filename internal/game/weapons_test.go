@@ -0,0 +1,113 @@
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCheckWeaponTier_Milestones(t *testing.T) {
+	w := NewWorld()
+	e := SpawnPlayer(w, 400, 300)
+
+	w.Score = weaponRapidScore - 1
+	checkWeaponTier(w)
+	if got := w.players[e].Weapon; got != WeaponSingle {
+		t.Errorf("below the first milestone: got %v", got)
+	}
+	w.Score = weaponRapidScore
+	checkWeaponTier(w)
+	if got := w.players[e].Weapon; got != WeaponRapid {
+		t.Errorf("at the first milestone: got %v", got)
+	}
+	w.Score = weaponSpreadScore
+	checkWeaponTier(w)
+	if got := w.players[e].Weapon; got != WeaponSpread {
+		t.Errorf("at the second milestone: got %v", got)
+	}
+}
+
+func TestCheckWeaponTier_ZeroDisables(t *testing.T) {
+	w := NewWorld()
+	w.Config.WeaponRapidScore = 0
+	w.Config.WeaponSpreadScore = 0
+	e := SpawnPlayer(w, 400, 300)
+	w.Score = 1_000_000
+	checkWeaponTier(w)
+
+	if got := w.players[e].Weapon; got != WeaponSingle {
+		t.Errorf("disabled milestones should keep the single gun, got %v", got)
+	}
+}
+
+func TestShootingSystem_RapidRaisesCap(t *testing.T) {
+	w := NewWorld()
+	e := SpawnPlayer(w, 400, 300)
+	w.players[e].Weapon = WeaponRapid
+	for i := 0; i < MaxPlayerBullets; i++ {
+		SpawnBullet(w, e)
+	}
+	w.players[e].ShootPressed = true
+
+	ShootingSystem(w)
+
+	if w.BulletCount() != MaxPlayerBullets+1 {
+		t.Errorf("rapid fire should exceed the standard cap, got %d bullets", w.BulletCount())
+	}
+}
+
+func TestShootingSystem_SpreadFiresThree(t *testing.T) {
+	w := NewWorld()
+	e := SpawnPlayer(w, 400, 300)
+	w.players[e].Weapon = WeaponSpread
+	w.players[e].ShootPressed = true
+
+	ShootingSystem(w)
+
+	if w.BulletCount() != 3 {
+		t.Fatalf("expected a 3-way spread, got %d bullets", w.BulletCount())
+	}
+	if w.Stats.ShotsFired != 3 {
+		t.Errorf("expected 3 shots counted, got %d", w.Stats.ShotsFired)
+	}
+	angles := map[float64]bool{}
+	for b := range w.bullets {
+		v := w.velocities[b]
+		angles[math.Round(math.Atan2(v.Y, v.X)*1000)] = true
+	}
+	if len(angles) != 3 {
+		t.Errorf("spread bullets should fly in 3 directions, got %d", len(angles))
+	}
+}
+
+func TestShootingSystem_Cooldown(t *testing.T) {
+	w := NewWorld()
+	w.Config.ShotCooldown = 2
+	e := SpawnPlayer(w, 400, 300)
+	w.players[e].ShootPressed = true
+
+	for i := 0; i < 3; i++ {
+		ShootingSystem(w)
+	}
+	if w.BulletCount() != 1 {
+		t.Errorf("cooldown should block the next two ticks, got %d bullets", w.BulletCount())
+	}
+	ShootingSystem(w)
+	if w.BulletCount() != 2 {
+		t.Errorf("expected a second shot after the cooldown, got %d bullets", w.BulletCount())
+	}
+}
+
+func TestShootingSystem_AutofireIsPaced(t *testing.T) {
+	w := NewGameWorld(1)
+	held := InputState{Shoot: true}
+
+	for i := 0; i < 4; i++ {
+		Step(w, held)
+	}
+	if w.BulletCount() != 1 {
+		t.Errorf("holding fire for 4 ticks should fire once, got %d bullets", w.BulletCount())
+	}
+	if obs := Observe(w); obs.Player.ShotCooldown == 0 {
+		t.Error("the observation should show the weapon cooling down")
+	}
+}
@@ -0,0 +1,87 @@
+// Package geom holds the vector maths for a wrapping playfield: offsets and
+// distances measured across the edges, closest approach and intercepts.
+package geom
+
+import "math"
+
+// WrapDelta shortens d to the nearest equivalent offset on a wrapping axis
+// of the given size.
+func WrapDelta(d, size float64) float64 {
+	if d > size/2 {
+		return d - size
+	}
+	if d < -size/2 {
+		return d + size
+	}
+	return d
+}
+
+// Torus is a W by H playfield whose opposite edges meet.
+type Torus struct {
+	W, H float64
+}
+
+// Delta returns the shortest offset from (ax, ay) to (bx, by), which may
+// cross an edge.
+func (t Torus) Delta(ax, ay, bx, by float64) (dx, dy float64) {
+	return WrapDelta(bx-ax, t.W), WrapDelta(by-ay, t.H)
+}
+
+// Wrap brings (x, y) back onto the playfield.
+func (t Torus) Wrap(x, y float64) (float64, float64) {
+	x, y = math.Mod(x, t.W), math.Mod(y, t.H)
+	if x < 0 {
+		x += t.W
+	}
+	if y < 0 {
+		y += t.H
+	}
+	return x, y
+}
+
+// Distance is the length of Delta.
+func (t Torus) Distance(ax, ay, bx, by float64) float64 {
+	return math.Hypot(t.Delta(ax, ay, bx, by))
+}
+
+// ClosestApproach returns when and how near two objects come, given the
+// second's position (px, py) and velocity (vx, vy) relative to the first.
+// Objects already moving apart have their closest approach now, at t = 0.
+func ClosestApproach(px, py, vx, vy float64) (t, dist float64) {
+	if v2 := vx*vx + vy*vy; v2 > 0 {
+		t = math.Max(0, -(px*vx+py*vy)/v2)
+	}
+	return t, math.Hypot(px+vx*t, py+vy*t)
+}
+
+// Intercept returns when a projectile fired now at speed meets a target at
+// (px, py) moving at (vx, vy), both relative to the shooter. ok is false
+// when the projectile can never catch the target.
+func Intercept(px, py, vx, vy, speed float64) (t float64, ok bool) {
+	// Solve |p + v t| = speed t for the smallest t >= 0.
+	a := vx*vx + vy*vy - speed*speed
+	b := 2 * (px*vx + py*vy)
+	c := px*px + py*py
+	if math.Abs(a) < 1e-9 {
+		if b >= 0 {
+			return 0, c == 0
+		}
+		return -c / b, true
+	}
+	disc := b*b - 4*a*c
+	if disc < 0 {
+		return 0, false
+	}
+	sq := math.Sqrt(disc)
+	t1, t2 := (-b-sq)/(2*a), (-b+sq)/(2*a)
+	if t1 > t2 {
+		t1, t2 = t2, t1
+	}
+	if t1 >= 0 {
+		return t1, true
+	}
+	if t2 >= 0 {
+		return t2, true
+	}
+	return 0, false
+}
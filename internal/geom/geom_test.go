@@ -0,0 +1,76 @@
+package geom
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWrapDelta(t *testing.T) {
+	cases := []struct{ d, want float64 }{
+		{10, 10},
+		{-10, -10},
+		{90, -10},
+		{-90, 10},
+	}
+	for _, c := range cases {
+		if got := WrapDelta(c.d, 100); got != c.want {
+			t.Errorf("WrapDelta(%v, 100) = %v, want %v", c.d, got, c.want)
+		}
+	}
+}
+
+func TestTorus_DistanceAcrossEdge(t *testing.T) {
+	tor := Torus{W: 800, H: 600}
+	if d := tor.Distance(790, 300, 10, 300); d != 20 {
+		t.Errorf("expected 20 across the right edge, got %v", d)
+	}
+	dx, dy := tor.Delta(400, 590, 400, 10)
+	if dx != 0 || dy != 20 {
+		t.Errorf("expected (0, 20) across the bottom edge, got (%v, %v)", dx, dy)
+	}
+}
+
+func TestTorus_Wrap(t *testing.T) {
+	tor := Torus{W: 800, H: 600}
+	if x, y := tor.Wrap(-10, 1250); x != 790 || y != 50 {
+		t.Errorf("expected (790, 50), got (%v, %v)", x, y)
+	}
+	if x, y := tor.Wrap(400, 300); x != 400 || y != 300 {
+		t.Errorf("points on the playfield should not move, got (%v, %v)", x, y)
+	}
+}
+
+func TestClosestApproach(t *testing.T) {
+	// Passing 30 below, 10 ticks away.
+	tm, d := ClosestApproach(-50, 30, 5, 0)
+	if tm != 10 || d != 30 {
+		t.Errorf("got t=%v d=%v, want t=10 d=30", tm, d)
+	}
+	// Moving away: closest is now.
+	tm, d = ClosestApproach(50, 0, 5, 0)
+	if tm != 0 || d != 50 {
+		t.Errorf("got t=%v d=%v, want t=0 d=50", tm, d)
+	}
+}
+
+func TestIntercept(t *testing.T) {
+	// Stationary target 100 away at speed 10.
+	if tm, ok := Intercept(100, 0, 0, 0, 10); !ok || tm != 10 {
+		t.Errorf("got t=%v ok=%v, want 10", tm, ok)
+	}
+
+	// Crossing target: the shot must land where the target will be.
+	px, py, vx, vy, speed := 100.0, 0.0, 0.0, 3.0, 5.0
+	tm, ok := Intercept(px, py, vx, vy, speed)
+	if !ok {
+		t.Fatal("expected an intercept")
+	}
+	if miss := math.Hypot(px+vx*tm, py+vy*tm) - speed*tm; math.Abs(miss) > 1e-9 {
+		t.Errorf("shot misses by %v", miss)
+	}
+
+	// A faster target running away can't be caught.
+	if _, ok := Intercept(100, 0, 10, 0, 5); ok {
+		t.Error("expected no intercept for a faster fleeing target")
+	}
+}
@@ -0,0 +1,135 @@
+// Package leaderboard keeps the best games by something other than score:
+// time survived, wave reached and accuracy. It is a JSON file in the data
+// directory, kept whether or not stats logging is on.
+package leaderboard
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/matheus3301/asteroids/internal/storage"
+)
+
+// FileName is the leaderboards file inside the data directory.
+const FileName = "leaderboards.json"
+
+const (
+	// BoardSize is how many games each leaderboard keeps.
+	BoardSize = 5
+	// MinAccuracyShots is how many shots that hit or missed a game needs
+	// to enter the accuracy leaderboard, so a single lucky shot does not
+	// top it.
+	MinAccuracyShots = 50
+)
+
+// Game is what one finished game is ranked by.
+type Game struct {
+	Score int
+	Level int
+	Ticks int
+	// ShotsHit and ShotsMissed count shots that hit something and that
+	// ran out of life first.
+	ShotsHit, ShotsMissed int
+}
+
+// Accuracy is the share of the game's shots that hit something, out of
+// those that hit or missed. Shots still in flight when the game ended count
+// neither way, and an explosion's extra kills do not count as hits.
+func (g Game) Accuracy() float64 {
+	if g.ShotsHit+g.ShotsMissed == 0 {
+		return 0
+	}
+	return float64(g.ShotsHit) / float64(g.ShotsHit+g.ShotsMissed)
+}
+
+// Entry is one game on a leaderboard: the value it is ranked by and, for
+// context, its score.
+type Entry struct {
+	Value float64 `json:"value"`
+	Score int     `json:"score"`
+}
+
+// Boards ranks games by something other than score, best first.
+type Boards struct {
+	// Survival ranks games by how long they lasted, in ticks.
+	Survival []Entry `json:"survival"`
+	// Wave ranks games by the highest wave reached.
+	Wave []Entry `json:"wave"`
+	// Accuracy ranks games of at least MinAccuracyShots shots by the share
+	// of shots that hit something.
+	Accuracy []Entry `json:"accuracy"`
+}
+
+// Add enters the game on every leaderboard it qualifies for.
+func (b *Boards) Add(g Game) {
+	b.Survival = insert(b.Survival, Entry{Value: float64(g.Ticks), Score: g.Score})
+	b.Wave = insert(b.Wave, Entry{Value: float64(g.Level), Score: g.Score})
+	if g.ShotsHit+g.ShotsMissed >= MinAccuracyShots {
+		b.Accuracy = insert(b.Accuracy, Entry{Value: g.Accuracy(), Score: g.Score})
+	}
+}
+
+// Empty reports whether no game has been entered yet.
+func (b *Boards) Empty() bool {
+	return len(b.Survival) == 0
+}
+
+// insert places e in board, kept best first and at most BoardSize long.
+// Ties go to the game recorded first.
+func insert(board []Entry, e Entry) []Entry {
+	i := len(board)
+	for i > 0 && board[i-1].Value < e.Value {
+		i--
+	}
+	if i >= BoardSize {
+		return board
+	}
+	board = append(board, Entry{})
+	copy(board[i+1:], board[i:])
+	board[i] = e
+	return board[:min(len(board), BoardSize)]
+}
+
+// Path returns where the leaderboards are stored.
+func Path() (string, error) {
+	dirs, err := storage.Default()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dirs.Data, FileName), nil
+}
+
+// Load reads the leaderboards at path. A missing file is empty boards.
+func Load(path string) (*Boards, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Boards{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	l := &Boards{}
+	if err := json.Unmarshal(b, l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Save writes the leaderboards to path, replacing them atomically.
+func (b *Boards) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := storage.Ensure(filepath.Dir(path)); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,65 @@
+package leaderboard
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestBoards_KeepTheBestFirst(t *testing.T) {
+	var b Boards
+	for i, ticks := range []int{600, 3000, 1200, 3000, 100, 2400, 1800} {
+		b.Add(Game{Score: i, Ticks: ticks, Level: 1})
+	}
+
+	if len(b.Survival) != BoardSize {
+		t.Fatalf("expected %d entries, got %d", BoardSize, len(b.Survival))
+	}
+	want := []Entry{{3000, 1}, {3000, 3}, {2400, 5}, {1800, 6}, {1200, 2}}
+	for i, e := range want {
+		if b.Survival[i] != e {
+			t.Errorf("entry %d = %+v, want %+v", i, b.Survival[i], e)
+		}
+	}
+}
+
+func TestBoards_AccuracyNeedsEnoughShots(t *testing.T) {
+	var b Boards
+	b.Add(Game{ShotsHit: 1})
+	b.Add(Game{Score: 3, ShotsHit: MinAccuracyShots - 1})
+	b.Add(Game{Score: 7, ShotsHit: MinAccuracyShots, ShotsMissed: MinAccuracyShots})
+
+	if len(b.Accuracy) != 1 || b.Accuracy[0].Score != 7 {
+		t.Fatalf("expected only the long game on the board, got %+v", b.Accuracy)
+	}
+	if b.Accuracy[0].Value != 0.5 {
+		t.Errorf("expected an accuracy of 0.5, got %v", b.Accuracy[0].Value)
+	}
+}
+
+func TestGame_AccuracyCountsShots(t *testing.T) {
+	if got := (Game{ShotsHit: 2, ShotsMissed: 6}).Accuracy(); got != 0.25 {
+		t.Errorf("accuracy = %v, want 0.25", got)
+	}
+	if (Game{}).Accuracy() != 0 {
+		t.Error("a game whose shots never landed or expired should have no accuracy")
+	}
+}
+
+func TestBoards_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	if b, err := Load(path); err != nil || !b.Empty() {
+		t.Fatalf("a missing file should be empty boards: %+v, %v", b, err)
+	}
+	b := &Boards{}
+	b.Add(Game{Score: 900, Level: 6, Ticks: 4000})
+	if err := b.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Wave) != 1 || got.Wave[0] != (Entry{6, 900}) {
+		t.Errorf("wave board not kept: %+v", got)
+	}
+}
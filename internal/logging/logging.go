@@ -0,0 +1,154 @@
+// Package logging provides leveled, structured logging on top of log/slog.
+//
+// Every package asks for a logger with For("module"). Output format and
+// levels are configured once per process from a spec string such as
+//
+//	info,replay=debug,sound=warn
+//
+// where the bare level is the default and module=level pairs override it.
+// Loggers obtained before Setup runs pick up the configuration when they
+// next log, so packages can keep their logger in a package-level variable.
+package logging
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// EnvVar is consulted for the default log spec when no flag is given.
+const EnvVar = "ASTEROIDS_LOG"
+
+type config struct {
+	base    slog.Handler
+	level   slog.Level
+	modules map[string]slog.Level
+}
+
+var current atomic.Pointer[config]
+
+func init() {
+	current.Store(&config{
+		base:  slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}),
+		level: slog.LevelInfo,
+	})
+}
+
+// Setup installs the logging configuration described by spec, writing text
+// or JSON records to out.
+func Setup(spec string, json bool, out io.Writer) error {
+	level, modules, err := ParseSpec(spec)
+	if err != nil {
+		return err
+	}
+	opts := &slog.HandlerOptions{Level: slog.LevelDebug}
+	var base slog.Handler
+	if json {
+		base = slog.NewJSONHandler(out, opts)
+	} else {
+		base = slog.NewTextHandler(out, opts)
+	}
+	current.Store(&config{base: base, level: level, modules: modules})
+	return nil
+}
+
+// ParseSpec parses a spec like "warn,replay=debug" into a default level and
+// per-module overrides. An empty spec means info for everything.
+func ParseSpec(spec string) (slog.Level, map[string]slog.Level, error) {
+	level := slog.LevelInfo
+	modules := make(map[string]slog.Level)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, lvl, scoped := strings.Cut(part, "=")
+		if !scoped {
+			lvl = name
+		}
+		var l slog.Level
+		if err := l.UnmarshalText([]byte(lvl)); err != nil {
+			return 0, nil, fmt.Errorf("log spec %q: %w", part, err)
+		}
+		if scoped {
+			modules[strings.TrimSpace(name)] = l
+		} else {
+			level = l
+		}
+	}
+	return level, modules, nil
+}
+
+// For returns a logger for the named module.
+func For(module string) *slog.Logger {
+	return slog.New(&moduleHandler{module: module})
+}
+
+// Fatal logs msg at error level and exits the process.
+func Fatal(l *slog.Logger, msg string, args ...any) {
+	l.Error(msg, args...)
+	os.Exit(1)
+}
+
+// Flags holds the command-line logging options of a binary.
+type Flags struct {
+	spec *string
+	json *bool
+}
+
+// RegisterFlags adds -log and -log-json to fs.
+func RegisterFlags(fs *flag.FlagSet) *Flags {
+	return &Flags{
+		spec: fs.String("log", os.Getenv(EnvVar), "log levels, e.g. info,replay=debug (default $"+EnvVar+")"),
+		json: fs.Bool("log-json", false, "write logs as JSON lines"),
+	}
+}
+
+// Setup applies the parsed flags, logging to stderr.
+func (f *Flags) Setup() error {
+	return Setup(*f.spec, *f.json, os.Stderr)
+}
+
+// moduleHandler filters by the module's configured level and forwards to the
+// current base handler. Attributes and groups are replayed onto the base
+// handler at log time so configuration changes apply to existing loggers.
+type moduleHandler struct {
+	module string
+	wraps  []func(slog.Handler) slog.Handler
+}
+
+func (h *moduleHandler) Enabled(_ context.Context, l slog.Level) bool {
+	cfg := current.Load()
+	threshold, ok := cfg.modules[h.module]
+	if !ok {
+		threshold = cfg.level
+	}
+	return l >= threshold
+}
+
+func (h *moduleHandler) Handle(ctx context.Context, r slog.Record) error {
+	base := current.Load().base.WithAttrs([]slog.Attr{slog.String("module", h.module)})
+	for _, wrap := range h.wraps {
+		base = wrap(base)
+	}
+	return base.Handle(ctx, r)
+}
+
+func (h *moduleHandler) with(wrap func(slog.Handler) slog.Handler) *moduleHandler {
+	wraps := make([]func(slog.Handler) slog.Handler, len(h.wraps), len(h.wraps)+1)
+	copy(wraps, h.wraps)
+	return &moduleHandler{module: h.module, wraps: append(wraps, wrap)}
+}
+
+func (h *moduleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.with(func(b slog.Handler) slog.Handler { return b.WithAttrs(attrs) })
+}
+
+func (h *moduleHandler) WithGroup(name string) slog.Handler {
+	return h.with(func(b slog.Handler) slog.Handler { return b.WithGroup(name) })
+}
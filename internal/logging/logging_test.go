@@ -0,0 +1,74 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestParseSpec(t *testing.T) {
+	level, modules, err := ParseSpec("warn, replay=debug,sound=error")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if level != slog.LevelWarn {
+		t.Errorf("expected default warn, got %v", level)
+	}
+	if modules["replay"] != slog.LevelDebug || modules["sound"] != slog.LevelError {
+		t.Errorf("unexpected module levels %v", modules)
+	}
+}
+
+func TestParseSpec_EmptyIsInfo(t *testing.T) {
+	level, modules, err := ParseSpec("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if level != slog.LevelInfo || len(modules) != 0 {
+		t.Errorf("expected info with no overrides, got %v %v", level, modules)
+	}
+}
+
+func TestParseSpec_BadLevel(t *testing.T) {
+	if _, _, err := ParseSpec("game=loud"); err == nil {
+		t.Error("expected an error for an unknown level")
+	}
+}
+
+func TestModuleLevels(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Setup("warn,replay=debug", false, &buf); err != nil {
+		t.Fatal(err)
+	}
+	For("game").Info("hidden")
+	For("game").Warn("shown-game")
+	For("replay").Debug("shown-replay")
+
+	out := buf.String()
+	if strings.Contains(out, "hidden") {
+		t.Error("info should be filtered for game at warn")
+	}
+	if !strings.Contains(out, "shown-game") || !strings.Contains(out, "shown-replay") {
+		t.Errorf("expected both enabled records, got:\n%s", out)
+	}
+}
+
+func TestLoggerCreatedBeforeSetup(t *testing.T) {
+	l := For("early").With("k", "v")
+
+	var buf bytes.Buffer
+	if err := Setup("info", true, &buf); err != nil {
+		t.Fatal(err)
+	}
+	l.Info("hello", "n", 3)
+
+	var rec map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("expected one JSON record, got %q: %v", buf.String(), err)
+	}
+	if rec["msg"] != "hello" || rec["module"] != "early" || rec["k"] != "v" || rec["n"] != float64(3) {
+		t.Errorf("unexpected record %v", rec)
+	}
+}
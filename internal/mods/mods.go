@@ -0,0 +1,464 @@
+// Package mods finds, validates and loads mod packs.
+//
+// A pack is a directory or a .zip file in the mods directory holding a
+// mod.json manifest and any of:
+//
+//	rules.star     Starlark rule hooks (see package script)
+//	config.json    gameplay values to change, e.g. {"max_bullets": 8}
+//	palette.json   colours to change, e.g. {"ship": "#00ffcc"}
+//	sounds/*.wav   replacement effects: fire, explosion_small,
+//	               explosion_medium, explosion_large, death, extra_life,
+//	               pickup
+//
+// Enabled packs load by priority and then name, and later packs override
+// earlier ones. Which packs are enabled is remembered in the config
+// directory.
+package mods
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/color"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2/audio/wav"
+	"github.com/matheus3301/asteroids/internal/game"
+	"github.com/matheus3301/asteroids/internal/logging"
+	"github.com/matheus3301/asteroids/internal/script"
+	"github.com/matheus3301/asteroids/internal/storage"
+)
+
+var logger = logging.For("mods")
+
+// ManifestFile is the file that makes a directory or zip a pack.
+const ManifestFile = "mod.json"
+
+// StateFile remembers which packs are enabled, in the config directory.
+const StateFile = "mods.json"
+
+// maxPackBytes bounds how much of a single file in a pack is read.
+const maxPackBytes = 32 << 20
+
+// Manifest is the contents of mod.json.
+type Manifest struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Description string `json:"description"`
+	// Priority orders loading; higher loads later and wins conflicts.
+	Priority int `json:"priority"`
+}
+
+var soundFiles = map[string]game.SoundEvent{
+	"fire":             game.SoundFire,
+	"explosion_small":  game.SoundExplosionSmall,
+	"explosion_medium": game.SoundExplosionMed,
+	"explosion_large":  game.SoundExplosionLarge,
+	"death":            game.SoundPlayerDeath,
+	"extra_life":       game.SoundExtraLife,
+	"pickup":           game.SoundPickup,
+	"life_bonus":       game.SoundLifeBonus,
+}
+
+// pack is one installed pack after validation.
+type pack struct {
+	Manifest
+	path    string
+	content game.ModContent
+	err     error
+}
+
+// Catalog implements game.ModCatalog over a directory of packs.
+type Catalog struct {
+	dir       string
+	statePath string
+	maxSteps  uint64
+
+	packs   []*pack
+	enabled map[string]bool
+}
+
+var _ game.ModCatalog = (*Catalog)(nil)
+
+// NewCatalog scans dir for packs, remembering the enabled set in
+// statePath. maxSteps is the step budget for each pack's rule hooks.
+func NewCatalog(dir, statePath string, maxSteps uint64) *Catalog {
+	c := &Catalog{dir: dir, statePath: statePath, maxSteps: maxSteps}
+	c.enabled = loadState(statePath)
+	c.Rescan()
+	return c
+}
+
+// Default opens the catalog in the standard data and config directories.
+func Default(maxSteps uint64) (*Catalog, error) {
+	dirs, err := storage.Default()
+	if err != nil {
+		return nil, err
+	}
+	return NewCatalog(filepath.Join(dirs.Data, "mods"), filepath.Join(dirs.Config, StateFile), maxSteps), nil
+}
+
+// Dir is where packs are installed.
+func (c *Catalog) Dir() string { return c.dir }
+
+// Rescan reloads every pack from disk.
+func (c *Catalog) Rescan() {
+	c.packs = nil
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			logger.Warn("cannot read mods directory", "dir", c.dir, "err", err)
+		}
+		return
+	}
+	seen := map[string]string{}
+	for _, e := range entries {
+		path := filepath.Join(c.dir, e.Name())
+		if !e.IsDir() && !strings.EqualFold(filepath.Ext(e.Name()), ".zip") {
+			continue
+		}
+		p := c.open(path)
+		if prev, ok := seen[p.Name]; ok && p.err == nil {
+			p.err = fmt.Errorf("name %q is already used by %s", p.Name, prev)
+		}
+		seen[p.Name] = e.Name()
+		c.packs = append(c.packs, p)
+	}
+	sort.SliceStable(c.packs, func(i, j int) bool {
+		a, b := c.packs[i], c.packs[j]
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		return a.Name < b.Name
+	})
+}
+
+// open reads and validates the pack at path. Problems are kept on the pack
+// rather than returned so that it can still be listed.
+func (c *Catalog) open(path string) *pack {
+	p := &pack{path: path}
+	p.Name = filepath.Base(path)
+	fsys, closer, err := openFS(path)
+	if err != nil {
+		p.err = err
+		return p
+	}
+	defer func() { _ = closer.Close() }()
+
+	b, err := readFile(fsys, ManifestFile)
+	if err != nil {
+		p.err = fmt.Errorf("no %s: %w", ManifestFile, err)
+		return p
+	}
+	var m Manifest
+	if err := decodeStrict(b, &m); err != nil {
+		p.err = fmt.Errorf("%s: %w", ManifestFile, err)
+		return p
+	}
+	if m.Name == "" {
+		p.err = fmt.Errorf("%s: name is required", ManifestFile)
+		return p
+	}
+	p.Manifest = m
+	p.content, p.err = c.load(fsys, m.Name)
+	return p
+}
+
+func openFS(path string) (fs.FS, io.Closer, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if info.IsDir() {
+		return os.DirFS(path), io.NopCloser(nil), nil
+	}
+	z, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return z, z, nil
+}
+
+func readFile(fsys fs.FS, name string) ([]byte, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	b, err := io.ReadAll(io.LimitReader(f, maxPackBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) > maxPackBytes {
+		return nil, fmt.Errorf("%s is larger than %d bytes", name, maxPackBytes)
+	}
+	return b, nil
+}
+
+func decodeStrict(b []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
+// load reads a pack's optional files. Config and palette changes are
+// recorded as overlays on the standard rules so packs can be stacked.
+func (c *Catalog) load(fsys fs.FS, name string) (game.ModContent, error) {
+	var content game.ModContent
+	content.Ruleset = game.StandardRules()
+
+	if b, err := readFile(fsys, "config.json"); err == nil {
+		if err := decodeStrict(b, &content.Config); err != nil {
+			return content, fmt.Errorf("config.json: %w", err)
+		}
+		if err := content.Config.Validate(); err != nil {
+			return content, fmt.Errorf("config.json: %w", err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return content, err
+	}
+
+	if b, err := readFile(fsys, "palette.json"); err == nil {
+		if err := decodePalette(b, &content.Palette); err != nil {
+			return content, fmt.Errorf("palette.json: %w", err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return content, err
+	}
+
+	if b, err := readFile(fsys, "rules.star"); err == nil {
+		s, err := script.Compile(name+"/rules.star", b, c.maxSteps)
+		if err != nil {
+			return content, err
+		}
+		content.Hooks = s
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return content, err
+	}
+
+	entries, err := fs.ReadDir(fsys, "sounds")
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return content, err
+	}
+	for _, e := range entries {
+		base, ext := strings.TrimSuffix(e.Name(), filepath.Ext(e.Name())), filepath.Ext(e.Name())
+		ev, ok := soundFiles[base]
+		if !ok || !strings.EqualFold(ext, ".wav") {
+			return content, fmt.Errorf("sounds/%s: unknown sound", e.Name())
+		}
+		b, err := readFile(fsys, "sounds/"+e.Name())
+		if err != nil {
+			return content, err
+		}
+		pcm, err := decodeWAV(b)
+		if err != nil {
+			return content, fmt.Errorf("sounds/%s: %w", e.Name(), err)
+		}
+		if content.Sounds == nil {
+			content.Sounds = map[game.SoundEvent][]byte{}
+		}
+		content.Sounds[ev] = pcm
+	}
+	return content, nil
+}
+
+func decodeWAV(b []byte) ([]byte, error) {
+	s, err := wav.DecodeWithSampleRate(game.SampleRate, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(s)
+}
+
+func decodePalette(b []byte, p *game.Palette) error {
+	var raw map[string]string
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	fields := map[string]*color.RGBA{
+		"background":    &p.Background,
+		"hud":           &p.HUD,
+		"ship":          &p.Ship,
+		"asteroid":      &p.Asteroid,
+		"golden":        &p.GoldenAsteroid,
+		"explosive":     &p.ExplosiveAsteroid,
+		"armored":       &p.ArmoredAsteroid,
+		"bullet":        &p.Bullet,
+		"saucer":        &p.Saucer,
+		"saucer_bullet": &p.SaucerBullet,
+		"particle":      &p.Particle,
+		"bonus":         &p.Bonus,
+	}
+	for key, hex := range raw {
+		dst, ok := fields[key]
+		if !ok {
+			return fmt.Errorf("unknown colour %q", key)
+		}
+		clr, err := parseHex(hex)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		*dst = clr
+	}
+	return nil
+}
+
+// parseHex reads #rrggbb or #rrggbbaa.
+func parseHex(s string) (color.RGBA, error) {
+	var c color.RGBA
+	c.A = 255
+	var n int
+	var err error
+	switch len(s) {
+	case 7:
+		n, err = fmt.Sscanf(s, "#%02x%02x%02x", &c.R, &c.G, &c.B)
+	case 9:
+		n, err = fmt.Sscanf(s, "#%02x%02x%02x%02x", &c.R, &c.G, &c.B, &c.A)
+	}
+	if err != nil || n < 3 {
+		return c, fmt.Errorf("%q is not a #rrggbb colour", s)
+	}
+	return c, nil
+}
+
+// Mods lists the installed packs in load order.
+func (c *Catalog) Mods() []game.ModInfo {
+	list := make([]game.ModInfo, len(c.packs))
+	for i, p := range c.packs {
+		list[i] = game.ModInfo{
+			Name:        p.Name,
+			Version:     p.Version,
+			Description: p.Description,
+			Enabled:     c.enabled[p.Name],
+			Err:         p.err,
+		}
+	}
+	return list
+}
+
+// SetEnabled switches a pack on or off and saves the choice.
+func (c *Catalog) SetEnabled(name string, on bool) error {
+	if c.find(name) == nil {
+		return fmt.Errorf("no mod named %q", name)
+	}
+	if c.enabled == nil {
+		c.enabled = map[string]bool{}
+	}
+	if on {
+		c.enabled[name] = true
+	} else {
+		delete(c.enabled, name)
+	}
+	return saveState(c.statePath, c.enabled)
+}
+
+func (c *Catalog) find(name string) *pack {
+	for _, p := range c.packs {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// Content merges the enabled packs in load order. Broken packs are left
+// out and reported in the error.
+func (c *Catalog) Content() (game.ModContent, error) {
+	out := game.ModContent{Ruleset: game.StandardRules()}
+	def := game.StandardRules()
+	var errs []error
+	var hooks []game.RuleHooks
+	for _, p := range c.packs {
+		if !c.enabled[p.Name] {
+			continue
+		}
+		if p.err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", p.Name, p.err))
+			continue
+		}
+		overlay(&out.Config, p.content.Config, def.Config)
+		overlay(&out.Palette, p.content.Palette, def.Palette)
+		hooks = append(hooks, p.content.Hooks)
+		for ev, pcm := range p.content.Sounds {
+			if out.Sounds == nil {
+				out.Sounds = map[game.SoundEvent][]byte{}
+			}
+			out.Sounds[ev] = pcm
+		}
+	}
+	out.Hooks = game.ChainHooks(hooks...)
+	if err := out.Config.Validate(); err != nil {
+		errs = append(errs, err)
+		out.Config = def.Config
+	}
+	return out, errors.Join(errs...)
+}
+
+// overlay copies into dst every field of src that differs from def.
+func overlay[T any](dst *T, src, def T) {
+	var d, s, z map[string]json.RawMessage
+	db, _ := json.Marshal(*dst)
+	sb, _ := json.Marshal(src)
+	zb, _ := json.Marshal(def)
+	_ = json.Unmarshal(db, &d)
+	_ = json.Unmarshal(sb, &s)
+	_ = json.Unmarshal(zb, &z)
+	for k, v := range s {
+		if !bytes.Equal(v, z[k]) {
+			d[k] = v
+		}
+	}
+	b, _ := json.Marshal(d)
+	_ = json.Unmarshal(b, dst)
+}
+
+type state struct {
+	Enabled []string `json:"enabled"`
+}
+
+func loadState(path string) map[string]bool {
+	enabled := map[string]bool{}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			logger.Warn("cannot read enabled mods", "path", path, "err", err)
+		}
+		return enabled
+	}
+	var s state
+	if err := json.Unmarshal(b, &s); err != nil {
+		logger.Warn("ignoring corrupt enabled mods file", "path", path, "err", err)
+		return enabled
+	}
+	for _, name := range s.Enabled {
+		enabled[name] = true
+	}
+	return enabled
+}
+
+func saveState(path string, enabled map[string]bool) error {
+	s := state{Enabled: []string{}}
+	for name := range enabled {
+		s.Enabled = append(s.Enabled, name)
+	}
+	sort.Strings(s.Enabled)
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := storage.Ensure(filepath.Dir(path)); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(b, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
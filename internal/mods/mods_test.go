@@ -0,0 +1,194 @@
+package mods
+
+import (
+	"archive/zip"
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/matheus3301/asteroids/internal/game"
+)
+
+func writePack(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, body := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func writeZip(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+	zw := zip.NewWriter(f)
+	for name, body := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newCatalog(t *testing.T) (*Catalog, string) {
+	t.Helper()
+	root := t.TempDir()
+	dir := filepath.Join(root, "mods")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return NewCatalog(dir, filepath.Join(root, StateFile), 10_000), dir
+}
+
+func TestCatalog_ListsPacksInLoadOrder(t *testing.T) {
+	c, dir := newCatalog(t)
+	writePack(t, filepath.Join(dir, "b"), map[string]string{ManifestFile: `{"name":"beta","priority":1}`})
+	writePack(t, filepath.Join(dir, "a"), map[string]string{ManifestFile: `{"name":"alpha","priority":1}`})
+	writeZip(t, filepath.Join(dir, "z.zip"), map[string]string{ManifestFile: `{"name":"zed","version":"1.0"}`})
+	c.Rescan()
+
+	list := c.Mods()
+	if len(list) != 3 {
+		t.Fatalf("expected 3 packs, got %d", len(list))
+	}
+	want := []string{"zed", "alpha", "beta"}
+	for i, m := range list {
+		if m.Name != want[i] || m.Err != nil {
+			t.Errorf("pack %d: got %q (err %v), want %q", i, m.Name, m.Err, want[i])
+		}
+	}
+}
+
+func TestCatalog_ReportsBrokenPacks(t *testing.T) {
+	c, dir := newCatalog(t)
+	writePack(t, filepath.Join(dir, "nomanifest"), map[string]string{"rules.star": ""})
+	writePack(t, filepath.Join(dir, "badconfig"), map[string]string{
+		ManifestFile:  `{"name":"badconfig"}`,
+		"config.json": `{"max_bullets": 0}`,
+	})
+	writePack(t, filepath.Join(dir, "typo"), map[string]string{
+		ManifestFile:  `{"name":"typo"}`,
+		"config.json": `{"max_bulets": 8}`,
+	})
+	writePack(t, filepath.Join(dir, "badscript"), map[string]string{
+		ManifestFile: `{"name":"badscript"}`,
+		"rules.star": `def on_tick(state`,
+	})
+	c.Rescan()
+
+	for _, m := range c.Mods() {
+		if m.Err == nil {
+			t.Errorf("%s: expected a validation error", m.Name)
+		}
+	}
+}
+
+func TestCatalog_MergesEnabledPacks(t *testing.T) {
+	c, dir := newCatalog(t)
+	writePack(t, filepath.Join(dir, "first"), map[string]string{
+		ManifestFile:   `{"name":"first"}`,
+		"config.json":  `{"max_bullets": 8, "starting_lives": 5}`,
+		"palette.json": `{"ship": "#0000ff"}`,
+	})
+	writePack(t, filepath.Join(dir, "second"), map[string]string{
+		ManifestFile:  `{"name":"second","priority":10}`,
+		"config.json": `{"max_bullets": 2}`,
+		"rules.star":  "def on_wave_start(state, wave, count):\n    return 1\n",
+	})
+	c.Rescan()
+	for _, name := range []string{"first", "second"} {
+		if err := c.SetEnabled(name, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	content, err := c.Content()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content.Config.MaxBullets != 2 {
+		t.Errorf("later pack should win max_bullets, got %d", content.Config.MaxBullets)
+	}
+	if content.Config.StartingLives != 5 {
+		t.Errorf("earlier pack's starting_lives should survive, got %d", content.Config.StartingLives)
+	}
+	if content.Palette.Ship != (color.RGBA{0, 0, 255, 255}) {
+		t.Errorf("unexpected ship colour %v", content.Palette.Ship)
+	}
+	if content.Hooks == nil {
+		t.Fatal("expected rule hooks from the second pack")
+	}
+
+	w := game.NewModdedWorld(1, content.Ruleset)
+	if w.Lives != 5 || w.AsteroidCount() != 1 {
+		t.Errorf("modded world: lives=%d asteroids=%d", w.Lives, w.AsteroidCount())
+	}
+}
+
+func TestCatalog_RemembersEnabledPacks(t *testing.T) {
+	c, dir := newCatalog(t)
+	writePack(t, filepath.Join(dir, "p"), map[string]string{ManifestFile: `{"name":"p"}`})
+	c.Rescan()
+	if err := c.SetEnabled("p", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetEnabled("missing", true); err == nil {
+		t.Error("enabling an unknown pack should fail")
+	}
+
+	again := NewCatalog(c.dir, c.statePath, 10_000)
+	if list := again.Mods(); len(list) != 1 || !list[0].Enabled {
+		t.Errorf("enabled state not restored: %+v", list)
+	}
+}
+
+func TestCatalog_SkipsBrokenEnabledPacks(t *testing.T) {
+	c, dir := newCatalog(t)
+	writePack(t, filepath.Join(dir, "p"), map[string]string{ManifestFile: `{"name":"p"}`})
+	c.Rescan()
+	if err := c.SetEnabled("p", true); err != nil {
+		t.Fatal(err)
+	}
+	writePack(t, filepath.Join(dir, "p"), map[string]string{"palette.json": `{"shp": "#fff"}`})
+	c.Rescan()
+
+	content, err := c.Content()
+	if err == nil {
+		t.Error("expected the broken pack to be reported")
+	}
+	if content.Palette != game.DefaultPalette() {
+		t.Error("a broken pack must not change the palette")
+	}
+}
+
+func TestParseHex(t *testing.T) {
+	for in, want := range map[string]color.RGBA{
+		"#ff8000":   {255, 128, 0, 255},
+		"#01020304": {1, 2, 3, 4},
+	} {
+		got, err := parseHex(in)
+		if err != nil || got != want {
+			t.Errorf("parseHex(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "fff", "#ggg000", "#12345"} {
+		if _, err := parseHex(in); err == nil {
+			t.Errorf("parseHex(%q) should fail", in)
+		}
+	}
+}
@@ -0,0 +1,453 @@
+// Package netplay implements two-player LAN co-op as deterministic lockstep.
+//
+// Both machines run the same World from the same seed and only exchange
+// inputs. Each local input is scheduled Delay ticks in the future, which
+// hides network latency: a tick is simulated once both players' inputs for
+// it have arrived. Every checksumInterval ticks the peers swap world
+// checksums, so a desync is reported as soon as it happens rather than when
+// the screens visibly disagree.
+//
+// Messages are newline-delimited JSON over TCP:
+//
+//	{"t":"hello","v":1,"seed":42,"delay":3,"rules":123}  host -> guest, once per connection
+//	{"t":"resume","tick":120}                           next tick of the peer's input we need
+//	{"t":"in","tick":123,"b":5}                         input bits for a tick
+//	{"t":"sum","tick":120,"sum":99}                     world checksum after a tick
+//
+// If the connection drops, both sides keep their input history. The guest
+// redials and the host accepts the same player again; after exchanging
+// resume messages each side resends the inputs the other is missing and the
+// game carries on from where it stalled.
+package netplay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/matheus3301/asteroids/internal/game"
+	"github.com/matheus3301/asteroids/internal/logging"
+)
+
+const (
+	// DefaultPort is where hosts listen unless told otherwise.
+	DefaultPort = 7778
+	// DefaultDelay is the input delay in ticks (50ms at 60 TPS).
+	DefaultDelay = 3
+
+	protocolVersion  = 1
+	checksumInterval = 60
+	writeTimeout     = time.Second
+	stallThreshold   = 30 // ticks without progress before we tell the player
+)
+
+var logger = logging.For("netplay")
+
+// redialInterval is how often a guest retries a lost connection.
+var redialInterval = time.Second
+
+type message struct {
+	Type    string `json:"t"`
+	Version int    `json:"v,omitempty"`
+	Seed    int64  `json:"seed,omitempty"`
+	Delay   int    `json:"delay,omitempty"`
+	Rules   uint64 `json:"rules,omitempty"`
+	Tick    int    `json:"tick,omitempty"`
+	Bits    uint8  `json:"b,omitempty"`
+	Sum     uint64 `json:"sum,omitempty"`
+}
+
+// event is sent from the network goroutines to the game goroutine.
+type event struct {
+	conn net.Conn // the connection the event concerns
+	msg  message
+	kind eventKind
+}
+
+type eventKind int
+
+const (
+	eventConnected eventKind = iota
+	eventMessage
+	eventLost
+)
+
+// Session is one side of a co-op game. It implements game.NetSession and
+// must be driven from a single goroutine.
+type Session struct {
+	slot  int
+	delay int
+	seed  int64
+	addr  string       // host: listen address; guest: address dialled
+	ln    net.Listener // host only
+
+	events chan event
+	done   chan struct{}
+
+	conn   net.Conn
+	world  *game.World
+	local  []uint8 // our input for every tick so far, by tick
+	remote []uint8 // the peer's input, by tick
+
+	sums       map[int]uint64 // our checksums not yet compared
+	peerSums   map[int]uint64 // the peer's checksums not yet compared
+	desyncTick int
+	stalled    int
+	err        string
+}
+
+func newSession(slot int, addr string) *Session {
+	return &Session{
+		slot:       slot,
+		addr:       addr,
+		events:     make(chan event, 256),
+		done:       make(chan struct{}),
+		sums:       map[int]uint64{},
+		peerSums:   map[int]uint64{},
+		desyncTick: -1,
+	}
+}
+
+// Host listens on addr and waits for a guest to join. The world is created
+// from seed once the guest connects.
+func Host(addr string, seed int64, delay int) (*Session, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	s := newSession(0, ln.Addr().String())
+	s.seed = seed
+	s.delay = delay
+	s.ln = ln
+	go s.acceptLoop()
+	return s, nil
+}
+
+// Join connects to the host at addr. The seed and input delay come from the
+// host, so the world appears once its hello has arrived.
+func Join(addr string) (*Session, error) {
+	conn, err := net.DialTimeout("tcp", addr, 3*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	s := newSession(1, addr)
+	s.events <- event{conn: conn, kind: eventConnected}
+	return s, nil
+}
+
+// Addr is the address the host listens on or the guest connected to.
+func (s *Session) Addr() string {
+	return s.addr
+}
+
+// World implements game.NetSession.
+func (s *Session) World() *game.World {
+	return s.world
+}
+
+// DesyncTick is the first tick whose checksums disagreed, or -1.
+func (s *Session) DesyncTick() int {
+	return s.desyncTick
+}
+
+// Status implements game.NetSession.
+func (s *Session) Status() string {
+	switch {
+	case s.err != "":
+		return s.err
+	case s.world == nil && s.slot == 0:
+		_, port, _ := net.SplitHostPort(s.addr)
+		return "WAITING FOR PLAYER ON PORT " + port
+	case s.world == nil:
+		return "CONNECTING TO " + s.addr
+	case s.conn == nil && s.slot == 0:
+		return "CONNECTION LOST - WAITING FOR PLAYER"
+	case s.conn == nil:
+		return "CONNECTION LOST - RECONNECTING"
+	case s.desyncTick >= 0:
+		return fmt.Sprintf("DESYNC AT TICK %d", s.desyncTick)
+	case s.stalled > stallThreshold:
+		return "WAITING FOR PLAYER"
+	}
+	return ""
+}
+
+// Close implements game.NetSession.
+func (s *Session) Close() error {
+	select {
+	case <-s.done:
+		return nil
+	default:
+	}
+	close(s.done)
+	var err error
+	if s.ln != nil {
+		err = s.ln.Close()
+	}
+	if s.conn != nil {
+		_ = s.conn.Close()
+		s.conn = nil
+	}
+	return err
+}
+
+// Advance implements game.NetSession.
+func (s *Session) Advance(local game.InputState) {
+	s.drain()
+	if s.world == nil {
+		return
+	}
+
+	// Schedule this frame's input delay ticks ahead, unless we are already
+	// that far ahead of the simulation (the peer is lagging).
+	if len(s.local) <= s.world.Tick+s.delay {
+		tick := len(s.local)
+		s.local = append(s.local, local.Bits())
+		s.send(message{Type: "in", Tick: tick, Bits: local.Bits()})
+	}
+
+	stepped := false
+	for s.world.Tick < len(s.local) && s.world.Tick < len(s.remote) {
+		s.step()
+		stepped = true
+	}
+	if stepped {
+		s.stalled = 0
+	} else {
+		s.stalled++
+	}
+}
+
+func (s *Session) step() {
+	w := s.world
+	var inputs game.Inputs
+	inputs[s.slot] = game.InputFromBits(s.local[w.Tick])
+	inputs[1-s.slot] = game.InputFromBits(s.remote[w.Tick])
+	game.StepCoop(w, inputs)
+
+	if w.Tick%checksumInterval == 0 {
+		sum := w.Checksum()
+		s.sums[w.Tick] = sum
+		s.send(message{Type: "sum", Tick: w.Tick, Sum: sum})
+		s.compare(w.Tick)
+	}
+}
+
+// compare checks the checksums for tick once both sides have reported.
+func (s *Session) compare(tick int) {
+	mine, ok1 := s.sums[tick]
+	theirs, ok2 := s.peerSums[tick]
+	if !ok1 || !ok2 {
+		return
+	}
+	delete(s.sums, tick)
+	delete(s.peerSums, tick)
+	if mine != theirs && s.desyncTick < 0 {
+		s.desyncTick = tick
+		logger.Error("desync", "tick", tick, "local", mine, "remote", theirs)
+	}
+}
+
+// drain handles every pending network event without blocking.
+func (s *Session) drain() {
+	for {
+		select {
+		case ev := <-s.events:
+			s.handle(ev)
+		default:
+			return
+		}
+	}
+}
+
+func (s *Session) handle(ev event) {
+	switch ev.kind {
+	case eventConnected:
+		s.connected(ev.conn)
+	case eventLost:
+		if ev.conn != s.conn {
+			return
+		}
+		logger.Warn("peer connection lost", "tick", s.tick())
+		_ = s.conn.Close()
+		s.conn = nil
+		if s.slot == 1 {
+			go s.redial()
+		}
+	case eventMessage:
+		if ev.conn != s.conn {
+			return // left over from a dropped connection
+		}
+		s.receive(ev.msg)
+	}
+}
+
+func (s *Session) connected(conn net.Conn) {
+	if s.conn != nil {
+		logger.Warn("rejecting extra player", "addr", conn.RemoteAddr())
+		_ = conn.Close()
+		return
+	}
+	logger.Info("peer connected", "addr", conn.RemoteAddr(), "tick", s.tick())
+	s.conn = conn
+	go s.readLoop(conn)
+
+	if s.slot == 0 {
+		if s.world == nil {
+			s.start()
+		}
+		s.send(message{Type: "hello", Version: protocolVersion, Seed: s.seed, Delay: s.delay, Rules: game.RulesHash()})
+	}
+	s.send(message{Type: "resume", Tick: len(s.remote)})
+}
+
+// start creates the shared world. The first delay ticks have no input.
+func (s *Session) start() {
+	s.world = game.NewCoopWorld(s.seed)
+	s.local = make([]uint8, s.delay)
+	s.remote = make([]uint8, s.delay)
+}
+
+func (s *Session) receive(m message) {
+	switch m.Type {
+	case "hello":
+		if s.world != nil {
+			return // reconnecting; the game is already set up
+		}
+		if m.Version != protocolVersion || m.Rules != game.RulesHash() {
+			s.err = "HOST IS RUNNING A DIFFERENT VERSION"
+			_ = s.Close()
+			return
+		}
+		s.seed, s.delay = m.Seed, m.Delay
+		s.start()
+	case "resume":
+		for tick := m.Tick; tick < len(s.local); tick++ {
+			s.send(message{Type: "in", Tick: tick, Bits: s.local[tick]})
+		}
+	case "in":
+		// Inputs arrive in order; anything else is a resend we already have.
+		if m.Tick == len(s.remote) {
+			s.remote = append(s.remote, m.Bits)
+		}
+	case "sum":
+		s.peerSums[m.Tick] = m.Sum
+		s.compare(m.Tick)
+	}
+}
+
+func (s *Session) send(m message) {
+	if s.conn == nil {
+		return
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return
+	}
+	if err := s.conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err == nil {
+		_, err = s.conn.Write(append(b, '\n'))
+	}
+	if err != nil {
+		// The read loop notices the closed connection and reports it.
+		_ = s.conn.Close()
+	}
+}
+
+func (s *Session) tick() int {
+	if s.world == nil {
+		return 0
+	}
+	return s.world.Tick
+}
+
+// post hands an event to the game goroutine unless the session is closed.
+func (s *Session) post(ev event) bool {
+	select {
+	case s.events <- ev:
+		return true
+	case <-s.done:
+		return false
+	}
+}
+
+func (s *Session) readLoop(conn net.Conn) {
+	dec := json.NewDecoder(conn)
+	for {
+		var m message
+		if err := dec.Decode(&m); err != nil {
+			s.post(event{conn: conn, kind: eventLost})
+			return
+		}
+		if !s.post(event{conn: conn, msg: m, kind: eventMessage}) {
+			return
+		}
+	}
+}
+
+func (s *Session) acceptLoop() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				logger.Error("accept", "err", err)
+			}
+			return
+		}
+		if !s.post(event{conn: conn, kind: eventConnected}) {
+			_ = conn.Close()
+			return
+		}
+	}
+}
+
+func (s *Session) redial() {
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-time.After(redialInterval):
+		}
+		conn, err := net.DialTimeout("tcp", s.addr, redialInterval)
+		if err != nil {
+			logger.Debug("redial failed", "addr", s.addr, "err", err)
+			continue
+		}
+		if !s.post(event{conn: conn, kind: eventConnected}) {
+			_ = conn.Close()
+		}
+		return
+	}
+}
+
+// Factory opens sessions for the in-game co-op screen.
+type Factory struct {
+	Port  int // port hosts listen on; 0 means DefaultPort
+	Delay int // input delay in ticks; 0 means DefaultDelay
+}
+
+// Host implements game.NetFactory.
+func (f Factory) Host(seed int64) (game.NetSession, error) {
+	port, delay := f.Port, f.Delay
+	if port == 0 {
+		port = DefaultPort
+	}
+	if delay <= 0 {
+		delay = DefaultDelay
+	}
+	return Host(":"+strconv.Itoa(port), seed, delay)
+}
+
+// Join implements game.NetFactory. The port may be left out of addr.
+func (f Factory) Join(addr string) (game.NetSession, error) {
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		port := f.Port
+		if port == 0 {
+			port = DefaultPort
+		}
+		addr = net.JoinHostPort(addr, strconv.Itoa(port))
+	}
+	return Join(addr)
+}
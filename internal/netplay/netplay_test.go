@@ -0,0 +1,109 @@
+package netplay
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matheus3301/asteroids/internal/game"
+)
+
+func pair(t *testing.T) (host, guest *Session) {
+	t.Helper()
+	host, err := Host("127.0.0.1:0", 7, DefaultDelay)
+	if err != nil {
+		t.Fatalf("Host: %v", err)
+	}
+	t.Cleanup(func() { host.Close() })
+	guest, err = Join(host.Addr())
+	if err != nil {
+		t.Fatalf("Join: %v", err)
+	}
+	t.Cleanup(func() { guest.Close() })
+	return host, guest
+}
+
+// run advances both sessions with different scripted inputs until both
+// worlds reach tick, failing after a generous wall-clock limit.
+func run(t *testing.T, host, guest *Session, tick int) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for frame := 0; ; frame++ {
+		host.Advance(game.ScriptedInput(frame))
+		guest.Advance(game.InputState{RotateLeft: true, Shoot: frame%11 == 0})
+		if host.World() != nil && guest.World() != nil &&
+			host.World().Tick >= tick && guest.World().Tick >= tick {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out: host %q guest %q", host.Status(), guest.Status())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// sameTick steps whichever world is behind so both can be compared.
+func sameTick(t *testing.T, host, guest *Session) {
+	t.Helper()
+	for host.World().Tick != guest.World().Tick {
+		if host.World().Tick < guest.World().Tick {
+			host.Advance(game.InputState{})
+		} else {
+			guest.Advance(game.InputState{})
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSession_LockstepStaysInSync(t *testing.T) {
+	host, guest := pair(t)
+	run(t, host, guest, 300)
+
+	if host.DesyncTick() >= 0 || guest.DesyncTick() >= 0 {
+		t.Fatalf("desync reported at host %d guest %d", host.DesyncTick(), guest.DesyncTick())
+	}
+	hw, gw := host.World(), guest.World()
+	if hw.Seed != 7 || gw.Seed != 7 {
+		t.Fatalf("seeds = %d, %d, want 7", hw.Seed, gw.Seed)
+	}
+	sameTick(t, host, guest)
+	if hw.Checksum() != gw.Checksum() {
+		t.Errorf("worlds differ at tick %d", hw.Tick)
+	}
+	if host.Status() != "" && host.Status() != "WAITING FOR PLAYER" {
+		t.Errorf("host status = %q", host.Status())
+	}
+}
+
+func TestSession_DetectsDesync(t *testing.T) {
+	host, guest := pair(t)
+	run(t, host, guest, 10)
+	host.World().Score += 1000
+
+	run(t, host, guest, 3*checksumInterval)
+	if host.DesyncTick() < 0 || guest.DesyncTick() < 0 {
+		t.Fatalf("desync not detected: host %d guest %d", host.DesyncTick(), guest.DesyncTick())
+	}
+	if got := host.Status(); got == "" {
+		t.Error("host should report the desync")
+	}
+}
+
+func TestSession_Reconnects(t *testing.T) {
+	old := redialInterval
+	redialInterval = 10 * time.Millisecond
+	t.Cleanup(func() { redialInterval = old })
+
+	host, guest := pair(t)
+	run(t, host, guest, 60)
+
+	guest.conn.Close()
+	run(t, host, guest, 240)
+
+	if host.DesyncTick() >= 0 || guest.DesyncTick() >= 0 {
+		t.Errorf("desync after reconnect at host %d guest %d", host.DesyncTick(), guest.DesyncTick())
+	}
+	sameTick(t, host, guest)
+	if host.World().Checksum() != guest.World().Checksum() {
+		t.Error("worlds differ after reconnect")
+	}
+}
@@ -0,0 +1,161 @@
+// Package profile keeps the player's career: lifetime totals, what they
+// have unlocked and which cosmetics and mode they picked. It is a JSON file
+// in the data directory.
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/matheus3301/asteroids/internal/storage"
+)
+
+// FileName is the profile file inside the data directory.
+const FileName = "profile.json"
+
+// Profile is one player's career.
+type Profile struct {
+	Games              int   `json:"games"`
+	TotalScore         int64 `json:"total_score"`
+	BestScore          int   `json:"best_score"`
+	AsteroidsDestroyed int   `json:"asteroids_destroyed"`
+	SaucersDestroyed   int   `json:"saucers_destroyed"`
+
+	// Unlocked lists unlock IDs in the order they were earned. Unlocks are
+	// kept even if the requirements change later.
+	Unlocked []string `json:"unlocked"`
+
+	// Ship, Palette and Mode are the player's choices, by unlock ID. Empty
+	// means the standard one.
+	Ship    string `json:"ship,omitempty"`
+	Palette string `json:"palette,omitempty"`
+	Mode    string `json:"mode,omitempty"`
+
+	// Drills holds the best medal earned on each practice drill, by drill
+	// ID, from 1 for bronze to 3 for gold.
+	Drills map[string]int `json:"drills,omitempty"`
+
+	// MutatorBests holds the best score of runs played with mutators, off
+	// the normal difficulty or in another game mode, by the run's key such
+	// as "fog+swarm", "hard+fog" or "survival". Those runs do not count
+	// toward the totals above.
+	MutatorBests map[string]int `json:"mutator_bests,omitempty"`
+
+	// ReducedMotion is the player's reduced motion preference, chosen on
+	// the accessibility page.
+	ReducedMotion bool `json:"reduced_motion,omitempty"`
+
+	// Difficulty is the ID of the difficulty chosen in settings. Empty
+	// means normal.
+	Difficulty string `json:"difficulty,omitempty"`
+
+	// Keys holds the player's key bindings, by action and then key name,
+	// as rebound on the controls page. Empty means the standard keys.
+	Keys map[string][]string `json:"keys,omitempty"`
+}
+
+// Game is what one finished game adds to the career.
+type Game struct {
+	Score              int
+	AsteroidsDestroyed int
+	SaucersDestroyed   int
+}
+
+// Add folds a finished game into the totals.
+func (p *Profile) Add(g Game) {
+	p.Games++
+	p.TotalScore += int64(g.Score)
+	p.BestScore = max(p.BestScore, g.Score)
+	p.AsteroidsDestroyed += g.AsteroidsDestroyed
+	p.SaucersDestroyed += g.SaucersDestroyed
+}
+
+// Kills is every asteroid and saucer destroyed.
+func (p *Profile) Kills() int {
+	return p.AsteroidsDestroyed + p.SaucersDestroyed
+}
+
+// Has reports whether id has been unlocked.
+func (p *Profile) Has(id string) bool {
+	return slices.Contains(p.Unlocked, id)
+}
+
+// Unlock records id, reporting whether it is new.
+func (p *Profile) Unlock(id string) bool {
+	if p.Has(id) {
+		return false
+	}
+	p.Unlocked = append(p.Unlocked, id)
+	return true
+}
+
+// RecordDrill keeps medal as the best for drill id if it beats the last
+// best, reporting whether it did.
+func (p *Profile) RecordDrill(id string, medal int) bool {
+	if medal <= p.Drills[id] {
+		return false
+	}
+	if p.Drills == nil {
+		p.Drills = map[string]int{}
+	}
+	p.Drills[id] = medal
+	return true
+}
+
+// RecordMutatorBest keeps score as the best for mutator key if it beats
+// the last best, reporting whether it did.
+func (p *Profile) RecordMutatorBest(key string, score int) bool {
+	if best, ok := p.MutatorBests[key]; ok && score <= best {
+		return false
+	}
+	if p.MutatorBests == nil {
+		p.MutatorBests = map[string]int{}
+	}
+	p.MutatorBests[key] = score
+	return true
+}
+
+// Path returns where the profile is stored.
+func Path() (string, error) {
+	dirs, err := storage.Default()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dirs.Data, FileName), nil
+}
+
+// Load reads the profile at path. A missing file is a new career.
+func Load(path string) (*Profile, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Profile{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	p := &Profile{}
+	if err := json.Unmarshal(b, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Save writes the profile to path, replacing it atomically.
+func (p *Profile) Save(path string) error {
+	b, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := storage.Ensure(filepath.Dir(path)); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(b, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,88 @@
+package profile
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestProfile_Add(t *testing.T) {
+	var p Profile
+	p.Add(Game{Score: 1200, AsteroidsDestroyed: 30, SaucersDestroyed: 1})
+	p.Add(Game{Score: 800, AsteroidsDestroyed: 10})
+
+	if p.Games != 2 || p.TotalScore != 2000 || p.BestScore != 1200 {
+		t.Errorf("unexpected totals: %+v", p)
+	}
+	if p.Kills() != 41 {
+		t.Errorf("kills = %d, want 41", p.Kills())
+	}
+}
+
+func TestProfile_Unlock(t *testing.T) {
+	var p Profile
+	if !p.Unlock("dart") {
+		t.Error("first unlock should be new")
+	}
+	if p.Unlock("dart") {
+		t.Error("unlocking twice should not be new")
+	}
+	if !p.Has("dart") || p.Has("arrow") {
+		t.Errorf("unexpected unlocks: %v", p.Unlocked)
+	}
+}
+
+func TestProfile_RecordDrill(t *testing.T) {
+	var p Profile
+	if !p.RecordDrill("sweep", 2) {
+		t.Error("a first medal should be recorded")
+	}
+	if p.RecordDrill("sweep", 1) || p.RecordDrill("sweep", 2) {
+		t.Error("a medal no better than the best should not be recorded")
+	}
+	if !p.RecordDrill("sweep", 3) || p.Drills["sweep"] != 3 {
+		t.Errorf("a better medal should replace the best, got %v", p.Drills)
+	}
+	if p.RecordDrill("split", 0) {
+		t.Error("no medal should not be recorded")
+	}
+}
+
+func TestProfile_RecordMutatorBest(t *testing.T) {
+	var p Profile
+	if !p.RecordMutatorBest("fog", 0) {
+		t.Error("a first run should be recorded, even scoring nothing")
+	}
+	if p.RecordMutatorBest("fog", 0) || !p.RecordMutatorBest("fog", 900) {
+		t.Error("only a better score should replace the best")
+	}
+	if !p.RecordMutatorBest("fog+swarm", 100) || p.MutatorBests["fog"] != 900 {
+		t.Errorf("each mutator key should keep its own best, got %v", p.MutatorBests)
+	}
+}
+
+func TestSaveLoad_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", FileName)
+	p := &Profile{Ship: "dart"}
+	p.Add(Game{Score: 50})
+	p.Unlock("dart")
+	if err := p.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Games != 1 || got.Ship != "dart" || !got.Has("dart") {
+		t.Errorf("round trip lost data: %+v", got)
+	}
+}
+
+func TestLoad_MissingFileIsNewCareer(t *testing.T) {
+	p, err := Load(filepath.Join(t.TempDir(), "none.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Games != 0 || len(p.Unlocked) != 0 {
+		t.Errorf("expected an empty profile, got %+v", p)
+	}
+}
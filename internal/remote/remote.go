@@ -0,0 +1,269 @@
+// Package remote lets an external process drive the player ship over TCP.
+//
+// The protocol is newline-delimited JSON. On connect the server sends a
+// hello message:
+//
+//	{"type":"hello","version":1,"tick_rate":60,"timeout_ms":50}
+//
+// Then, once per gameplay tick, it sends an observation:
+//
+//	{"type":"obs","episode":1,"tick":42,"obs":{...game.Observation...}}
+//
+// and waits up to the timeout for the matching action:
+//
+//	{"tick":42,"action":{"left":false,"right":true,"thrust":true,"shoot":false,"hyperspace":false,"missile":false,"shield":false}}
+//
+// Actions for older ticks are discarded. When no action for the current
+// tick arrives in time the previous action is held (with shoot, hyperspace
+// and missile released, since those are edge-triggered) so a slow agent
+// degrades gracefully instead of stalling the game. Without a connected
+// client the ship receives no input. The episode counter increases every
+// time a new game starts.
+package remote
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/matheus3301/asteroids/internal/game"
+	"github.com/matheus3301/asteroids/internal/logging"
+)
+
+// ProtocolVersion is sent in the hello message.
+const ProtocolVersion = 1
+
+// DefaultTimeout is how long a tick waits for the agent's action.
+const DefaultTimeout = 50 * time.Millisecond
+
+var logger = logging.For("remote")
+
+// Action is the agent's input for one tick.
+type Action struct {
+	Left       bool `json:"left"`
+	Right      bool `json:"right"`
+	Thrust     bool `json:"thrust"`
+	Shoot      bool `json:"shoot"`
+	Hyperspace bool `json:"hyperspace"`
+	Missile    bool `json:"missile"`
+	Shield     bool `json:"shield"`
+}
+
+// Input converts the action into the game's input state.
+func (a Action) Input() game.InputState {
+	return game.InputState{
+		RotateLeft:  a.Left,
+		RotateRight: a.Right,
+		Thrust:      a.Thrust,
+		Shoot:       a.Shoot,
+		Hyperspace:  a.Hyperspace,
+		Missile:     a.Missile,
+		Shield:      a.Shield,
+	}
+}
+
+type hello struct {
+	Type      string `json:"type"`
+	Version   int    `json:"version"`
+	TickRate  int    `json:"tick_rate"`
+	TimeoutMS int64  `json:"timeout_ms"`
+}
+
+type observation struct {
+	Type    string           `json:"type"`
+	Episode int              `json:"episode"`
+	Tick    int              `json:"tick"`
+	Obs     game.Observation `json:"obs"`
+}
+
+type reply struct {
+	Tick   int    `json:"tick"`
+	Action Action `json:"action"`
+}
+
+// Stats counts how well the agent is keeping up.
+type Stats struct {
+	Ticks int // observations sent
+	Late  int // ticks that fell back to the held action
+}
+
+// Server accepts one agent at a time and implements game.InputSource.
+// A new connection replaces the previous one.
+type Server struct {
+	ln      net.Listener
+	timeout time.Duration
+
+	mu      sync.Mutex
+	conn    net.Conn
+	replies chan reply
+
+	world   *game.World
+	episode int
+	last    Action
+	stats   Stats
+	view    float64
+}
+
+// Listen starts accepting agents on addr. A timeout of zero means
+// DefaultTimeout.
+func Listen(addr string, timeout time.Duration) (*Server, error) {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{ln: ln, timeout: timeout}
+	go s.acceptLoop()
+	return s, nil
+}
+
+// LimitView leaves objects further than radius from the ship out of the
+// observations sent, so an agent sees no more than a player could. Zero
+// sends everything the fog, if any, does not hide. Call it before the
+// game starts.
+func (s *Server) LimitView(radius float64) {
+	s.view = radius
+}
+
+// Addr returns the listening address.
+func (s *Server) Addr() net.Addr {
+	return s.ln.Addr()
+}
+
+// Close stops listening and drops the current agent.
+func (s *Server) Close() error {
+	err := s.ln.Close()
+	s.mu.Lock()
+	if s.conn != nil {
+		_ = s.conn.Close()
+		s.conn = nil
+	}
+	s.mu.Unlock()
+	return err
+}
+
+// Stats returns the counters collected so far.
+func (s *Server) Stats() Stats {
+	return s.stats
+}
+
+func (s *Server) acceptLoop() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				logger.Error("accept", "err", err)
+			}
+			return
+		}
+		logger.Info("agent connected", "addr", conn.RemoteAddr())
+
+		h := hello{Type: "hello", Version: ProtocolVersion, TickRate: 60, TimeoutMS: s.timeout.Milliseconds()}
+		if err := writeLine(conn, s.timeout, h); err != nil {
+			logger.Warn("sending hello", "err", err)
+			_ = conn.Close()
+			continue
+		}
+
+		replies := make(chan reply, 16)
+		s.mu.Lock()
+		if s.conn != nil {
+			_ = s.conn.Close()
+		}
+		s.conn = conn
+		s.replies = replies
+		s.mu.Unlock()
+		go s.readLoop(conn, replies)
+	}
+}
+
+func (s *Server) readLoop(conn net.Conn, replies chan<- reply) {
+	defer close(replies)
+	sc := bufio.NewScanner(conn)
+	for sc.Scan() {
+		var r reply
+		if err := json.Unmarshal(sc.Bytes(), &r); err != nil {
+			logger.Warn("malformed action", "err", err)
+			continue
+		}
+		select {
+		case replies <- r:
+		default:
+			logger.Debug("dropping action, agent is running ahead", "tick", r.Tick)
+		}
+	}
+	logger.Info("agent disconnected", "addr", conn.RemoteAddr())
+	s.mu.Lock()
+	if s.conn == conn {
+		s.conn = nil
+	}
+	s.mu.Unlock()
+}
+
+// NextInput implements game.InputSource. It sends the observation for the
+// current tick and waits for the agent's answer.
+func (s *Server) NextInput(w *game.World) game.InputState {
+	if w != s.world {
+		s.world = w
+		s.episode++
+		s.last = Action{}
+	}
+
+	s.mu.Lock()
+	conn, replies := s.conn, s.replies
+	s.mu.Unlock()
+	if conn == nil {
+		return game.InputState{}
+	}
+
+	s.stats.Ticks++
+	obs := game.ObserveVisible(w)
+	if s.view > 0 {
+		obs = obs.Within(s.view)
+	}
+	msg := observation{Type: "obs", Episode: s.episode, Tick: w.Tick, Obs: obs}
+	if err := writeLine(conn, s.timeout, msg); err != nil {
+		logger.Warn("sending observation", "err", err)
+		_ = conn.Close()
+		return game.InputState{}
+	}
+
+	deadline := time.NewTimer(s.timeout)
+	defer deadline.Stop()
+	for {
+		select {
+		case r, ok := <-replies:
+			if !ok {
+				return game.InputState{}
+			}
+			if r.Tick < w.Tick {
+				continue // a late answer to an earlier tick
+			}
+			s.last = r.Action
+			return r.Action.Input()
+		case <-deadline.C:
+			s.stats.Late++
+			s.last.Shoot = false
+			s.last.Hyperspace = false
+			s.last.Missile = false
+			return s.last.Input()
+		}
+	}
+}
+
+func writeLine(conn net.Conn, timeout time.Duration, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := conn.SetWriteDeadline(time.Now().Add(timeout)); err != nil {
+		return err
+	}
+	_, err = conn.Write(append(b, '\n'))
+	return err
+}
@@ -0,0 +1,169 @@
+package remote
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/matheus3301/asteroids/internal/game"
+)
+
+type client struct {
+	t    *testing.T
+	conn net.Conn
+	sc   *bufio.Scanner
+}
+
+func dial(t *testing.T, s *Server) *client {
+	t.Helper()
+	conn, err := net.Dial("tcp", s.Addr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	c := &client{t: t, conn: conn, sc: bufio.NewScanner(conn)}
+	c.sc.Buffer(nil, 1<<20)
+
+	var h hello
+	c.read(&h)
+	if h.Type != "hello" || h.Version != ProtocolVersion {
+		t.Fatalf("hello = %+v", h)
+	}
+	return c
+}
+
+func (c *client) read(v any) {
+	c.t.Helper()
+	if !c.sc.Scan() {
+		c.t.Fatalf("reading: %v", c.sc.Err())
+	}
+	if err := json.Unmarshal(c.sc.Bytes(), v); err != nil {
+		c.t.Fatalf("decoding %q: %v", c.sc.Text(), err)
+	}
+}
+
+func (c *client) send(tick int, a Action) {
+	c.t.Helper()
+	b, _ := json.Marshal(reply{Tick: tick, Action: a})
+	if _, err := c.conn.Write(append(b, '\n')); err != nil {
+		c.t.Fatalf("writing: %v", err)
+	}
+}
+
+func listen(t *testing.T, timeout time.Duration) *Server {
+	t.Helper()
+	s, err := Listen("127.0.0.1:0", timeout)
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+// waitConnected blocks until the server has registered the client.
+func waitConnected(t *testing.T, s *Server) {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		s.mu.Lock()
+		ok := s.conn != nil
+		s.mu.Unlock()
+		if ok {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("client never registered")
+}
+
+func TestServer_NoClientMeansNoInput(t *testing.T) {
+	s := listen(t, 10*time.Millisecond)
+	if in := s.NextInput(game.NewGameWorld(1)); in != (game.InputState{}) {
+		t.Errorf("input without a client = %+v, want zero", in)
+	}
+}
+
+func TestServer_ObservationAndAction(t *testing.T) {
+	s := listen(t, time.Second)
+	c := dial(t, s)
+	waitConnected(t, s)
+	w := game.NewGameWorld(1)
+
+	done := make(chan game.InputState)
+	go func() { done <- s.NextInput(w) }()
+
+	var msg observation
+	c.read(&msg)
+	if msg.Type != "obs" || msg.Episode != 1 || msg.Tick != w.Tick {
+		t.Fatalf("header = %+v", msg)
+	}
+	if msg.Obs.Player == nil || len(msg.Obs.Asteroids) == 0 {
+		t.Fatalf("observation is missing the player or asteroids: %+v", msg.Obs)
+	}
+	c.send(msg.Tick, Action{Right: true, Shoot: true})
+
+	in := <-done
+	want := game.InputState{RotateRight: true, Shoot: true}
+	if in != want {
+		t.Errorf("input = %+v, want %+v", in, want)
+	}
+}
+
+func TestServer_LimitView(t *testing.T) {
+	s := listen(t, time.Second)
+	s.LimitView(100)
+	c := dial(t, s)
+	waitConnected(t, s)
+
+	go s.NextInput(game.NewGameWorld(1))
+	var msg observation
+	c.read(&msg)
+	// Waves spawn well clear of the ship.
+	if msg.Obs.Player == nil || len(msg.Obs.Asteroids) != 0 {
+		t.Errorf("expected only the ship within 100px, got %d asteroids", len(msg.Obs.Asteroids))
+	}
+	c.send(msg.Tick, Action{})
+}
+
+func TestServer_TimeoutHoldsLastAction(t *testing.T) {
+	s := listen(t, 30*time.Millisecond)
+	c := dial(t, s)
+	waitConnected(t, s)
+	w := game.NewGameWorld(1)
+
+	c.send(0, Action{Thrust: true, Shoot: true})
+	var msg observation
+	first := make(chan game.InputState)
+	go func() { first <- s.NextInput(w) }()
+	c.read(&msg)
+	if in := <-first; !in.Thrust || !in.Shoot {
+		t.Fatalf("first input = %+v, want thrust and shoot", in)
+	}
+
+	// No answer this time: thrust is held, shoot is released.
+	w.Tick++
+	in := s.NextInput(w)
+	c.read(&msg)
+	if !in.Thrust || in.Shoot {
+		t.Errorf("held input = %+v, want thrust only", in)
+	}
+	if st := s.Stats(); st.Late != 1 || st.Ticks != 2 {
+		t.Errorf("stats = %+v, want 2 ticks with 1 late", st)
+	}
+}
+
+func TestServer_NewWorldStartsNewEpisode(t *testing.T) {
+	s := listen(t, 10*time.Millisecond)
+	c := dial(t, s)
+	waitConnected(t, s)
+
+	var msg observation
+	s.NextInput(game.NewGameWorld(1))
+	c.read(&msg)
+	s.NextInput(game.NewGameWorld(2))
+	c.read(&msg)
+	if msg.Episode != 2 {
+		t.Errorf("episode = %d, want 2", msg.Episode)
+	}
+}
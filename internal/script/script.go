@@ -0,0 +1,164 @@
+// Package script runs Starlark mods that change the game rules.
+//
+// A mod is a .star file defining any of these functions:
+//
+//	def on_wave_start(state, wave, count):    # return the asteroid count
+//	def on_asteroid_destroyed(state, asteroid, points):  # return the points
+//	def on_tick(state):
+//
+// state exposes the game: score and lives can be changed, while tick,
+// level, asteroids, saucers, player_x and player_y are read-only. asteroid
+// has size ("large", "medium" or "small"), x and y. Returning None keeps the
+// default. Mods cannot load files or reach the network, and each hook call
+// is limited to a fixed number of interpreter steps. A mod that fails is
+// switched off for the rest of the session and the standard rules resume.
+package script
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/matheus3301/asteroids/internal/game"
+	"github.com/matheus3301/asteroids/internal/logging"
+	"go.starlark.net/starlark"
+)
+
+var logger = logging.For("script")
+
+// DefaultMaxSteps is the interpreter step budget of a single hook call.
+const DefaultMaxSteps = 100_000
+
+// Hook function names.
+const (
+	hookWaveStart         = "on_wave_start"
+	hookAsteroidDestroyed = "on_asteroid_destroyed"
+	hookTick              = "on_tick"
+)
+
+// Script is a loaded mod. It implements game.RuleHooks.
+type Script struct {
+	name     string
+	thread   *starlark.Thread
+	maxSteps uint64
+	hooks    map[string]starlark.Callable
+	err      error
+}
+
+var _ game.RuleHooks = (*Script)(nil)
+
+// Load reads and runs the mod at path.
+func Load(path string, maxSteps uint64) (*Script, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Compile(path, src, maxSteps)
+}
+
+// Compile runs a mod's top level, which may only define functions and
+// constants, and collects its hooks. name is used in error messages.
+func Compile(name string, src []byte, maxSteps uint64) (*Script, error) {
+	if maxSteps == 0 {
+		maxSteps = DefaultMaxSteps
+	}
+	s := &Script{name: name, maxSteps: maxSteps, hooks: map[string]starlark.Callable{}}
+	s.thread = &starlark.Thread{
+		Name:  name,
+		Print: func(_ *starlark.Thread, msg string) { logger.Info(msg, "script", name) },
+	}
+	s.thread.SetMaxExecutionSteps(maxSteps)
+	globals, err := starlark.ExecFile(s.thread, name, src, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, hook := range []string{hookWaveStart, hookAsteroidDestroyed, hookTick} {
+		v, ok := globals[hook]
+		if !ok {
+			continue
+		}
+		fn, ok := v.(starlark.Callable)
+		if !ok {
+			return nil, fmt.Errorf("%s: %s is a %s, not a function", name, hook, v.Type())
+		}
+		s.hooks[hook] = fn
+	}
+	if len(s.hooks) == 0 {
+		return nil, fmt.Errorf("%s: defines none of %s, %s or %s", name, hookWaveStart, hookAsteroidDestroyed, hookTick)
+	}
+	return s, nil
+}
+
+// Err returns the error that switched the mod off, or nil while it runs.
+func (s *Script) Err() error {
+	return s.err
+}
+
+// call runs a hook with a fresh step budget. It returns nil when the hook
+// is not defined, the mod has failed, or the hook returned None.
+func (s *Script) call(hook string, w *game.World, args ...starlark.Value) starlark.Value {
+	fn := s.hooks[hook]
+	if fn == nil || s.err != nil {
+		return nil
+	}
+	st := &state{w: w}
+	defer func() { st.w = nil }()
+
+	s.thread.SetMaxExecutionSteps(s.thread.ExecutionSteps() + s.maxSteps)
+	v, err := starlark.Call(s.thread, fn, append(starlark.Tuple{st}, args...), nil)
+	if err != nil {
+		s.fail(err)
+		return nil
+	}
+	if v == starlark.None {
+		return nil
+	}
+	return v
+}
+
+func (s *Script) fail(err error) {
+	var evalErr *starlark.EvalError
+	if errors.As(err, &evalErr) {
+		err = errors.New(evalErr.Backtrace())
+	}
+	s.err = err
+	logger.Error("mod failed, standard rules resume", "script", s.name, "err", err)
+}
+
+// toInt converts a hook's return value, failing the mod when it is not an
+// int.
+func (s *Script) toInt(hook string, v starlark.Value, def int) int {
+	if v == nil {
+		return def
+	}
+	n, err := starlark.AsInt32(v)
+	if err != nil {
+		s.fail(fmt.Errorf("%s: %s must return an int or None, got %s", s.name, hook, v.Type()))
+		return def
+	}
+	return n
+}
+
+// WaveStart implements game.RuleHooks.
+func (s *Script) WaveStart(w *game.World, wave, count int) int {
+	v := s.call(hookWaveStart, w, starlark.MakeInt(wave), starlark.MakeInt(count))
+	return s.toInt(hookWaveStart, v, count)
+}
+
+// AsteroidDestroyed implements game.RuleHooks.
+func (s *Script) AsteroidDestroyed(w *game.World, size game.AsteroidSize, x, y float64, points int) int {
+	ast := &asteroid{size: sizeNames[size], x: x, y: y}
+	v := s.call(hookAsteroidDestroyed, w, ast, starlark.MakeInt(points))
+	return s.toInt(hookAsteroidDestroyed, v, points)
+}
+
+// Tick implements game.RuleHooks.
+func (s *Script) Tick(w *game.World) {
+	s.call(hookTick, w)
+}
+
+var sizeNames = map[game.AsteroidSize]string{
+	game.SizeLarge:  "large",
+	game.SizeMedium: "medium",
+	game.SizeSmall:  "small",
+}
@@ -0,0 +1,171 @@
+package script
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matheus3301/asteroids/internal/game"
+)
+
+func compile(t *testing.T, src string) *Script {
+	t.Helper()
+	s, err := Compile("test.star", []byte(src), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func newWorld(seed int64, s *Script) *game.World {
+	rules := game.StandardRules()
+	rules.Hooks = s
+	return game.NewModdedWorld(seed, rules)
+}
+
+func TestWaveStart_ChangesAsteroidCount(t *testing.T) {
+	s := compile(t, `
+def on_wave_start(state, wave, count):
+    return wave * 2
+`)
+	w := newWorld(1, s)
+	if n := w.AsteroidCount(); n != 2 {
+		t.Errorf("wave 1 has %d asteroids, want 2", n)
+	}
+}
+
+func TestAsteroidDestroyed_CustomScoring(t *testing.T) {
+	s := compile(t, `
+def on_asteroid_destroyed(state, asteroid, points):
+    if asteroid.size == "large":
+        return points * 3
+`)
+	w := newWorld(1, s)
+	if got := s.AsteroidDestroyed(w, game.SizeLarge, 10, 10, 20); got != 60 {
+		t.Errorf("large asteroid scored %d, want 60", got)
+	}
+	if got := s.AsteroidDestroyed(w, game.SizeSmall, 10, 10, 100); got != 100 {
+		t.Errorf("returning None should keep the default, got %d", got)
+	}
+}
+
+func TestTick_ModifiesWhitelistedState(t *testing.T) {
+	s := compile(t, `
+def on_tick(state):
+    if state.tick == 5:
+        state.score += 1000
+        state.lives = 9
+`)
+	w := newWorld(1, s)
+	for i := 0; i < 10; i++ {
+		game.Step(w, game.InputState{})
+	}
+	if w.Score != 1000 || w.Lives != 9 {
+		t.Errorf("score=%d lives=%d, want 1000 and 9", w.Score, w.Lives)
+	}
+	if s.Err() != nil {
+		t.Error(s.Err())
+	}
+}
+
+func TestReadOnlyStateFailsTheMod(t *testing.T) {
+	s := compile(t, `
+def on_tick(state):
+    state.level = 10
+`)
+	w := newWorld(1, s)
+	game.Step(w, game.InputState{})
+	if s.Err() == nil || !strings.Contains(s.Err().Error(), "read-only") {
+		t.Errorf("expected a read-only error, got %v", s.Err())
+	}
+	if w.Level != 1 {
+		t.Error("the level must not change")
+	}
+}
+
+func TestStepLimit(t *testing.T) {
+	s, err := Compile("slow.star", []byte(`
+def on_asteroid_destroyed(state, asteroid, points):
+    total = 0
+    for i in range(10000000):
+        total += i
+    return total
+`), 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := newWorld(1, s)
+	if got := s.AsteroidDestroyed(w, game.SizeLarge, 0, 0, 20); got != 20 {
+		t.Errorf("a runaway hook should fall back to the default, got %d", got)
+	}
+	if s.Err() == nil {
+		t.Fatal("expected the step limit to switch the mod off")
+	}
+	if got := s.AsteroidDestroyed(w, game.SizeLarge, 0, 0, 20); got != 20 {
+		t.Error("a failed mod should stay off")
+	}
+}
+
+func TestStepBudgetIsPerCall(t *testing.T) {
+	s, err := Compile("busy.star", []byte(`
+def on_tick(state):
+    for i in range(100):
+        pass
+`), 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := newWorld(1, s)
+	for i := 0; i < 100; i++ {
+		game.Step(w, game.InputState{})
+	}
+	if s.Err() != nil {
+		t.Errorf("cheap hooks should not run out of steps over many ticks: %v", s.Err())
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	for name, src := range map[string]string{
+		"syntax":      "def on_tick(state)\n",
+		"no hooks":    "x = 1\n",
+		"not a func":  "on_tick = 3\n",
+		"load":        "load('other.star', 'x')\ndef on_tick(state):\n    pass\n",
+		"top runaway": "def f():\n    for i in range(100000000):\n        pass\nf()\ndef on_tick(state):\n    pass\n",
+	} {
+		if _, err := Compile(name, []byte(src), 1000); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestExampleMod(t *testing.T) {
+	s, err := Load("../../examples/mods/bonus.star", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := newWorld(1, s)
+	for i := 0; i < 600; i++ {
+		game.Step(w, game.ScriptedInput(w.Tick))
+	}
+	if s.Err() != nil {
+		t.Error(s.Err())
+	}
+}
+
+func TestDeterministic(t *testing.T) {
+	src := `
+def on_wave_start(state, wave, count):
+    return count + 1
+
+def on_asteroid_destroyed(state, asteroid, points):
+    return points + state.tick % 7
+`
+	a := newWorld(4, compile(t, src))
+	b := newWorld(4, compile(t, src))
+	for i := 0; i < 1200; i++ {
+		game.Step(a, game.ScriptedInput(a.Tick))
+		game.Step(b, game.ScriptedInput(b.Tick))
+	}
+	if a.Checksum() != b.Checksum() {
+		t.Error("the same mod and seed should reproduce the same game")
+	}
+}
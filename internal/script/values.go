@@ -0,0 +1,104 @@
+package script
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/matheus3301/asteroids/internal/game"
+	"go.starlark.net/starlark"
+)
+
+// state is the view of the world passed to hooks. It is only valid during
+// the call; a mod that keeps it gets an error when using it later.
+type state struct {
+	w *game.World
+}
+
+var stateAttrs = []string{"asteroids", "level", "lives", "player_x", "player_y", "saucers", "score", "tick"}
+
+func (s *state) String() string        { return "<state>" }
+func (s *state) Type() string          { return "state" }
+func (s *state) Freeze()               {}
+func (s *state) Truth() starlark.Bool  { return starlark.True }
+func (s *state) Hash() (uint32, error) { return 0, errors.New("unhashable type: state") }
+func (s *state) AttrNames() []string   { return stateAttrs }
+
+func (s *state) Attr(name string) (starlark.Value, error) {
+	if s.w == nil {
+		return nil, errors.New("state used outside the hook it was passed to")
+	}
+	switch name {
+	case "score":
+		return starlark.MakeInt(s.w.Score), nil
+	case "lives":
+		return starlark.MakeInt(s.w.Lives), nil
+	case "level":
+		return starlark.MakeInt(s.w.Level), nil
+	case "tick":
+		return starlark.MakeInt(s.w.Tick), nil
+	case "asteroids":
+		return starlark.MakeInt(s.w.AsteroidCount()), nil
+	case "saucers":
+		return starlark.MakeInt(s.w.SaucerCount()), nil
+	case "player_x", "player_y":
+		x, y, ok := s.w.PlayerPosition()
+		if !ok {
+			return starlark.None, nil
+		}
+		if name == "player_x" {
+			return starlark.Float(x), nil
+		}
+		return starlark.Float(y), nil
+	}
+	return nil, nil
+}
+
+func (s *state) SetField(name string, val starlark.Value) error {
+	if s.w == nil {
+		return errors.New("state used outside the hook it was passed to")
+	}
+	n, err := starlark.AsInt32(val)
+	if err != nil {
+		return fmt.Errorf("state.%s must be an int", name)
+	}
+	switch name {
+	case "score":
+		if n < 0 {
+			return errors.New("state.score cannot be negative")
+		}
+		s.w.Score = n
+	case "lives":
+		if n < 0 {
+			return errors.New("state.lives cannot be negative")
+		}
+		s.w.Lives = n
+	default:
+		return fmt.Errorf("state.%s is read-only", name)
+	}
+	return nil
+}
+
+// asteroid describes the asteroid passed to on_asteroid_destroyed.
+type asteroid struct {
+	size string
+	x, y float64
+}
+
+func (a *asteroid) String() string        { return fmt.Sprintf("<asteroid %s>", a.size) }
+func (a *asteroid) Type() string          { return "asteroid" }
+func (a *asteroid) Freeze()               {}
+func (a *asteroid) Truth() starlark.Bool  { return starlark.True }
+func (a *asteroid) Hash() (uint32, error) { return 0, errors.New("unhashable type: asteroid") }
+func (a *asteroid) AttrNames() []string   { return []string{"size", "x", "y"} }
+
+func (a *asteroid) Attr(name string) (starlark.Value, error) {
+	switch name {
+	case "size":
+		return starlark.String(a.size), nil
+	case "x":
+		return starlark.Float(a.x), nil
+	case "y":
+		return starlark.Float(a.y), nil
+	}
+	return nil, nil
+}
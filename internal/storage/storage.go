@@ -0,0 +1,140 @@
+// Package storage decides where the game keeps its files.
+//
+// Files are split the way the XDG base directory spec splits them: config
+// for settings the player edits, data for things the game produces and
+// wants to keep (replays, high scores, profiles), and cache for anything
+// that can be regenerated. Each platform's usual locations are used:
+//
+//	Linux/BSD: $XDG_CONFIG_HOME, $XDG_DATA_HOME, $XDG_CACHE_HOME (or ~/.config, ~/.local/share, ~/.cache)
+//	macOS:     ~/Library/Application Support, ~/Library/Caches
+//	Windows:   %AppData%, %LocalAppData%
+//
+// Setting ASTEROIDS_HOME puts everything under one directory instead, which
+// is handy for portable installs and tests.
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+)
+
+// EnvVar overrides the platform directories when set.
+const EnvVar = "ASTEROIDS_HOME"
+
+const appName = "asteroids"
+
+// Dirs is a resolved set of directories. Nothing is created until Ensure
+// is called.
+type Dirs struct {
+	Config string
+	Data   string
+	Cache  string
+}
+
+// At lays out Dirs under a single root directory.
+func At(root string) Dirs {
+	return Dirs{
+		Config: filepath.Join(root, "config"),
+		Data:   filepath.Join(root, "data"),
+		Cache:  filepath.Join(root, "cache"),
+	}
+}
+
+// Replays is where recorded games are saved.
+func (d Dirs) Replays() string {
+	return filepath.Join(d.Data, "replays")
+}
+
+// Ensure creates dir (and its parents) if needed and returns it.
+func Ensure(dir string) (string, error) {
+	return dir, os.MkdirAll(dir, 0o755)
+}
+
+var (
+	mu       sync.Mutex
+	override *Dirs
+)
+
+// Default resolves the directories for this platform, honouring EnvVar.
+func Default() (Dirs, error) {
+	mu.Lock()
+	o := override
+	mu.Unlock()
+	if o != nil {
+		return *o, nil
+	}
+	if root := os.Getenv(EnvVar); root != "" {
+		return At(root), nil
+	}
+	return platformDirs(runtime.GOOS, os.Getenv, os.UserHomeDir)
+}
+
+// Override makes Default return d until the returned function is called.
+// It exists so tests never touch the player's real files.
+func Override(d Dirs) (restore func()) {
+	mu.Lock()
+	prev := override
+	override = &d
+	mu.Unlock()
+	return func() {
+		mu.Lock()
+		override = prev
+		mu.Unlock()
+	}
+}
+
+func platformDirs(goos string, getenv func(string) string, home func() (string, error)) (Dirs, error) {
+	switch goos {
+	case "windows":
+		roaming, local := getenv("AppData"), getenv("LocalAppData")
+		if roaming == "" || local == "" {
+			h, err := home()
+			if err != nil {
+				return Dirs{}, err
+			}
+			roaming = filepath.Join(h, "AppData", "Roaming")
+			local = filepath.Join(h, "AppData", "Local")
+		}
+		return Dirs{
+			Config: filepath.Join(roaming, appName),
+			Data:   filepath.Join(local, appName),
+			Cache:  filepath.Join(local, appName, "cache"),
+		}, nil
+	case "darwin", "ios":
+		h, err := home()
+		if err != nil {
+			return Dirs{}, err
+		}
+		support := filepath.Join(h, "Library", "Application Support", appName)
+		return Dirs{
+			Config: support,
+			Data:   support,
+			Cache:  filepath.Join(h, "Library", "Caches", appName),
+		}, nil
+	}
+
+	xdg := func(env, fallback string) (string, error) {
+		if dir := getenv(env); filepath.IsAbs(dir) {
+			return filepath.Join(dir, appName), nil
+		}
+		h, err := home()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(h, fallback, appName), nil
+	}
+	var d Dirs
+	var err error
+	if d.Config, err = xdg("XDG_CONFIG_HOME", ".config"); err != nil {
+		return Dirs{}, err
+	}
+	if d.Data, err = xdg("XDG_DATA_HOME", filepath.Join(".local", "share")); err != nil {
+		return Dirs{}, err
+	}
+	if d.Cache, err = xdg("XDG_CACHE_HOME", ".cache"); err != nil {
+		return Dirs{}, err
+	}
+	return d, nil
+}
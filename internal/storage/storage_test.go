@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func env(vars map[string]string) func(string) string {
+	return func(k string) string { return vars[k] }
+}
+
+func home(dir string) func() (string, error) {
+	return func() (string, error) { return dir, nil }
+}
+
+func TestPlatformDirs_Linux(t *testing.T) {
+	d, err := platformDirs("linux", env(nil), home("/home/p"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Dirs{
+		Config: filepath.Join("/home/p", ".config", "asteroids"),
+		Data:   filepath.Join("/home/p", ".local", "share", "asteroids"),
+		Cache:  filepath.Join("/home/p", ".cache", "asteroids"),
+	}
+	if d != want {
+		t.Errorf("got %+v, want %+v", d, want)
+	}
+}
+
+func TestPlatformDirs_XDGOverrides(t *testing.T) {
+	d, err := platformDirs("freebsd", env(map[string]string{
+		"XDG_CONFIG_HOME": "/xdg/config",
+		"XDG_DATA_HOME":   "relative/ignored",
+	}), home("/home/p"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Config != filepath.Join("/xdg/config", "asteroids") {
+		t.Errorf("config = %q", d.Config)
+	}
+	if d.Data != filepath.Join("/home/p", ".local", "share", "asteroids") {
+		t.Errorf("relative XDG paths must be ignored, data = %q", d.Data)
+	}
+}
+
+func TestPlatformDirs_Windows(t *testing.T) {
+	d, err := platformDirs("windows", env(map[string]string{
+		"AppData":      `C:\Users\p\AppData\Roaming`,
+		"LocalAppData": `C:\Users\p\AppData\Local`,
+	}), home(`C:\Users\p`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Config != filepath.Join(`C:\Users\p\AppData\Roaming`, "asteroids") {
+		t.Errorf("config = %q", d.Config)
+	}
+	if d.Data != filepath.Join(`C:\Users\p\AppData\Local`, "asteroids") {
+		t.Errorf("data = %q", d.Data)
+	}
+}
+
+func TestPlatformDirs_NoHome(t *testing.T) {
+	fail := func() (string, error) { return "", errors.New("no home") }
+	if _, err := platformDirs("darwin", env(nil), fail); err == nil {
+		t.Error("expected an error without a home directory")
+	}
+}
+
+func TestDefault_EnvVar(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv(EnvVar, root)
+	d, err := Default()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d != At(root) {
+		t.Errorf("got %+v, want everything under %s", d, root)
+	}
+}
+
+func TestOverride(t *testing.T) {
+	want := At("/fake")
+	restore := Override(want)
+	if d, _ := Default(); d != want {
+		t.Errorf("got %+v, want the override", d)
+	}
+	restore()
+	if d, _ := Default(); d == want {
+		t.Error("restore should remove the override")
+	}
+}
+
+func TestEnsure(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "a", "b")
+	got, err := Ensure(dir)
+	if err != nil || got != dir {
+		t.Fatalf("Ensure = %q, %v", got, err)
+	}
+	if fi, err := os.Stat(dir); err != nil || !fi.IsDir() {
+		t.Errorf("directory was not created: %v", err)
+	}
+}
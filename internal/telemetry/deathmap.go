@@ -0,0 +1,48 @@
+package telemetry
+
+import "slices"
+
+// The death map divides the playfield into DeathMapCols x DeathMapRows
+// cells, whatever its size in pixels.
+const (
+	DeathMapCols = 40
+	DeathMapRows = 30
+)
+
+// DeathSpot is where a ship was lost, as a fraction of the playfield's
+// width and height from its top-left corner, and to what.
+type DeathSpot struct {
+	X, Y  float64
+	Cause string
+}
+
+// DeathMap counts ship losses in each cell of the playfield, by cause.
+// Each count list holds DeathMapCols x DeathMapRows cells, row by row.
+type DeathMap map[string][]int
+
+// Add counts a loss at d.
+func (m DeathMap) Add(d DeathSpot) {
+	cells := m[d.Cause]
+	if len(cells) != DeathMapCols*DeathMapRows {
+		cells = make([]int, DeathMapCols*DeathMapRows)
+		m[d.Cause] = cells
+	}
+	col := min(max(int(d.X*DeathMapCols), 0), DeathMapCols-1)
+	row := min(max(int(d.Y*DeathMapRows), 0), DeathMapRows-1)
+	cells[row*DeathMapCols+col]++
+}
+
+// Cells sums the counts of the given causes, or of every cause when none
+// are given, into one list laid out as the map's.
+func (m DeathMap) Cells(causes ...string) []int {
+	sum := make([]int, DeathMapCols*DeathMapRows)
+	for cause, cells := range m {
+		if len(causes) > 0 && !slices.Contains(causes, cause) {
+			continue
+		}
+		for i := range min(len(cells), len(sum)) {
+			sum[i] += cells[i]
+		}
+	}
+	return sum
+}
@@ -0,0 +1,44 @@
+package telemetry
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDeathMap_CountsByCell(t *testing.T) {
+	var s Summary
+	s.Add(Game{DeathSpots: []DeathSpot{
+		{X: 0.5, Y: 0.5, Cause: "asteroid"},
+		{X: 0.51, Y: 0.51, Cause: "saucer"},
+		{X: 1.2, Y: -0.1, Cause: "asteroid"},
+	}})
+
+	center := (DeathMapRows/2)*DeathMapCols + DeathMapCols/2
+	corner := DeathMapCols - 1
+	all := s.DeathMap.Cells()
+	if all[center] != 2 || all[corner] != 1 {
+		t.Errorf("expected 2 deaths in the centre and 1 clamped to the top-right, got %d and %d", all[center], all[corner])
+	}
+	if got := s.DeathMap.Cells("saucer")[center]; got != 1 {
+		t.Errorf("expected 1 saucer death in the centre, got %d", got)
+	}
+	if got := s.DeathMap.Cells("hyperspace")[center]; got != 0 {
+		t.Errorf("expected no hyperspace deaths, got %d", got)
+	}
+}
+
+func TestDeathMap_SavedWithTheSummary(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	s := &Summary{}
+	s.Add(Game{DeathSpots: []DeathSpot{{X: 0.1, Y: 0.1, Cause: "saucer bullet"}}})
+	if err := s.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := got.DeathMap.Cells()[3*DeathMapCols+4]; n != 1 {
+		t.Errorf("expected the death kept in its cell, got %d", n)
+	}
+}
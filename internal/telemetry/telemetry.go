@@ -0,0 +1,151 @@
+// Package telemetry keeps opt-in, local-only aggregates of how games go,
+// to help tune balance. Nothing is ever sent anywhere: the summary is a
+// JSON file in the data directory that the player can read or delete.
+package telemetry
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/matheus3301/asteroids/internal/storage"
+)
+
+// FileName is the summary file inside the data directory.
+const FileName = "telemetry.json"
+
+// Game is what one finished game contributes to the summary.
+type Game struct {
+	Score           int
+	Level           int
+	Ticks           int
+	Deaths          map[string]int
+	HyperspaceJumps int
+	ShotsFired      int
+	// ShotsHit and ShotsMissed count shots that hit something and that
+	// ran out of life first.
+	ShotsHit, ShotsMissed int
+	AsteroidsDestroyed    int
+	SaucersDestroyed      int
+	PowerUps              int
+	// DeathSpots are where the ship was lost, in order.
+	DeathSpots []DeathSpot
+}
+
+// Summary aggregates every recorded game.
+type Summary struct {
+	// Enabled is the player's opt-in choice. It is stored with the data so
+	// the choice survives restarts.
+	Enabled bool `json:"enabled"`
+
+	Games              int            `json:"games"`
+	Ticks              int64          `json:"ticks"`
+	TotalWaves         int            `json:"total_waves"`
+	BestWave           int            `json:"best_wave"`
+	TotalScore         int64          `json:"total_score"`
+	BestScore          int            `json:"best_score"`
+	Deaths             map[string]int `json:"deaths"`
+	HyperspaceJumps    int            `json:"hyperspace_jumps"`
+	ShotsFired         int            `json:"shots_fired"`
+	ShotsHit           int            `json:"shots_hit"`
+	ShotsMissed        int            `json:"shots_missed"`
+	AsteroidsDestroyed int            `json:"asteroids_destroyed"`
+	SaucersDestroyed   int            `json:"saucers_destroyed"`
+	PowerUps           int            `json:"power_ups"`
+
+	DeathMap DeathMap `json:"death_map,omitempty"`
+}
+
+// Add folds one game into the summary.
+func (s *Summary) Add(g Game) {
+	s.Games++
+	s.Ticks += int64(g.Ticks)
+	s.TotalWaves += g.Level
+	s.BestWave = max(s.BestWave, g.Level)
+	s.TotalScore += int64(g.Score)
+	s.BestScore = max(s.BestScore, g.Score)
+	if s.Deaths == nil {
+		s.Deaths = map[string]int{}
+	}
+	for cause, n := range g.Deaths {
+		s.Deaths[cause] += n
+	}
+	s.HyperspaceJumps += g.HyperspaceJumps
+	s.ShotsFired += g.ShotsFired
+	s.ShotsHit += g.ShotsHit
+	s.ShotsMissed += g.ShotsMissed
+	s.AsteroidsDestroyed += g.AsteroidsDestroyed
+	s.SaucersDestroyed += g.SaucersDestroyed
+	s.PowerUps += g.PowerUps
+	if len(g.DeathSpots) > 0 && s.DeathMap == nil {
+		s.DeathMap = DeathMap{}
+	}
+	for _, d := range g.DeathSpots {
+		s.DeathMap.Add(d)
+	}
+}
+
+// PerGame divides n by the number of games, or returns 0 before any.
+func (s *Summary) PerGame(n int64) float64 {
+	if s.Games == 0 {
+		return 0
+	}
+	return float64(n) / float64(s.Games)
+}
+
+// HitRate is the share of shots that hit something, out of those that hit
+// or missed. Summaries from before hits were counted fall back to what was
+// destroyed per shot fired, which explosions and armour skew.
+func (s *Summary) HitRate() float64 {
+	if n := s.ShotsHit + s.ShotsMissed; n > 0 {
+		return float64(s.ShotsHit) / float64(n)
+	}
+	if s.ShotsFired == 0 {
+		return 0
+	}
+	return float64(s.AsteroidsDestroyed+s.SaucersDestroyed) / float64(s.ShotsFired)
+}
+
+// Path returns where the summary is stored.
+func Path() (string, error) {
+	dirs, err := storage.Default()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dirs.Data, FileName), nil
+}
+
+// Load reads the summary at path. A missing file is an empty summary with
+// telemetry disabled.
+func Load(path string) (*Summary, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Summary{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	s := &Summary{}
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Save writes the summary to path, replacing it atomically.
+func (s *Summary) Save(path string) error {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := storage.Ensure(filepath.Dir(path)); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(b, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,81 @@
+package telemetry
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSummary_Add(t *testing.T) {
+	var s Summary
+	s.Add(Game{Score: 1000, Level: 2, Ticks: 600, Deaths: map[string]int{"asteroid": 3}, ShotsFired: 10, AsteroidsDestroyed: 4})
+	s.Add(Game{Score: 3000, Level: 4, Ticks: 900, Deaths: map[string]int{"asteroid": 1, "hyperspace": 2}, ShotsFired: 10, SaucersDestroyed: 1, HyperspaceJumps: 5})
+
+	if s.Games != 2 || s.BestWave != 4 || s.BestScore != 3000 {
+		t.Errorf("unexpected totals: %+v", s)
+	}
+	if got := s.PerGame(int64(s.TotalWaves)); got != 3 {
+		t.Errorf("average wave = %v, want 3", got)
+	}
+	if s.Deaths["asteroid"] != 4 || s.Deaths["hyperspace"] != 2 {
+		t.Errorf("deaths = %v", s.Deaths)
+	}
+	if got := s.HitRate(); math.Abs(got-0.25) > 1e-9 {
+		t.Errorf("hit rate = %v, want 0.25", got)
+	}
+
+	s.Add(Game{ShotsFired: 20, ShotsHit: 3, ShotsMissed: 9, AsteroidsDestroyed: 12})
+	if s.ShotsHit != 3 || s.ShotsMissed != 9 {
+		t.Errorf("shots hit %d, missed %d", s.ShotsHit, s.ShotsMissed)
+	}
+	if got := s.HitRate(); got != 0.25 {
+		t.Errorf("once hits are counted the hit rate should use them, got %v", got)
+	}
+}
+
+func TestSummary_EmptyRates(t *testing.T) {
+	var s Summary
+	if s.PerGame(10) != 0 || s.HitRate() != 0 {
+		t.Error("rates of an empty summary should be zero")
+	}
+}
+
+func TestLoad_MissingFileIsDisabled(t *testing.T) {
+	s, err := Load(filepath.Join(t.TempDir(), "none.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Enabled || s.Games != 0 {
+		t.Errorf("expected an empty disabled summary, got %+v", s)
+	}
+}
+
+func TestSaveLoad_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", FileName)
+	s := &Summary{Enabled: true}
+	s.Add(Game{Score: 50, Level: 1, Deaths: map[string]int{"saucer": 1}})
+	if err := s.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Enabled || got.Games != 1 || got.Deaths["saucer"] != 1 {
+		t.Errorf("round trip lost data: %+v", got)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Error("temporary file should be renamed away")
+	}
+}
+
+func TestLoad_Corrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("expected an error for a corrupt file")
+	}
+}
@@ -0,0 +1,91 @@
+// Package tui turns rasterised frames into text, so the game can be watched
+// in a terminal on machines without a display.
+package tui
+
+import (
+	"fmt"
+	"image"
+	"strings"
+)
+
+// Style selects how pixels are packed into characters.
+type Style int
+
+const (
+	// Braille packs a 2x4 block of pixels into one braille pattern
+	// character, one dot per pixel.
+	Braille Style = iota
+	// ASCII shades each 2x4 block by how many of its pixels are lit. It is
+	// coarser but works in terminals and fonts without braille glyphs.
+	ASCII
+)
+
+// CellW and CellH are the pixels covered by one character.
+const (
+	CellW = 2
+	CellH = 4
+)
+
+// litThreshold is the brightness (0-255) above which a pixel counts as lit.
+const litThreshold = 64
+
+// brailleDots maps a pixel's position in its cell to its braille dot bit.
+var brailleDots = [CellH][CellW]rune{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+// asciiRamp shades a cell by its count of lit pixels, 0 to 8.
+const asciiRamp = " ..::++##"
+
+// ParseStyle accepts "braille" or "ascii".
+func ParseStyle(s string) (Style, error) {
+	switch strings.ToLower(s) {
+	case "braille":
+		return Braille, nil
+	case "ascii":
+		return ASCII, nil
+	}
+	return 0, fmt.Errorf("unknown style %q (want braille or ascii)", s)
+}
+
+// Render converts img to lines of text, one character per CellW x CellH
+// pixels. Lines are separated by newlines, without a trailing one.
+func Render(img *image.RGBA, style Style) string {
+	b := img.Bounds()
+	cols := (b.Dx() + CellW - 1) / CellW
+	rows := (b.Dy() + CellH - 1) / CellH
+
+	var sb strings.Builder
+	for row := 0; row < rows; row++ {
+		if row > 0 {
+			sb.WriteByte('\n')
+		}
+		for col := 0; col < cols; col++ {
+			var dots rune
+			n := 0
+			for dy := 0; dy < CellH; dy++ {
+				for dx := 0; dx < CellW; dx++ {
+					x, y := b.Min.X+col*CellW+dx, b.Min.Y+row*CellH+dy
+					if x < b.Max.X && y < b.Max.Y && lit(img, x, y) {
+						dots |= brailleDots[dy][dx]
+						n++
+					}
+				}
+			}
+			if style == ASCII {
+				sb.WriteByte(asciiRamp[n])
+			} else {
+				sb.WriteRune(0x2800 + dots)
+			}
+		}
+	}
+	return sb.String()
+}
+
+func lit(img *image.RGBA, x, y int) bool {
+	c := img.RGBAAt(x, y)
+	return max(c.R, c.G, c.B) > litThreshold
+}
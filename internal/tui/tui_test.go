@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/matheus3301/asteroids/internal/canvas"
+)
+
+var white = color.RGBA{255, 255, 255, 255}
+
+func TestRender_Braille(t *testing.T) {
+	r := canvas.NewRaster(4, 4)
+	r.Fill(color.Black)
+	// Light the left column of the first cell and every pixel of the second.
+	r.FillRect(0, 0, 1, 4, white)
+	r.FillRect(2, 0, 2, 4, white)
+
+	if got, want := Render(r.Img, Braille), "⡇⣿"; got != want {
+		t.Errorf("Render = %q, want %q", got, want)
+	}
+}
+
+func TestRender_ASCII(t *testing.T) {
+	r := canvas.NewRaster(6, 8)
+	r.Fill(color.Black)
+	r.FillRect(2, 0, 1, 1, white)
+	r.FillRect(4, 0, 2, 4, white)
+
+	got := strings.Split(Render(r.Img, ASCII), "\n")
+	if len(got) != 2 || got[0] != " .#" || got[1] != "   " {
+		t.Errorf("Render = %q", got)
+	}
+}
+
+func TestRender_PartialCells(t *testing.T) {
+	r := canvas.NewRaster(3, 5)
+	r.Fill(white)
+	lines := strings.Split(Render(r.Img, Braille), "\n")
+	if len(lines) != 2 || len([]rune(lines[0])) != 2 {
+		t.Fatalf("a 3x5 image should be 2x2 cells, got %q", lines)
+	}
+	if lines[1] != "⠉⠁" {
+		t.Errorf("partial cells should only light pixels inside the image, got %q", lines[1])
+	}
+}
+
+func TestRender_DimPixelsAreUnlit(t *testing.T) {
+	r := canvas.NewRaster(2, 4)
+	r.Fill(color.RGBA{40, 40, 40, 255})
+	if got := Render(r.Img, Braille); got != "⠀" {
+		t.Errorf("Render = %q, want a blank cell", got)
+	}
+}
+
+func TestParseStyle(t *testing.T) {
+	if s, err := ParseStyle("ASCII"); err != nil || s != ASCII {
+		t.Errorf("ParseStyle(ASCII) = %v, %v", s, err)
+	}
+	if _, err := ParseStyle("sixel"); err == nil {
+		t.Error("expected an error for an unknown style")
+	}
+}
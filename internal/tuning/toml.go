@@ -0,0 +1,137 @@
+package tuning
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parse reads the subset of TOML that tuning files need: # comments,
+// [table] headers (dotted for nested tables), and bare keys set to a
+// string, integer, float or boolean. Arrays, inline tables and dates are
+// not supported.
+func parse(data []byte) (map[string]any, error) {
+	root := map[string]any{}
+	table := root
+	for i, line := range strings.Split(string(data), "\n") {
+		n := i + 1
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		if line[0] == '[' {
+			end := strings.IndexByte(line, ']')
+			if end < 0 || strings.HasPrefix(line, "[[") || !isComment(line[end+1:]) {
+				return nil, fmt.Errorf("line %d: bad table header", n)
+			}
+			var err error
+			if table, err = subtable(root, strings.TrimSpace(line[1:end])); err != nil {
+				return nil, fmt.Errorf("line %d: %w", n, err)
+			}
+			continue
+		}
+		key, raw, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || !bareKey(key) {
+			return nil, fmt.Errorf("line %d: want key = value", n)
+		}
+		v, err := value(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s: %w", n, key, err)
+		}
+		if _, dup := table[key]; dup {
+			return nil, fmt.Errorf("line %d: %s set twice", n, key)
+		}
+		table[key] = v
+	}
+	return root, nil
+}
+
+// subtable finds or makes the table at the dotted path under root.
+func subtable(root map[string]any, path string) (map[string]any, error) {
+	t := root
+	for _, key := range strings.Split(path, ".") {
+		key = strings.TrimSpace(key)
+		if !bareKey(key) {
+			return nil, fmt.Errorf("bad table name %q", path)
+		}
+		switch next := t[key].(type) {
+		case nil:
+			m := map[string]any{}
+			t[key] = m
+			t = m
+		case map[string]any:
+			t = next
+		default:
+			return nil, fmt.Errorf("%s is already a value", key)
+		}
+	}
+	return t, nil
+}
+
+// value reads the value after a key's '=', with any trailing comment.
+func value(s string) (any, error) {
+	if s == "" {
+		return nil, fmt.Errorf("no value")
+	}
+	switch s[0] {
+	case '"', '\'':
+		end := closingQuote(s)
+		if end < 0 || !isComment(s[end+1:]) {
+			return nil, fmt.Errorf("bad string %s", s)
+		}
+		if s[0] == '\'' {
+			return s[1:end], nil
+		}
+		return strconv.Unquote(s[:end+1])
+	}
+	if i := strings.IndexByte(s, '#'); i >= 0 {
+		s = strings.TrimSpace(s[:i])
+	}
+	switch s {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	num := strings.ReplaceAll(s, "_", "")
+	if i, err := strconv.ParseInt(num, 0, 64); err == nil {
+		return i, nil
+	}
+	if f, err := strconv.ParseFloat(num, 64); err == nil && !strings.ContainsAny(num, "xXpPnN") {
+		return f, nil
+	}
+	return nil, fmt.Errorf("unsupported value %s", s)
+}
+
+// closingQuote is the index of the quote that ends the string s starts
+// with, or -1.
+func closingQuote(s string) int {
+	q := s[0]
+	for i := 1; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && q == '"':
+			i++
+		case s[i] == q:
+			return i
+		}
+	}
+	return -1
+}
+
+func isComment(rest string) bool {
+	rest = strings.TrimSpace(rest)
+	return rest == "" || rest[0] == '#'
+}
+
+func bareKey(k string) bool {
+	if k == "" {
+		return false
+	}
+	for _, r := range k {
+		if !(r == '_' || r == '-' || '0' <= r && r <= '9' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,77 @@
+// Package tuning reads TOML tuning files and watches them for changes, so
+// gameplay values can be adjusted while the game runs.
+//
+// A tuning file sets any of the keys a struct has JSON tags for; nested
+// structs are TOML tables:
+//
+//	# faster ship, slower bullets
+//	thrust_power = 0.2
+//	bullet_speed = 6
+//
+//	[small_saucer.hard]
+//	aim_error = 0.1
+package tuning
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"time"
+)
+
+// Decode sets the fields of v, a pointer to a struct, named in the TOML
+// data. Fields the data does not name keep their values, and a key v has
+// no field for is an error.
+func Decode(data []byte, v any) error {
+	m, err := parse(data)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
+// Watcher notices when a file changes. It polls the file's modification
+// time and size rather than asking the OS for events, which is plenty for
+// a file edited by hand.
+type Watcher struct {
+	path string
+	mod  time.Time
+	size int64
+	seen bool
+}
+
+// NewWatcher watches the file at path. The first Changed reports the file
+// as changed if it exists.
+func NewWatcher(path string) *Watcher {
+	return &Watcher{path: path}
+}
+
+// Path is the watched file.
+func (w *Watcher) Path() string { return w.path }
+
+// Changed reports whether the file has changed since the last call and,
+// if so, returns its contents. A missing file is not a change.
+func (w *Watcher) Changed() ([]byte, bool, error) {
+	info, err := os.Stat(w.path)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if w.seen && info.ModTime().Equal(w.mod) && info.Size() == w.size {
+		return nil, false, nil
+	}
+	data, err := os.ReadFile(w.path)
+	if err != nil {
+		return nil, false, err
+	}
+	w.mod, w.size, w.seen = info.ModTime(), info.Size(), true
+	return data, true, nil
+}
@@ -0,0 +1,90 @@
+package tuning
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type inner struct {
+	Aim  float64 `json:"aim"`
+	Keep int     `json:"keep"`
+}
+
+type config struct {
+	Speed   float64 `json:"speed"`
+	Lives   int     `json:"lives"`
+	Pattern string  `json:"pattern"`
+	Fog     bool    `json:"fog"`
+	Small   inner   `json:"small"`
+}
+
+func TestDecode_OverlaysNamedFields(t *testing.T) {
+	c := config{Speed: 1, Lives: 3, Pattern: "uniform", Small: inner{Aim: 0.5, Keep: 7}}
+	data := `# tuning
+speed = 2.5   # faster
+lives = 10_000
+pattern = "ring" # a comment
+fog = true
+
+[small]
+aim = 1e-1
+`
+	if err := Decode([]byte(data), &c); err != nil {
+		t.Fatal(err)
+	}
+	want := config{Speed: 2.5, Lives: 10000, Pattern: "ring", Fog: true, Small: inner{Aim: 0.1, Keep: 7}}
+	if c != want {
+		t.Errorf("got %+v, want %+v", c, want)
+	}
+}
+
+func TestDecode_Errors(t *testing.T) {
+	for _, data := range []string{
+		"speed",
+		"speed = ",
+		"speed = [1, 2]",
+		"speed = nan",
+		"speed = 1\nspeed = 2",
+		`pattern = "ring`,
+		"[small\naim = 1",
+		"bogus = 1",
+		"speed = \"fast\"",
+	} {
+		var c config
+		if err := Decode([]byte(data), &c); err == nil {
+			t.Errorf("%q: expected an error", data)
+		}
+	}
+}
+
+func TestWatcher_Changed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tuning.toml")
+	w := NewWatcher(path)
+
+	if _, changed, err := w.Changed(); changed || err != nil {
+		t.Fatalf("missing file: changed=%v err=%v", changed, err)
+	}
+	if err := os.WriteFile(path, []byte("speed = 1"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	data, changed, err := w.Changed()
+	if !changed || err != nil || string(data) != "speed = 1" {
+		t.Fatalf("new file: changed=%v err=%v data=%q", changed, err, data)
+	}
+	if _, changed, _ := w.Changed(); changed {
+		t.Error("unchanged file reported as changed")
+	}
+
+	if err := os.WriteFile(path, []byte("speed = 2"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Second)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if data, changed, _ := w.Changed(); !changed || string(data) != "speed = 2" {
+		t.Errorf("edited file: changed=%v data=%q", changed, data)
+	}
+}
@@ -0,0 +1,183 @@
+// Package asteroids is the supported API for embedding the game simulation
+// in other programs, such as training harnesses, bots or analysis tools.
+//
+// Everything under internal/ may change between releases; this package will
+// not break existing callers. It offers deterministic, headless simulations
+// that are stepped one tick at a time, observation snapshots for agents,
+// rendering to an image, and a small Agent interface with an episode runner.
+//
+//	sim := asteroids.New(42)
+//	for !sim.GameOver() {
+//		sim.Step(asteroids.Input{Thrust: true, Shoot: sim.Tick()%10 == 0})
+//	}
+//	fmt.Println(sim.Score())
+package asteroids
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/matheus3301/asteroids/internal/canvas"
+	"github.com/matheus3301/asteroids/internal/game"
+)
+
+// Width and Height are the size of the standard playfield in pixels. A
+// Sim from NewWithField may be larger; observations give the actual size.
+const (
+	Width  = game.ScreenWidth
+	Height = game.ScreenHeight
+)
+
+// TicksPerSecond is the rate the game runs at when played in real time.
+const TicksPerSecond = 60
+
+// Input is the set of buttons held during one tick.
+type Input = game.InputState
+
+// Observation, ShipObservation and ObjectObservation describe what an agent
+// can see. Their JSON form is the one sent to remote agents, so it is kept
+// stable as well.
+type (
+	Observation       = game.Observation
+	ShipObservation   = game.ShipObservation
+	ObjectObservation = game.ObjectObservation
+)
+
+// Sim is one running game. A Sim is not safe for concurrent use, but
+// separate Sims are independent and may run in parallel.
+type Sim struct {
+	w    *game.World
+	view float64
+}
+
+// New starts a single-player game. Games with the same seed and inputs play
+// out identically.
+func New(seed int64) *Sim {
+	return &Sim{w: game.NewGameWorld(seed)}
+}
+
+// NewWithField starts a single-player game on a larger playfield, such as
+// one the shape of an ultrawide window. width and height may not be below
+// Width and Height.
+func NewWithField(seed int64, width, height float64) (*Sim, error) {
+	r := game.StandardRules()
+	r.Config.FieldWidth, r.Config.FieldHeight = width, height
+	if err := r.Config.Validate(); err != nil {
+		return nil, err
+	}
+	return &Sim{w: game.NewModdedWorld(seed, r)}, nil
+}
+
+// NewCoop starts a two-player game, stepped with StepCoop.
+func NewCoop(seed int64) *Sim {
+	return &Sim{w: game.NewCoopWorld(seed)}
+}
+
+// DisableHitStop turns off the brief freeze-frame when the ship dies or a
+// saucer is destroyed. Frozen ticks change nothing but the tick count, so
+// training harnesses may want to skip them.
+func (s *Sim) DisableHitStop() {
+	s.w.Config.HitStopTicks = 0
+}
+
+// EnableShootDown lets the ship's bullets destroy saucer bullets, for a
+// few points each. It changes the balance, so it is off by default.
+func (s *Sim) EnableShootDown() {
+	s.w.Config.SaucerBulletShootDown = true
+}
+
+// LimitView makes Observe leave out objects further than radius from the
+// ship, so an agent trained on it sees no more than a player in a foggy
+// run could, and the two can be compared fairly. The whole playfield is
+// always on screen, so distance is the only limit. Zero removes it.
+func (s *Sim) LimitView(radius float64) {
+	s.view = radius
+}
+
+// Step advances the game by one tick.
+func (s *Sim) Step(in Input) {
+	game.Step(s.w, in)
+	s.w.SoundQueue = s.w.SoundQueue[:0]
+}
+
+// StepCoop advances a two-player game by one tick.
+func (s *Sim) StepCoop(p1, p2 Input) {
+	game.StepCoop(s.w, game.Inputs{p1, p2})
+	s.w.SoundQueue = s.w.SoundQueue[:0]
+}
+
+// Observe returns a snapshot of the current state, limited by LimitView.
+func (s *Sim) Observe() Observation {
+	obs := game.Observe(s.w)
+	if s.view > 0 {
+		obs = obs.Within(s.view)
+	}
+	return obs
+}
+
+// Tick returns the number of ticks played.
+func (s *Sim) Tick() int { return s.w.Tick }
+
+// Score returns the current score.
+func (s *Sim) Score() int { return s.w.Score }
+
+// Lives returns the lives left, including the ship in play.
+func (s *Sim) Lives() int { return s.w.Lives }
+
+// Level returns the current wave, starting at 1.
+func (s *Sim) Level() int { return s.w.Level }
+
+// GameOver reports whether every life has been lost.
+func (s *Sim) GameOver() bool { return s.w.GameOver() }
+
+// Checksum hashes the simulation state. Two Sims with equal checksums are,
+// in practice, in the same state; it is cheap enough to call every tick.
+func (s *Sim) Checksum() uint64 { return s.w.Checksum() }
+
+// Render draws the playfield into img, scaled to fit its width. The image
+// is cleared to black first.
+func (s *Sim) Render(img *image.RGBA) {
+	r := &canvas.Raster{Img: img}
+	r.Fill(color.Black)
+	game.DrawWorld(s.w, canvas.Scale(r, float64(img.Bounds().Dx())/s.w.Config.FieldWidth))
+}
+
+// Agent decides the input for each tick from an observation.
+type Agent interface {
+	Act(obs Observation) Input
+}
+
+// AgentFunc adapts a function to the Agent interface.
+type AgentFunc func(obs Observation) Input
+
+// Act calls f.
+func (f AgentFunc) Act(obs Observation) Input { return f(obs) }
+
+// Hold wraps agent so that each rotate or thrust it presses stays pressed
+// for at least minTicks ticks, and an opposite turn waits until the current
+// one has run. Shoot, hyperspace and missile are passed through. Use the
+// same minTicks when training and when watching so the agent is played the
+// way it learned; cmd/watch takes it as -min-hold.
+func Hold(agent Agent, minTicks int) Agent {
+	h := &game.ActionHold{MinTicks: minTicks}
+	return AgentFunc(func(obs Observation) Input { return h.Apply(agent.Act(obs)) })
+}
+
+// Result summarises a finished episode.
+type Result struct {
+	Score int
+	Level int
+	Ticks int
+	// GameOver is false when the episode was cut short by the tick limit.
+	GameOver bool
+}
+
+// RunEpisode plays a single-player game with the given seed until it ends
+// or maxTicks have been played. A maxTicks of zero means no limit.
+func RunEpisode(seed int64, agent Agent, maxTicks int) Result {
+	s := New(seed)
+	for !s.GameOver() && (maxTicks <= 0 || s.Tick() < maxTicks) {
+		s.Step(agent.Act(s.Observe()))
+	}
+	return Result{Score: s.Score(), Level: s.Level(), Ticks: s.Tick(), GameOver: s.GameOver()}
+}
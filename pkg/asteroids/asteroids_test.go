@@ -0,0 +1,150 @@
+package asteroids
+
+import (
+	"image"
+	"testing"
+)
+
+func spin(obs Observation) Input {
+	return Input{RotateLeft: true, Shoot: obs.Tick%8 == 0}
+}
+
+func TestSim_Deterministic(t *testing.T) {
+	a, b := New(9), New(9)
+	for i := 0; i < 600; i++ {
+		a.Step(spin(a.Observe()))
+		b.Step(spin(b.Observe()))
+	}
+	if a.Checksum() != b.Checksum() || a.Score() != b.Score() {
+		t.Error("two sims with the same seed and inputs diverged")
+	}
+	if a.Tick() != 600 {
+		t.Errorf("Tick = %d, want 600", a.Tick())
+	}
+}
+
+func TestSim_Observe(t *testing.T) {
+	s := New(1)
+	obs := s.Observe()
+	if obs.Player == nil || len(obs.Asteroids) == 0 {
+		t.Fatalf("a fresh game should have a ship and asteroids: %+v", obs)
+	}
+	if obs.Lives != s.Lives() || obs.Level != s.Level() || obs.Width != Width {
+		t.Errorf("observation disagrees with the sim: %+v", obs)
+	}
+}
+
+func TestNewWithField(t *testing.T) {
+	s, err := NewWithField(1, 1400, 600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	obs := s.Observe()
+	if obs.Width != 1400 || obs.Height != 600 || obs.Player.X != 700 {
+		t.Errorf("expected a 1400x600 field with the ship in its centre, got %vx%v and x %v", obs.Width, obs.Height, obs.Player.X)
+	}
+	if _, err := NewWithField(1, 640, 600); err == nil {
+		t.Error("expected an error for a field narrower than the standard one")
+	}
+}
+
+func TestSim_Coop(t *testing.T) {
+	s := NewCoop(3)
+	s.StepCoop(Input{Thrust: true}, Input{})
+	if s.Tick() != 1 {
+		t.Errorf("Tick = %d, want 1", s.Tick())
+	}
+}
+
+func TestSim_Render(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, Width/4, Height/4))
+	New(1).Render(img)
+
+	lit := 0
+	for i := 0; i < len(img.Pix); i += 4 {
+		if img.Pix[i] > 0 {
+			lit++
+		}
+	}
+	if lit == 0 {
+		t.Error("nothing was drawn")
+	}
+	if img.RGBAAt(0, 0).A != 255 {
+		t.Error("the background should be cleared to opaque black")
+	}
+}
+
+func TestRunEpisode(t *testing.T) {
+	res := RunEpisode(5, AgentFunc(spin), 300)
+	if res.Ticks != 300 || res.GameOver {
+		t.Errorf("expected the tick limit to end the episode, got %+v", res)
+	}
+
+	idle := RunEpisode(5, AgentFunc(func(Observation) Input { return Input{} }), 0)
+	if !idle.GameOver {
+		t.Errorf("an idle ship should eventually lose every life, got %+v", idle)
+	}
+}
+
+func TestSim_DisableHitStop(t *testing.T) {
+	// moved reports whether the asteroids move on the tick after the
+	// ship first dies.
+	moved := func(disable bool) bool {
+		sim := New(3)
+		if disable {
+			sim.DisableHitStop()
+		}
+		lives := sim.Lives()
+		for sim.Lives() == lives {
+			sim.Step(Input{Hyperspace: sim.Tick()%40 == 0})
+		}
+		before := sim.Observe().Asteroids[0]
+		sim.Step(Input{})
+		return sim.Observe().Asteroids[0] != before
+	}
+	if moved(false) {
+		t.Error("the game should freeze briefly when the ship dies")
+	}
+	if !moved(true) {
+		t.Error("DisableHitStop should keep the game running when the ship dies")
+	}
+}
+
+func TestSim_LimitView(t *testing.T) {
+	sim := New(1)
+	all := len(sim.Observe().Asteroids)
+	sim.LimitView(400)
+	near := sim.Observe()
+	if len(near.Asteroids) >= all {
+		t.Fatalf("expected fewer than %d asteroids within 400px, got %d", all, len(near.Asteroids))
+	}
+	for _, a := range near.Asteroids {
+		if a.DX*a.DX+a.DY*a.DY >= 400*400 {
+			t.Errorf("asteroid at (%v, %v) is out of view", a.DX, a.DY)
+		}
+	}
+	sim.LimitView(0)
+	if len(sim.Observe().Asteroids) != all {
+		t.Error("LimitView(0) should remove the limit")
+	}
+}
+
+func TestHold(t *testing.T) {
+	tap := AgentFunc(func(obs Observation) Input {
+		return Input{RotateLeft: obs.Tick == 0, RotateRight: obs.Tick > 0}
+	})
+	agent := Hold(tap, 4)
+	var left, right int
+	for tick := 0; tick < 8; tick++ {
+		in := agent.Act(Observation{Tick: tick})
+		if in.RotateLeft {
+			left++
+		}
+		if in.RotateRight {
+			right++
+		}
+	}
+	if left != 4 || right != 4 {
+		t.Errorf("expected a 4-tick left turn and then a right turn, got %d left and %d right", left, right)
+	}
+}